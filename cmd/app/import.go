@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"med/pkg/model"
+	services "med/pkg/service"
+	"med/pkg/utils"
+	"os"
+)
+
+const importUsage = "usage: import [-mapping mapping.json] [-dry-run] -user <id> <file.csv|file.xlsx>"
+
+// runImport handles the import subcommand, which imports blood count results from a CSV or XLSX file
+// as an admin and prints the import report:
+//
+//	import -user <id> [-mapping mapping.json] [-dry-run] <file>
+//
+// The user ID is recorded in the audit log as the author of the results.
+func runImport(service services.ProcedureBloodCount, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	mappingFile := flags.String("mapping", "", "JSON file with the column mapping")
+	dryRun := flags.Bool("dry-run", false, "validate the file without saving the results")
+	userId := flags.Int("user", 0, "ID of the user recorded as the author of the results")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || *userId <= 0 {
+		return errors.New(importUsage)
+	}
+
+	var mapping model.ImportMapping
+	if *mappingFile != "" {
+		content, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(content, &mapping); err != nil {
+			return fmt.Errorf("invalid mapping file: %w", err)
+		}
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := utils.ReadTable(file.Name(), file)
+	if err != nil {
+		return err
+	}
+
+	user := services.UserData{Id: *userId, Role: model.AdminRole}
+	report, err := service.ImportProcedureBloodCounts(user, rows, mapping, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d errors in the file, nothing was imported", len(report.Errors))
+	}
+	return nil
+}
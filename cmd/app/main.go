@@ -5,10 +5,12 @@ import (
 	server "med"
 	_ "med/docs"
 	"med/pkg/config"
+	"med/pkg/database"
 	"med/pkg/handler"
 	"med/pkg/repository"
 	route "med/pkg/routes"
 	services "med/pkg/service"
+	"med/pkg/utils"
 	"os"
 	"os/signal"
 	"syscall"
@@ -34,11 +36,57 @@ func main() {
 
 	db, err := repository.NewPostgresDB(&config.Database)
 	if err != nil {
-		logger.Error()
+		logger.Fatal().Msgf("error occured on db connection: %s", err.Error())
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(db, logger, os.Args[2:]); err != nil {
+			logger.Fatal().Msgf("error occured on migration: %s", err.Error())
+		}
+		return
+	}
+
+	if config.Database.Migrate {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			logger.Fatal().Msgf("error occured on loading migrations: %s", err.Error())
+		}
+		if err := migrator.Up(); err != nil {
+			logger.Fatal().Msgf("error occured on migration: %s", err.Error())
+		}
+	}
+
+	if err := repository.CheckSchema(db); err != nil {
+		logger.Fatal().Msgf("error occured on schema check: %s", err.Error())
 	}
 
 	repository := repository.NewRepository(db)
-	service := services.NewService(*repository)
+	mailer := utils.NewEmailService(&config.Email)
+	service := services.NewService(*repository, mailer, config.Export, config.Privacy)
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(service.ProcedureBloodCount, os.Args[2:]); err != nil {
+			logger.Fatal().Msgf("error occured on import: %s", err.Error())
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "mllp" {
+		if err := runMLLP(service, logger, os.Args[2:]); err != nil {
+			logger.Fatal().Msgf("error occured on MLLP listener: %s", err.Error())
+		}
+		return
+	}
+
+	// Jobs of the previous run are never finished, their goroutines are gone
+	failed, err := service.Export.FailUnfinishedExportJobs()
+	if err != nil {
+		logger.Fatal().Msgf("error occured on failing unfinished export jobs: %s", err.Error())
+	}
+	if failed > 0 {
+		logger.Warn().Msgf("%d unfinished export jobs failed", failed)
+	}
+
 	handler := handler.NewHandler(service)
 
 	routes := route.InitRoutes(handler)
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"med/pkg/model"
+	services "med/pkg/service"
+	"med/pkg/utils"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const mllpUsage = "usage: mllp [-address 127.0.0.1:2575] [-allow ip,cidr] [-cert cert.pem -key key.pem -client-ca ca.pem] [-idle-timeout 5m] -user <id>"
+
+// mllpWriteTimeout bounds sending an ACK to an analyzer that stopped reading
+const mllpWriteTimeout = 30 * time.Second
+
+// runMLLP handles the mllp subcommand, which listens for HL7 v2 lab messages of analyzers over MLLP
+// and ingests them as the user until it is interrupted:
+//
+//	mllp -user <id> [-address 127.0.0.1:2575] [-allow 10.0.5.0/24,10.0.6.7] [-cert cert.pem -key key.pem -client-ca ca.pem]
+//
+// MLLP has no authentication of its own, so only the allowed analyzer addresses may connect, the loopback ones by default,
+// and with a certificate the listener requires TLS with client certificates issued by the client CA.
+// Every message is answered with its ACK on the same connection. The user must be allowed to create lab messages,
+// it is recorded in the audit log as the author of the results and its access to the patients is checked.
+func runMLLP(service *services.Service, logger zerolog.Logger, args []string) error {
+	flags := flag.NewFlagSet("mllp", flag.ContinueOnError)
+	address := flags.String("address", "127.0.0.1:2575", "TCP address to listen on")
+	allow := flags.String("allow", "", "comma-separated IP addresses and CIDR ranges of the analyzers, loopback only by default")
+	certFile := flags.String("cert", "", "PEM certificate of the listener, enables TLS")
+	keyFile := flags.String("key", "", "PEM private key of the certificate")
+	clientCAFile := flags.String("client-ca", "", "PEM certificates of the CA that issues the analyzer certificates, required with TLS")
+	idleTimeout := flags.Duration("idle-timeout", 5*time.Minute, "time to receive the next message before the connection is closed")
+	userId := flags.Int("user", 0, "ID of the user recorded as the author of the results")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 || *userId <= 0 || *idleTimeout <= 0 || (*certFile == "") != (*keyFile == "") || (*certFile != "" && *clientCAFile == "") {
+		return errors.New(mllpUsage)
+	}
+
+	allowlist, err := parseMLLPAllowlist(*allow)
+	if err != nil {
+		return err
+	}
+	user, err := mllpUser(service, *userId)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *address)
+	if err != nil {
+		return err
+	}
+	if *certFile != "" {
+		config, err := mllpTLSConfig(*certFile, *keyFile, *clientCAFile)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		listener = tls.NewListener(listener, config)
+	}
+	logger.Print("MLLP listener started on " + listener.Addr().String())
+
+	quitch := make(chan os.Signal, 1)
+	signal.Notify(quitch, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-quitch
+		logger.Print("MLLP listener shutting down")
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !allowlist.allows(conn.RemoteAddr()) {
+			logger.Warn().Msgf("MLLP connection from %s is not allowed", conn.RemoteAddr())
+			conn.Close()
+			continue
+		}
+		go serveMLLP(service.LabMessage, logger, user, conn, *idleTimeout)
+	}
+}
+
+// mllpUser returns the user the messages are ingested as, who must be unlocked and allowed to create lab messages
+func mllpUser(service *services.Service, userId int) (services.UserData, error) {
+	profile, err := service.User.GetUserById(userId)
+	if err != nil {
+		return services.UserData{}, fmt.Errorf("user %d: %w", userId, err)
+	}
+	if profile.Locked {
+		return services.UserData{}, fmt.Errorf("user %d is locked", userId)
+	}
+	allowed, err := service.Permission.HasPermission(profile.Role, model.LabMessageResource, model.CreateAction)
+	if err != nil {
+		return services.UserData{}, err
+	}
+	if !allowed {
+		return services.UserData{}, fmt.Errorf("user %d with role %s may not create lab messages", userId, profile.Role)
+	}
+	return services.UserData{Id: profile.Id, Role: profile.Role}, nil
+}
+
+// mllpTLSConfig requires the analyzers to present certificates issued by the client CA
+func mllpTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(clientCAs) {
+		return nil, fmt.Errorf("no certificates in %s", clientCAFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// mllpAllowlist is the IP ranges that may connect to the listener
+type mllpAllowlist []*net.IPNet
+
+// parseMLLPAllowlist parses comma-separated IP addresses and CIDR ranges, an empty list allows loopback addresses only
+func parseMLLPAllowlist(value string) (mllpAllowlist, error) {
+	if strings.TrimSpace(value) == "" {
+		value = "127.0.0.0/8,::1"
+	}
+
+	var allowlist mllpAllowlist
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid allowed address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			allowlist = append(allowlist, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed range %q", entry)
+		}
+		allowlist = append(allowlist, network)
+	}
+	return allowlist, nil
+}
+
+func (a mllpAllowlist) allows(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range a {
+		if network.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveMLLP ingests the messages of the connection until the sender closes it or sends no message for the idle timeout.
+// A message that is not stored is acknowledged all the same, an analyzer resends the message it gets no ACK of.
+func serveMLLP(service services.LabMessage, logger zerolog.Logger, user services.UserData, conn net.Conn, idleTimeout time.Duration) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		// The deadline covers waiting for the message and reading all of it, so a sender cannot hold the connection open
+		if err := conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+			logger.Error().Msgf("error occured on MLLP connection from %s: %s", conn.RemoteAddr(), err.Error())
+			return
+		}
+		message, err := utils.ReadMLLP(reader)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			logger.Info().Msgf("MLLP connection from %s is idle, closing it", conn.RemoteAddr())
+			return
+		}
+		if err != nil {
+			logger.Error().Msgf("error occured on MLLP read from %s: %s", conn.RemoteAddr(), err.Error())
+			return
+		}
+
+		ack, err := service.IngestLabMessage(user, message)
+		if err != nil {
+			logger.Error().Msgf("error occured on lab message ingestion: %s", err.Error())
+		} else if ack.Code != model.HL7AcceptAck {
+			logger.Warn().Msgf("lab message from %s is not accepted: %s", conn.RemoteAddr(), ack.Code)
+		}
+
+		if err := conn.SetWriteDeadline(time.Now().Add(mllpWriteTimeout)); err != nil {
+			logger.Error().Msgf("error occured on MLLP connection from %s: %s", conn.RemoteAddr(), err.Error())
+			return
+		}
+		if err := utils.WriteMLLP(conn, ack.Message); err != nil {
+			logger.Error().Msgf("error occured on MLLP write to %s: %s", conn.RemoteAddr(), err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"med/pkg/model"
+	services "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"med/pkg/utils"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseMLLPAllowlist(t *testing.T) {
+	testTable := []struct {
+		name          string
+		value         string
+		allowed       []string
+		denied        []string
+		expectedError string
+	}{
+		{
+			name:    "Loopback by default",
+			allowed: []string{"127.0.0.1", "127.8.0.2", "::1"},
+			denied:  []string{"10.0.5.1", "192.168.1.10", "::2"},
+		},
+		{
+			name:    "Addresses and ranges",
+			value:   "10.0.5.0/24, 10.0.6.7,fd00::/8",
+			allowed: []string{"10.0.5.1", "10.0.5.254", "10.0.6.7", "fd00::1"},
+			denied:  []string{"127.0.0.1", "10.0.6.8", "10.0.4.1"},
+		},
+		{
+			name:          "Invalid address",
+			value:         "10.0.5.300",
+			expectedError: `invalid allowed address "10.0.5.300"`,
+		},
+		{
+			name:          "Invalid range",
+			value:         "10.0.5.0/40",
+			expectedError: `invalid allowed range "10.0.5.0/40"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			allowlist, err := parseMLLPAllowlist(testCase.value)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			for _, ip := range testCase.allowed {
+				assert.True(t, allowlist.allows(&net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}), ip)
+			}
+			for _, ip := range testCase.denied {
+				assert.False(t, allowlist.allows(&net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}), ip)
+			}
+		})
+	}
+}
+
+func TestServeMLLP(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	user := services.UserData{Id: 5, Role: model.DoctorRole}
+	labMessage := mock.NewMockLabMessage(c)
+	labMessage.EXPECT().IngestLabMessage(user, "MSH|^~\\&|A\r").Return(model.LabMessageAck{Code: "AA", Message: "MSH|^~\\&\rMSA|AA|1\r"}, nil)
+
+	server, client := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		serveMLLP(labMessage, zerolog.Nop(), user, server, 100*time.Millisecond)
+		close(done)
+	}()
+
+	assert.NoError(t, utils.WriteMLLP(client, "MSH|^~\\&|A\r"))
+	ack, err := utils.ReadMLLP(bufio.NewReader(client))
+	assert.NoError(t, err)
+	assert.Equal(t, "MSH|^~\\&\rMSA|AA|1\r", ack)
+
+	// A silent sender is disconnected after the idle timeout
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the idle connection is not closed")
+	}
+	_, err = client.Write([]byte{0x0b})
+	assert.Error(t, err)
+}
@@ -22,7 +22,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves blood count data.",
+                "description": "Retrieves the patient's own procedure blood counts ordered by procedure date.",
                 "produces": [
                     "application/json"
                 ],
@@ -34,7 +34,13 @@ const docTemplate = `{
                     "200": {
                         "description": "Blood count data",
                         "schema": {
-                            "type": "array"
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.AccountBloodCount"
+                                }
+                            }
                         }
                     },
                     "401": {
@@ -42,6 +48,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -53,19 +71,39 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves console data.",
+                "description": "Retrieves counts of patients and doctors, and counts of active courses and procedures for the period. The period defaults to the last 30 days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Account"
+                    "Console"
+                ],
+                "summary": "Get console statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period start (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get console",
                 "responses": {
                     "200": {
-                        "description": "Console data",
+                        "description": "Console statistics",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConsoleStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
-                            "type": "array"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
@@ -73,37 +111,102 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/account/doctors": {
+        "/account/console/changes": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves doctor information.",
+                "description": "Retrieves the latest entries of the audit log, optionally filtered by entity, user and period.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Account"
+                    "Console"
+                ],
+                "summary": "Get changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Changed entity, e.g. patient or procedure-blood-count",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changed entity ID",
+                        "name": "entity-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the user who made the change",
+                        "name": "user-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, 50 by default, 500 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get doctors",
                 "responses": {
                     "200": {
-                        "description": "Doctor list",
+                        "description": "Change list",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "array"
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.AuditEntry"
+                                }
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -111,30 +214,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/account/patient-data": {
+        "/account/console/failed-logins": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves patient data.",
+                "description": "Retrieves the latest rejected login attempts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Account"
+                    "Console"
+                ],
+                "summary": "Get failed logins",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, 50 by default, 500 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get patient data",
                 "responses": {
                     "200": {
-                        "description": "Patient data",
+                        "description": "Failed login list",
                         "schema": {
-                            "type": "array"
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.FailedLogin"
+                                }
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -142,30 +271,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/account/settings": {
+        "/account/console/users": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves the account settings for the authenticated user.",
+                "description": "Retrieves users matching the filter. The search string is matched against email and names.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Account"
+                    "Console"
+                ],
+                "summary": "Search users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search string",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Locked users only or unlocked users only",
+                        "name": "locked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get account settings",
                 "responses": {
                     "200": {
-                        "description": "Account settings",
+                        "description": "User list",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/model.Page-model_UserProfile"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -173,35 +358,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Logs in the user and returns an authentication token.",
-                "consumes": [
-                    "application/json"
+        "/account/console/users/{id}/lock": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Locks the user account and revokes all sessions of the user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Console"
                 ],
-                "summary": "Log in user",
+                "summary": "Lock user",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.AuthUser"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Authentication token",
+                        "description": "User ID",
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     },
                     "400": {
@@ -210,6 +395,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -217,31 +414,72 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
-            "post": {
-                "description": "Logs out the currently authenticated user.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Unlocks the user account.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Console"
+                ],
+                "summary": "Unlock user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Log out user",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "User ID",
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/registry": {
-            "post": {
-                "description": "Registers a new user and returns the user's email.",
+        "/account/console/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Changes the role of the user and revokes all sessions of the user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,25 +487,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Console"
                 ],
-                "summary": "Register new user",
+                "summary": "Change user role",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.RoleInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User email",
+                        "description": "Updated user profile",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.UserProfile"
                         }
                     },
                     "400": {
@@ -276,6 +521,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -285,49 +542,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/reset-password": {
-            "post": {
-                "description": "Sends a password reset message to the user's email.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Reset user password",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/blood-count": {
+        "/account/doctors": {
             "get": {
-                "description": "Retrieves a list of blood counts.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the patient's attending doctors.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCount"
+                    "Account"
                 ],
-                "summary": "Get blood count list",
+                "summary": "Get doctors",
                 "responses": {
                     "200": {
-                        "description": "Blood count list",
+                        "description": "Doctor list",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/model.BloodCount"
+                                    "$ref": "#/definitions/model.Doctor"
                                 }
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -335,9 +589,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing blood count entry.",
+            }
+        },
+        "/account/invitation": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Emails an invitation to create a staff account. Allowed roles are admin, doctor and researcher.",
                 "consumes": [
                     "application/json"
                 ],
@@ -345,25 +606,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCount"
+                    "Invitation"
                 ],
-                "summary": "Update blood count",
+                "summary": "Invite staff user",
                 "parameters": [
                     {
-                        "description": "Updated blood count data",
+                        "description": "Invitation data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCount"
+                            "$ref": "#/definitions/model.Invitation"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated blood count data",
+                        "description": "Sent invitation",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCount"
+                            "$ref": "#/definitions/model.Invitation"
                         }
                     },
                     "400": {
@@ -372,6 +633,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -379,35 +646,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/account/patient/{id}/registration-code": {
             "post": {
-                "description": "Creates a new blood count entry.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Creates a one-time code the patient uses to register an account. A new code replaces the previous one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCount"
+                    "Patient"
                 ],
-                "summary": "Create a new blood count",
+                "summary": "Issue patient registration code",
                 "parameters": [
                     {
-                        "description": "Blood count data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.BloodCount"
-                        }
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created blood count data",
+                        "description": "Registration code",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCount"
+                            "$ref": "#/definitions/model.RegistrationCode"
                         }
                     },
                     "400": {
@@ -416,6 +685,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -425,29 +700,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/blood-count-value": {
+        "/account/patients-data": {
             "get": {
-                "description": "Retrieves a list of all blood count values.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the doctor's patient panel with current diseases and active courses.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "Account"
                 ],
-                "summary": "Get blood count value list",
+                "summary": "Get patient data",
                 "responses": {
                     "200": {
-                        "description": "Blood count value list",
+                        "description": "Patient data",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/model.BloodCountValue"
+                                    "$ref": "#/definitions/model.PanelPatient"
                                 }
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -455,35 +747,66 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing blood count value entry.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/account/permission": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Retrieves the role permission matrix.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "Permission"
                 ],
-                "summary": "Update blood count value",
+                "summary": "Get permission list",
                 "parameters": [
                     {
-                        "description": "Blood count value data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.BloodCountValue"
-                        }
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated blood count value",
+                        "description": "Permission list",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCountValue"
+                            "$ref": "#/definitions/model.Page-model_Permission"
                         }
                     },
                     "400": {
@@ -492,6 +815,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -501,7 +830,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new blood count value entry.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Allows a role to perform an action on a resource.",
                 "consumes": [
                     "application/json"
                 ],
@@ -509,25 +843,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "Permission"
                 ],
-                "summary": "Create blood count value",
+                "summary": "Grant permission",
                 "parameters": [
                     {
-                        "description": "Blood count value data",
+                        "description": "Permission data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCountValue"
+                            "$ref": "#/definitions/model.Permission"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created blood count value",
+                        "description": "Created permission data",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCountValue"
+                            "$ref": "#/definitions/model.Permission"
                         }
                     },
                     "400": {
@@ -536,6 +870,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already has the permission",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -543,38 +889,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/blood-count-value/blood-count/{blood_count_id}": {
-            "get": {
-                "description": "Retrieves a list of blood count values associated with a specific blood count.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revokes a role's permission to perform an action on a resource.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "Permission"
                 ],
-                "summary": "Get blood count value list by blood count",
+                "summary": "Revoke permission",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Permission data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Permission"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Blood count value list",
+                        "description": "Revoked permission data",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.BloodCountValue"
-                                }
-                            }
+                            "$ref": "#/definitions/model.Permission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -586,36 +946,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/blood-count-value/disease/{disease_id}": {
-            "get": {
-                "description": "Retrieves a list of blood count values associated with a specific disease.",
+        "/account/session/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revokes every refresh and access token issued to the user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "Auth"
                 ],
-                "summary": "Get blood count value list by disease",
+                "summary": "Revoke user sessions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Blood count value list",
+                        "description": "User ID",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.BloodCountValue"
-                                }
-                            }
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -627,37 +998,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/blood-count-value/{disease_id}/{blood_count_id}": {
+        "/account/settings": {
             "get": {
-                "description": "Retrieves a blood count value by its ID.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "BloodCountValue"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
-                "summary": "Get blood count value by ID",
+                "description": "Retrieves the account settings for the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get account settings",
+                "responses": {
+                    "200": {
+                        "description": "Account settings",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the list of all users, both internal staff and external users.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user list",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search string",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Locked users only or unlocked users only",
+                        "name": "locked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Blood count value",
+                        "description": "User list",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCountValue"
+                            "$ref": "#/definitions/model.Page-model_UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -668,36 +1115,51 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing blood count value entry.",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates an internal account for clinic staff. Allowed roles are admin, doctor and researcher.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCountValue"
+                    "User"
                 ],
-                "summary": "Delete blood count value",
+                "summary": "Create staff user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Staff user data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StaffUser"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deleted blood count value",
+                        "description": "Created user profile",
                         "schema": {
-                            "$ref": "#/definitions/handler.BloodCountValueResponse"
+                            "$ref": "#/definitions/model.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -709,30 +1171,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/blood-count/{id}": {
-            "get": {
-                "description": "Retrieves a blood count entry by its ID.",
+        "/account/user/migrate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves users from the legacy internal_user and external_user tables into the unified user table. External users keep their ids, which patient and doctor records reference. Users moved by an earlier run are skipped. If an id or an email is taken by another user, nothing is moved and the conflicting rows are returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCount"
-                ],
-                "summary": "Get blood count by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Blood count ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "User"
                 ],
+                "summary": "Migrate legacy users",
                 "responses": {
                     "200": {
-                        "description": "Blood count data",
+                        "description": "Number of migrated users and skipped rows",
                         "schema": {
-                            "$ref": "#/definitions/model.BloodCount"
+                            "$ref": "#/definitions/model.LegacyUserMigration"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicting rows, nothing is migrated",
+                        "schema": {
+                            "$ref": "#/definitions/model.LegacyUserMigration"
                         }
                     },
                     "500": {
@@ -742,20 +1212,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a blood count entry by its ID.",
+            }
+        },
+        "/account/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the user profile with the linked patient and doctor records.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BloodCount"
+                    "User"
                 ],
-                "summary": "Delete blood count",
+                "summary": "Get user by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Blood count ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -763,9 +1240,27 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Blood count ID",
+                        "description": "User profile",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -777,29 +1272,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/course-procedure": {
-            "get": {
-                "description": "Retrieves a list of course procedures.",
+        "/analysis/score": {
+            "post": {
+                "description": "Scores diseases from ad-hoc blood count results without storing them. Every value is normalized against the ranges of its blood count and weighted with the disease coefficients. Diseases are ranked by score, from -1 to 1, positive scores support the disease.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CourseProcedure"
+                    "Analysis"
+                ],
+                "summary": "Score blood count results",
+                "parameters": [
+                    {
+                        "description": "Blood count results",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoreInput"
+                        }
+                    }
                 ],
-                "summary": "Get course procedure list",
                 "responses": {
                     "200": {
-                        "description": "Course procedure list",
+                        "description": "Ranked disease scores",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/model.CourseProcedure"
+                                    "$ref": "#/definitions/model.DiseaseScore"
                                 }
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -807,9 +1328,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing course procedure entry.",
+            }
+        },
+        "/auth/invitation/accept": {
+            "post": {
+                "description": "Creates the invited staff account. A doctor record is created for invited doctors.",
                 "consumes": [
                     "application/json"
                 ],
@@ -817,25 +1340,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CourseProcedure"
+                    "Auth"
                 ],
-                "summary": "Update course procedure",
+                "summary": "Accept invitation",
                 "parameters": [
                     {
-                        "description": "Updated course procedure data",
+                        "description": "Invitation token and account data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CourseProcedure"
+                            "$ref": "#/definitions/model.InvitationAcceptance"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated course procedure data",
+                        "description": "User email",
                         "schema": {
-                            "$ref": "#/definitions/model.CourseProcedure"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -851,9 +1374,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "description": "Creates a new course procedure entry.",
+                "description": "Logs in the user and returns an authentication token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -861,25 +1386,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CourseProcedure"
+                    "Auth"
                 ],
-                "summary": "Create a new course procedure",
+                "summary": "Log in user",
                 "parameters": [
                     {
-                        "description": "Course procedure data",
+                        "description": "User credentials",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CourseProcedure"
+                            "$ref": "#/definitions/model.AuthUser"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created course procedure data",
+                        "description": "Access and refresh tokens",
                         "schema": {
-                            "$ref": "#/definitions/model.CourseProcedure"
+                            "$ref": "#/definitions/model.Tokens"
                         }
                     },
                     "400": {
@@ -888,6 +1413,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User account is locked",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -897,30 +1434,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/course-procedure/{id}": {
-            "get": {
-                "description": "Retrieves a course procedure entry by its ID.",
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Logs out the currently authenticated user. Revokes the refresh token and the access token.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CourseProcedure"
+                    "Auth"
                 ],
-                "summary": "Get course procedure by ID",
+                "summary": "Log out user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Course procedure ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Course procedure data",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.CourseProcedure"
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -930,30 +1482,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a course procedure entry by its ID.",
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new access and refresh token pair. The used refresh token is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CourseProcedure"
+                    "Auth"
                 ],
-                "summary": "Delete course procedure",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Course procedure ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Course procedure ID",
+                        "description": "Access and refresh tokens",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.Tokens"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -965,24 +1536,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses": {
-            "get": {
-                "description": "Returns a list of courses.",
+        "/auth/registry": {
+            "post": {
+                "description": "Registers a patient account linked to the patient record with the given SNILS. The registration code is issued by the patient's doctor.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "Auth"
+                ],
+                "summary": "Register patient",
+                "parameters": [
+                    {
+                        "description": "Registration data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientRegistration"
+                        }
+                    }
                 ],
-                "summary": "Get list of courses",
                 "responses": {
                     "200": {
-                        "description": "List of courses",
+                        "description": "User email",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Course"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -992,9 +1580,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing course.",
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Sends a password reset token to the user's email. The response is the same whether the email is registered or not.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1002,25 +1592,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "Auth"
                 ],
-                "summary": "Update course",
+                "summary": "Reset user password",
                 "parameters": [
                     {
-                        "description": "Updated course data",
-                        "name": "course",
+                        "description": "User email",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Course"
+                            "$ref": "#/definitions/model.ResetPasswordInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated course details",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Course"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1036,9 +1626,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/reset-password/confirm": {
             "post": {
-                "description": "Creates a new course.",
+                "description": "Sets a new password using the token from the password reset email. All sessions of the user are revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,25 +1638,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "Auth"
                 ],
-                "summary": "Create new course",
+                "summary": "Confirm password reset",
                 "parameters": [
                     {
-                        "description": "Course data",
-                        "name": "course",
+                        "description": "Reset token and new password",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Course"
+                            "$ref": "#/definitions/model.ConfirmResetPasswordInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created course details",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Course"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1082,30 +1674,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{id}": {
+        "/blood-count": {
             "get": {
-                "description": "Returns a course by its ID.",
+                "description": "Retrieves a list of blood counts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "BloodCount"
                 ],
-                "summary": "Get course by ID",
+                "summary": "Get blood count list",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Unit measure code",
+                        "name": "measure-code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Course details",
+                        "description": "Blood count list",
                         "schema": {
-                            "$ref": "#/definitions/model.Course"
+                            "$ref": "#/definitions/model.Page-model_BloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1116,59 +1737,41 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing course by its ID.",
+            "put": {
+                "description": "Updates an existing blood count entry.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Courses"
+                    "BloodCount"
                 ],
-                "summary": "Delete course",
+                "summary": "Update blood count",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Updated blood count data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BloodCount"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ID of deleted course",
+                        "description": "Updated blood count data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.BloodCount"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/diagnoses": {
-            "get": {
-                "description": "Retrieves a list of diagnoses",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Diagnoses"
-                ],
-                "summary": "Get diagnosis list",
-                "responses": {
-                    "200": {
-                        "description": "List of diagnoses",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Diagnosis"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -1179,7 +1782,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new diagnosis",
+                "description": "Creates a new blood count entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1187,25 +1790,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "BloodCount"
                 ],
-                "summary": "Create diagnosis",
+                "summary": "Create a new blood count",
                 "parameters": [
                     {
-                        "description": "Diagnosis object",
-                        "name": "diagnosis",
+                        "description": "Blood count data",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Diagnosis"
+                            "$ref": "#/definitions/model.BloodCount"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created diagnosis",
+                        "description": "Created blood count data",
                         "schema": {
-                            "$ref": "#/definitions/model.Diagnosis"
+                            "$ref": "#/definitions/model.BloodCount"
                         }
                     },
                     "400": {
@@ -1223,33 +1826,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/diagnoses/{id}": {
+        "/blood-count-value": {
             "get": {
-                "description": "Retrieves a diagnosis by its ID",
+                "description": "Retrieves a list of all blood count values.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "BloodCountValue"
                 ],
-                "summary": "Get diagnosis by ID",
+                "summary": "Get blood count value list",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Diagnosis ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Disease ID",
+                        "name": "disease",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood-count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Diagnosis",
+                        "description": "Blood count value list",
                         "schema": {
-                            "$ref": "#/definitions/model.Diagnosis"
+                            "$ref": "#/definitions/model.Page-model_BloodCountValue"
                         }
                     },
-                    "500": {
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
@@ -1258,7 +1896,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing diagnosis",
+                "description": "Updates an existing blood count value entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1266,32 +1904,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "BloodCountValue"
                 ],
-                "summary": "Update diagnosis",
+                "summary": "Update blood count value",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Diagnosis ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Diagnosis object",
-                        "name": "diagnosis",
+                        "description": "Blood count value data",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Diagnosis"
+                            "$ref": "#/definitions/model.BloodCountValue"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated diagnosis",
+                        "description": "Updated blood count value",
                         "schema": {
-                            "$ref": "#/definitions/model.Diagnosis"
+                            "$ref": "#/definitions/model.BloodCountValue"
                         }
                     },
                     "400": {
@@ -1308,62 +1939,41 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing diagnosis by its ID",
+            "post": {
+                "description": "Creates a new blood count value entry.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnoses"
+                    "BloodCountValue"
                 ],
-                "summary": "Delete diagnosis",
+                "summary": "Create blood count value",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Diagnosis ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Blood count value data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BloodCountValue"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ID of deleted diagnosis",
+                        "description": "Created blood count value",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.BloodCountValue"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/disease": {
-            "get": {
-                "description": "Retrieves a list of diseases.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Disease"
-                ],
-                "summary": "Get disease list",
-                "responses": {
-                    "200": {
-                        "description": "Disease list",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.Disease"
-                                }
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -1372,35 +1982,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing disease entry.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/blood-count-value/blood-count/{blood_count_id}": {
+            "get": {
+                "description": "Retrieves a page of the values of a blood count for every disease.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disease"
+                    "BloodCountValue"
                 ],
-                "summary": "Update disease",
+                "summary": "Get blood count value list by blood count",
                 "parameters": [
                     {
-                        "description": "Updated disease data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Disease"
-                        }
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated disease data",
+                        "description": "Blood count value list",
                         "schema": {
-                            "$ref": "#/definitions/model.Disease"
+                            "$ref": "#/definitions/model.Page-model_BloodCountValue"
                         }
                     },
                     "400": {
@@ -1416,35 +2047,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new disease entry.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/blood-count-value/disease/{disease_id}": {
+            "get": {
+                "description": "Retrieves a page of the blood count values of a disease.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disease"
+                    "BloodCountValue"
                 ],
-                "summary": "Create a new disease",
+                "summary": "Get blood count value list by disease",
                 "parameters": [
                     {
-                        "description": "Disease data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Disease"
-                        }
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created disease data",
+                        "description": "Blood count value list",
                         "schema": {
-                            "$ref": "#/definitions/model.Disease"
+                            "$ref": "#/definitions/model.Page-model_BloodCountValue"
                         }
                     },
                     "400": {
@@ -1462,30 +2114,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/disease/{id}": {
+        "/blood-count-value/{disease_id}/{blood_count_id}": {
             "get": {
-                "description": "Retrieves a disease entry by its ID.",
+                "description": "Retrieves a blood count value by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disease"
+                    "BloodCountValue"
                 ],
-                "summary": "Get disease by ID",
+                "summary": "Get blood count value by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Disease ID",
-                        "name": "id",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Disease data",
+                        "description": "Blood count value",
                         "schema": {
-                            "$ref": "#/definitions/model.Disease"
+                            "$ref": "#/definitions/model.BloodCountValue"
                         }
                     },
                     "500": {
@@ -1497,28 +2156,35 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a disease entry by its ID.",
+                "description": "Deletes an existing blood count value entry.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disease"
+                    "BloodCountValue"
                 ],
-                "summary": "Delete disease",
+                "summary": "Delete blood count value",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Disease ID",
-                        "name": "id",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Disease ID",
+                        "description": "Deleted blood count value",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.BloodCountValueResponse"
                         }
                     },
                     "500": {
@@ -1530,41 +2196,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/doctor-patient": {
-            "post": {
-                "description": "Creates a relationship between a doctor and a patient.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/blood-count/{id}": {
+            "get": {
+                "description": "Retrieves a blood count entry by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DoctorPatient"
+                    "BloodCount"
                 ],
-                "summary": "Create doctor-patient relationship",
+                "summary": "Get blood count by ID",
                 "parameters": [
                     {
-                        "description": "Doctor-patient relationship data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.DoctorPatient"
-                        }
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created doctor-patient relationship data",
-                        "schema": {
-                            "$ref": "#/definitions/model.DoctorPatient"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Blood count data",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/model.BloodCount"
                         }
                     },
                     "500": {
@@ -1574,38 +2229,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/doctor-patient/{doctor_id}": {
-            "get": {
-                "description": "Retrieves a list of patient IDs associated with the given doctor ID.",
+            },
+            "delete": {
+                "description": "Deletes a blood count entry by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DoctorPatient"
+                    "BloodCount"
                 ],
-                "summary": "Get doctor-patient relationship list by doctor ID",
+                "summary": "Delete blood count",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Doctor ID",
-                        "name": "doctor_id",
+                        "description": "Blood count ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient ID list",
+                        "description": "Blood count ID",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.DoctorPatient"
-                                }
-                            }
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -1617,69 +2264,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/doctor-patient/{doctor_id}/{patient_id}": {
-            "delete": {
-                "description": "Deletes the relationship between a doctor and a patient.",
+        "/cohort": {
+            "get": {
+                "description": "Retrieves a list of saved cohorts.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DoctorPatient"
+                    "Cohort"
                 ],
-                "summary": "Delete doctor-patient relationship",
+                "summary": "Get cohort list",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by author user ID",
+                        "name": "created-by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Doctor ID",
-                        "name": "doctor_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patient_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deleted doctor-patient relationship data",
+                        "description": "Cohort list",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/model.Page-model_Cohort"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/doctors": {
-            "get": {
-                "description": "Retrieves a list of doctors.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Doctor"
-                ],
-                "summary": "Get doctor list",
-                "responses": {
-                    "200": {
-                        "description": "Doctor list",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.Doctor"
-                                }
-                            }
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1691,7 +2334,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing doctor.",
+                "description": "Updates the name, description and definition of a cohort. Only the author and administrators can change a cohort.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1699,25 +2342,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Doctor"
+                    "Cohort"
                 ],
-                "summary": "Update doctor",
+                "summary": "Update cohort",
                 "parameters": [
                     {
-                        "description": "Doctor data",
+                        "description": "Cohort data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Doctor"
+                            "$ref": "#/definitions/model.Cohort"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated doctor data",
+                        "description": "Updated cohort data",
                         "schema": {
-                            "$ref": "#/definitions/model.Doctor"
+                            "$ref": "#/definitions/model.Cohort"
                         }
                     },
                     "400": {
@@ -1726,6 +2369,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1735,7 +2390,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new doctor.",
+                "description": "Saves a cohort definition. Patient courses match the definition when they match every set criterion: disease, stage, diagnosis, course, drug, patient sex and age at the course begin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1743,25 +2398,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Doctor"
+                    "Cohort"
                 ],
-                "summary": "Create doctor",
+                "summary": "Create cohort",
                 "parameters": [
                     {
-                        "description": "Doctor data",
+                        "description": "Cohort data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Doctor"
+                            "$ref": "#/definitions/model.Cohort"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created doctor data",
+                        "description": "Created cohort data",
                         "schema": {
-                            "$ref": "#/definitions/model.Doctor"
+                            "$ref": "#/definitions/model.Cohort"
                         }
                     },
                     "400": {
@@ -1770,6 +2425,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1779,20 +2440,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/doctors/{id}": {
+        "/cohort/{id}": {
             "get": {
-                "description": "Retrieves a doctor by ID.",
+                "description": "Retrieves a saved cohort by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Doctor"
+                    "Cohort"
                 ],
-                "summary": "Get doctor by ID",
+                "summary": "Get cohort by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Doctor ID",
+                        "description": "Cohort ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1800,9 +2461,27 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Doctor data",
+                        "description": "Cohort data",
                         "schema": {
-                            "$ref": "#/definitions/model.Doctor"
+                            "$ref": "#/definitions/model.Cohort"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1814,18 +2493,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a doctor by ID.",
+                "description": "Deletes a cohort by ID. Only the author and administrators can delete a cohort.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Doctor"
+                    "Cohort"
                 ],
-                "summary": "Delete doctor",
+                "summary": "Delete cohort",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Doctor ID",
+                        "description": "Cohort ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1833,11 +2512,29 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Doctor ID deleted",
+                        "description": "Cohort ID deleted",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1847,27 +2544,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/drugs": {
+        "/cohort/{id}/statistics": {
             "get": {
-                "description": "Retrieves a list of drugs.",
+                "description": "Runs the cohort definition and retrieves aggregates of the matching patient courses: patient and course counts, patients by sex and stage, and blood count statistics per time bucket after the course begin. No individual records are returned, groups of fewer than k patients are merged or suppressed and listed in the privacy report.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Drug"
+                    "Cohort"
+                ],
+                "summary": "Get cohort statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cohort ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width of the time buckets in days, 30 by default",
+                        "name": "bucket-days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Blood count IDs, all blood counts by default",
+                        "name": "blood-count",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get drug list",
                 "responses": {
                     "200": {
-                        "description": "Drug list",
+                        "description": "Cohort statistics",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.Drug"
-                                }
-                            }
+                            "$ref": "#/definitions/model.CohortStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1877,35 +2611,73 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing drug.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/course-procedure": {
+            "get": {
+                "description": "Retrieves a list of course procedures.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Drug"
+                    "CourseProcedure"
                 ],
-                "summary": "Update drug",
+                "summary": "Get course procedure list",
                 "parameters": [
                     {
-                        "description": "Drug data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Drug"
-                        }
+                        "type": "integer",
+                        "description": "Patient course ID",
+                        "name": "patient-course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Doctor ID",
+                        "name": "doctor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest begin date (YYYY-MM-DD)",
+                        "name": "begin-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest begin date (YYYY-MM-DD)",
+                        "name": "begin-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated drug data",
+                        "description": "Course procedure list",
                         "schema": {
-                            "$ref": "#/definitions/model.Drug"
+                            "$ref": "#/definitions/model.Page-model_CourseProcedure"
                         }
                     },
                     "400": {
@@ -1914,6 +2686,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1922,8 +2700,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Creates a new drug.",
+            "put": {
+                "description": "Updates an existing course procedure entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1931,25 +2709,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Drug"
+                    "CourseProcedure"
                 ],
-                "summary": "Create drug",
+                "summary": "Update course procedure",
                 "parameters": [
                     {
-                        "description": "Drug data",
+                        "description": "Updated course procedure data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Drug"
+                            "$ref": "#/definitions/model.CourseProcedure"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created drug data",
+                        "description": "Updated course procedure data",
                         "schema": {
-                            "$ref": "#/definitions/model.Drug"
+                            "$ref": "#/definitions/model.CourseProcedure"
                         }
                     },
                     "400": {
@@ -1958,6 +2736,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1965,32 +2755,100 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/drugs/{id}": {
-            "get": {
-                "description": "Retrieves a drug by ID.",
+            },
+            "post": {
+                "description": "Creates a new course procedure entry.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Drug"
+                    "CourseProcedure"
                 ],
-                "summary": "Get drug by ID",
+                "summary": "Create a new course procedure",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Drug ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Course procedure data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CourseProcedure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created course procedure data",
+                        "schema": {
+                            "$ref": "#/definitions/model.CourseProcedure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/course-procedure/{id}": {
+            "get": {
+                "description": "Retrieves a course procedure entry by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CourseProcedure"
+                ],
+                "summary": "Get course procedure by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course procedure ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Drug data",
+                        "description": "Course procedure data",
                         "schema": {
-                            "$ref": "#/definitions/model.Drug"
+                            "$ref": "#/definitions/model.CourseProcedure"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2002,18 +2860,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a drug by ID.",
+                "description": "Deletes a course procedure entry by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Drug"
+                    "CourseProcedure"
                 ],
-                "summary": "Delete drug",
+                "summary": "Delete course procedure",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Drug ID",
+                        "description": "Course procedure ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2021,11 +2879,23 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Drug ID deleted",
+                        "description": "Course procedure ID",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2035,29 +2905,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/patient-courses": {
+        "/course-procedure/{id}/risk": {
             "get": {
-                "description": "Retrieves a list of patient courses.",
+                "description": "Scores diseases from the blood count results of the course procedure, like /analysis/score.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientCourse"
+                    "CourseProcedure"
+                ],
+                "summary": "Get course procedure risk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course procedure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get patient course list",
                 "responses": {
                     "200": {
-                        "description": "Patient course list",
+                        "description": "Ranked disease scores",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "type": "array",
                                 "items": {
-                                    "$ref": "#/definitions/model.PatientCourse"
+                                    "$ref": "#/definitions/model.DiseaseScore"
                                 }
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses": {
+            "get": {
+                "description": "Returns a list of courses.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get list of courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Drug ID",
+                        "name": "drug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of courses",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_Course"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2067,7 +3028,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing patient course.",
+                "description": "Updates an existing course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2075,25 +3036,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PatientCourse"
+                    "Courses"
                 ],
-                "summary": "Update patient course",
+                "summary": "Update course",
                 "parameters": [
                     {
-                        "description": "Patient course data",
-                        "name": "input",
+                        "description": "Updated course data",
+                        "name": "course",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PatientCourse"
+                            "$ref": "#/definitions/model.Course"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated patient course data",
+                        "description": "Updated course details",
                         "schema": {
-                            "$ref": "#/definitions/model.PatientCourse"
+                            "$ref": "#/definitions/model.Course"
                         }
                     },
                     "400": {
@@ -2111,7 +3072,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new patient course.",
+                "description": "Creates a new course.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2119,25 +3080,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PatientCourse"
+                    "Courses"
                 ],
-                "summary": "Create patient course",
+                "summary": "Create new course",
                 "parameters": [
                     {
-                        "description": "Patient course data",
-                        "name": "input",
+                        "description": "Course data",
+                        "name": "course",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PatientCourse"
+                            "$ref": "#/definitions/model.Course"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created patient course data",
+                        "description": "Created course details",
                         "schema": {
-                            "$ref": "#/definitions/model.PatientCourse"
+                            "$ref": "#/definitions/model.Course"
                         }
                     },
                     "400": {
@@ -2155,20 +3116,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/patient-courses/{id}": {
+        "/courses/{id}": {
             "get": {
-                "description": "Retrieves a patient course by ID.",
+                "description": "Returns a course by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientCourse"
+                    "Courses"
                 ],
-                "summary": "Get patient course by ID",
+                "summary": "Get course by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient course ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2176,9 +3137,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient course data",
+                        "description": "Course details",
                         "schema": {
-                            "$ref": "#/definitions/model.PatientCourse"
+                            "$ref": "#/definitions/model.Course"
                         }
                     },
                     "500": {
@@ -2190,18 +3151,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a patient course by ID.",
+                "description": "Deletes an existing course by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientCourse"
+                    "Courses"
                 ],
-                "summary": "Delete patient course",
+                "summary": "Delete course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient course ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2209,7 +3170,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient course ID deleted",
+                        "description": "ID of deleted course",
                         "schema": {
                             "type": "string"
                         }
@@ -2223,27 +3184,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/patient-diseases": {
+        "/diagnoses": {
             "get": {
-                "description": "Retrieves a list of patient diseases.",
+                "description": "Retrieves a list of diagnoses",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Diagnoses"
+                ],
+                "summary": "Get diagnosis list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get patient disease list",
                 "responses": {
                     "200": {
-                        "description": "Patient disease list",
+                        "description": "List of diagnoses",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.PatientDisease"
-                                }
-                            }
+                            "$ref": "#/definitions/model.Page-model_Diagnosis"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2254,8 +3241,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Updates an existing patient disease.",
+            "post": {
+                "description": "Creates a new diagnosis",
                 "consumes": [
                     "application/json"
                 ],
@@ -2263,25 +3250,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Diagnoses"
                 ],
-                "summary": "Update patient disease",
+                "summary": "Create diagnosis",
                 "parameters": [
                     {
-                        "description": "Patient disease data",
-                        "name": "input",
+                        "description": "Diagnosis object",
+                        "name": "diagnosis",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.PatientDisease"
+                            "$ref": "#/definitions/model.Diagnosis"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated patient disease data",
+                        "description": "Created diagnosis",
                         "schema": {
-                            "$ref": "#/definitions/model.PatientDisease"
+                            "$ref": "#/definitions/model.Diagnosis"
                         }
                     },
                     "400": {
@@ -2297,41 +3284,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new patient disease.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/diagnoses/{id}": {
+            "get": {
+                "description": "Retrieves a diagnosis by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Diagnoses"
                 ],
-                "summary": "Create patient disease",
+                "summary": "Get diagnosis by ID",
                 "parameters": [
                     {
-                        "description": "Patient disease data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.PatientDisease"
-                        }
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created patient disease data",
-                        "schema": {
-                            "$ref": "#/definitions/model.PatientDisease"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Diagnosis",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/model.Diagnosis"
                         }
                     },
                     "500": {
@@ -2341,38 +3319,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/patient-diseases/disease/{disease_id}": {
-            "get": {
-                "description": "Retrieves a list of patient diseases by disease ID.",
+            },
+            "put": {
+                "description": "Updates an existing diagnosis",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Diagnoses"
                 ],
-                "summary": "Get patient disease list by disease",
+                "summary": "Update diagnosis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
+                        "description": "Diagnosis ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Diagnosis object",
+                        "name": "diagnosis",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Diagnosis"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient disease list",
+                        "description": "Updated diagnosis",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.PatientDisease"
-                                }
-                            }
+                            "$ref": "#/definitions/model.Diagnosis"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2382,38 +3370,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/patient-diseases/patient/{patient_id}": {
-            "get": {
-                "description": "Retrieves a list of patient diseases by patient ID.",
+            },
+            "delete": {
+                "description": "Deletes an existing diagnosis by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Diagnoses"
                 ],
-                "summary": "Get patient disease list by patient",
+                "summary": "Delete diagnosis",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
-                        "name": "patient_id",
+                        "description": "Diagnosis ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient disease list",
+                        "description": "ID of deleted diagnosis",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.PatientDisease"
-                                }
-                            }
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -2425,110 +3405,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/patient-diseases/{patient_id}/{disease_id}": {
+        "/disease": {
             "get": {
-                "description": "Retrieves a patient disease by patient and disease ID.",
+                "description": "Retrieves a list of diseases.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PatientDisease"
+                    "Disease"
                 ],
-                "summary": "Get patient disease by ID",
+                "summary": "Get disease list",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Patient ID",
-                        "name": "patient_id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Patient disease data",
-                        "schema": {
-                            "$ref": "#/definitions/model.PatientDisease"
-                        }
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Deletes a patient disease by patient and disease ID.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "PatientDisease"
-                ],
-                "summary": "Delete patient disease",
-                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Patient ID",
-                        "name": "patient_id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Disease ID",
-                        "name": "disease_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient disease ID deleted",
+                        "description": "Disease list",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.Page-model_Disease"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/patients": {
-            "get": {
-                "description": "Retrieves a list of patients.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Patient"
-                ],
-                "summary": "Get patient list",
-                "responses": {
-                    "200": {
-                        "description": "Patient list",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.Patient"
-                                }
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -2539,7 +3463,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing patient.",
+                "description": "Updates an existing disease entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2547,25 +3471,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patient"
+                    "Disease"
                 ],
-                "summary": "Update patient",
+                "summary": "Update disease",
                 "parameters": [
                     {
-                        "description": "Patient data",
+                        "description": "Updated disease data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Patient"
+                            "$ref": "#/definitions/model.Disease"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated patient data",
+                        "description": "Updated disease data",
                         "schema": {
-                            "$ref": "#/definitions/model.Patient"
+                            "$ref": "#/definitions/model.Disease"
                         }
                     },
                     "400": {
@@ -2583,7 +3507,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new patient.",
+                "description": "Creates a new disease entry.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2591,25 +3515,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patient"
+                    "Disease"
                 ],
-                "summary": "Create patient",
+                "summary": "Create a new disease",
                 "parameters": [
                     {
-                        "description": "Patient data",
+                        "description": "Disease data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Patient"
+                            "$ref": "#/definitions/model.Disease"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created patient data",
+                        "description": "Created disease data",
                         "schema": {
-                            "$ref": "#/definitions/model.Patient"
+                            "$ref": "#/definitions/model.Disease"
                         }
                     },
                     "400": {
@@ -2627,20 +3551,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/patients/{id}": {
+        "/disease/{id}": {
             "get": {
-                "description": "Retrieves a patient by ID.",
+                "description": "Retrieves a disease entry by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Patient"
+                    "Disease"
                 ],
-                "summary": "Get patient by ID",
+                "summary": "Get disease by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
+                        "description": "Disease ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2648,9 +3572,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient data",
+                        "description": "Disease data",
                         "schema": {
-                            "$ref": "#/definitions/model.Patient"
+                            "$ref": "#/definitions/model.Disease"
                         }
                     },
                     "500": {
@@ -2662,18 +3586,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a patient by ID.",
+                "description": "Deletes a disease entry by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Patient"
+                    "Disease"
                 ],
-                "summary": "Delete patient",
+                "summary": "Delete disease",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Patient ID",
+                        "description": "Disease ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2681,7 +3605,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Patient ID deleted",
+                        "description": "Disease ID",
                         "schema": {
                             "type": "string"
                         }
@@ -2695,39 +3619,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/procedure-blood-count": {
-            "get": {
-                "description": "Retrieves a list of procedure blood count entries.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProcedureBloodCount"
-                ],
-                "summary": "Get procedure blood count list",
-                "responses": {
-                    "200": {
-                        "description": "Procedure blood count list",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.ProcedureBloodCount"
-                                }
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Updates an existing procedure blood count entry.",
+        "/doctor-patient": {
+            "post": {
+                "description": "Creates a relationship between a doctor and a patient.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2735,25 +3629,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProcedureBloodCount"
+                    "DoctorPatient"
                 ],
-                "summary": "Update procedure blood count",
+                "summary": "Create doctor-patient relationship",
                 "parameters": [
                     {
-                        "description": "Procedure blood count data",
+                        "description": "Doctor-patient relationship data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                            "$ref": "#/definitions/model.DoctorPatient"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated procedure blood count data",
+                        "description": "Created doctor-patient relationship data",
                         "schema": {
-                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                            "$ref": "#/definitions/model.DoctorPatient"
                         }
                     },
                     "400": {
@@ -2769,35 +3663,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new procedure blood count entry.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/doctor-patient/{doctor_id}": {
+            "get": {
+                "description": "Retrieves a list of patient IDs associated with the given doctor ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProcedureBloodCount"
+                    "DoctorPatient"
                 ],
-                "summary": "Create procedure blood count",
+                "summary": "Get doctor-patient relationship list by doctor ID",
                 "parameters": [
                     {
-                        "description": "Procedure blood count data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ProcedureBloodCount"
-                        }
+                        "type": "string",
+                        "description": "Doctor ID",
+                        "name": "doctor_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created procedure blood count data",
+                        "description": "Patient ID list",
                         "schema": {
-                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                            "$ref": "#/definitions/model.Page-model_DoctorPatient"
                         }
                     },
                     "400": {
@@ -2815,77 +3730,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/procedure-blood-count/blood-counts/{blood_count_id}": {
-            "get": {
-                "description": "Retrieves a list of procedure blood count entries by blood count ID.",
+        "/doctor-patient/{doctor_id}/{patient_id}": {
+            "delete": {
+                "description": "Deletes the relationship between a doctor and a patient.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProcedureBloodCount"
+                    "DoctorPatient"
                 ],
-                "summary": "Get procedure blood count list by blood count",
+                "summary": "Delete doctor-patient relationship",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
+                        "description": "Doctor ID",
+                        "name": "doctor_id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Procedure blood count list",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.ProcedureBloodCount"
-                                }
-                            }
-                        }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/procedure-blood-count/procedures/{procedure_id}": {
-            "get": {
-                "description": "Retrieves a list of procedure blood count entries by procedure ID.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProcedureBloodCount"
-                ],
-                "summary": "Get procedure blood count list by procedure",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Procedure ID",
-                        "name": "procedure_id",
+                        "description": "Patient ID",
+                        "name": "patient_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Procedure blood count list",
+                        "description": "Deleted doctor-patient relationship data",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.ProcedureBloodCount"
-                                }
-                            }
+                            "type": "integer"
                         }
                     },
                     "500": {
@@ -2897,107 +3772,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/procedure-blood-count/procedures/{procedure_id}/blood-counts/{blood_count_id}": {
+        "/doctors": {
             "get": {
-                "description": "Retrieves a procedure blood count entry by procedure ID and blood count ID.",
+                "description": "Retrieves a list of doctors.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProcedureBloodCount"
+                    "Doctor"
                 ],
-                "summary": "Get procedure blood count by IDs",
+                "summary": "Get doctor list",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Procedure ID",
-                        "name": "procedure_id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Procedure blood count data",
-                        "schema": {
-                            "$ref": "#/definitions/model.ProcedureBloodCount"
-                        }
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Deletes a procedure blood count entry by procedure ID and blood count ID.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ProcedureBloodCount"
-                ],
-                "summary": "Delete procedure blood count",
-                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Procedure ID",
-                        "name": "procedure_id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Blood count ID",
-                        "name": "blood_count_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Procedure blood count entry deleted",
+                        "description": "Doctor list",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.Page-model_Doctor"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/unit-measure": {
-            "get": {
-                "description": "Retrieves a list of unit measure entries.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "UnitMeasure"
-                ],
-                "summary": "Get unit measure list",
-                "responses": {
-                    "200": {
-                        "description": "Unit measure list",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UnitMeasure"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -3008,7 +3830,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing unit measure entry.",
+                "description": "Updates an existing doctor.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3016,25 +3838,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitMeasure"
+                    "Doctor"
                 ],
-                "summary": "Update unit measure",
+                "summary": "Update doctor",
                 "parameters": [
                     {
-                        "description": "Unit measure data",
+                        "description": "Doctor data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UnitMeasure"
+                            "$ref": "#/definitions/model.Doctor"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated unit measure data",
+                        "description": "Updated doctor data",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitMeasure"
+                            "$ref": "#/definitions/model.Doctor"
                         }
                     },
                     "400": {
@@ -3052,7 +3874,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new unit measure entry.",
+                "description": "Creates a new doctor.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3060,25 +3882,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UnitMeasure"
+                    "Doctor"
                 ],
-                "summary": "Create unit measure",
+                "summary": "Create doctor",
                 "parameters": [
                     {
-                        "description": "Unit measure data",
+                        "description": "Doctor data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UnitMeasure"
+                            "$ref": "#/definitions/model.Doctor"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created unit measure data",
+                        "description": "Created doctor data",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitMeasure"
+                            "$ref": "#/definitions/model.Doctor"
                         }
                     },
                     "400": {
@@ -3096,20 +3918,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/unit-measure/{id}": {
+        "/doctors/{id}": {
             "get": {
-                "description": "Retrieves a unit measure entry by ID.",
+                "description": "Retrieves a doctor by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "UnitMeasure"
+                    "Doctor"
                 ],
-                "summary": "Get unit measure by ID",
+                "summary": "Get doctor by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unit measure ID",
+                        "description": "Doctor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3117,9 +3939,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unit measure data",
+                        "description": "Doctor data",
                         "schema": {
-                            "$ref": "#/definitions/model.UnitMeasure"
+                            "$ref": "#/definitions/model.Doctor"
                         }
                     },
                     "500": {
@@ -3131,18 +3953,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a unit measure entry by ID.",
+                "description": "Deletes a doctor by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "UnitMeasure"
+                    "Doctor"
                 ],
-                "summary": "Delete unit measure",
+                "summary": "Delete doctor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unit measure ID",
+                        "description": "Doctor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3150,7 +3972,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Unit measure deleted",
+                        "description": "Doctor ID deleted",
                         "schema": {
                             "type": "string"
                         }
@@ -3163,171 +3985,6011 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.BloodCountValueResponse": {
-            "type": "object",
-            "properties": {
-                "blood_count_id": {
-                    "type": "string"
-                },
-                "disease_id": {
-                    "type": "string"
+        },
+        "/drugs": {
+            "get": {
+                "description": "Retrieves a list of drugs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drug"
+                ],
+                "summary": "Get drug list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dosage form",
+                        "name": "dosage-form",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Manufacturer",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Drug list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_Drug"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing drug.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drug"
+                ],
+                "summary": "Update drug",
+                "parameters": [
+                    {
+                        "description": "Drug data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Drug"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated drug data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Drug"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new drug.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drug"
+                ],
+                "summary": "Create drug",
+                "parameters": [
+                    {
+                        "description": "Drug data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Drug"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created drug data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Drug"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+        "/drugs/{id}": {
+            "get": {
+                "description": "Retrieves a drug by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drug"
+                ],
+                "summary": "Get drug by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Drug ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Drug data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Drug"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a drug by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drug"
+                ],
+                "summary": "Delete drug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Drug ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Drug ID deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "description": "Retrieves a list of the export jobs of the user, administrators see the jobs of every user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Get export job list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status: pending, running, done or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by requesting user ID, only administrators may list the jobs of other users",
+                        "name": "requested-by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_ExportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Starts a de-identified export of patients, diseases, courses, procedures and blood counts as CSV or NDJSON files in a zip bundle with a manifest of the data dictionary. Names, SNILS, phone, user accounts and free-text procedure results are stripped, patient, course and procedure IDs are replaced with keyed pseudonyms and birth dates are generalized to the year or a 10-year age band. Course and procedure dates are exported as days since the first course of the patient (dates: relative, the default) or shifted by a secret number of days per patient (dates: shifted). Quasi-identifiers of patients in groups of fewer than k patients are suppressed, the manifest reports what was suppressed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Create export job",
+                "parameters": [
+                    {
+                        "description": "Export job data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExportJob"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created export job data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Export pseudonym key is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/{id}": {
+            "get": {
+                "description": "Retrieves an export job by ID to follow its status. Only the requester and administrators may read a job.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Get export job by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export job data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ExportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/{id}/download": {
+            "get": {
+                "description": "Downloads the zip bundle of a finished export job. Only the requester and administrators may download it.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Export"
+                ],
+                "summary": "Download export bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export bundle",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Export is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Condition": {
+            "get": {
+                "description": "Searches patient diseases and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR Conditions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient reference",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease ID, optionally prefixed with the system and |",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a patient disease from a FHIR R4 Condition. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Create FHIR Condition",
+                "parameters": [
+                    {
+                        "description": "FHIR Condition",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRCondition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created FHIR Condition",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRCondition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Condition/{id}": {
+            "get": {
+                "description": "Retrieves a patient disease as a FHIR R4 Condition. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR Condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "\u003cpatient ID\u003e-\u003cdisease ID\u003e",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Condition",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRCondition"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a patient disease from a FHIR R4 Condition, the resource replaces the stored one. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Update FHIR Condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "\u003cpatient ID\u003e-\u003cdisease ID\u003e",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "FHIR Condition",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRCondition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated FHIR Condition",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRCondition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Medication": {
+            "get": {
+                "description": "Searches drugs and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR Medications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Medication/{id}": {
+            "get": {
+                "description": "Retrieves a drug as a FHIR R4 Medication. Medication is a drug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR Medication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Drug ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Medication",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedication"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/MedicationRequest": {
+            "get": {
+                "description": "Searches patient courses and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR MedicationRequests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient reference",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Practitioner reference",
+                        "name": "requester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a patient course from a FHIR R4 MedicationRequest. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Create FHIR MedicationRequest",
+                "parameters": [
+                    {
+                        "description": "FHIR MedicationRequest",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created FHIR MedicationRequest",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedicationRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/MedicationRequest/{id}": {
+            "get": {
+                "description": "Retrieves a patient course as a FHIR R4 MedicationRequest. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR MedicationRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR MedicationRequest",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedicationRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a patient course from a FHIR R4 MedicationRequest, the resource replaces the stored one. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Update FHIR MedicationRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "FHIR MedicationRequest",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated FHIR MedicationRequest",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRMedicationRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Observation": {
+            "get": {
+                "description": "Searches blood count results and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR Observations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient reference",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID, optionally prefixed with the system and |",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Procedure date (YYYY-MM-DD) with an optional eq, ge, gt, le or lt prefix",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a blood count result from a FHIR R4 Observation. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Create FHIR Observation",
+                "parameters": [
+                    {
+                        "description": "FHIR Observation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRObservation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created FHIR Observation",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRObservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Observation/{id}": {
+            "get": {
+                "description": "Retrieves a blood count result as a FHIR R4 Observation. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR Observation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "\u003cprocedure ID\u003e-\u003cblood count ID\u003e",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Observation",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRObservation"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a blood count result from a FHIR R4 Observation, the resource replaces the stored one. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Update FHIR Observation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "\u003cprocedure ID\u003e-\u003cblood count ID\u003e",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "FHIR Observation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRObservation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated FHIR Observation",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRObservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Patient": {
+            "get": {
+                "description": "Searches patients and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR Patients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SNILS, optionally prefixed with the system and |",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Beginning of the first, middle or last name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "male",
+                            "female",
+                            "other",
+                            "unknown"
+                        ],
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Birth date (YYYY-MM-DD) with an optional eq, ge, gt, le or lt prefix",
+                        "name": "birthdate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a patient from a FHIR R4 Patient. Patient is a patient with SNILS as the identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Create FHIR Patient",
+                "parameters": [
+                    {
+                        "description": "FHIR Patient",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPatient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created FHIR Patient",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPatient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Patient/{id}": {
+            "get": {
+                "description": "Retrieves a patient as a FHIR R4 Patient. Patient is a patient with SNILS as the identifier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR Patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Patient",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPatient"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a patient from a FHIR R4 Patient, the resource replaces the stored one. Patient is a patient with SNILS as the identifier.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Update FHIR Patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "FHIR Patient",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPatient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated FHIR Patient",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPatient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Practitioner": {
+            "get": {
+                "description": "Searches doctors and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Search FHIR Practitioners",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page from the next link",
+                        "name": "_cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "estimate",
+                            "accurate"
+                        ],
+                        "type": "string",
+                        "description": "Whether the bundle has the number of matching resources",
+                        "name": "_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Bundle",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRBundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/Practitioner/{id}": {
+            "get": {
+                "description": "Retrieves a doctor as a FHIR R4 Practitioner. Practitioner is a doctor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "Get FHIR Practitioner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Doctor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FHIR Practitioner",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIRPractitioner"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.FHIROperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/lab-code-mapping": {
+            "get": {
+                "description": "Retrieves a list of lab code mappings.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabCodeMapping"
+                ],
+                "summary": "Get lab code mapping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lab code mapping list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_LabCodeMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Maps an observation code of lab analyzers (OBX-3) to a blood count. A mapping with an empty system matches the code in any coding system. The measure code is the unit of the analyzer values, the unit of the message (OBX-6) is used without it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabCodeMapping"
+                ],
+                "summary": "Create lab code mapping",
+                "parameters": [
+                    {
+                        "description": "Lab code mapping data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LabCodeMapping"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created lab code mapping data",
+                        "schema": {
+                            "$ref": "#/definitions/model.LabCodeMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the mapping of the observation code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LabCodeMapping"
+                ],
+                "summary": "Delete lab code mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coding system, empty for the mapping of any system",
+                        "name": "system",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Observation code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted lab code mapping code",
+                        "schema": {
+                            "$ref": "#/definitions/model.LabCodeMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lab-message": {
+            "post": {
+                "description": "Stores the blood count results of an HL7 v2 ORU^R01 message of a lab analyzer and replies with the ACK message. Every order (OBR) becomes a course procedure of the patient (PID), found by SNILS or by the OncoBase patient ID, in the patient course of the placer order number or in the course under way on the observation date. The results (OBX) of the codes mapped to blood counts become the results of the procedure. The message is stored only if it has no errors, a message resent with the same control ID is acknowledged without storing it again. The ACK is AA for an accepted message with the warnings in ERR segments, AE for a message with errors and AR for a rejected message.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "LabMessage"
+                ],
+                "summary": "Ingest lab message",
+                "parameters": [
+                    {
+                        "description": "HL7 v2 ORU^R01 message",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "AA acknowledgment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "AR acknowledgment of a malformed or unsupported message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "AE acknowledgment with the errors of the message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "AR acknowledgment of a message that could not be processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-courses": {
+            "get": {
+                "description": "Retrieves a list of patient courses.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientCourse"
+                ],
+                "summary": "Get patient course list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Doctor ID",
+                        "name": "doctor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest begin date (YYYY-MM-DD)",
+                        "name": "begin-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest begin date (YYYY-MM-DD)",
+                        "name": "begin-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient course list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_PatientCourse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing patient course.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientCourse"
+                ],
+                "summary": "Update patient course",
+                "parameters": [
+                    {
+                        "description": "Patient course data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientCourse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated patient course data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientCourse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new patient course.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientCourse"
+                ],
+                "summary": "Create patient course",
+                "parameters": [
+                    {
+                        "description": "Patient course data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientCourse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created patient course data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientCourse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-courses/{id}": {
+            "get": {
+                "description": "Retrieves a patient course by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientCourse"
+                ],
+                "summary": "Get patient course by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient course data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientCourse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a patient course by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientCourse"
+                ],
+                "summary": "Delete patient course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient course ID deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-diseases": {
+            "get": {
+                "description": "Retrieves a list of patient diseases.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Get patient disease list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "diagnosis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient disease list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_PatientDisease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing patient disease.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Update patient disease",
+                "parameters": [
+                    {
+                        "description": "Patient disease data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientDisease"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated patient disease data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientDisease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new patient disease.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Create patient disease",
+                "parameters": [
+                    {
+                        "description": "Patient disease data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientDisease"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created patient disease data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientDisease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-diseases/disease/{disease_id}": {
+            "get": {
+                "description": "Retrieves a page of the patients with a disease among the patients the user has access to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Get patient disease list by disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient disease list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_PatientDisease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-diseases/patient/{patient_id}": {
+            "get": {
+                "description": "Retrieves a page of the diseases of a patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Get patient disease list by patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient disease list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_PatientDisease"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patient-diseases/{patient_id}/{disease_id}": {
+            "get": {
+                "description": "Retrieves a patient disease by patient and disease ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Get patient disease by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient disease data",
+                        "schema": {
+                            "$ref": "#/definitions/model.PatientDisease"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a patient disease by patient and disease ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PatientDisease"
+                ],
+                "summary": "Delete patient disease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "patient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Disease ID",
+                        "name": "disease_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient disease ID deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients": {
+            "get": {
+                "description": "Retrieves a list of patients.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Get patient list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Beginning of the first, middle or last name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "SNILS",
+                        "name": "snils",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sex",
+                        "name": "sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest birth date (YYYY-MM-DD)",
+                        "name": "birth-date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest birth date (YYYY-MM-DD)",
+                        "name": "birth-date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing patient.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Update patient",
+                "parameters": [
+                    {
+                        "description": "Patient data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated patient data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new patient. A doctor gets the created patient on their panel.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Create patient",
+                "parameters": [
+                    {
+                        "description": "Patient data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created patient data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/search": {
+            "get": {
+                "description": "Searches patients by full name with typo tolerance, the whole SNILS, at least the last 4 digits of the phone and birth date (YYYY-MM-DD or DD.MM.YYYY). Words of the query may be combined, like \"Иванов 01.05.1980\". Results are ranked, best matches first, a SNILS match above a phone match. Doctors only find the patients of their panel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Search patients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Found patients",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.PatientSearchResult"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}": {
+            "get": {
+                "description": "Retrieves a patient by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Get patient by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Patient"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a patient by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Delete patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient ID deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/timeline": {
+            "get": {
+                "description": "Retrieves the history of the patient as a chronological event stream: recorded diagnoses, started and ended courses, performed procedures and blood count results flagged against the normal range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Get patient timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patient timeline",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.TimelineEvent"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/trend": {
+            "get": {
+                "description": "Retrieves the results of a blood count of the patient ordered by procedure date, with the rate of change per day, the percent change from the baseline and the streaks of consecutive out-of-range results. With a patient course the results are compared before, during and after the course.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patient"
+                ],
+                "summary": "Get patient blood count trend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood-count",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Patient course ID",
+                        "name": "patient-course",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blood count trend",
+                        "schema": {
+                            "$ref": "#/definitions/model.Trend"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/procedure-blood-count": {
+            "get": {
+                "description": "Retrieves a list of procedure blood count entries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Get procedure blood count list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Course procedure ID",
+                        "name": "procedure",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood-count",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "low",
+                            "normal",
+                            "high"
+                        ],
+                        "type": "string",
+                        "description": "Flag of the result against the normal range",
+                        "name": "flag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Low and high results only or normal results only",
+                        "name": "abnormal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest procedure date (YYYY-MM-DD)",
+                        "name": "date-from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest procedure date (YYYY-MM-DD)",
+                        "name": "date-to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert the values to",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Procedure blood count list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing procedure blood count entry. The value is checked and flagged as on creation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Update procedure blood count",
+                "parameters": [
+                    {
+                        "description": "Procedure blood count data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated procedure blood count data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new procedure blood count entry. The value is converted to the unit of the blood count and stored in it. A value outside the possible range of the blood count is rejected, otherwise the result is flagged as low, normal or high against the normal range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Create procedure blood count",
+                "parameters": [
+                    {
+                        "description": "Procedure blood count data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created procedure blood count data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/procedure-blood-count/blood-counts/{blood_count_id}": {
+            "get": {
+                "description": "Retrieves a page of the results of a blood count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Get procedure blood count list by blood count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert the values to",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Procedure blood count list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/procedure-blood-count/import": {
+            "post": {
+                "description": "Imports blood count results from a CSV or XLSX file, the first row of which is the header. The mapping binds the file columns to blood counts and units, without columns every column other than the procedure one is a blood count ID with values in the unit of the blood count. Every row is validated against the possible ranges of the blood counts and the existing procedures and results. The results are created in a single transaction and only if no row has errors, the report lists the errors by row.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Import procedure blood counts",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column mapping as JSON",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file without saving the results",
+                        "name": "dry-run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import report",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Import report with the row errors",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/procedure-blood-count/procedures/{procedure_id}": {
+            "get": {
+                "description": "Retrieves a page of the blood count results of a procedure.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Get procedure blood count list by procedure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Procedure ID",
+                        "name": "procedure_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert the values to",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Procedure blood count list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/procedure-blood-count/procedures/{procedure_id}/blood-counts/{blood_count_id}": {
+            "get": {
+                "description": "Retrieves a procedure blood count entry by procedure ID and blood count ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Get procedure blood count by IDs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Procedure ID",
+                        "name": "procedure_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert the values to",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Procedure blood count data",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcedureBloodCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a procedure blood count entry by procedure ID and blood count ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProcedureBloodCount"
+                ],
+                "summary": "Delete procedure blood count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Procedure ID",
+                        "name": "procedure_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Blood count ID",
+                        "name": "blood_count_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Procedure blood count entry deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unit-conversion": {
+            "get": {
+                "description": "Retrieves a list of unit conversions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversion"
+                ],
+                "summary": "Get unit conversion list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unit conversion list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a conversion between units, a value is converted as value * factor + offset. The reverse conversion is derived.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversion"
+                ],
+                "summary": "Create unit conversion",
+                "parameters": [
+                    {
+                        "description": "Unit conversion data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitConversion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created unit conversion data",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the conversion between the units.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversion"
+                ],
+                "summary": "Delete unit conversion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted unit conversion units",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unit-conversion/convert": {
+            "get": {
+                "description": "Converts a value between units with the stored conversion or the reverse of it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitConversion"
+                ],
+                "summary": "Convert value",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Value",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID of the value",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID to convert the value to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Converted value",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConvertedValue"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unit-measure": {
+            "get": {
+                "description": "Retrieves a list of unit measure entries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitMeasure"
+                ],
+                "summary": "Get unit measure list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, next-cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the items matching the filter, true by default",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort fields, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unit measure list",
+                        "schema": {
+                            "$ref": "#/definitions/model.Page-model_UnitMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing unit measure entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitMeasure"
+                ],
+                "summary": "Update unit measure",
+                "parameters": [
+                    {
+                        "description": "Unit measure data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitMeasure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated unit measure data",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new unit measure entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitMeasure"
+                ],
+                "summary": "Create unit measure",
+                "parameters": [
+                    {
+                        "description": "Unit measure data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitMeasure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created unit measure data",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitMeasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/unit-measure/{id}": {
+            "get": {
+                "description": "Retrieves a unit measure entry by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitMeasure"
+                ],
+                "summary": "Get unit measure by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unit measure data",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnitMeasure"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a unit measure entry by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UnitMeasure"
+                ],
+                "summary": "Delete unit measure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unit measure ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unit measure deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.BloodCountValueResponse": {
+            "type": "object",
+            "properties": {
+                "blood_count_id": {
+                    "type": "string"
+                },
+                "disease_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AccountBloodCount": {
+            "type": "object",
+            "properties": {
+                "blood-count": {
+                    "type": "string"
+                },
+                "course": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "max-normal-value": {
+                    "type": "number"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "min-normal-value": {
+                    "type": "number"
+                },
+                "procedure": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created-at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity-id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "user-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuthUser": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BloodCount": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max-normal-value": {
+                    "type": "number"
+                },
+                "max-possible-value": {
+                    "type": "number"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "min-normal-value": {
+                    "type": "number"
+                },
+                "min-possible-value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BloodCountResult": {
+            "type": "object",
+            "required": [
+                "blood-count"
+            ],
+            "properties": {
+                "blood-count": {
+                    "type": "string"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BloodCountValue": {
+            "type": "object",
+            "required": [
+                "blood_count",
+                "coefficient",
+                "disease"
+            ],
+            "properties": {
+                "blood_count": {
+                    "description": "Type of blood count.",
+                    "type": "string"
+                },
+                "coefficient": {
+                    "description": "Coefficient value.",
+                    "type": "number"
+                },
+                "description": {
+                    "description": "Description of the blood count value. Optional.",
+                    "type": "string"
+                },
+                "disease": {
+                    "description": "Name of the disease.",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Cohort": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created-at": {
+                    "type": "string"
+                },
+                "created-by": {
+                    "type": "integer"
+                },
+                "definition": {
+                    "$ref": "#/definitions/model.CohortDefinition"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 300
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "model.CohortBloodCountStatistics": {
+            "type": "object",
+            "properties": {
+                "blood-count": {
+                    "type": "string"
+                },
+                "from-day": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "p5": {
+                    "type": "number"
+                },
+                "p95": {
+                    "type": "number"
+                },
+                "patients": {
+                    "type": "integer"
+                },
+                "q1": {
+                    "type": "number"
+                },
+                "q3": {
+                    "type": "number"
+                },
+                "results": {
+                    "type": "integer"
+                },
+                "to-day": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CohortCount": {
+            "type": "object",
+            "properties": {
+                "patients": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CohortDefinition": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diagnoses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "diseases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "drugs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max-age": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min-age": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CohortStatistics": {
+            "type": "object",
+            "properties": {
+                "blood-counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CohortBloodCountStatistics"
+                    }
+                },
+                "bucket-days": {
+                    "type": "integer"
+                },
+                "cohort": {
+                    "type": "integer"
+                },
+                "courses": {
+                    "type": "integer"
+                },
+                "patients": {
+                    "type": "integer"
+                },
+                "privacy": {
+                    "$ref": "#/definitions/model.PrivacyReport"
+                },
+                "sex": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CohortCount"
+                    }
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CohortCount"
+                    }
+                }
+            }
+        },
+        "model.ConfirmResetPasswordInput": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConsoleStatistics": {
+            "type": "object",
+            "properties": {
+                "active-courses": {
+                    "type": "integer"
+                },
+                "doctors": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "patients": {
+                    "type": "integer"
+                },
+                "procedures": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConvertedValue": {
+            "type": "object",
+            "properties": {
+                "measure-code": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Course": {
+            "type": "object",
+            "properties": {
+                "dose": {
+                    "type": "number"
+                },
+                "drug": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CourseProcedure": {
+            "type": "object",
+            "properties": {
+                "begin-date": {
+                    "type": "string"
+                },
+                "doctor": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "patient-course": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Diagnosis": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Disease": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DiseaseScore": {
+            "type": "object",
+            "properties": {
+                "disease": {
+                    "type": "string"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Doctor": {
+            "type": "object",
+            "properties": {
+                "first-name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last-name": {
+                    "type": "string"
+                },
+                "middle-name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "qualification": {
+                    "type": "string"
+                },
+                "user-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DoctorPatient": {
+            "type": "object",
+            "properties": {
+                "doctor": {
+                    "type": "integer"
+                },
+                "patient": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Drug": {
+            "type": "object",
+            "properties": {
+                "active-ingredients": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dosage-form": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "manufacturer": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prescribing-order": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExportJob": {
+            "type": "object",
+            "required": [
+                "birth-date",
+                "format"
+            ],
+            "properties": {
+                "birth-date": {
+                    "type": "string",
+                    "enum": [
+                        "year",
+                        "age-band"
+                    ]
+                },
+                "created-at": {
+                    "type": "string"
+                },
+                "dates": {
+                    "type": "string",
+                    "enum": [
+                        "relative",
+                        "shifted"
+                    ]
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished-at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "ndjson"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requested-by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRBundle": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRBundleEntry"
+                    }
+                },
+                "link": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRBundleLink"
+                    }
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRBundleEntry": {
+            "type": "object",
+            "properties": {
+                "fullUrl": {
+                    "type": "string"
+                },
+                "resource": {}
+            }
+        },
+        "model.FHIRBundleLink": {
+            "type": "object",
+            "properties": {
+                "relation": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRCodeableConcept": {
+            "type": "object",
+            "properties": {
+                "coding": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRCoding"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRCoding": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRCondition": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRConditionEvidence"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRConditionStage"
+                    }
+                },
+                "subject": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                }
+            }
+        },
+        "model.FHIRConditionEvidence": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRCodeableConcept"
+                    }
+                }
+            }
+        },
+        "model.FHIRConditionStage": {
+            "type": "object",
+            "properties": {
+                "summary": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                }
+            }
+        },
+        "model.FHIRContactPoint": {
+            "type": "object",
+            "properties": {
+                "system": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRDosage": {
+            "type": "object",
+            "properties": {
+                "doseAndRate": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRDoseAndRate"
+                    }
+                },
+                "timing": {
+                    "$ref": "#/definitions/model.FHIRTiming"
+                }
+            }
+        },
+        "model.FHIRDoseAndRate": {
+            "type": "object",
+            "properties": {
+                "doseQuantity": {
+                    "$ref": "#/definitions/model.FHIRQuantity"
+                }
+            }
+        },
+        "model.FHIRHumanName": {
+            "type": "object",
+            "properties": {
+                "family": {
+                    "type": "string"
+                },
+                "given": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "use": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRIdentifier": {
+            "type": "object",
+            "properties": {
+                "system": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRIssue": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "diagnostics": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRMedication": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                },
+                "form": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRMedicationIngredient"
+                    }
+                },
+                "manufacturer": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                },
+                "resourceType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRMedicationIngredient": {
+            "type": "object",
+            "properties": {
+                "itemCodeableConcept": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                }
+            }
+        },
+        "model.FHIRMedicationRequest": {
+            "type": "object",
+            "properties": {
+                "dosageInstruction": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRDosage"
+                    }
+                },
+                "groupIdentifier": {
+                    "$ref": "#/definitions/model.FHIRIdentifier"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "intent": {
+                    "type": "string"
+                },
+                "medicationReference": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                },
+                "reasonCode": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRCodeableConcept"
+                    }
+                },
+                "reasonReference": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRReference"
+                    }
+                },
+                "requester": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                }
+            }
+        },
+        "model.FHIRObservation": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRCodeableConcept"
+                    }
+                },
+                "code": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                },
+                "effectiveDateTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interpretation": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRCodeableConcept"
+                    }
+                },
+                "partOf": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRReference"
+                    }
+                },
+                "referenceRange": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRObservationRange"
+                    }
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "$ref": "#/definitions/model.FHIRReference"
+                },
+                "valueQuantity": {
+                    "$ref": "#/definitions/model.FHIRQuantity"
+                }
+            }
+        },
+        "model.FHIRObservationRange": {
+            "type": "object",
+            "properties": {
+                "high": {
+                    "$ref": "#/definitions/model.FHIRQuantity"
+                },
+                "low": {
+                    "$ref": "#/definitions/model.FHIRQuantity"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                }
+            }
+        },
+        "model.FHIROperationOutcome": {
+            "type": "object",
+            "properties": {
+                "issue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRIssue"
+                    }
+                },
+                "resourceType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRPatient": {
+            "type": "object",
+            "properties": {
+                "birthDate": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRIdentifier"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRHumanName"
+                    }
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "telecom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRContactPoint"
+                    }
+                }
+            }
+        },
+        "model.FHIRPeriod": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRPractitioner": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRHumanName"
+                    }
+                },
+                "qualification": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRPractitionerQualification"
+                    }
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "telecom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FHIRContactPoint"
+                    }
+                }
+            }
+        },
+        "model.FHIRPractitionerQualification": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/model.FHIRCodeableConcept"
+                }
+            }
+        },
+        "model.FHIRQuantity": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.FHIRReference": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FHIRTiming": {
+            "type": "object",
+            "properties": {
+                "repeat": {
+                    "$ref": "#/definitions/model.FHIRTimingRepeat"
+                }
+            }
+        },
+        "model.FHIRTimingRepeat": {
+            "type": "object",
+            "properties": {
+                "boundsPeriod": {
+                    "$ref": "#/definitions/model.FHIRPeriod"
+                }
+            }
+        },
+        "model.FailedLogin": {
+            "type": "object",
+            "properties": {
+                "created-at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportReport": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "dry-run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportError"
+                    }
+                },
+                "results": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Invitation": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "expires-at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InvitationAcceptance": {
+            "type": "object",
+            "required": [
+                "first-name",
+                "last-name",
+                "password",
+                "token"
+            ],
+            "properties": {
+                "first-name": {
+                    "type": "string"
+                },
+                "last-name": {
+                    "type": "string"
+                },
+                "middle-name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "qualification": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LabCodeMapping": {
+            "type": "object",
+            "required": [
+                "blood-count",
+                "code"
+            ],
+            "properties": {
+                "blood-count": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LegacyUserMigration": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LegacyUserRow"
+                    }
+                },
+                "external": {
+                    "type": "integer"
+                },
+                "internal": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LegacyUserRow"
+                    }
+                }
+            }
+        },
+        "model.LegacyUserRow": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Page-model_BloodCount": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BloodCount"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_BloodCountValue": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BloodCountValue"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Cohort": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Cohort"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Course": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Course"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_CourseProcedure": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CourseProcedure"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Diagnosis": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Diagnosis"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Disease": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Disease"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Doctor": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Doctor"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_DoctorPatient": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DoctorPatient"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Drug": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Drug"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_ExportJob": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExportJob"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_LabCodeMapping": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LabCodeMapping"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Patient": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Patient"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_PatientCourse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PatientCourse"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_PatientDisease": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PatientDisease"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_Permission": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Permission"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_ProcedureBloodCount": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProcedureBloodCount"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_UnitConversion": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnitConversion"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_UnitMeasure": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UnitMeasure"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Page-model_UserProfile": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserProfile"
+                    }
+                },
+                "next-cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PanelPatient": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PatientCourse"
+                    }
+                },
+                "diseases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PatientDisease"
+                    }
+                },
+                "patient": {
+                    "$ref": "#/definitions/model.Patient"
+                }
+            }
+        },
+        "model.Patient": {
+            "type": "object",
+            "properties": {
+                "birth-date": {
+                    "type": "string"
+                },
+                "first-name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last-name": {
+                    "type": "string"
+                },
+                "middle-name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "snils": {
+                    "type": "string"
+                },
+                "user-id": {
+                    "type": "object"
+                }
+            }
+        },
+        "model.PatientCourse": {
+            "type": "object",
+            "properties": {
+                "begin-date": {
+                    "type": "string"
+                },
+                "course": {
+                    "type": "string"
+                },
+                "diagnosis": {
+                    "type": "string"
+                },
+                "disease": {
+                    "type": "string"
+                },
+                "doctor": {
+                    "type": "integer"
+                },
+                "end-date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "patient": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PatientDisease": {
+            "type": "object",
+            "properties": {
+                "diagnosis": {
+                    "type": "string"
+                },
+                "disease": {
+                    "type": "string"
+                },
+                "patient": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PatientRegistration": {
+            "type": "object",
+            "required": [
+                "code",
+                "email",
+                "password",
+                "snils"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "snils": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PatientSearchResult": {
+            "type": "object",
+            "properties": {
+                "birth-date": {
+                    "type": "string"
+                },
+                "first-name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last-name": {
+                    "type": "string"
+                },
+                "middle-name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "snils": {
+                    "type": "string"
+                },
+                "user-id": {
+                    "type": "object"
+                }
+            }
+        },
+        "model.Permission": {
+            "type": "object",
+            "required": [
+                "action",
+                "resource",
+                "role"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PrivacyReport": {
+            "type": "object",
+            "properties": {
+                "k": {
+                    "type": "integer"
+                },
+                "suppressed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PrivacySuppression"
+                    }
                 }
             }
         },
-        "model.AuthUser": {
+        "model.PrivacySuppression": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "action": {
                     "type": "string"
                 },
-                "password": {
+                "field": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "model.BloodCount": {
+        "model.ProcedureBloodCount": {
             "type": "object",
             "properties": {
-                "description": {
+                "blood-count": {
                     "type": "string"
                 },
-                "id": {
+                "flag": {
                     "type": "string"
                 },
-                "max-normal-value": {
-                    "type": "number"
-                },
-                "max-possible-value": {
-                    "type": "number"
-                },
                 "measure-code": {
                     "type": "string"
                 },
-                "min-normal-value": {
-                    "type": "number"
+                "procedure": {
+                    "type": "integer"
                 },
-                "min-possible-value": {
+                "value": {
                     "type": "number"
                 }
             }
         },
-        "model.BloodCountValue": {
+        "model.RefreshInput": {
             "type": "object",
             "required": [
-                "blood_count",
-                "coefficient",
-                "disease"
+                "refresh-token"
             ],
             "properties": {
-                "blood_count": {
-                    "description": "Type of blood count.",
-                    "type": "string"
-                },
-                "coefficient": {
-                    "description": "Coefficient value.",
-                    "type": "number"
-                },
-                "description": {
-                    "description": "Description of the blood count value. Optional.",
-                    "type": "string"
-                },
-                "disease": {
-                    "description": "Name of the disease.",
+                "refresh-token": {
                     "type": "string"
                 }
             }
         },
-        "model.Course": {
+        "model.RegistrationCode": {
             "type": "object",
             "properties": {
-                "dose": {
-                    "type": "number"
-                },
-                "drug": {
-                    "type": "string"
-                },
-                "frequency": {
-                    "type": "number"
-                },
-                "id": {
+                "code": {
                     "type": "string"
                 },
-                "measure-code": {
+                "expires-at": {
                     "type": "string"
                 },
-                "period": {
+                "patient-id": {
                     "type": "integer"
                 }
             }
         },
-        "model.CourseProcedure": {
+        "model.ResetPasswordInput": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "begin-date": {
+                "email": {
                     "type": "string"
-                },
-                "doctor": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "patient-course": {
-                    "type": "integer"
-                },
-                "period": {
-                    "type": "integer"
-                },
-                "result": {
-                    "type": "integer"
                 }
             }
         },
-        "model.Diagnosis": {
+        "model.RoleInput": {
             "type": "object",
+            "required": [
+                "role"
+            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "model.Disease": {
+        "model.ScoreInput": {
             "type": "object",
+            "required": [
+                "results"
+            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.BloodCountResult"
+                    }
                 }
             }
         },
-        "model.Doctor": {
+        "model.StaffUser": {
             "type": "object",
+            "required": [
+                "email",
+                "first-name",
+                "last-name",
+                "password",
+                "role"
+            ],
             "properties": {
-                "first-name": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "first-name": {
+                    "type": "string"
                 },
                 "last-name": {
                     "type": "string"
@@ -3335,119 +9997,197 @@ const docTemplate = `{
                 "middle-name": {
                     "type": "string"
                 },
-                "phone": {
+                "password": {
                     "type": "string"
                 },
-                "qualification": {
+                "phone": {
                     "type": "string"
                 },
-                "user-id": {
-                    "type": "integer"
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "model.DoctorPatient": {
+        "model.TimelineEvent": {
             "type": "object",
             "properties": {
+                "blood-count": {
+                    "type": "string"
+                },
+                "course": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "diagnosis": {
+                    "type": "string"
+                },
+                "disease": {
+                    "type": "string"
+                },
                 "doctor": {
                     "type": "integer"
                 },
-                "patient": {
+                "flag": {
+                    "type": "string"
+                },
+                "measure-code": {
+                    "type": "string"
+                },
+                "patient-course": {
                     "type": "integer"
+                },
+                "procedure": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "model.Drug": {
+        "model.Tokens": {
             "type": "object",
             "properties": {
-                "active-ingredients": {
+                "refresh-token": {
                     "type": "string"
                 },
-                "country": {
+                "token": {
                     "type": "string"
+                }
+            }
+        },
+        "model.Trend": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "type": "number"
                 },
-                "description": {
+                "blood-count": {
                     "type": "string"
                 },
-                "dosage-form": {
-                    "type": "string"
+                "patient": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "patient-course": {
+                    "type": "integer"
                 },
-                "manufacturer": {
-                    "type": "string"
+                "phases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrendPhase"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrendPoint"
+                    }
                 },
-                "prescribing-order": {
-                    "type": "string"
+                "streaks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrendStreak"
+                    }
                 }
             }
         },
-        "model.Patient": {
-            "type": "object"
-        },
-        "model.PatientCourse": {
+        "model.TrendPhase": {
             "type": "object",
             "properties": {
-                "begin-date": {
+                "count": {
+                    "type": "integer"
+                },
+                "first": {
+                    "type": "number"
+                },
+                "last": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "phase": {
                     "type": "string"
+                }
+            }
+        },
+        "model.TrendPoint": {
+            "type": "object",
+            "properties": {
+                "change-from-baseline": {
+                    "type": "number"
                 },
-                "course": {
+                "date": {
                     "type": "string"
                 },
-                "diagnosis": {
+                "flag": {
                     "type": "string"
                 },
-                "disease": {
+                "measure-code": {
                     "type": "string"
                 },
-                "doctor": {
+                "patient-course": {
                     "type": "integer"
                 },
-                "end-date": {
+                "phase": {
                     "type": "string"
                 },
-                "id": {
+                "procedure": {
                     "type": "integer"
                 },
-                "patient": {
-                    "type": "integer"
+                "rate-of-change": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "model.PatientDisease": {
+        "model.TrendStreak": {
             "type": "object",
             "properties": {
-                "diagnosis": {
+                "flag": {
                     "type": "string"
                 },
-                "disease": {
+                "from": {
                     "type": "string"
                 },
-                "patient": {
+                "length": {
                     "type": "integer"
                 },
-                "stage": {
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "model.ProcedureBloodCount": {
+        "model.UnitConversion": {
             "type": "object",
+            "required": [
+                "factor",
+                "from",
+                "to"
+            ],
             "properties": {
-                "blood-count": {
-                    "type": "string"
+                "factor": {
+                    "type": "number"
                 },
-                "measure-code": {
+                "from": {
                     "type": "string"
                 },
-                "procedure": {
-                    "type": "integer"
+                "offset": {
+                    "type": "number"
                 },
-                "value": {
+                "to": {
                     "type": "string"
                 }
             }
@@ -3469,25 +10209,44 @@ const docTemplate = `{
                 }
             }
         },
-        "model.User": {
+        "model.UserProfile": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "role"
-            ],
             "properties": {
+                "created-at": {
+                    "type": "string"
+                },
+                "doctor-id": {
+                    "type": "integer"
+                },
                 "email": {
                     "type": "string"
                 },
+                "first-name": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "password": {
+                "last-name": {
+                    "type": "string"
+                },
+                "locked": {
+                    "type": "boolean"
+                },
+                "middle-name": {
+                    "type": "string"
+                },
+                "patient-id": {
+                    "type": "integer"
+                },
+                "phone": {
                     "type": "string"
                 },
                 "role": {
                     "type": "string"
+                },
+                "user-type": {
+                    "type": "string"
                 }
             }
         }
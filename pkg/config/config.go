@@ -15,6 +15,7 @@ type ConfigDatabase struct {
 	User     string `yml:"user" env:"USER" env-default:"user"`
 	Password string `yml:"password" env:"PASSWORD"`
 	SSLMode  string `yml:"sslmode" env:"SSLMODE"`
+	Migrate  bool   `yml:"migrate" env:"MIGRATE"` // apply pending migrations on startup
 }
 
 func (c *ConfigDatabase) GetDataSourceName() string {
@@ -27,9 +28,28 @@ type ConfigServer struct {
 	Host string `yml:"host" env:"HOST" env-default:"localhost"`
 }
 
+type ConfigEmail struct {
+	Host     string `yml:"host" env:"HOST" env-default:"localhost"`
+	Port     string `yml:"port" env:"PORT" env-default:"25"`
+	From     string `yml:"from" env:"FROM"`
+	Password string `yml:"password" env:"PASSWORD"`
+}
+
+type ConfigExport struct {
+	Dir          string `yml:"dir" env:"DIR" env-default:"exports"` // directory of the export bundles
+	PseudonymKey string `yml:"pseudonym-key" env:"PSEUDONYM_KEY"`   // key of the patient pseudonyms in exports
+}
+
+type ConfigPrivacy struct {
+	K int `yml:"k" env:"K" env-default:"5"` // smallest group of patients reported by aggregates and exports
+}
+
 type ConfigApp struct {
 	Database ConfigDatabase
 	Server   ConfigServer
+	Email    ConfigEmail
+	Export   ConfigExport
+	Privacy  ConfigPrivacy
 }
 
 type ConfigInfo struct {
@@ -76,8 +96,31 @@ func InitConfig(configInfo ConfigInfo) *ConfigApp {
 		panic(fmt.Errorf("unable to decode into struct, %v", err))
 	}
 
+	var emailConfig ConfigEmail
+	err = viper.Sub("email").Unmarshal(&emailConfig)
+	if err != nil {
+		panic(fmt.Errorf("unable to decode into struct, %v", err))
+	}
+	emailConfig.Password = os.Getenv("EMAIL_PASSWORD")
+
+	var exportConfig ConfigExport
+	err = viper.Sub("export").Unmarshal(&exportConfig)
+	if err != nil {
+		panic(fmt.Errorf("unable to decode into struct, %v", err))
+	}
+	exportConfig.PseudonymKey = os.Getenv("EXPORT_PSEUDONYM_KEY")
+
+	var privacyConfig ConfigPrivacy
+	err = viper.Sub("privacy").Unmarshal(&privacyConfig)
+	if err != nil {
+		panic(fmt.Errorf("unable to decode into struct, %v", err))
+	}
+
 	return &ConfigApp{
 		Database: databaseConfig,
 		Server:   serverConfig,
+		Email:    emailConfig,
+		Export:   exportConfig,
+		Privacy:  privacyConfig,
 	}
 }
@@ -0,0 +1,278 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Migration files are named <version>_<name>.up.sql and <version>_<name>.down.sql
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const (
+	migrationTable = "public.schema_migrations"
+
+	// migrationLockId is the key of the advisory lock held while migrating,
+	// so application instances started together do not migrate at the same time
+	migrationLockId = 7261543
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrUnknownVersion is returned when migrating to a version that has no migration.
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+// Migration is a numbered schema change with the SQL to apply and to revert it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Migrator applies the embedded migrations and records applied versions in the schema_migrations table.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sqlx.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns the known migrations in version order.
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest returns the version of the newest migration.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the current schema version, 0 if no migration was applied.
+func (m *Migrator) Version() (int, error) {
+	if err := m.createMigrationTable(context.Background(), m.db); err != nil {
+		return 0, err
+	}
+	var version int
+	err := m.db.Get(&version, fmt.Sprintf("SELECT COALESCE(max(version), 0) FROM %s", migrationTable))
+	return version, err
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	return m.To(m.Latest())
+}
+
+// Down reverts the given number of the latest applied migrations.
+func (m *Migrator) Down(steps int) error {
+	return m.migrate(func(current int) int {
+		return stepsBack(m.migrations, current, steps)
+	})
+}
+
+// To applies or reverts migrations until the schema is at the version. Version 0 reverts all migrations.
+func (m *Migrator) To(version int) error {
+	if version != 0 && indexOf(m.migrations, version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.migrate(func(int) int {
+		return version
+	})
+}
+
+// migrate moves the schema from the current version to the target one under the migration lock.
+// Every migration runs in its own transaction together with the update of the schema_migrations table.
+func (m *Migrator) migrate(target func(current int) int) error {
+	ctx := context.Background()
+
+	// Advisory locks belong to the session, so the whole run uses a single connection
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockId); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockId)
+
+	if err := m.createMigrationTable(ctx, conn); err != nil {
+		return err
+	}
+
+	var current int
+	query := fmt.Sprintf("SELECT COALESCE(max(version), 0) FROM %s", migrationTable)
+	if err := conn.GetContext(ctx, &current, query); err != nil {
+		return err
+	}
+
+	for _, s := range plan(m.migrations, current, target(current)) {
+		if err := m.apply(ctx, conn, s); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", s.migration.Version, s.migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// apply runs a single migration step and records it in the schema_migrations table
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, s step) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if s.up {
+		if _, err := tx.ExecContext(ctx, s.migration.Up); err != nil {
+			return err
+		}
+		query := fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", migrationTable)
+		if _, err := tx.ExecContext(ctx, query, s.migration.Version, s.migration.Name); err != nil {
+			return err
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx, s.migration.Down); err != nil {
+			return err
+		}
+		query := fmt.Sprintf("DELETE FROM %s WHERE version=$1", migrationTable)
+		if _, err := tx.ExecContext(ctx, query, s.migration.Version); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) createMigrationTable(ctx context.Context, db sqlx.ExecerContext) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s
+	(
+		version    INT          NOT NULL,
+		name       VARCHAR(100) NOT NULL,
+		applied_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+		PRIMARY KEY (version)
+	)`, migrationTable)
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+// step is a migration to apply, or to revert if up is false.
+type step struct {
+	migration Migration
+	up        bool
+}
+
+// plan returns the steps moving the schema from the current version to the target one.
+func plan(migrations []Migration, current, target int) []step {
+	var steps []step
+	if target >= current {
+		for _, migration := range migrations {
+			if migration.Version > current && migration.Version <= target {
+				steps = append(steps, step{migration: migration, up: true})
+			}
+		}
+		return steps
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if migrations[i].Version <= current && migrations[i].Version > target {
+			steps = append(steps, step{migration: migrations[i], up: false})
+		}
+	}
+	return steps
+}
+
+// stepsBack returns the version the schema is at after reverting the given number of migrations from the current version.
+func stepsBack(migrations []Migration, current, steps int) int {
+	i := len(migrations) - 1
+	for i >= 0 && migrations[i].Version > current {
+		i--
+	}
+	i -= steps
+	if i < 0 {
+		return 0
+	}
+	return migrations[i].Version
+}
+
+func indexOf(migrations []Migration, version int) int {
+	for i, migration := range migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// loadMigrations reads migrations from the directory, every version needs both an up and a down file
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, err
+		}
+		if version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].Version)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	testTable := []struct {
+		name             string
+		files            fstest.MapFS
+		expectedVersions []int
+		expectedError    string
+	}{
+		{
+			name: "OK",
+			files: fstest.MapFS{
+				"m/0002_add_column.up.sql":      {Data: []byte("ALTER")},
+				"m/0002_add_column.down.sql":    {Data: []byte("ALTER")},
+				"m/0010_add_index.up.sql":       {Data: []byte("CREATE")},
+				"m/0010_add_index.down.sql":     {Data: []byte("DROP")},
+				"m/0001_create_schema.up.sql":   {Data: []byte("CREATE")},
+				"m/0001_create_schema.down.sql": {Data: []byte("DROP")},
+			},
+			expectedVersions: []int{1, 2, 10},
+		},
+		{
+			name: "Missing down",
+			files: fstest.MapFS{
+				"m/0001_create_schema.up.sql": {Data: []byte("CREATE")},
+			},
+			expectedError: "migration 1_create_schema needs both up and down files",
+		},
+		{
+			name: "Invalid name",
+			files: fstest.MapFS{
+				"m/create_schema.sql": {Data: []byte("CREATE")},
+			},
+			expectedError: `invalid migration file name "create_schema.sql"`,
+		},
+		{
+			name: "Different names",
+			files: fstest.MapFS{
+				"m/0001_create_schema.up.sql": {Data: []byte("CREATE")},
+				"m/0001_drop_schema.down.sql": {Data: []byte("DROP")},
+			},
+			expectedError: `migration 1 has different names "create_schema" and "drop_schema"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			migrations, err := loadMigrations(testCase.files, "m")
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			var versions []int
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, testCase.expectedVersions, versions)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	testTable := []struct {
+		name          string
+		current       int
+		target        int
+		expectedSteps []step
+	}{
+		{
+			name:    "Up from empty",
+			current: 0,
+			target:  5,
+			expectedSteps: []step{
+				{migration: migrations[0], up: true},
+				{migration: migrations[1], up: true},
+				{migration: migrations[2], up: true},
+			},
+		},
+		{
+			name:          "Up to version",
+			current:       1,
+			target:        2,
+			expectedSteps: []step{{migration: migrations[1], up: true}},
+		},
+		{
+			name:    "Down to version",
+			current: 5,
+			target:  1,
+			expectedSteps: []step{
+				{migration: migrations[2], up: false},
+				{migration: migrations[1], up: false},
+			},
+		},
+		{
+			name:    "Current",
+			current: 2,
+			target:  2,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedSteps, plan(migrations, testCase.current, testCase.target))
+		})
+	}
+}
+
+func TestStepsBack(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	assert.Equal(t, 2, stepsBack(migrations, 5, 1))
+	assert.Equal(t, 1, stepsBack(migrations, 5, 2))
+	assert.Equal(t, 0, stepsBack(migrations, 5, 3))
+	assert.Equal(t, 0, stepsBack(migrations, 2, 10))
+	assert.Equal(t, 0, stepsBack(migrations, 0, 1))
+}
@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS onco_base.invitation;
+DROP TABLE IF EXISTS onco_base.audit_log;
+DROP FUNCTION IF EXISTS onco_base.reject_audit_log_change;
+DROP TABLE IF EXISTS onco_base.failed_login;
+DROP TABLE IF EXISTS onco_base.registration_code;
+DROP TABLE IF EXISTS onco_base.password_reset_token;
+DROP TABLE IF EXISTS onco_base.user_token_revocation;
+DROP TABLE IF EXISTS onco_base.revoked_access_token;
+DROP TABLE IF EXISTS onco_base.refresh_token;
+DROP TABLE IF EXISTS onco_base.role_permission;
+DROP TABLE IF EXISTS onco_base.procedure_blood_count;
+DROP TABLE IF EXISTS onco_base.course_procedure;
+DROP TABLE IF EXISTS onco_base.blood_count_value;
+DROP TABLE IF EXISTS onco_base.patient_course;
+DROP TABLE IF EXISTS onco_base.patient_disease;
+DROP TABLE IF EXISTS onco_base.disease;
+DROP TABLE IF EXISTS onco_base.blood_count;
+DROP TABLE IF EXISTS onco_base.course;
+DROP TABLE IF EXISTS onco_base.unit_measure;
+DROP TABLE IF EXISTS onco_base.drug;
+DROP TABLE IF EXISTS onco_base.diagnosis;
+DROP TABLE IF EXISTS onco_base.doctor_patient;
+DROP TABLE IF EXISTS onco_base.doctor;
+DROP TABLE IF EXISTS onco_base.patient;
+DROP TABLE IF EXISTS onco_base.admin;
+DROP TABLE IF EXISTS onco_base.app_user;
+DROP TABLE IF EXISTS onco_base.internal_user;
+DROP TABLE IF EXISTS onco_base.external_user;
+
+DROP SCHEMA IF EXISTS onco_base;
@@ -0,0 +1,331 @@
+CREATE SCHEMA IF NOT EXISTS onco_base;
+
+-- internal users are clinic staff created by an administrator, external users register themselves
+CREATE TABLE IF NOT EXISTS onco_base.app_user
+(
+    id          SERIAL       NOT NULL UNIQUE,
+    email       VARCHAR(60)  NOT NULL UNIQUE,
+    password    VARCHAR(255) NOT NULL,
+    role        VARCHAR(30)  NOT NULL,
+    user_type   VARCHAR(10)  NOT NULL DEFAULT 'external',
+    first_name  VARCHAR(30)  NOT NULL DEFAULT '',
+    middle_name VARCHAR(30)  NOT NULL DEFAULT '',
+    last_name   VARCHAR(30)  NOT NULL DEFAULT '',
+    phone       VARCHAR(12) UNIQUE,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    locked_at   TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    CHECK (user_type IN ('internal', 'external'))
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.diagnosis
+(
+    id          VARCHAR(10)  NOT NULL UNIQUE,
+    description VARCHAR(300) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.patient
+(
+    id          SERIAL NOT NULL UNIQUE,
+    first_name  VARCHAR(30),
+    middle_name VARCHAR(30),
+    last_name   VARCHAR(30),
+    birth_date  DATE,
+    sex         VARCHAR(10),
+    snils       VARCHAR(12) UNIQUE,
+    user_id     INT UNIQUE,
+    phone       VARCHAR(12) UNIQUE,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES onco_base.app_user (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.doctor
+(
+    id            SERIAL      NOT NULL UNIQUE,
+    first_name    VARCHAR(30) NOT NULL,
+    middle_name   VARCHAR(30) NOT NULL,
+    last_name     VARCHAR(30) NOT NULL,
+    qualification VARCHAR(300),
+    user_id       INT UNIQUE,
+    phone         VARCHAR(12) UNIQUE,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES onco_base.app_user (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.doctor_patient
+(
+    patient INT NOT NULL,
+    doctor  INT NOT NULL,
+    PRIMARY KEY (patient, doctor),
+    FOREIGN KEY (patient) REFERENCES onco_base.patient (id),
+    FOREIGN KEY (doctor) REFERENCES onco_base.doctor (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.drug
+(
+    id                 VARCHAR(10) NOT NULL UNIQUE,
+    name               VARCHAR(60) NOT NULL UNIQUE,
+    dosage_form        VARCHAR(30) NOT NULL,
+    active_ingredients VARCHAR(60) NOT NULL,
+    country            VARCHAR(30),
+    manufacturer       VARCHAR(45),
+    prescribing_order  VARCHAR(30),
+    description        VARCHAR(300),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.unit_measure
+(
+    id        VARCHAR(15) NOT NULL UNIQUE,
+    shorthand VARCHAR(15) NOT NULL,
+    full_text VARCHAR(30),
+    global    VARCHAR(15),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.course
+(
+    id           VARCHAR(30) NOT NULL UNIQUE,
+    period       INT         NOT NULL,
+    frequency    FLOAT       NOT NULL,
+    dose         FLOAT       NOT NULL,
+    drug         VARCHAR(10) NOT NULL,
+    measure_code VARCHAR(15) NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (drug) REFERENCES onco_base.drug (id),
+    FOREIGN KEY (measure_code) REFERENCES onco_base.unit_measure (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.blood_count
+(
+    id               VARCHAR(15) NOT NULL UNIQUE,
+    description      VARCHAR(300),
+    min_normal_value FLOAT       NOT NULL,
+    max_normal_value FLOAT       NOT NULL,
+    min_possible_value        FLOAT       NOT NULL,
+    max_possible_value        FLOAT       NOT NULL,
+    measure_code     VARCHAR(15) NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (measure_code) REFERENCES onco_base.unit_measure (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.disease
+(
+    id          VARCHAR(15) NOT NULL UNIQUE,
+    description VARCHAR(300),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.patient_disease
+(
+    patient   INT NOT NULL,
+    disease   VARCHAR(15),
+    stage     VARCHAR(10),
+    diagnosis VARCHAR(10),
+    PRIMARY KEY (patient, disease),
+    FOREIGN KEY (patient) REFERENCES onco_base.patient (id),
+    FOREIGN KEY (disease) REFERENCES onco_base.disease (id),
+    FOREIGN KEY (diagnosis) REFERENCES onco_base.diagnosis (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.patient_course
+(
+    id         SERIAL      NOT NULL UNIQUE,
+    patient    INT         NOT NULL,
+    disease    VARCHAR(15),
+    course     VARCHAR(30) NOT NULL,
+    doctor     INT         NOT NULL,
+    begin_date DATE        NOT NULL,
+    end_date   DATE,
+    diagnosis  VARCHAR(10),
+    PRIMARY KEY (id),
+    FOREIGN KEY (patient, disease) REFERENCES onco_base.patient_disease (patient, disease),
+    FOREIGN KEY (course) REFERENCES onco_base.course (id),
+    FOREIGN KEY (doctor) REFERENCES onco_base.doctor (id),
+    FOREIGN KEY (diagnosis) REFERENCES onco_base.diagnosis (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.blood_count_value
+(
+    disease     VARCHAR(15) NOT NULL,
+    blood_count VARCHAR(15) NOT NULL,
+    coefficient FLOAT       NOT NULL,
+    description VARCHAR(300),
+    PRIMARY KEY (disease, blood_count),
+    FOREIGN KEY (disease) REFERENCES onco_base.disease (id),
+    FOREIGN KEY (blood_count) REFERENCES onco_base.blood_count (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.course_procedure
+(
+    id             SERIAL NOT NULL UNIQUE,
+    patient_course INT    NOT NULL,
+    begin_date     DATE   NOT NULL,
+    doctor         INT    NOT NULL,
+    result         VARCHAR(10),
+    PRIMARY KEY (id),
+    FOREIGN KEY (patient_course) REFERENCES onco_base.patient_course (id),
+    FOREIGN KEY (doctor) REFERENCES onco_base.doctor (id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.procedure_blood_count
+(
+    procedure    INT         NOT NULL,
+    blood_count  VARCHAR(15) NOT NULL,
+    value        FLOAT,
+    measure_code VARCHAR(15),
+    PRIMARY KEY (procedure, blood_count),
+    FOREIGN KEY (procedure) REFERENCES onco_base.course_procedure (id),
+    FOREIGN KEY (measure_code) REFERENCES onco_base.unit_measure (id)
+);
+
+
+CREATE TABLE IF NOT EXISTS onco_base.role_permission
+(
+    role     VARCHAR(30) NOT NULL,
+    resource VARCHAR(30) NOT NULL,
+    action   VARCHAR(10) NOT NULL,
+    PRIMARY KEY (role, resource, action)
+);
+
+-- admin: full access to every resource
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'admin', resource, action
+FROM (VALUES ('blood-count'), ('blood-count-value'), ('console'), ('course'), ('course-procedure'), ('diagnosis'),
+             ('disease'), ('doctor'), ('doctor-patient'), ('drug'), ('invitation'), ('patient'), ('patient-course'),
+             ('patient-disease'), ('permission'), ('procedure-blood-count'), ('session'), ('unit-measure'),
+             ('user')) AS resources (resource)
+         CROSS JOIN (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+-- doctor: reads reference data, manages clinical data
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'doctor', resource, 'read'
+FROM (VALUES ('blood-count'), ('blood-count-value'), ('course'), ('diagnosis'), ('disease'), ('doctor'),
+             ('doctor-patient'), ('drug'), ('unit-measure')) AS resources (resource)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'doctor', resource, action
+FROM (VALUES ('course-procedure'), ('patient'), ('patient-course'), ('patient-disease'),
+             ('procedure-blood-count')) AS resources (resource)
+         CROSS JOIN (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+-- patient: reads reference data
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'patient', resource, 'read'
+FROM (VALUES ('blood-count'), ('diagnosis'), ('disease'), ('doctor'), ('drug'), ('unit-measure')) AS resources (resource)
+ON CONFLICT DO NOTHING;
+
+-- researcher: reads reference data
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'researcher', resource, 'read'
+FROM (VALUES ('blood-count'), ('blood-count-value'), ('course'), ('diagnosis'), ('disease'), ('drug'),
+             ('unit-measure')) AS resources (resource)
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS onco_base.refresh_token
+(
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    user_id    INT         NOT NULL,
+    role       VARCHAR(30) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ,
+    PRIMARY KEY (token_hash)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.revoked_access_token
+(
+    jti        VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (jti)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.user_token_revocation
+(
+    user_id    INT         NOT NULL UNIQUE,
+    revoked_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.password_reset_token
+(
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    email      VARCHAR(60) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at    TIMESTAMPTZ,
+    PRIMARY KEY (token_hash)
+);
+
+CREATE TABLE IF NOT EXISTS onco_base.failed_login
+(
+    id         BIGSERIAL   NOT NULL UNIQUE,
+    email      VARCHAR(60) NOT NULL,
+    ip         VARCHAR(45) NOT NULL,
+    reason     VARCHAR(60) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS failed_login_created_at_idx ON onco_base.failed_login (created_at);
+
+-- changes of data, before and after are the row values as JSON
+CREATE TABLE IF NOT EXISTS onco_base.audit_log
+(
+    id         BIGSERIAL   NOT NULL UNIQUE,
+    user_id    INT         NOT NULL,
+    action     VARCHAR(10) NOT NULL,
+    entity     VARCHAR(30) NOT NULL,
+    entity_id  VARCHAR(60) NOT NULL,
+    before     JSONB,
+    after      JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON onco_base.audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON onco_base.audit_log (entity, entity_id);
+CREATE INDEX IF NOT EXISTS audit_log_user_id_idx ON onco_base.audit_log (user_id);
+
+-- the audit log is append-only, entries can be neither changed nor removed
+CREATE OR REPLACE FUNCTION onco_base.reject_audit_log_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON onco_base.audit_log
+    EXECUTE FUNCTION onco_base.reject_audit_log_change();
+
+-- one-time codes handed to a patient to link a self-registered account to the patient record
+CREATE TABLE IF NOT EXISTS onco_base.registration_code
+(
+    patient_id INT         NOT NULL UNIQUE,
+    code_hash  VARCHAR(64) NOT NULL,
+    attempts   INT         NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (patient_id),
+    FOREIGN KEY (patient_id) REFERENCES onco_base.patient (id) ON DELETE CASCADE
+);
+
+-- invitations sent by an administrator to create staff accounts
+CREATE TABLE IF NOT EXISTS onco_base.invitation
+(
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    email       VARCHAR(60) NOT NULL,
+    role        VARCHAR(30) NOT NULL,
+    invited_by  INT         NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_at TIMESTAMPTZ,
+    PRIMARY KEY (token_hash),
+    FOREIGN KEY (invited_by) REFERENCES onco_base.app_user (id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE onco_base.course_procedure
+    DROP COLUMN IF EXISTS period;
@@ -0,0 +1,3 @@
+-- model.CourseProcedure has a period, but the table never had the column
+ALTER TABLE onco_base.course_procedure
+    ADD COLUMN IF NOT EXISTS period INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS onco_base.patient_birth_date_idx;
+DROP INDEX IF EXISTS onco_base.patient_search_name_idx;
+DROP FUNCTION IF EXISTS onco_base.patient_search_name(VARCHAR, VARCHAR, VARCHAR);
+-- The pg_trgm extension is left installed, other database objects may depend on it
@@ -0,0 +1,18 @@
+-- Fuzzy patient search by name uses trigram similarity
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full name in lower case with ё replaced by е, so the spelling of ё does not affect the match.
+-- concat_ws is only stable, the function is declared immutable to be usable in an index.
+CREATE OR REPLACE FUNCTION onco_base.patient_search_name(last_name VARCHAR, first_name VARCHAR, middle_name VARCHAR)
+    RETURNS TEXT
+    LANGUAGE sql
+    IMMUTABLE
+AS
+$$
+SELECT translate(lower(concat_ws(' ', last_name, first_name, middle_name)), 'ё', 'е')
+$$;
+
+CREATE INDEX IF NOT EXISTS patient_search_name_idx
+    ON onco_base.patient USING gin (onco_base.patient_search_name(last_name, first_name, middle_name) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS patient_birth_date_idx ON onco_base.patient (birth_date);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS onco_base.procedure_blood_count_flag_idx;
+ALTER TABLE onco_base.procedure_blood_count
+    DROP COLUMN IF EXISTS flag;
@@ -0,0 +1,16 @@
+-- Flag of a blood count result against the normal range of the blood count, empty if there is no value
+ALTER TABLE onco_base.procedure_blood_count
+    ADD COLUMN IF NOT EXISTS flag VARCHAR(10) NOT NULL DEFAULT ''
+        CHECK (flag IN ('', 'low', 'normal', 'high'));
+
+UPDATE onco_base.procedure_blood_count pbc
+SET flag = CASE
+               WHEN pbc.value < bc.min_normal_value THEN 'low'
+               WHEN pbc.value > bc.max_normal_value THEN 'high'
+               ELSE 'normal'
+    END
+FROM onco_base.blood_count bc
+WHERE bc.id = pbc.blood_count
+  AND pbc.value IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS procedure_blood_count_flag_idx ON onco_base.procedure_blood_count (flag);
@@ -0,0 +1 @@
+DELETE FROM onco_base.role_permission WHERE resource = 'analysis';
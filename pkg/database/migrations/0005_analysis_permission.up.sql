@@ -0,0 +1,11 @@
+-- Scoring ad-hoc blood count results is a POST request, so it needs the create action although nothing is stored
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'admin', 'analysis', action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT role, 'analysis', action
+FROM (VALUES ('doctor'), ('researcher')) AS roles (role)
+         CROSS JOIN (VALUES ('read'), ('create')) AS actions (action)
+ON CONFLICT DO NOTHING;
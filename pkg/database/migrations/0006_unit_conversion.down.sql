@@ -0,0 +1,2 @@
+DELETE FROM onco_base.role_permission WHERE resource = 'unit-conversion';
+DROP TABLE IF EXISTS onco_base.unit_conversion;
@@ -0,0 +1,22 @@
+-- A value in from_unit is converted to to_unit as value * factor + "offset", the reverse conversion is derived
+CREATE TABLE IF NOT EXISTS onco_base.unit_conversion
+(
+    from_unit VARCHAR(15) NOT NULL,
+    to_unit   VARCHAR(15) NOT NULL,
+    factor    FLOAT       NOT NULL CHECK (factor <> 0),
+    "offset"  FLOAT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (from_unit, to_unit),
+    CHECK (from_unit <> to_unit),
+    FOREIGN KEY (from_unit) REFERENCES onco_base.unit_measure (id),
+    FOREIGN KEY (to_unit) REFERENCES onco_base.unit_measure (id)
+);
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'admin', 'unit-conversion', action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT role, 'unit-conversion', 'read'
+FROM (VALUES ('doctor'), ('researcher')) AS roles (role)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+-- cohorts saved by researchers, the definition holds the criteria selecting patient courses
+CREATE TABLE IF NOT EXISTS onco_base.cohort
+(
+    id          SERIAL       NOT NULL UNIQUE,
+    name        VARCHAR(100) NOT NULL,
+    description VARCHAR(300) NOT NULL DEFAULT '',
+    definition  JSONB        NOT NULL,
+    created_by  INT          NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    FOREIGN KEY (created_by) REFERENCES onco_base.app_user (id)
+);
+
+CREATE INDEX IF NOT EXISTS cohort_created_by_idx ON onco_base.cohort (created_by);
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT role, 'cohort', action
+FROM (VALUES ('admin'), ('researcher')) AS roles (role)
+         CROSS JOIN (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
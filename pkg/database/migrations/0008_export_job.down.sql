@@ -0,0 +1,2 @@
+DELETE FROM onco_base.role_permission WHERE resource = 'export';
+DROP TABLE IF EXISTS onco_base.export_job;
@@ -0,0 +1,27 @@
+-- background jobs exporting the de-identified dataset, finished bundles are kept in the export directory
+CREATE TABLE IF NOT EXISTS onco_base.export_job
+(
+    id           SERIAL      NOT NULL UNIQUE,
+    format       VARCHAR(10) NOT NULL,
+    birth_date   VARCHAR(10) NOT NULL,
+    status       VARCHAR(10) NOT NULL DEFAULT 'pending',
+    error        TEXT        NOT NULL DEFAULT '',
+    requested_by INT         NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at  TIMESTAMPTZ,
+    PRIMARY KEY (id),
+    FOREIGN KEY (requested_by) REFERENCES onco_base.app_user (id),
+    CHECK (format IN ('csv', 'ndjson')),
+    CHECK (birth_date IN ('year', 'age-band')),
+    CHECK (status IN ('pending', 'running', 'done', 'failed'))
+);
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'admin', 'export', action
+FROM (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'researcher', 'export', action
+FROM (VALUES ('read'), ('create')) AS actions (action)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DELETE FROM onco_base.role_permission WHERE resource IN ('lab-code-mapping', 'lab-message');
+DROP TABLE IF EXISTS onco_base.lab_message;
+DROP TABLE IF EXISTS onco_base.lab_code_mapping;
@@ -0,0 +1,35 @@
+-- observation codes of lab analyzers (OBX-3) mapped to blood counts, an empty system matches codes of any coding system
+CREATE TABLE IF NOT EXISTS onco_base.lab_code_mapping
+(
+    system       VARCHAR(50) NOT NULL DEFAULT '',
+    code         VARCHAR(50) NOT NULL,
+    blood_count  VARCHAR(15) NOT NULL,
+    measure_code VARCHAR(15) NOT NULL DEFAULT '',
+    PRIMARY KEY (system, code),
+    FOREIGN KEY (blood_count) REFERENCES onco_base.blood_count (id)
+);
+
+-- received HL7 v2 lab messages, a resent message is acknowledged without storing its results again
+CREATE TABLE IF NOT EXISTS onco_base.lab_message
+(
+    sending_application VARCHAR(180) NOT NULL,
+    sending_facility    VARCHAR(180) NOT NULL,
+    control_id          VARCHAR(199) NOT NULL,
+    received_by         INT          NOT NULL,
+    received_at         TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    procedures          INT          NOT NULL,
+    results             INT          NOT NULL,
+    PRIMARY KEY (sending_application, sending_facility, control_id),
+    FOREIGN KEY (received_by) REFERENCES onco_base.app_user (id)
+);
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+SELECT 'admin', resource, action
+FROM (VALUES ('lab-code-mapping'), ('lab-message')) AS resources (resource),
+     (VALUES ('read'), ('create'), ('update'), ('delete')) AS actions (action)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO onco_base.role_permission (role, resource, action)
+VALUES ('doctor', 'lab-code-mapping', 'read'),
+       ('doctor', 'lab-message', 'create')
+ON CONFLICT DO NOTHING;
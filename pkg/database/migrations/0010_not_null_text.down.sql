@@ -0,0 +1,52 @@
+ALTER TABLE onco_base.procedure_blood_count
+    ALTER COLUMN measure_code DROP NOT NULL;
+
+ALTER TABLE onco_base.course_procedure
+    ALTER COLUMN result DROP NOT NULL,
+    ALTER COLUMN result DROP DEFAULT;
+
+ALTER TABLE onco_base.patient_disease
+    ALTER COLUMN stage DROP NOT NULL,
+    ALTER COLUMN stage DROP DEFAULT;
+
+ALTER TABLE onco_base.blood_count_value
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT;
+
+ALTER TABLE onco_base.disease
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT;
+
+ALTER TABLE onco_base.blood_count
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT;
+
+ALTER TABLE onco_base.unit_measure
+    ALTER COLUMN full_text DROP NOT NULL,
+    ALTER COLUMN full_text DROP DEFAULT,
+    ALTER COLUMN global DROP NOT NULL,
+    ALTER COLUMN global DROP DEFAULT;
+
+ALTER TABLE onco_base.drug
+    ALTER COLUMN country DROP NOT NULL,
+    ALTER COLUMN country DROP DEFAULT,
+    ALTER COLUMN manufacturer DROP NOT NULL,
+    ALTER COLUMN manufacturer DROP DEFAULT,
+    ALTER COLUMN prescribing_order DROP NOT NULL,
+    ALTER COLUMN prescribing_order DROP DEFAULT,
+    ALTER COLUMN description DROP NOT NULL,
+    ALTER COLUMN description DROP DEFAULT;
+
+ALTER TABLE onco_base.doctor
+    ALTER COLUMN qualification DROP NOT NULL,
+    ALTER COLUMN qualification DROP DEFAULT;
+
+ALTER TABLE onco_base.patient
+    ALTER COLUMN first_name DROP NOT NULL,
+    ALTER COLUMN first_name DROP DEFAULT,
+    ALTER COLUMN middle_name DROP NOT NULL,
+    ALTER COLUMN middle_name DROP DEFAULT,
+    ALTER COLUMN last_name DROP NOT NULL,
+    ALTER COLUMN last_name DROP DEFAULT,
+    ALTER COLUMN sex DROP NOT NULL,
+    ALTER COLUMN sex DROP DEFAULT;
@@ -0,0 +1,93 @@
+-- Free text columns that the models read as strings are empty instead of NULL
+UPDATE onco_base.patient
+SET first_name  = COALESCE(first_name, ''),
+    middle_name = COALESCE(middle_name, ''),
+    last_name   = COALESCE(last_name, ''),
+    sex         = COALESCE(sex, '')
+WHERE first_name IS NULL
+   OR middle_name IS NULL
+   OR last_name IS NULL
+   OR sex IS NULL;
+ALTER TABLE onco_base.patient
+    ALTER COLUMN first_name SET DEFAULT '',
+    ALTER COLUMN first_name SET NOT NULL,
+    ALTER COLUMN middle_name SET DEFAULT '',
+    ALTER COLUMN middle_name SET NOT NULL,
+    ALTER COLUMN last_name SET DEFAULT '',
+    ALTER COLUMN last_name SET NOT NULL,
+    ALTER COLUMN sex SET DEFAULT '',
+    ALTER COLUMN sex SET NOT NULL;
+
+UPDATE onco_base.doctor SET qualification = '' WHERE qualification IS NULL;
+ALTER TABLE onco_base.doctor
+    ALTER COLUMN qualification SET DEFAULT '',
+    ALTER COLUMN qualification SET NOT NULL;
+
+UPDATE onco_base.drug
+SET country           = COALESCE(country, ''),
+    manufacturer      = COALESCE(manufacturer, ''),
+    prescribing_order = COALESCE(prescribing_order, ''),
+    description       = COALESCE(description, '')
+WHERE country IS NULL
+   OR manufacturer IS NULL
+   OR prescribing_order IS NULL
+   OR description IS NULL;
+ALTER TABLE onco_base.drug
+    ALTER COLUMN country SET DEFAULT '',
+    ALTER COLUMN country SET NOT NULL,
+    ALTER COLUMN manufacturer SET DEFAULT '',
+    ALTER COLUMN manufacturer SET NOT NULL,
+    ALTER COLUMN prescribing_order SET DEFAULT '',
+    ALTER COLUMN prescribing_order SET NOT NULL,
+    ALTER COLUMN description SET DEFAULT '',
+    ALTER COLUMN description SET NOT NULL;
+
+UPDATE onco_base.unit_measure
+SET full_text = COALESCE(full_text, ''),
+    global    = COALESCE(global, '')
+WHERE full_text IS NULL
+   OR global IS NULL;
+ALTER TABLE onco_base.unit_measure
+    ALTER COLUMN full_text SET DEFAULT '',
+    ALTER COLUMN full_text SET NOT NULL,
+    ALTER COLUMN global SET DEFAULT '',
+    ALTER COLUMN global SET NOT NULL;
+
+UPDATE onco_base.blood_count SET description = '' WHERE description IS NULL;
+ALTER TABLE onco_base.blood_count
+    ALTER COLUMN description SET DEFAULT '',
+    ALTER COLUMN description SET NOT NULL;
+
+UPDATE onco_base.disease SET description = '' WHERE description IS NULL;
+ALTER TABLE onco_base.disease
+    ALTER COLUMN description SET DEFAULT '',
+    ALTER COLUMN description SET NOT NULL;
+
+UPDATE onco_base.blood_count_value SET description = '' WHERE description IS NULL;
+ALTER TABLE onco_base.blood_count_value
+    ALTER COLUMN description SET DEFAULT '',
+    ALTER COLUMN description SET NOT NULL;
+
+UPDATE onco_base.patient_disease SET stage = '' WHERE stage IS NULL;
+ALTER TABLE onco_base.patient_disease
+    ALTER COLUMN stage SET DEFAULT '',
+    ALTER COLUMN stage SET NOT NULL;
+
+UPDATE onco_base.course_procedure SET result = '' WHERE result IS NULL;
+ALTER TABLE onco_base.course_procedure
+    ALTER COLUMN result SET DEFAULT '',
+    ALTER COLUMN result SET NOT NULL;
+
+-- A result without a unit is in the unit of its blood count
+UPDATE onco_base.procedure_blood_count pbc
+SET measure_code = bc.measure_code
+FROM onco_base.blood_count bc
+WHERE bc.id = pbc.blood_count
+  AND pbc.measure_code IS NULL;
+ALTER TABLE onco_base.procedure_blood_count
+    ALTER COLUMN measure_code SET NOT NULL;
+
+-- Unique and referencing columns stay nullable, the models read them as pointers, and an empty value is absent
+UPDATE onco_base.patient SET snils = NULL WHERE snils = '';
+UPDATE onco_base.patient SET phone = NULL WHERE phone = '';
+UPDATE onco_base.doctor SET phone = NULL WHERE phone = '';
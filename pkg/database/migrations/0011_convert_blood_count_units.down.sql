@@ -0,0 +1 @@
+-- The units the results were entered in are not kept, converted results stay in the unit of the blood count
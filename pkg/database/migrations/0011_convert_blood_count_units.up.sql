@@ -0,0 +1,64 @@
+-- Results entered before unit conversions existed are in the unit they were entered in, while the ranges, trends,
+-- cohort statistics and exports take them to be in the unit of the blood count. They are converted with the stored
+-- conversion or the reverse of it and flagged again, a result in a unit without a conversion fails the migration.
+UPDATE onco_base.procedure_blood_count pbc
+SET measure_code = bc.measure_code
+FROM onco_base.blood_count bc
+WHERE bc.id = pbc.blood_count
+  AND pbc.measure_code <> bc.measure_code
+  AND pbc.value IS NULL;
+
+DO
+$$
+    DECLARE
+        missing RECORD;
+    BEGIN
+        SELECT pbc.measure_code AS from_unit, bc.measure_code AS to_unit, count(*) AS results
+        INTO missing
+        FROM onco_base.procedure_blood_count pbc
+                 JOIN onco_base.blood_count bc ON bc.id = pbc.blood_count
+        WHERE pbc.measure_code <> bc.measure_code
+          AND NOT EXISTS (SELECT 1
+                          FROM onco_base.unit_conversion uc
+                          WHERE (uc.from_unit = pbc.measure_code AND uc.to_unit = bc.measure_code)
+                             OR (uc.from_unit = bc.measure_code AND uc.to_unit = pbc.measure_code))
+        GROUP BY pbc.measure_code, bc.measure_code
+        LIMIT 1;
+        IF FOUND THEN
+            RAISE EXCEPTION 'no conversion between units % and % for % blood count results',
+                missing.from_unit, missing.to_unit, missing.results;
+        END IF;
+    END
+$$;
+
+UPDATE onco_base.procedure_blood_count pbc
+SET value        = converted.value,
+    measure_code = converted.measure_code
+FROM (SELECT pbc.procedure,
+             pbc.blood_count,
+             bc.measure_code,
+             CASE
+                 WHEN uc.from_unit = pbc.measure_code THEN pbc.value * uc.factor + uc."offset"
+                 ELSE (pbc.value - uc."offset") / uc.factor
+                 END AS value
+      FROM onco_base.procedure_blood_count pbc
+               JOIN onco_base.blood_count bc ON bc.id = pbc.blood_count
+               JOIN LATERAL (SELECT *
+                             FROM onco_base.unit_conversion uc
+                             WHERE (uc.from_unit = pbc.measure_code AND uc.to_unit = bc.measure_code)
+                                OR (uc.from_unit = bc.measure_code AND uc.to_unit = pbc.measure_code)
+                             ORDER BY uc.from_unit = pbc.measure_code DESC
+                             LIMIT 1) uc ON true
+      WHERE pbc.measure_code <> bc.measure_code) AS converted
+WHERE converted.procedure = pbc.procedure
+  AND converted.blood_count = pbc.blood_count;
+
+UPDATE onco_base.procedure_blood_count pbc
+SET flag = CASE
+               WHEN pbc.value < bc.min_normal_value THEN 'low'
+               WHEN pbc.value > bc.max_normal_value THEN 'high'
+               ELSE 'normal'
+    END
+FROM onco_base.blood_count bc
+WHERE bc.id = pbc.blood_count
+  AND pbc.value IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE onco_base.export_job DROP COLUMN IF EXISTS dates;
@@ -0,0 +1,3 @@
+-- exported course and procedure dates are days since the first course of the patient or dates shifted per patient
+ALTER TABLE onco_base.export_job
+    ADD COLUMN IF NOT EXISTS dates VARCHAR(10) NOT NULL DEFAULT 'relative' CHECK (dates IN ('relative', 'shifted'));
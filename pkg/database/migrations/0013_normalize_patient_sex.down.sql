@@ -0,0 +1 @@
+-- The spellings the sex was stored in are not kept, normalized sex codes stay
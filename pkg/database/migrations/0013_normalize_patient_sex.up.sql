@@ -0,0 +1,10 @@
+-- patient sex was stored as FHIR codes by FHIR writes and as letters or words by other clients,
+-- known spellings are normalized to the sex codes, which only tell male and female apart
+UPDATE onco_base.patient
+SET sex = CASE
+              WHEN lower(trim(sex)) IN ('male', 'm', 'м', 'муж', 'мужской') THEN 'м'
+              WHEN lower(trim(sex)) IN ('female', 'f', 'ж', 'жен', 'женский') THEN 'ж'
+              ELSE ''
+    END
+WHERE lower(trim(sex)) IN ('male', 'm', 'м', 'муж', 'мужской', 'female', 'f', 'ж', 'жен', 'женский', 'other', 'unknown')
+  AND sex NOT IN ('м', 'ж');
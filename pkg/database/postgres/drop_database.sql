@@ -1,20 +0,0 @@
-DROP TABLE IF EXISTS onco_base.course_procedure;
-DROP TABLE IF EXISTS onco_base.blood_count_value;
-DROP TABLE IF EXISTS onco_base.patient_course;
-DROP TABLE IF EXISTS onco_base.patient_disease;
-DROP TABLE IF EXISTS onco_base.disease;
-DROP TABLE IF EXISTS onco_base.blood_count;
-DROP TABLE IF EXISTS onco_base.course;
-DROP TABLE IF EXISTS onco_base.unit_measure;
-DROP TABLE IF EXISTS onco_base.drug;
-DROP TABLE IF EXISTS onco_base.diagnosis;
-DROP TABLE IF EXISTS onco_base.doctor_patient;
-DROP TABLE IF EXISTS onco_base.doctor;
-DROP TABLE IF EXISTS onco_base.patient;
-DROP TABLE IF EXISTS onco_base.admin;
-
-DROP SCHEMA IF EXISTS onco_base;
-
-DROP DATABASE IF EXISTS med_base;
-
-DROP SCHEMA onco_base CASCADE;
\ No newline at end of file
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	services "med/pkg/service"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AccountHandlers struct {
+	services *services.Service
 }
 
 // Settings godoc
@@ -27,52 +29,63 @@ func (h *AccountHandlers) Settings(ctx *gin.Context) {
 
 // BloodCount godoc
 // @Summary Get blood count
-// @Description Retrieves blood count data.
+// @Description Retrieves the patient's own procedure blood counts ordered by procedure date.
 // @Tags Account
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} array "Blood count data"
+// @Success 200 {array} []model.AccountBloodCount "Blood count data"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /account/blood-count [get]
 func (h *AccountHandlers) BloodCount(ctx *gin.Context) {
+	bloodCountList, err := h.services.Account.GetBloodCountList(getUser(ctx))
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
 
+	ctx.JSON(http.StatusOK, bloodCountList)
 }
 
 // Doctors godoc
 // @Summary Get doctors
-// @Description Retrieves doctor information.
+// @Description Retrieves the patient's attending doctors.
 // @Tags Account
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {array} array "Doctor list"
+// @Success 200 {array} []model.Doctor "Doctor list"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /account/doctors [get]
 func (h *AccountHandlers) Doctors(ctx *gin.Context) {
+	doctorList, err := h.services.Account.GetDoctorList(getUser(ctx))
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
 
+	ctx.JSON(http.StatusOK, doctorList)
 }
 
 // PatientData godoc
 // @Summary Get patient data
-// @Description Retrieves patient data.
+// @Description Retrieves the doctor's patient panel with current diseases and active courses.
 // @Tags Account
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} array "Patient data"
+// @Success 200 {array} []model.PanelPatient "Patient data"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /account/patient-data [get]
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/patients-data [get]
 func (h *AccountHandlers) PatientData(ctx *gin.Context) {
+	panel, err := h.services.Account.GetPatientPanel(getUser(ctx))
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
 
-}
-
-// Console godoc
-// @Summary Get console
-// @Description Retrieves console data.
-// @Tags Account
-// @Produce json
-// @Security ApiKeyAuth
-// @Success 200 {object} array "Console data"
-// @Failure 401 {object} ErrorResponse "Unauthorized"
-// @Router /account/console [get]
-func (h *AccountHandlers) Console(ctx *gin.Context) {
-
+	ctx.JSON(http.StatusOK, panel)
 }
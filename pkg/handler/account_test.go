@@ -0,0 +1,75 @@
+package handler
+
+import (
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPatientData(t *testing.T) {
+	type mockBehavior func(s *mock.MockAccount, user service.UserData)
+
+	testTable := []struct {
+		name           string
+		user           service.UserData
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "OK",
+			user: service.UserData{Id: 7, Role: "doctor"},
+			mockBehavior: func(s *mock.MockAccount, user service.UserData) {
+				s.EXPECT().GetPatientPanel(user).Return([]model.PanelPatient{{
+					Patient:  model.Patient{Id: 1, LastName: "Ivanov"},
+					Diseases: []model.PatientDisease{{Patient: 1, Disease: "C50", Stage: "II"}},
+					Courses:  []model.PatientCourse{},
+				}}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `[{"patient":{"id":1,"first-name":"","middle-name":"","last-name":"Ivanov","birth-date":null,"sex":"","snils":null,"user-id":{"Int64":0,"Valid":false},"phone":null},"diseases":[{"stage":"II","diagnosis":null,"patient":1,"disease":"C50"}],"courses":[]}]`,
+		},
+		{
+			name: "Not a doctor",
+			user: service.UserData{Id: 8, Role: "patient"},
+			mockBehavior: func(s *mock.MockAccount, user service.UserData) {
+				s.EXPECT().GetPatientPanel(user).Return(nil, service.ErrForbidden)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"access to patient data is forbidden"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			account := mock.NewMockAccount(c)
+			testCase.mockBehavior(account, testCase.user)
+
+			services := &service.Service{Account: account}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/patients-data", func(ctx *gin.Context) {
+				ctx.Set(userContext, testCase.user.Id)
+				ctx.Set(roleContext, testCase.user.Role)
+			}, handler.AccountHandler.PatientData)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/patients-data", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
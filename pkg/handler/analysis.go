@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScoreBloodCounts godoc
+// @Summary Score blood count results
+// @Description Scores diseases from ad-hoc blood count results without storing them. Every value is normalized against the ranges of its blood count and weighted with the disease coefficients. Diseases are ranked by score, from -1 to 1, positive scores support the disease.
+// @Tags Analysis
+// @Accept json
+// @Produce json
+// @Param input body model.ScoreInput true "Blood count results"
+// @Success 200 {array} []model.DiseaseScore "Ranked disease scores"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /analysis/score [post]
+func (h *Handler) ScoreBloodCounts(ctx *gin.Context) {
+	var input model.ScoreInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scoreList, err := h.services.Analysis.ScoreBloodCounts(input.Results)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scoreList)
+}
+
+// GetProcedureRisk godoc
+// @Summary Get course procedure risk
+// @Description Scores diseases from the blood count results of the course procedure, like /analysis/score.
+// @Tags CourseProcedure
+// @Produce json
+// @Param id path string true "Course procedure ID"
+// @Success 200 {array} []model.DiseaseScore "Ranked disease scores"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /course-procedure/{id}/risk [get]
+func (h *Handler) GetProcedureRisk(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scoreList, err := h.services.Analysis.GetProcedureRisk(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scoreList)
+}
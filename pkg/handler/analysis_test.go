@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScoreBloodCounts(t *testing.T) {
+	type mockBehavior func(s *mock.MockAnalysis, results []model.BloodCountResult)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		results        []model.BloodCountResult
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"results":[{"blood-count":"CA15-3","value":45}]}`,
+			results:   []model.BloodCountResult{{BloodCount: "CA15-3", Value: 45}},
+			mockBehavior: func(s *mock.MockAnalysis, results []model.BloodCountResult) {
+				s.EXPECT().ScoreBloodCounts(results).Return([]model.DiseaseScore{{Rank: 1, Disease: "C50", Score: 0.5, Matched: 1, Total: 2}}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `[{"rank":1,"disease":"C50","score":0.5,"matched":1,"total":2}]`,
+		},
+		{
+			name:      "Unknown blood count",
+			inputBody: `{"results":[{"blood-count":"XYZ","value":1}]}`,
+			results:   []model.BloodCountResult{{BloodCount: "XYZ", Value: 1}},
+			mockBehavior: func(s *mock.MockAnalysis, results []model.BloodCountResult) {
+				s.EXPECT().ScoreBloodCounts(results).Return(nil, fmt.Errorf("%w: unknown blood count XYZ", service.ErrInvalidScoreInput))
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid blood count results: unknown blood count XYZ"}`,
+		},
+		{
+			name:           "No results",
+			inputBody:      `{"results":[]}`,
+			mockBehavior:   func(s *mock.MockAnalysis, results []model.BloodCountResult) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'ScoreInput.Results' Error:Field validation for 'Results' failed on the 'min' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			analysis := mock.NewMockAnalysis(c)
+			testCase.mockBehavior(analysis, testCase.results)
+
+			services := &service.Service{Analysis: analysis}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/analysis/score", handler.ScoreBloodCounts)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/analysis/score", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
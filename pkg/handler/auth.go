@@ -3,6 +3,7 @@ package handler
 import (
 	"med/pkg/model"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,8 +15,10 @@ import (
 // @Accept json
 // @Produce json
 // @Param input body model.AuthUser true "User credentials"
-// @Success 200 {object} string "Authentication token"
+// @Success 200 {object} model.Tokens "Access and refresh tokens"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Invalid email or password"
+// @Failure 403 {object} ErrorResponse "User account is locked"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /auth/login [post]
 func (h *Handler) LogIn(ctx *gin.Context) {
@@ -26,39 +29,66 @@ func (h *Handler) LogIn(ctx *gin.Context) {
 		return
 	}
 
-	token, err := h.services.Authorization.GenerateToken(input.Email, input.Password)
+	tokens, err := h.services.Authorization.GenerateToken(input.Email, input.Password, ctx.ClientIP())
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, map[string]interface{}{
-		"token": token,
-	})
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access and refresh token pair. The used refresh token is revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param input body model.RefreshInput true "Refresh token"
+// @Success 200 {object} model.Tokens "Access and refresh tokens"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Invalid refresh token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/refresh [post]
+func (h *Handler) Refresh(ctx *gin.Context) {
+	var input model.RefreshInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokens, err := h.services.Authorization.RefreshToken(input.RefreshToken)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
 }
 
 // Registry godoc
-// @Summary Register new user
-// @Description Registers a new user and returns the user's email.
+// @Summary Register patient
+// @Description Registers a patient account linked to the patient record with the given SNILS. The registration code is issued by the patient's doctor.
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param user body model.User true "User data"
+// @Param input body model.PatientRegistration true "Registration data"
 // @Success 200 {object} string "User email"
 // @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /auth/registry [post]
 func (h *Handler) Registry(ctx *gin.Context) {
-	var user model.User
+	var registration model.PatientRegistration
 
-	if err := ctx.BindJSON(&user); err != nil {
+	if err := ctx.BindJSON(&registration); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, "Invalid input body")
 		return
 	}
 
-	email, err := h.services.Authorization.CreateUser(user)
+	email, err := h.services.Registration.RegisterPatient(registration)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -69,22 +99,111 @@ func (h *Handler) Registry(ctx *gin.Context) {
 
 // LogOut godoc
 // @Summary Log out user
-// @Description Logs out the currently authenticated user.
+// @Description Logs out the currently authenticated user. Revokes the refresh token and the access token.
 // @Tags Auth
+// @Accept json
 // @Produce json
+// @Security ApiKeyAuth
+// @Param input body model.RefreshInput false "Refresh token"
 // @Success 200 {string} string "OK"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /auth/logout [post]
 func (h *Handler) LogOut(ctx *gin.Context) {
-	// logout user
+	userData, err := h.getUserData(ctx)
+	if err != nil {
+		return
+	}
+
+	// The refresh token is optional, the access token is revoked anyway
+	var input model.RefreshInput
+	_ = ctx.ShouldBindJSON(&input)
+
+	if err := h.services.Authorization.LogOut(*userData, input.RefreshToken); err != nil {
+		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "OK")
+}
+
+// RevokeUserSessions godoc
+// @Summary Revoke user sessions
+// @Description Revokes every refresh and access token issued to the user.
+// @Tags Auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} int "User ID"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/session/{id} [delete]
+func (h *Handler) RevokeUserSessions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Authorization.RevokeUserSessions(id); err != nil {
+		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, id)
 }
 
 // ResetPassword godoc
 // @Summary Reset user password
-// @Description Sends a password reset message to the user's email.
+// @Description Sends a password reset token to the user's email. The response is the same whether the email is registered or not.
 // @Tags Auth
+// @Accept json
 // @Produce json
+// @Param input body model.ResetPasswordInput true "User email"
 // @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /auth/reset-password [post]
 func (h *Handler) ResetPassword(ctx *gin.Context) {
-	// send message to mail
+	var input model.ResetPasswordInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Authorization.RequestPasswordReset(input.Email); err != nil {
+		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "OK")
+}
+
+// ConfirmResetPassword godoc
+// @Summary Confirm password reset
+// @Description Sets a new password using the token from the password reset email. All sessions of the user are revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param input body model.ConfirmResetPasswordInput true "Reset token and new password"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/reset-password/confirm [post]
+func (h *Handler) ConfirmResetPassword(ctx *gin.Context) {
+	var input model.ConfirmResetPasswordInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Authorization.ResetPassword(input.Token, input.Password); err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "OK")
 }
@@ -16,47 +16,64 @@ import (
 
 func TestRegistry(t *testing.T) {
 
-	type mockBehavior func(s *mock.MockAuthorization, user model.User)
+	type mockBehavior func(s *mock.MockRegistration, registration model.PatientRegistration)
 
 	testTable := []struct {
-		name           string
-		inputBody      string
-		inputUser      model.User
-		mockBehavior   mockBehavior
-		expectedStatus int
-		expectedBody   string
+		name              string
+		inputBody         string
+		inputRegistration model.PatientRegistration
+		mockBehavior      mockBehavior
+		expectedStatus    int
+		expectedBody      string
 	}{
 		{
 			name:      "OK",
-			inputBody: `{"email": "user_email", "password": "pass", "role": "doctor"}`,
-			inputUser: model.User{
+			inputBody: `{"email": "user_email", "password": "pass", "snils": "12345678901", "code": "12345678"}`,
+			inputRegistration: model.PatientRegistration{
 				Email:    "user_email",
 				Password: "pass",
-				Role:     "doctor",
+				SNILS:    "12345678901",
+				Code:     "12345678",
 			},
-			mockBehavior: func(s *mock.MockAuthorization, user model.User) {
-				s.EXPECT().CreateUser(user).Return("user_email", nil)
+			mockBehavior: func(s *mock.MockRegistration, registration model.PatientRegistration) {
+				s.EXPECT().RegisterPatient(registration).Return("user_email", nil)
 			},
 			expectedStatus: 200,
 			expectedBody:   `{"email":"user_email"}`,
 		},
 		{
 			name:           "Empty fields",
-			inputBody:      `{"password": "pass", "role": "doctor"}`,
-			mockBehavior:   func(s *mock.MockAuthorization, user model.User) {},
+			inputBody:      `{"email": "user_email", "password": "pass", "role": "admin"}`,
+			mockBehavior:   func(s *mock.MockRegistration, registration model.PatientRegistration) {},
 			expectedStatus: 400,
 			expectedBody:   `{"message":"Invalid input body"}`,
 		},
+		{
+			name:      "Invalid code",
+			inputBody: `{"email": "user_email", "password": "pass", "snils": "12345678901", "code": "00000000"}`,
+			inputRegistration: model.PatientRegistration{
+				Email:    "user_email",
+				Password: "pass",
+				SNILS:    "12345678901",
+				Code:     "00000000",
+			},
+			mockBehavior: func(s *mock.MockRegistration, registration model.PatientRegistration) {
+				s.EXPECT().RegisterPatient(registration).Return("", service.ErrInvalidRegistrationCode)
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid SNILS or registration code"}`,
+		},
 		{
 			name:      "Service error",
-			inputBody: `{"email": "user_email", "password": "pass", "role": "doctor"}`,
-			inputUser: model.User{
+			inputBody: `{"email": "user_email", "password": "pass", "snils": "12345678901", "code": "12345678"}`,
+			inputRegistration: model.PatientRegistration{
 				Email:    "user_email",
 				Password: "pass",
-				Role:     "doctor",
+				SNILS:    "12345678901",
+				Code:     "12345678",
 			},
-			mockBehavior: func(s *mock.MockAuthorization, user model.User) {
-				s.EXPECT().CreateUser(user).Return("", errors.New("Internal server error"))
+			mockBehavior: func(s *mock.MockRegistration, registration model.PatientRegistration) {
+				s.EXPECT().RegisterPatient(registration).Return("", errors.New("Internal server error"))
 			},
 			expectedStatus: 500,
 			expectedBody:   `{"message":"Internal server error"}`,
@@ -68,10 +85,10 @@ func TestRegistry(t *testing.T) {
 			c := gomock.NewController(t)
 			defer c.Finish()
 
-			auth := mock.NewMockAuthorization(c)
-			testCase.mockBehavior(auth, testCase.inputUser)
+			registration := mock.NewMockRegistration(c)
+			testCase.mockBehavior(registration, testCase.inputRegistration)
 
-			services := &service.Service{Authorization: auth}
+			services := &service.Service{Registration: registration}
 			handler := NewHandler(services)
 
 			r := gin.New()
@@ -87,3 +104,254 @@ func TestRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	type mockBehavior func(s *mock.MockAuthorization, refreshToken string)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		refreshToken   string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:         "OK",
+			inputBody:    `{"refresh-token": "refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshToken(refreshToken).Return(model.Tokens{AccessToken: "access", RefreshToken: "new refresh"}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"token":"access","refresh-token":"new refresh"}`,
+		},
+		{
+			name:           "Empty token",
+			inputBody:      `{}`,
+			mockBehavior:   func(s *mock.MockAuthorization, refreshToken string) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'RefreshInput.RefreshToken' Error:Field validation for 'RefreshToken' failed on the 'required' tag"}`,
+		},
+		{
+			name:         "Revoked token",
+			inputBody:    `{"refresh-token": "refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshToken(refreshToken).Return(model.Tokens{}, service.ErrInvalidRefreshToken)
+			},
+			expectedStatus: 401,
+			expectedBody:   `{"message":"invalid refresh token"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock.NewMockAuthorization(c)
+			testCase.mockBehavior(auth, testCase.refreshToken)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/refresh", handler.Refresh)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestLogOut(t *testing.T) {
+	user := &service.UserData{Id: 1, Role: "doctor", TokenId: "jti"}
+
+	type mockBehavior func(s *mock.MockAuthorization)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"refresh-token": "refresh"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().ParseToken("token").Return(user, nil)
+				s.EXPECT().LogOut(*user, "refresh").Return(nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `"OK"`,
+		},
+		{
+			name:      "Without refresh token",
+			inputBody: ``,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().ParseToken("token").Return(user, nil)
+				s.EXPECT().LogOut(*user, "").Return(nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `"OK"`,
+		},
+		{
+			name:      "Revoked token",
+			inputBody: `{"refresh-token": "refresh"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().ParseToken("token").Return(nil, service.ErrRevokedToken)
+			},
+			expectedStatus: 401,
+			expectedBody:   `{"message":"token has been revoked"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock.NewMockAuthorization(c)
+			testCase.mockBehavior(auth)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/logout", handler.LogOut)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/logout", bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Authorization", "Bearer token")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestConfirmResetPassword(t *testing.T) {
+	type mockBehavior func(s *mock.MockAuthorization)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"token": "reset", "password": "new pass"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().ResetPassword("reset", "new pass").Return(nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `"OK"`,
+		},
+		{
+			name:           "Empty password",
+			inputBody:      `{"token": "reset"}`,
+			mockBehavior:   func(s *mock.MockAuthorization) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'ConfirmResetPasswordInput.Password' Error:Field validation for 'Password' failed on the 'required' tag"}`,
+		},
+		{
+			name:      "Invalid token",
+			inputBody: `{"token": "reset", "password": "new pass"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().ResetPassword("reset", "new pass").Return(service.ErrInvalidResetToken)
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid password reset token"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock.NewMockAuthorization(c)
+			testCase.mockBehavior(auth)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/reset-password/confirm", handler.ConfirmResetPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/reset-password/confirm", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestLogIn(t *testing.T) {
+	type mockBehavior func(s *mock.MockAuthorization)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"email": "user_email", "password": "pass"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().GenerateToken("user_email", "pass", "192.0.2.1").Return(model.Tokens{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"token":"access","refresh-token":"refresh"}`,
+		},
+		{
+			name:      "Invalid credentials",
+			inputBody: `{"email": "user_email", "password": "wrong"}`,
+			mockBehavior: func(s *mock.MockAuthorization) {
+				s.EXPECT().GenerateToken("user_email", "wrong", "192.0.2.1").Return(model.Tokens{}, service.ErrInvalidCredentials)
+			},
+			expectedStatus: 401,
+			expectedBody:   `{"message":"invalid email or password"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock.NewMockAuthorization(c)
+			testCase.mockBehavior(auth)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/login", handler.LogIn)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/login", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
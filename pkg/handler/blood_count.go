@@ -40,13 +40,31 @@ func (h *Handler) CreateBloodCount(ctx *gin.Context) {
 // @Description Retrieves a list of blood counts.
 // @Tags BloodCount
 // @Produce json
-// @Success 200 {array} []model.BloodCount "Blood count list"
+// @Param measure-code query string false "Unit measure code"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.BloodCount] "Blood count list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /blood-count [get]
 func (h *Handler) GetBloodCountList(ctx *gin.Context) {
-	bloodCountList, err := h.services.BloodCount.GetBloodCountList()
+	var filter model.BloodCountFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bloodCountList, err := h.services.BloodCount.GetBloodCountList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -45,13 +45,32 @@ func (h *Handler) CreateBloodCountValue(ctx *gin.Context) {
 // @Description Retrieves a list of all blood count values.
 // @Tags BloodCountValue
 // @Produce json
-// @Success 200 {array} []model.BloodCountValue "Blood count value list"
+// @Param disease query string false "Disease ID"
+// @Param blood-count query string false "Blood count ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.BloodCountValue] "Blood count value list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /blood-count-value [get]
 func (h *Handler) GetBloodCountValueList(ctx *gin.Context) {
-	bloodCountValueList, err := h.services.BloodCountValue.GetBloodCountValueList()
+	var filter model.BloodCountValueFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bloodCountValueList, err := h.services.BloodCountValue.GetBloodCountValueList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -60,44 +79,66 @@ func (h *Handler) GetBloodCountValueList(ctx *gin.Context) {
 
 // GetBloodCountValueListByDisease godoc
 // @Summary Get blood count value list by disease
-// @Description Retrieves a list of blood count values associated with a specific disease.
+// @Description Retrieves a page of the blood count values of a disease.
 // @Tags BloodCountValue
-// @Param disease_id path string true "Disease ID"
 // @Produce json
-// @Success 200 {array} []model.BloodCountValue "Blood count value list"
+// @Param disease_id path string true "Disease ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.BloodCountValue] "Blood count value list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /blood-count-value/disease/{disease_id} [get]
 func (h *Handler) GetBloodCountValueListByDisease(ctx *gin.Context) {
-	diseaseId := ctx.Param(diseaseContext)
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	bloodCountValue, err := h.services.BloodCountValue.GetBloodCountValueListByDisease(diseaseId)
+	filter := model.BloodCountValueFilter{Disease: ctx.Param(diseaseContext)}
+	bloodCountValueList, err := h.services.BloodCountValue.GetBloodCountValueList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, bloodCountValue)
+	ctx.JSON(http.StatusOK, bloodCountValueList)
 }
 
 // GetBloodCountValueListByBloodCount godoc
 // @Summary Get blood count value list by blood count
-// @Description Retrieves a list of blood count values associated with a specific blood count.
+// @Description Retrieves a page of the values of a blood count for every disease.
 // @Tags BloodCountValue
-// @Param blood_count_id path string true "Blood count ID"
 // @Produce json
-// @Success 200 {array} []model.BloodCountValue "Blood count value list"
+// @Param blood_count_id path string true "Blood count ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.BloodCountValue] "Blood count value list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /blood-count-value/blood-count/{blood_count_id} [get]
 func (h *Handler) GetBloodCountValueListByBloodCount(ctx *gin.Context) {
-	bloodCountId := ctx.Param(bloodCountContext)
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	bloodCountValue, err := h.services.BloodCountValue.GetBloodCountValueListByBloodCount(bloodCountId)
+	filter := model.BloodCountValueFilter{BloodCount: ctx.Param(bloodCountContext)}
+	bloodCountValueList, err := h.services.BloodCountValue.GetBloodCountValueList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, bloodCountValue)
+	ctx.JSON(http.StatusOK, bloodCountValueList)
 }
 
 // GetBloodCountValueById godoc
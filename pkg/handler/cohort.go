@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCohort godoc
+// @Summary Create cohort
+// @Description Saves a cohort definition. Patient courses match the definition when they match every set criterion: disease, stage, diagnosis, course, drug, patient sex and age at the course begin.
+// @Tags Cohort
+// @Accept json
+// @Produce json
+// @Param input body model.Cohort true "Cohort data"
+// @Success 200 {object} model.Cohort "Created cohort data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort [post]
+func (h *Handler) CreateCohort(ctx *gin.Context) {
+	var cohort model.Cohort
+
+	if err := ctx.BindJSON(&cohort); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdCohort, err := h.services.Cohort.CreateCohort(getUser(ctx), cohort)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdCohort)
+}
+
+// GetCohortList godoc
+// @Summary Get cohort list
+// @Description Retrieves a list of saved cohorts.
+// @Tags Cohort
+// @Produce json
+// @Param created-by query int false "Filter by author user ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Cohort] "Cohort list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort [get]
+func (h *Handler) GetCohortList(ctx *gin.Context) {
+	var filter model.CohortFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cohortList, err := h.services.Cohort.GetCohortList(filter, listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cohortList)
+}
+
+// GetCohortById godoc
+// @Summary Get cohort by ID
+// @Description Retrieves a saved cohort by ID.
+// @Tags Cohort
+// @Produce json
+// @Param id path string true "Cohort ID"
+// @Success 200 {object} model.Cohort "Cohort data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort/{id} [get]
+func (h *Handler) GetCohortById(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cohort, err := h.services.Cohort.GetCohortById(id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cohort)
+}
+
+// UpdateCohort godoc
+// @Summary Update cohort
+// @Description Updates the name, description and definition of a cohort. Only the author and administrators can change a cohort.
+// @Tags Cohort
+// @Accept json
+// @Produce json
+// @Param input body model.Cohort true "Cohort data"
+// @Success 200 {object} model.Cohort "Updated cohort data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort [put]
+func (h *Handler) UpdateCohort(ctx *gin.Context) {
+	var cohort model.Cohort
+
+	if err := ctx.BindJSON(&cohort); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedCohort, err := h.services.Cohort.UpdateCohort(getUser(ctx), cohort)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedCohort)
+}
+
+// DeleteCohort godoc
+// @Summary Delete cohort
+// @Description Deletes a cohort by ID. Only the author and administrators can delete a cohort.
+// @Tags Cohort
+// @Produce json
+// @Param id path string true "Cohort ID"
+// @Success 200 {string} string "Cohort ID deleted"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort/{id} [delete]
+func (h *Handler) DeleteCohort(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Cohort.DeleteCohort(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, id)
+}
+
+// GetCohortStatistics godoc
+// @Summary Get cohort statistics
+// @Description Runs the cohort definition and retrieves aggregates of the matching patient courses: patient and course counts, patients by sex and stage, and blood count statistics per time bucket after the course begin. No individual records are returned, groups of fewer than k patients are merged or suppressed and listed in the privacy report.
+// @Tags Cohort
+// @Produce json
+// @Param id path string true "Cohort ID"
+// @Param bucket-days query int false "Width of the time buckets in days, 30 by default"
+// @Param blood-count query []string false "Blood count IDs, all blood counts by default" collectionFormat(multi)
+// @Success 200 {object} model.CohortStatistics "Cohort statistics"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /cohort/{id}/statistics [get]
+func (h *Handler) GetCohortStatistics(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var query model.CohortStatisticsQuery
+	if err := ctx.BindQuery(&query); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	statistics, err := h.services.Cohort.GetCohortStatistics(id, query)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statistics)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"database/sql"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCohortStatistics(t *testing.T) {
+	type mockBehavior func(s *mock.MockCohort)
+
+	testTable := []struct {
+		name           string
+		url            string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "OK",
+			url:  "/cohort/4/statistics?bucket-days=14&blood-count=CA15-3&blood-count=HGB",
+			mockBehavior: func(s *mock.MockCohort) {
+				s.EXPECT().GetCohortStatistics(4, model.CohortStatisticsQuery{BucketDays: 14, BloodCounts: []string{"CA15-3", "HGB"}}).Return(model.CohortStatistics{
+					Cohort:     4,
+					Patients:   12,
+					Courses:    15,
+					BucketDays: 14,
+					Sex:        []model.CohortCount{{Value: "female", Patients: 12}},
+					Stages:     []model.CohortCount{{Value: "II", Patients: 7}, {Value: "III", Patients: 5}},
+					BloodCounts: []model.CohortBloodCountStatistics{
+						{BloodCount: "CA15-3", Bucket: 1, FromDay: 14, ToDay: 27, Results: 9, Patients: 8, Mean: 31, Q1: 20, Median: 30, Q3: 41},
+					},
+					Privacy: model.PrivacyReport{K: 5, Suppressed: []model.PrivacySuppression{
+						{Field: "blood-count", Value: "HGB days 0-13", Action: model.SuppressedCell},
+						{Field: "blood-count-range", Value: "p5-p95", Action: model.TrimmedRange},
+						{Field: "blood-count-range", Value: "CA15-3 days 14-27", Action: model.SuppressedCell},
+					}},
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody: `{"cohort":4,"patients":12,"courses":15,"bucket-days":14,"sex":[{"value":"female","patients":12}],` +
+				`"stages":[{"value":"II","patients":7},{"value":"III","patients":5}],` +
+				`"blood-counts":[{"blood-count":"CA15-3","from-day":14,"to-day":27,"results":9,"patients":8,"mean":31,"p5":null,"q1":20,"median":30,"q3":41,"p95":null}],` +
+				`"privacy":{"k":5,"suppressed":[{"field":"blood-count","value":"HGB days 0-13","action":"suppressed"},` +
+				`{"field":"blood-count-range","value":"p5-p95","action":"trimmed"},{"field":"blood-count-range","value":"CA15-3 days 14-27","action":"suppressed"}]}}`,
+		},
+		{
+			name:           "Invalid bucket",
+			url:            "/cohort/4/statistics?bucket-days=-7",
+			mockBehavior:   func(s *mock.MockCohort) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'CohortStatisticsQuery.BucketDays' Error:Field validation for 'BucketDays' failed on the 'min' tag"}`,
+		},
+		{
+			name: "Not found",
+			url:  "/cohort/9/statistics",
+			mockBehavior: func(s *mock.MockCohort) {
+				s.EXPECT().GetCohortStatistics(9, model.CohortStatisticsQuery{}).Return(model.CohortStatistics{}, sql.ErrNoRows)
+			},
+			expectedStatus: 404,
+			expectedBody:   `{"message":"sql: no rows in result set"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cohort := mock.NewMockCohort(c)
+			testCase.mockBehavior(cohort)
+
+			services := &service.Service{Cohort: cohort}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/cohort/:id/statistics", handler.GetCohortStatistics)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	dateLayout           = "2006-01-02"
+	defaultConsolePeriod = 30 * 24 * time.Hour
+	defaultConsoleLimit  = 50
+	maxConsoleLimit      = 500
+)
+
+// Console godoc
+// @Summary Get console statistics
+// @Description Retrieves counts of patients and doctors, and counts of active courses and procedures for the period. The period defaults to the last 30 days.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string false "Period start (YYYY-MM-DD)"
+// @Param to query string false "Period end (YYYY-MM-DD)"
+// @Success 200 {object} model.ConsoleStatistics "Console statistics"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console [get]
+func (h *AccountHandlers) Console(ctx *gin.Context) {
+	to := time.Now()
+	if value := ctx.Query("to"); value != "" {
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		to = date
+	}
+
+	from := to.Add(-defaultConsolePeriod)
+	if value := ctx.Query("from"); value != "" {
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		from = date
+	}
+
+	statistics, err := h.services.Console.GetStatistics(from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statistics)
+}
+
+// SearchUsers godoc
+// @Summary Search users
+// @Description Retrieves users matching the filter. The search string is matched against email and names.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param search query string false "Search string"
+// @Param role query string false "Role"
+// @Param locked query bool false "Locked users only or unlocked users only"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.UserProfile] "User list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/users [get]
+func (h *AccountHandlers) SearchUsers(ctx *gin.Context) {
+	var filter model.UserFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userList, err := h.services.Console.SearchUserList(filter, listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userList)
+}
+
+// LockUser godoc
+// @Summary Lock user
+// @Description Locks the user account and revokes all sessions of the user.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} int "User ID"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/users/{id}/lock [put]
+func (h *AccountHandlers) LockUser(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Console.LockUser(getUser(ctx), id); err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, id)
+}
+
+// UnlockUser godoc
+// @Summary Unlock user
+// @Description Unlocks the user account.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} int "User ID"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/users/{id}/lock [delete]
+func (h *AccountHandlers) UnlockUser(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Console.UnlockUser(getUser(ctx), id); err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, id)
+}
+
+// ChangeUserRole godoc
+// @Summary Change user role
+// @Description Changes the role of the user and revokes all sessions of the user.
+// @Tags Console
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param input body model.RoleInput true "New role"
+// @Success 200 {object} model.UserProfile "Updated user profile"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/users/{id}/role [put]
+func (h *AccountHandlers) ChangeUserRole(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var input model.RoleInput
+	if err := ctx.BindJSON(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.services.Console.ChangeUserRole(getUser(ctx), id, input.Role)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// FailedLogins godoc
+// @Summary Get failed logins
+// @Description Retrieves the latest rejected login attempts.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "Number of entries, 50 by default, 500 at most"
+// @Success 200 {array} []model.FailedLogin "Failed login list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/failed-logins [get]
+func (h *AccountHandlers) FailedLogins(ctx *gin.Context) {
+	limit, err := queryLimit(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	failedLoginList, err := h.services.Console.GetFailedLoginList(limit)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, failedLoginList)
+}
+
+// Changes godoc
+// @Summary Get changes
+// @Description Retrieves the latest entries of the audit log, optionally filtered by entity, user and period.
+// @Tags Console
+// @Produce json
+// @Security ApiKeyAuth
+// @Param entity query string false "Changed entity, e.g. patient or procedure-blood-count"
+// @Param entity-id query string false "Changed entity ID"
+// @Param user-id query int false "ID of the user who made the change"
+// @Param from query string false "Period start (YYYY-MM-DD)"
+// @Param to query string false "Period end (YYYY-MM-DD)"
+// @Param limit query int false "Number of entries, 50 by default, 500 at most"
+// @Success 200 {array} []model.AuditEntry "Change list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/console/changes [get]
+func (h *AccountHandlers) Changes(ctx *gin.Context) {
+	var filter model.AuditFilter
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, date := range []string{filter.From, filter.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	limit, err := queryLimit(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Limit = limit
+
+	changeList, err := h.services.Console.GetChangeList(filter)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changeList)
+}
+
+// queryLimit reads the limit query parameter, capped at maxConsoleLimit
+func queryLimit(ctx *gin.Context) (int, error) {
+	value := ctx.Query("limit")
+	if value == "" {
+		return defaultConsoleLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 || limit > maxConsoleLimit {
+		limit = maxConsoleLimit
+	}
+	return limit, nil
+}
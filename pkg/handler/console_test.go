@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"fmt"
+	"med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLockUser(t *testing.T) {
+	admin := service.UserData{Id: 1, Role: "admin"}
+
+	type mockBehavior func(s *mock.MockConsole, id int)
+
+	testTable := []struct {
+		name           string
+		userId         string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "OK",
+			userId: "2",
+			mockBehavior: func(s *mock.MockConsole, id int) {
+				s.EXPECT().LockUser(admin, id).Return(nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `2`,
+		},
+		{
+			name:   "Own account",
+			userId: "1",
+			mockBehavior: func(s *mock.MockConsole, id int) {
+				s.EXPECT().LockUser(admin, id).Return(service.ErrOwnAccount)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"cannot change own account"}`,
+		},
+		{
+			name:           "Invalid id",
+			userId:         "user",
+			mockBehavior:   func(s *mock.MockConsole, id int) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"strconv.Atoi: parsing \"user\": invalid syntax"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			var id int
+			fmt.Sscan(testCase.userId, &id)
+
+			console := mock.NewMockConsole(c)
+			testCase.mockBehavior(console, id)
+
+			services := &service.Service{Console: console}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.PUT("/users/:id/lock", func(ctx *gin.Context) {
+				ctx.Set(userContext, admin.Id)
+				ctx.Set(roleContext, admin.Role)
+			}, handler.AccountHandler.LockUser)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/users/"+testCase.userId+"/lock", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	userId := 2
+
+	type mockBehavior func(s *mock.MockConsole)
+
+	testTable := []struct {
+		name           string
+		query          string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "OK",
+			query: "?entity=patient&user-id=2&from=2024-01-01&to=2024-01-31",
+			mockBehavior: func(s *mock.MockConsole) {
+				s.EXPECT().GetChangeList(model.AuditFilter{
+					Entity: "patient",
+					UserId: &userId,
+					From:   "2024-01-01",
+					To:     "2024-01-31",
+					Limit:  50,
+				}).Return([]model.AuditEntry{{
+					Id:        1,
+					UserId:    2,
+					Action:    "update",
+					Entity:    "patient",
+					EntityId:  "7",
+					Before:    []byte(`{"phone":"1"}`),
+					After:     []byte(`{"phone":"2"}`),
+					CreatedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				}}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `[{"id":1,"user-id":2,"action":"update","entity":"patient","entity-id":"7","before":{"phone":"1"},"after":{"phone":"2"},"created-at":"2024-01-15T00:00:00Z"}]`,
+		},
+		{
+			name:           "Invalid date",
+			query:          "?from=15.01.2024",
+			mockBehavior:   func(s *mock.MockConsole) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"parsing time \"15.01.2024\" as \"2006-01-02\": cannot parse \"15.01.2024\" as \"2006\""}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			console := mock.NewMockConsole(c)
+			testCase.mockBehavior(console)
+
+			services := &service.Service{Console: console}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/changes", handler.AccountHandler.Changes)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/changes"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
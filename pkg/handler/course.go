@@ -40,13 +40,31 @@ func (h *Handler) CreateCourse(ctx *gin.Context) {
 // @Description Returns a list of courses.
 // @Tags Courses
 // @Produce json
-// @Success 200 {object} []model.Course "List of courses"
+// @Param drug query string false "Drug ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Course] "List of courses"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /courses [get]
 func (h *Handler) GetCourseList(ctx *gin.Context) {
-	courseList, err := h.services.Course.GetCourseList()
+	var filter model.CourseFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	courseList, err := h.services.Course.GetCourseList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -3,6 +3,7 @@ package handler
 import (
 	"med/pkg/model"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +17,8 @@ import (
 // @Param input body model.CourseProcedure true "Course procedure data"
 // @Success 200 {object} model.CourseProcedure "Created course procedure data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /course-procedure [post]
 func (h *Handler) CreateCourseProcedure(ctx *gin.Context) {
@@ -26,9 +29,9 @@ func (h *Handler) CreateCourseProcedure(ctx *gin.Context) {
 		return
 	}
 
-	createdCourseProcedure, err := h.services.CourseProcedure.CreateCourseProcedure(courseProcedure)
+	createdCourseProcedure, err := h.services.CourseProcedure.CreateCourseProcedure(getUser(ctx), courseProcedure)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -40,13 +43,35 @@ func (h *Handler) CreateCourseProcedure(ctx *gin.Context) {
 // @Description Retrieves a list of course procedures.
 // @Tags CourseProcedure
 // @Produce json
-// @Success 200 {array} []model.CourseProcedure "Course procedure list"
+// @Param patient-course query int false "Patient course ID"
+// @Param doctor query int false "Doctor ID"
+// @Param begin-date-from query string false "Earliest begin date (YYYY-MM-DD)"
+// @Param begin-date-to query string false "Latest begin date (YYYY-MM-DD)"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.CourseProcedure] "Course procedure list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /course-procedure [get]
 func (h *Handler) GetCourseProcedureList(ctx *gin.Context) {
-	courseProcedureList, err := h.services.CourseProcedure.GetCourseProcedureList()
+	var filter model.CourseProcedureFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	courseProcedureList, err := h.services.CourseProcedure.GetCourseProcedureList(getUser(ctx), filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -60,14 +85,20 @@ func (h *Handler) GetCourseProcedureList(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Course procedure ID"
 // @Success 200 {object} model.CourseProcedure "Course procedure data"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /course-procedure/{id} [get]
 func (h *Handler) GetCourseProcedureById(ctx *gin.Context) {
-	id := ctx.Param(userContext)
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	courseProcedure, err := h.services.CourseProcedure.GetCourseProcedureById(id)
+	courseProcedure, err := h.services.CourseProcedure.GetCourseProcedureById(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -83,6 +114,8 @@ func (h *Handler) GetCourseProcedureById(ctx *gin.Context) {
 // @Param input body model.CourseProcedure true "Updated course procedure data"
 // @Success 200 {object} model.CourseProcedure "Updated course procedure data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /course-procedure [put]
 func (h *Handler) UpdateCourseProcedure(ctx *gin.Context) {
@@ -93,9 +126,9 @@ func (h *Handler) UpdateCourseProcedure(ctx *gin.Context) {
 		return
 	}
 
-	updatedCourseProcedure, err := h.services.CourseProcedure.UpdateCourseProcedure(courseProcedure)
+	updatedCourseProcedure, err := h.services.CourseProcedure.UpdateCourseProcedure(getUser(ctx), courseProcedure)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -109,14 +142,20 @@ func (h *Handler) UpdateCourseProcedure(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Course procedure ID"
 // @Success 200 {string} string "Course procedure ID"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /course-procedure/{id} [delete]
 func (h *Handler) DeleteCourseProcedure(ctx *gin.Context) {
-	id := ctx.Param(userContext)
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	err := h.services.CourseProcedure.DeleteCourseProcedure(id)
+	err = h.services.CourseProcedure.DeleteCourseProcedure(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
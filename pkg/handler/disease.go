@@ -40,13 +40,25 @@ func (h *Handler) CreateDisease(ctx *gin.Context) {
 // @Description Retrieves a list of diseases.
 // @Tags Disease
 // @Produce json
-// @Success 200 {array} []model.Disease "Disease list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Disease] "Disease list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /disease [get]
 func (h *Handler) GetDiseaseList(ctx *gin.Context) {
-	diseaseList, err := h.services.Disease.GetDiseaseList()
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	diseaseList, err := h.services.Disease.GetDiseaseList(listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
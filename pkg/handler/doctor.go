@@ -41,13 +41,25 @@ func (h *Handler) CreateDoctor(ctx *gin.Context) {
 // @Description Retrieves a list of doctors.
 // @Tags Doctor
 // @Produce json
-// @Success 200 {array} []model.Doctor "Doctor list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Doctor] "Doctor list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /doctors [get]
 func (h *Handler) GetDoctorList(ctx *gin.Context) {
-	doctorList, err := h.services.Doctor.GetDoctorList()
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	doctorList, err := h.services.Doctor.GetDoctorList(listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -42,7 +42,12 @@ func (h *Handler) CreateDoctorPatient(ctx *gin.Context) {
 // @Tags DoctorPatient
 // @Produce json
 // @Param doctor_id path string true "Doctor ID"
-// @Success 200 {array} []model.DoctorPatient "Patient ID list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.DoctorPatient] "Patient ID list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /doctor-patient/{doctor_id} [get]
 func (h *Handler) GetDoctorPatientList(ctx *gin.Context) {
@@ -51,10 +56,15 @@ func (h *Handler) GetDoctorPatientList(ctx *gin.Context) {
 		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
+	var listQuery model.ListQuery
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	doctorPatientList, err := h.services.DoctorPatient.GetDoctorPatientList(doctorId)
+	doctorPatientList, err := h.services.DoctorPatient.GetDoctorPatientList(doctorId, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -40,13 +40,33 @@ func (h *Handler) CreateDrug(ctx *gin.Context) {
 // @Description Retrieves a list of drugs.
 // @Tags Drug
 // @Produce json
-// @Success 200 {array} []model.Drug "Drug list"
+// @Param dosage-form query string false "Dosage form"
+// @Param country query string false "Country"
+// @Param manufacturer query string false "Manufacturer"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Drug] "Drug list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /drugs [get]
 func (h *Handler) GetDrugList(ctx *gin.Context) {
-	drugList, err := h.services.Drug.GetDrugList()
+	var filter model.DrugFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	drugList, err := h.services.Drug.GetDrugList(filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
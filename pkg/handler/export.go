@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"med/pkg/model"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateExportJob godoc
+// @Summary Create export job
+// @Description Starts a de-identified export of patients, diseases, courses, procedures and blood counts as CSV or NDJSON files in a zip bundle with a manifest of the data dictionary. Names, SNILS, phone, user accounts and free-text procedure results are stripped, patient, course and procedure IDs are replaced with keyed pseudonyms and birth dates are generalized to the year or a 10-year age band. Course and procedure dates are exported as days since the first course of the patient (dates: relative, the default) or shifted by a secret number of days per patient (dates: shifted). Quasi-identifiers of patients in groups of fewer than k patients are suppressed, the manifest reports what was suppressed.
+// @Tags Export
+// @Accept json
+// @Produce json
+// @Param input body model.ExportJob true "Export job data"
+// @Success 200 {object} model.ExportJob "Created export job data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Export pseudonym key is not configured"
+// @Router /export [post]
+func (h *Handler) CreateExportJob(ctx *gin.Context) {
+	var job model.ExportJob
+
+	if err := ctx.BindJSON(&job); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdJob, err := h.services.Export.CreateExportJob(getUser(ctx), job)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdJob)
+}
+
+// GetExportJobList godoc
+// @Summary Get export job list
+// @Description Retrieves a list of the export jobs of the user, administrators see the jobs of every user.
+// @Tags Export
+// @Produce json
+// @Param status query string false "Filter by status: pending, running, done or failed"
+// @Param requested-by query int false "Filter by requesting user ID, only administrators may list the jobs of other users"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.ExportJob] "Export job list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /export [get]
+func (h *Handler) GetExportJobList(ctx *gin.Context) {
+	var filter model.ExportJobFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jobList, err := h.services.Export.GetExportJobList(getUser(ctx), filter, listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobList)
+}
+
+// GetExportJobById godoc
+// @Summary Get export job by ID
+// @Description Retrieves an export job by ID to follow its status. Only the requester and administrators may read a job.
+// @Tags Export
+// @Produce json
+// @Param id path string true "Export job ID"
+// @Success 200 {object} model.ExportJob "Export job data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /export/{id} [get]
+func (h *Handler) GetExportJobById(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.services.Export.GetExportJobById(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// DownloadExport godoc
+// @Summary Download export bundle
+// @Description Downloads the zip bundle of a finished export job. Only the requester and administrators may download it.
+// @Tags Export
+// @Produce application/zip
+// @Param id path string true "Export job ID"
+// @Success 200 {file} file "Export bundle"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 409 {object} ErrorResponse "Export is not ready"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /export/{id}/download [get]
+func (h *Handler) DownloadExport(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	path, err := h.services.Export.GetExportBundle(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.FileAttachment(path, fmt.Sprintf("onco-base-%s", filepath.Base(path)))
+}
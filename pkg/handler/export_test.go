@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateExportJob(t *testing.T) {
+	type mockBehavior func(s *mock.MockExport, user service.UserData, job model.ExportJob)
+
+	user := service.UserData{Id: 4, Role: "researcher"}
+	job := model.ExportJob{Format: "csv", BirthDate: "year"}
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"format":"csv","birth-date":"year"}`,
+			mockBehavior: func(s *mock.MockExport, user service.UserData, job model.ExportJob) {
+				s.EXPECT().CreateExportJob(user, job).Return(model.ExportJob{Id: 1, Format: "csv", BirthDate: "year", Dates: "relative", Status: "pending", RequestedBy: 4}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"id":1,"format":"csv","birth-date":"year","dates":"relative","status":"pending","requested-by":4,"created-at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "No pseudonym key",
+			inputBody: `{"format":"csv","birth-date":"year"}`,
+			mockBehavior: func(s *mock.MockExport, user service.UserData, job model.ExportJob) {
+				s.EXPECT().CreateExportJob(user, job).Return(model.ExportJob{}, service.ErrNoPseudonymKey)
+			},
+			expectedStatus: 503,
+			expectedBody:   `{"message":"export pseudonym key is not configured"}`,
+		},
+		{
+			name:           "Unknown format",
+			inputBody:      `{"format":"xml","birth-date":"year"}`,
+			mockBehavior:   func(s *mock.MockExport, user service.UserData, job model.ExportJob) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'ExportJob.Format' Error:Field validation for 'Format' failed on the 'oneof' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			export := mock.NewMockExport(c)
+			testCase.mockBehavior(export, user, job)
+
+			services := &service.Service{Export: export}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/export", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.CreateExportJob)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/export", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
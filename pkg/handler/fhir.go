@@ -0,0 +1,644 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// GetFHIRPatient godoc
+// @Summary Get FHIR Patient
+// @Description Retrieves a patient as a FHIR R4 Patient. Patient is a patient with SNILS as the identifier.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Success 200 {object} model.FHIRPatient "FHIR Patient"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Patient/{id} [get]
+func (h *Handler) GetFHIRPatient(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRPatient(getUser(ctx), ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRPatients godoc
+// @Summary Search FHIR Patients
+// @Description Searches patients and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param identifier query string false "SNILS, optionally prefixed with the system and |"
+// @Param name query string false "Beginning of the first, middle or last name"
+// @Param gender query string false "Gender" Enums(male, female, other, unknown)
+// @Param birthdate query []string false "Birth date (YYYY-MM-DD) with an optional eq, ge, gt, le or lt prefix" collectionFormat(multi)
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Patient [get]
+func (h *Handler) SearchFHIRPatients(ctx *gin.Context) {
+	var query model.FHIRPatientQuery
+
+	if err := ctx.BindQuery(&query); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRPatients(getUser(ctx), query)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// CreateFHIRPatient godoc
+// @Summary Create FHIR Patient
+// @Description Creates a patient from a FHIR R4 Patient. Patient is a patient with SNILS as the identifier.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param input body model.FHIRPatient true "FHIR Patient"
+// @Success 201 {object} model.FHIRPatient "Created FHIR Patient"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Patient [post]
+func (h *Handler) CreateFHIRPatient(ctx *gin.Context) {
+	var resource model.FHIRPatient
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdResource, err := h.services.FHIR.CreateFHIRPatient(getUser(ctx), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.Header("Location", fhirBaseUrl(ctx)+"/"+createdResource.ResourceType+"/"+createdResource.Id)
+	fhirResponse(ctx, http.StatusCreated, createdResource)
+}
+
+// UpdateFHIRPatient godoc
+// @Summary Update FHIR Patient
+// @Description Updates a patient from a FHIR R4 Patient, the resource replaces the stored one. Patient is a patient with SNILS as the identifier.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param input body model.FHIRPatient true "FHIR Patient"
+// @Success 200 {object} model.FHIRPatient "Updated FHIR Patient"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Patient/{id} [put]
+func (h *Handler) UpdateFHIRPatient(ctx *gin.Context) {
+	var resource model.FHIRPatient
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedResource, err := h.services.FHIR.UpdateFHIRPatient(getUser(ctx), ctx.Param("id"), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, updatedResource)
+}
+
+// GetFHIRObservation godoc
+// @Summary Get FHIR Observation
+// @Description Retrieves a blood count result as a FHIR R4 Observation. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "<procedure ID>-<blood count ID>"
+// @Success 200 {object} model.FHIRObservation "FHIR Observation"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Observation/{id} [get]
+func (h *Handler) GetFHIRObservation(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRObservation(getUser(ctx), ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRObservations godoc
+// @Summary Search FHIR Observations
+// @Description Searches blood count results and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param patient query string false "Patient reference"
+// @Param code query string false "Blood count ID, optionally prefixed with the system and |"
+// @Param date query []string false "Procedure date (YYYY-MM-DD) with an optional eq, ge, gt, le or lt prefix" collectionFormat(multi)
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Observation [get]
+func (h *Handler) SearchFHIRObservations(ctx *gin.Context) {
+	var query model.FHIRObservationQuery
+
+	if err := ctx.BindQuery(&query); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRObservations(getUser(ctx), query)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// CreateFHIRObservation godoc
+// @Summary Create FHIR Observation
+// @Description Creates a blood count result from a FHIR R4 Observation. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param input body model.FHIRObservation true "FHIR Observation"
+// @Success 201 {object} model.FHIRObservation "Created FHIR Observation"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Observation [post]
+func (h *Handler) CreateFHIRObservation(ctx *gin.Context) {
+	var resource model.FHIRObservation
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdResource, err := h.services.FHIR.CreateFHIRObservation(getUser(ctx), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.Header("Location", fhirBaseUrl(ctx)+"/"+createdResource.ResourceType+"/"+createdResource.Id)
+	fhirResponse(ctx, http.StatusCreated, createdResource)
+}
+
+// UpdateFHIRObservation godoc
+// @Summary Update FHIR Observation
+// @Description Updates a blood count result from a FHIR R4 Observation, the resource replaces the stored one. Observation is a blood count result of a procedure with the normal range of the blood count as the reference range. A created or updated value is converted to the unit of the blood count and flagged against its ranges.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param id path string true "<procedure ID>-<blood count ID>"
+// @Param input body model.FHIRObservation true "FHIR Observation"
+// @Success 200 {object} model.FHIRObservation "Updated FHIR Observation"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Observation/{id} [put]
+func (h *Handler) UpdateFHIRObservation(ctx *gin.Context) {
+	var resource model.FHIRObservation
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedResource, err := h.services.FHIR.UpdateFHIRObservation(getUser(ctx), ctx.Param("id"), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, updatedResource)
+}
+
+// GetFHIRCondition godoc
+// @Summary Get FHIR Condition
+// @Description Retrieves a patient disease as a FHIR R4 Condition. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "<patient ID>-<disease ID>"
+// @Success 200 {object} model.FHIRCondition "FHIR Condition"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Condition/{id} [get]
+func (h *Handler) GetFHIRCondition(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRCondition(getUser(ctx), ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRConditions godoc
+// @Summary Search FHIR Conditions
+// @Description Searches patient diseases and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param patient query string false "Patient reference"
+// @Param code query string false "Disease ID, optionally prefixed with the system and |"
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Condition [get]
+func (h *Handler) SearchFHIRConditions(ctx *gin.Context) {
+	var query model.FHIRConditionQuery
+
+	if err := ctx.BindQuery(&query); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRConditions(getUser(ctx), query)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// CreateFHIRCondition godoc
+// @Summary Create FHIR Condition
+// @Description Creates a patient disease from a FHIR R4 Condition. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param input body model.FHIRCondition true "FHIR Condition"
+// @Success 201 {object} model.FHIRCondition "Created FHIR Condition"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Condition [post]
+func (h *Handler) CreateFHIRCondition(ctx *gin.Context) {
+	var resource model.FHIRCondition
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdResource, err := h.services.FHIR.CreateFHIRCondition(getUser(ctx), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.Header("Location", fhirBaseUrl(ctx)+"/"+createdResource.ResourceType+"/"+createdResource.Id)
+	fhirResponse(ctx, http.StatusCreated, createdResource)
+}
+
+// UpdateFHIRCondition godoc
+// @Summary Update FHIR Condition
+// @Description Updates a patient disease from a FHIR R4 Condition, the resource replaces the stored one. Condition is a disease of a patient with the stage as the stage summary and the diagnosis as the evidence.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param id path string true "<patient ID>-<disease ID>"
+// @Param input body model.FHIRCondition true "FHIR Condition"
+// @Success 200 {object} model.FHIRCondition "Updated FHIR Condition"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Condition/{id} [put]
+func (h *Handler) UpdateFHIRCondition(ctx *gin.Context) {
+	var resource model.FHIRCondition
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedResource, err := h.services.FHIR.UpdateFHIRCondition(getUser(ctx), ctx.Param("id"), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, updatedResource)
+}
+
+// GetFHIRMedicationRequest godoc
+// @Summary Get FHIR MedicationRequest
+// @Description Retrieves a patient course as a FHIR R4 MedicationRequest. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "Patient course ID"
+// @Success 200 {object} model.FHIRMedicationRequest "FHIR MedicationRequest"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/MedicationRequest/{id} [get]
+func (h *Handler) GetFHIRMedicationRequest(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRMedicationRequest(getUser(ctx), ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRMedicationRequests godoc
+// @Summary Search FHIR MedicationRequests
+// @Description Searches patient courses and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param patient query string false "Patient reference"
+// @Param requester query string false "Practitioner reference"
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/MedicationRequest [get]
+func (h *Handler) SearchFHIRMedicationRequests(ctx *gin.Context) {
+	var query model.FHIRMedicationRequestQuery
+
+	if err := ctx.BindQuery(&query); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRMedicationRequests(getUser(ctx), query)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// CreateFHIRMedicationRequest godoc
+// @Summary Create FHIR MedicationRequest
+// @Description Creates a patient course from a FHIR R4 MedicationRequest. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param input body model.FHIRMedicationRequest true "FHIR MedicationRequest"
+// @Success 201 {object} model.FHIRMedicationRequest "Created FHIR MedicationRequest"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/MedicationRequest [post]
+func (h *Handler) CreateFHIRMedicationRequest(ctx *gin.Context) {
+	var resource model.FHIRMedicationRequest
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdResource, err := h.services.FHIR.CreateFHIRMedicationRequest(getUser(ctx), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.Header("Location", fhirBaseUrl(ctx)+"/"+createdResource.ResourceType+"/"+createdResource.Id)
+	fhirResponse(ctx, http.StatusCreated, createdResource)
+}
+
+// UpdateFHIRMedicationRequest godoc
+// @Summary Update FHIR MedicationRequest
+// @Description Updates a patient course from a FHIR R4 MedicationRequest, the resource replaces the stored one. MedicationRequest is a course of a patient. The course is the group identifier, the doctor is the requester, the disease is the Condition reason reference and the dates are the bounds of the dosage timing.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Param id path string true "Patient course ID"
+// @Param input body model.FHIRMedicationRequest true "FHIR MedicationRequest"
+// @Success 200 {object} model.FHIRMedicationRequest "Updated FHIR MedicationRequest"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/MedicationRequest/{id} [put]
+func (h *Handler) UpdateFHIRMedicationRequest(ctx *gin.Context) {
+	var resource model.FHIRMedicationRequest
+
+	if err := ctx.BindJSON(&resource); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedResource, err := h.services.FHIR.UpdateFHIRMedicationRequest(getUser(ctx), ctx.Param("id"), resource)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, updatedResource)
+}
+
+// GetFHIRMedication godoc
+// @Summary Get FHIR Medication
+// @Description Retrieves a drug as a FHIR R4 Medication. Medication is a drug.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "Drug ID"
+// @Success 200 {object} model.FHIRMedication "FHIR Medication"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Medication/{id} [get]
+func (h *Handler) GetFHIRMedication(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRMedication(ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRMedications godoc
+// @Summary Search FHIR Medications
+// @Description Searches drugs and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Medication [get]
+func (h *Handler) SearchFHIRMedications(ctx *gin.Context) {
+	var search model.FHIRSearch
+
+	if err := ctx.BindQuery(&search); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRMedications(search)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// GetFHIRPractitioner godoc
+// @Summary Get FHIR Practitioner
+// @Description Retrieves a doctor as a FHIR R4 Practitioner. Practitioner is a doctor.
+// @Tags FHIR
+// @Produce json
+// @Param id path string true "Doctor ID"
+// @Success 200 {object} model.FHIRPractitioner "FHIR Practitioner"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 404 {object} model.FHIROperationOutcome "Not found"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Practitioner/{id} [get]
+func (h *Handler) GetFHIRPractitioner(ctx *gin.Context) {
+	resource, err := h.services.FHIR.GetFHIRPractitioner(ctx.Param("id"))
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirResponse(ctx, http.StatusOK, resource)
+}
+
+// SearchFHIRPractitioners godoc
+// @Summary Search FHIR Practitioners
+// @Description Searches doctors and returns a page of them as a FHIR R4 searchset Bundle, the next link holds the cursor of the next page.
+// @Tags FHIR
+// @Produce json
+// @Param _count query int false "Page size, 50 by default and at most 500"
+// @Param _cursor query string false "Cursor of the page from the next link"
+// @Param _total query string false "Whether the bundle has the number of matching resources" Enums(none, estimate, accurate)
+// @Success 200 {object} model.FHIRBundle "FHIR Bundle"
+// @Failure 400 {object} model.FHIROperationOutcome "Bad request"
+// @Failure 403 {object} model.FHIROperationOutcome "Forbidden"
+// @Failure 500 {object} model.FHIROperationOutcome "Internal server error"
+// @Router /fhir/Practitioner [get]
+func (h *Handler) SearchFHIRPractitioners(ctx *gin.Context) {
+	var search model.FHIRSearch
+
+	if err := ctx.BindQuery(&search); err != nil {
+		newFHIRErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.services.FHIR.SearchFHIRPractitioners(search)
+	if err != nil {
+		newFHIRErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	fhirBundleResponse(ctx, bundle)
+}
+
+// fhirResponse sends the resource with the FHIR JSON content type, URLs in bundle links are not HTML escaped
+func fhirResponse(ctx *gin.Context, statusCode int, resource interface{}) {
+	ctx.Header("Content-Type", model.FHIRContentType+"; charset=utf-8")
+	ctx.PureJSON(statusCode, resource)
+}
+
+// fhirBundleResponse makes the full URLs of the bundle entries absolute, adds the self link and,
+// if there is a next page, the next link with its cursor, and sends the bundle
+func fhirBundleResponse(ctx *gin.Context, bundle model.FHIRBundle) {
+	base := fhirBaseUrl(ctx)
+	for i := range bundle.Entry {
+		bundle.Entry[i].FullUrl = base + "/" + bundle.Entry[i].FullUrl
+	}
+
+	origin := fhirOrigin(ctx)
+	bundle.Link = []model.FHIRBundleLink{{Relation: "self", Url: origin + ctx.Request.URL.RequestURI()}}
+	if bundle.NextCursor != "" {
+		query := ctx.Request.URL.Query()
+		query.Set("_cursor", bundle.NextCursor)
+		next := url.URL{Path: ctx.Request.URL.Path, RawQuery: query.Encode()}
+		bundle.Link = append(bundle.Link, model.FHIRBundleLink{Relation: "next", Url: origin + next.RequestURI()})
+	}
+
+	fhirResponse(ctx, http.StatusOK, bundle)
+}
+
+// fhirBaseUrl is the absolute URL of the FHIR facade
+func fhirBaseUrl(ctx *gin.Context) string {
+	path := ctx.Request.URL.Path
+	if i := strings.Index(path, "/fhir"); i >= 0 {
+		path = path[:i+len("/fhir")]
+	}
+	return fhirOrigin(ctx) + path
+}
+
+// fhirOrigin is the scheme and the host of the request, the scheme is taken from X-Forwarded-Proto behind a proxy
+func fhirOrigin(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := ctx.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + ctx.Request.Host
+}
+
+// newFHIRErrorResponse logs the error and sends it to the client as an OperationOutcome with the status code.
+func newFHIRErrorResponse(ctx *gin.Context, statusCode int, message string) {
+	log.Error().Msg(message)
+
+	code := "exception"
+	switch statusCode {
+	case http.StatusBadRequest:
+		code = "invalid"
+	case http.StatusUnauthorized:
+		code = "login"
+	case http.StatusForbidden:
+		code = "forbidden"
+	case http.StatusNotFound:
+		code = "not-found"
+	case http.StatusConflict:
+		code = "conflict"
+	}
+
+	ctx.Header("Content-Type", model.FHIRContentType+"; charset=utf-8")
+	ctx.AbortWithStatusJSON(statusCode, model.FHIROperationOutcome{
+		ResourceType: model.FHIROperationOutcomeType,
+		Issue:        []model.FHIRIssue{{Severity: "error", Code: code, Diagnostics: message}},
+	})
+}
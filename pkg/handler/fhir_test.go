@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"database/sql"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetFHIRPatient(t *testing.T) {
+	type mockBehavior func(s *mock.MockFHIR, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+
+	testTable := []struct {
+		name           string
+		patientId      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			patientId: "1",
+			mockBehavior: func(s *mock.MockFHIR, user service.UserData) {
+				s.EXPECT().GetFHIRPatient(user, "1").Return(model.FHIRPatient{ResourceType: "Patient", Id: "1", Gender: "female"}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"resourceType":"Patient","id":"1","gender":"female"}` + "\n",
+		},
+		{
+			name:      "Not found",
+			patientId: "2",
+			mockBehavior: func(s *mock.MockFHIR, user service.UserData) {
+				s.EXPECT().GetFHIRPatient(user, "2").Return(model.FHIRPatient{}, sql.ErrNoRows)
+			},
+			expectedStatus: 404,
+			expectedBody:   `{"resourceType":"OperationOutcome","issue":[{"severity":"error","code":"not-found","diagnostics":"sql: no rows in result set"}]}`,
+		},
+		{
+			name:      "Foreign patient",
+			patientId: "3",
+			mockBehavior: func(s *mock.MockFHIR, user service.UserData) {
+				s.EXPECT().GetFHIRPatient(user, "3").Return(model.FHIRPatient{}, service.ErrForbidden)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"resourceType":"OperationOutcome","issue":[{"severity":"error","code":"forbidden","diagnostics":"access to patient data is forbidden"}]}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			fhir := mock.NewMockFHIR(c)
+			testCase.mockBehavior(fhir, user)
+
+			services := &service.Service{FHIR: fhir}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/fhir/Patient/:id", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.GetFHIRPatient)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/fhir/Patient/"+testCase.patientId, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, "application/fhir+json; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestSearchFHIRPatients(t *testing.T) {
+	user := service.UserData{Id: 1, Role: "admin"}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	total := 2
+	fhir := mock.NewMockFHIR(c)
+	fhir.EXPECT().SearchFHIRPatients(user, model.FHIRPatientQuery{FHIRSearch: model.FHIRSearch{Count: 1, Total: "accurate"}, Gender: "female"}).Return(model.FHIRBundle{
+		ResourceType: "Bundle",
+		Type:         "searchset",
+		Total:        &total,
+		Entry:        []model.FHIRBundleEntry{{FullUrl: "Patient/1", Resource: model.FHIRPatient{ResourceType: "Patient", Id: "1"}}},
+		NextCursor:   "abc",
+	}, nil)
+
+	services := &service.Service{FHIR: fhir}
+	handler := NewHandler(services)
+
+	r := gin.New()
+	r.GET("/api/fhir/Patient", func(ctx *gin.Context) {
+		ctx.Set(userContext, user.Id)
+		ctx.Set(roleContext, user.Role)
+	}, handler.SearchFHIRPatients)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "http://example.org/api/fhir/Patient?gender=female&_count=1&_total=accurate", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"resourceType":"Bundle","type":"searchset","total":2,"link":[`+
+		`{"relation":"self","url":"https://example.org/api/fhir/Patient?gender=female&_count=1&_total=accurate"},`+
+		`{"relation":"next","url":"https://example.org/api/fhir/Patient?_count=1&_cursor=abc&_total=accurate&gender=female"}],`+
+		`"entry":[{"fullUrl":"https://example.org/api/fhir/Patient/1","resource":{"resourceType":"Patient","id":"1"}}]}`+"\n", w.Body.String())
+}
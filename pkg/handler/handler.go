@@ -11,7 +11,7 @@ type Handler struct {
 
 func NewHandler(s *services.Service) *Handler {
 	return &Handler{
-		AccountHandler: AccountHandlers{},
+		AccountHandler: AccountHandlers{services: s},
 		services:       s,
 	}
 }
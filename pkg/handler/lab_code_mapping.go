@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateLabCodeMapping godoc
+// @Summary Create lab code mapping
+// @Description Maps an observation code of lab analyzers (OBX-3) to a blood count. A mapping with an empty system matches the code in any coding system. The measure code is the unit of the analyzer values, the unit of the message (OBX-6) is used without it.
+// @Tags LabCodeMapping
+// @Accept json
+// @Produce json
+// @Param input body model.LabCodeMapping true "Lab code mapping data"
+// @Success 200 {object} model.LabCodeMapping "Created lab code mapping data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /lab-code-mapping [post]
+func (h *Handler) CreateLabCodeMapping(ctx *gin.Context) {
+	var labCodeMapping model.LabCodeMapping
+
+	if err := ctx.BindJSON(&labCodeMapping); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdLabCodeMapping, err := h.services.LabCodeMapping.CreateLabCodeMapping(labCodeMapping)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdLabCodeMapping)
+}
+
+// GetLabCodeMappingList godoc
+// @Summary Get lab code mapping list
+// @Description Retrieves a list of lab code mappings.
+// @Tags LabCodeMapping
+// @Produce json
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.LabCodeMapping] "Lab code mapping list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /lab-code-mapping [get]
+func (h *Handler) GetLabCodeMappingList(ctx *gin.Context) {
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	labCodeMappingList, err := h.services.LabCodeMapping.GetLabCodeMappingList(listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, labCodeMappingList)
+}
+
+// DeleteLabCodeMapping godoc
+// @Summary Delete lab code mapping
+// @Description Deletes the mapping of the observation code.
+// @Tags LabCodeMapping
+// @Produce json
+// @Param system query string false "Coding system, empty for the mapping of any system"
+// @Param code query string true "Observation code"
+// @Success 200 {object} model.LabCodeMapping "Deleted lab code mapping code"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /lab-code-mapping [delete]
+func (h *Handler) DeleteLabCodeMapping(ctx *gin.Context) {
+	system, code := ctx.Query("system"), ctx.Query("code")
+	if code == "" {
+		newErrorResponse(ctx, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	if err := h.services.LabCodeMapping.DeleteLabCodeMapping(system, code); err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.LabCodeMapping{System: system, Code: code})
+}
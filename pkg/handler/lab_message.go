@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"io"
+	"med/pkg/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// hl7ContentType is the media type of HL7 v2 messages in the ER7 encoding
+const hl7ContentType = "x-application/hl7-v2+er7"
+
+// IngestLabMessage godoc
+// @Summary Ingest lab message
+// @Description Stores the blood count results of an HL7 v2 ORU^R01 message of a lab analyzer and replies with the ACK message. Every order (OBR) becomes a course procedure of the patient (PID), found by SNILS or by the OncoBase patient ID, in the patient course of the placer order number or in the course under way on the observation date. The results (OBX) of the codes mapped to blood counts become the results of the procedure. The message is stored only if it has no errors, a message resent with the same control ID is acknowledged without storing it again. The ACK is AA for an accepted message with the warnings in ERR segments, AE for a message with errors and AR for a rejected message.
+// @Tags LabMessage
+// @Accept plain
+// @Produce plain
+// @Param input body string true "HL7 v2 ORU^R01 message"
+// @Success 200 {string} string "AA acknowledgment"
+// @Failure 400 {string} string "AR acknowledgment of a malformed or unsupported message"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 422 {string} string "AE acknowledgment with the errors of the message"
+// @Failure 500 {string} string "AR acknowledgment of a message that could not be processed"
+// @Router /lab-message [post]
+func (h *Handler) IngestLabMessage(ctx *gin.Context) {
+	message, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ack, err := h.services.LabMessage.IngestLabMessage(getUser(ctx), string(message))
+	if err != nil {
+		log.Error().Msg(err.Error())
+		ctx.Data(http.StatusInternalServerError, hl7ContentType, []byte(ack.Message))
+		return
+	}
+
+	switch ack.Code {
+	case model.HL7ErrorAck:
+		ctx.Data(http.StatusUnprocessableEntity, hl7ContentType, []byte(ack.Message))
+	case model.HL7RejectAck:
+		ctx.Data(http.StatusBadRequest, hl7ContentType, []byte(ack.Message))
+	default:
+		ctx.Data(http.StatusOK, hl7ContentType, []byte(ack.Message))
+	}
+}
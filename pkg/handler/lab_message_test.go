@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIngestLabMessage(t *testing.T) {
+	type mockBehavior func(s *mock.MockLabMessage, user service.UserData, message string)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+	message := "MSH|^~\\&|ANALYZER|LAB|||20240301||ORU^R01|1|P|2.5\rPID|1||42^^^OncoBase\r"
+
+	testTable := []struct {
+		name           string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Accepted",
+			mockBehavior: func(s *mock.MockLabMessage, user service.UserData, message string) {
+				s.EXPECT().IngestLabMessage(user, message).Return(model.LabMessageAck{Code: "AA", Message: "MSH|^~\\&\rMSA|AA|1\r"}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   "MSH|^~\\&\rMSA|AA|1\r",
+		},
+		{
+			name: "Errors",
+			mockBehavior: func(s *mock.MockLabMessage, user service.UserData, message string) {
+				s.EXPECT().IngestLabMessage(user, message).Return(model.LabMessageAck{Code: "AE", Message: "MSH|^~\\&\rMSA|AE|1\r"}, nil)
+			},
+			expectedStatus: 422,
+			expectedBody:   "MSH|^~\\&\rMSA|AE|1\r",
+		},
+		{
+			name: "Rejected",
+			mockBehavior: func(s *mock.MockLabMessage, user service.UserData, message string) {
+				s.EXPECT().IngestLabMessage(user, message).Return(model.LabMessageAck{Code: "AR", Message: "MSH|^~\\&\rMSA|AR|1\r"}, nil)
+			},
+			expectedStatus: 400,
+			expectedBody:   "MSH|^~\\&\rMSA|AR|1\r",
+		},
+		{
+			name: "Internal error",
+			mockBehavior: func(s *mock.MockLabMessage, user service.UserData, message string) {
+				s.EXPECT().IngestLabMessage(user, message).Return(model.LabMessageAck{Code: "AR", Message: "MSH|^~\\&\rMSA|AR|1\r"}, errors.New("db is down"))
+			},
+			expectedStatus: 500,
+			expectedBody:   "MSH|^~\\&\rMSA|AR|1\r",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			labMessage := mock.NewMockLabMessage(c)
+			testCase.mockBehavior(labMessage, user, message)
+
+			services := &service.Service{LabMessage: labMessage}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/lab-message", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.IngestLabMessage)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/lab-message", strings.NewReader(message))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, "x-application/hl7-v2+er7", w.Header().Get("Content-Type"))
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"med/pkg/model"
 	services "med/pkg/service"
 
 	"github.com/gin-gonic/gin"
@@ -15,36 +16,27 @@ const (
 	authorizationHeader = "Authorization"
 
 	userContext       = "id"
+	roleContext       = "role"
 	bloodCountContext = "blood_count_id"
 	diseaseContext    = "disease_id"
 	doctorContext     = "doctor_id"
 	patientContext    = "patient_id"
 	procedureContext  = "procedure_id"
 
-	adminRole   = "admin"
-	patientRole = "patient"
-	doctorRole  = "doctor"
+	adminRole   = model.AdminRole
+	patientRole = model.PatientRole
+	doctorRole  = model.DoctorRole
 )
 
-// type rolePermissions struct {
-// 	Role        string
-// 	Permissions []url.URL
-// }
-
-// var rolePermissionsMap map[string][]url.URL
-
-// func (h *Handler) checkPermissions(role string, url url.URL) bool {
-// 	permissions, ok := rolePermissionsMap[role]
-// 	if !ok {
-// 		return false
-// 	}
-// 	for _, permission := range permissions {
-// 		if permission == url {
-// 			return true
-// 		}
-// 	}
-// 	return false
-// }
+// methodActions maps HTTP methods to the permission actions they require
+var methodActions = map[string]string{
+	http.MethodGet:    model.ReadAction,
+	http.MethodHead:   model.ReadAction,
+	http.MethodPost:   model.CreateAction,
+	http.MethodPut:    model.UpdateAction,
+	http.MethodPatch:  model.UpdateAction,
+	http.MethodDelete: model.DeleteAction,
+}
 
 // getUserData retrieves user data from the authorization header
 func (h *Handler) getUserData(ctx *gin.Context) (*services.UserData, error) {
@@ -75,6 +67,14 @@ func (h *Handler) getUserData(ctx *gin.Context) (*services.UserData, error) {
 	return userData, nil
 }
 
+// getUser returns the authenticated user stored in context by UserIdentity
+func getUser(ctx *gin.Context) services.UserData {
+	return services.UserData{
+		Id:   ctx.GetInt(userContext),
+		Role: ctx.GetString(roleContext),
+	}
+}
+
 // UserIdentity middleware sets user ID in context
 func (h *Handler) UserIdentity(ctx *gin.Context) {
 	userData, err := h.getUserData(ctx)
@@ -83,6 +83,35 @@ func (h *Handler) UserIdentity(ctx *gin.Context) {
 	}
 
 	ctx.Set(userContext, userData.Id)
+	ctx.Set(roleContext, userData.Role)
+}
+
+// CheckPermissions returns middleware that checks the user's role against the permission matrix
+// for the given resource. The action is derived from the request method. Must run after UserIdentity.
+func (h *Handler) CheckPermissions(resource string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := ctx.GetString(roleContext)
+		if role == "" {
+			newErrorResponse(ctx, http.StatusUnauthorized, "user role not found")
+			return
+		}
+
+		action, ok := methodActions[ctx.Request.Method]
+		if !ok {
+			newErrorResponse(ctx, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		allowed, err := h.services.Permission.HasPermission(role, resource, action)
+		if err != nil {
+			newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !allowed {
+			newErrorResponse(ctx, http.StatusForbidden, "insufficient permissions")
+			return
+		}
+	}
 }
 
 // AdminIdentity middleware checks if the user is an admin
@@ -104,3 +104,135 @@ func TestUserIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPermissions(t *testing.T) {
+	type mockBehavior func(s *mock.MockPermission, role, action string)
+
+	testTable := []struct {
+		name           string
+		method         string
+		role           string
+		action         string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "OK",
+			method: "GET",
+			role:   "doctor",
+			action: "read",
+			mockBehavior: func(s *mock.MockPermission, role, action string) {
+				s.EXPECT().HasPermission(role, "drug", action).Return(true, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   "ok",
+		},
+		{
+			name:   "Forbidden",
+			method: "DELETE",
+			role:   "patient",
+			action: "delete",
+			mockBehavior: func(s *mock.MockPermission, role, action string) {
+				s.EXPECT().HasPermission(role, "drug", action).Return(false, nil)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"insufficient permissions"}`,
+		},
+		{
+			name:           "Empty Role",
+			method:         "GET",
+			role:           "",
+			mockBehavior:   func(s *mock.MockPermission, role, action string) {},
+			expectedStatus: 401,
+			expectedBody:   `{"message":"user role not found"}`,
+		},
+		{
+			name:   "Service Error",
+			method: "POST",
+			role:   "admin",
+			action: "create",
+			mockBehavior: func(s *mock.MockPermission, role, action string) {
+				s.EXPECT().HasPermission(role, "drug", action).Return(false, errors.New("Internal server error"))
+			},
+			expectedStatus: 500,
+			expectedBody:   `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			permission := mock.NewMockPermission(c)
+			testCase.mockBehavior(permission, testCase.role, testCase.action)
+
+			services := &service.Service{Permission: permission}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.Handle(testCase.method, "/protected", func(ctx *gin.Context) {
+				ctx.Set(roleContext, testCase.role)
+			}, handler.CheckPermissions("drug"), func(ctx *gin.Context) {
+				ctx.String(200, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/protected", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestCheckPermissionsActions(t *testing.T) {
+	// The doctor may read and update drugs only
+	granted := map[string]bool{"read": true, "update": true}
+
+	testTable := []struct {
+		method         string
+		action         string
+		expectedStatus int
+	}{
+		{method: "GET", action: "read", expectedStatus: 200},
+		{method: "HEAD", action: "read", expectedStatus: 200},
+		{method: "POST", action: "create", expectedStatus: 403},
+		{method: "PUT", action: "update", expectedStatus: 200},
+		{method: "PATCH", action: "update", expectedStatus: 200},
+		{method: "DELETE", action: "delete", expectedStatus: 403},
+		{method: "OPTIONS", expectedStatus: 405},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.method, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			permission := mock.NewMockPermission(c)
+			if testCase.action != "" {
+				permission.EXPECT().HasPermission("doctor", "drug", testCase.action).Return(granted[testCase.action], nil)
+			}
+
+			services := &service.Service{Permission: permission}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.Handle(testCase.method, "/protected", func(ctx *gin.Context) {
+				ctx.Set(roleContext, "doctor")
+			}, handler.CheckPermissions("drug"), func(ctx *gin.Context) {
+				ctx.Status(200)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/protected", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+		})
+	}
+}
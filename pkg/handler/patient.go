@@ -10,7 +10,7 @@ import (
 
 // CreatePatient godoc
 // @Summary Create patient
-// @Description Creates a new patient.
+// @Description Creates a new patient. A doctor gets the created patient on their panel.
 // @Tags Patient
 // @Accept json
 // @Produce json
@@ -27,7 +27,7 @@ func (h *Handler) CreatePatient(ctx *gin.Context) {
 		return
 	}
 
-	createdPatient, err := h.services.Patient.CreatePatient(patient)
+	createdPatient, err := h.services.Patient.CreatePatient(getUser(ctx), patient)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
@@ -41,19 +41,71 @@ func (h *Handler) CreatePatient(ctx *gin.Context) {
 // @Description Retrieves a list of patients.
 // @Tags Patient
 // @Produce json
-// @Success 200 {array} []model.Patient "Patient list"
+// @Param name query string false "Beginning of the first, middle or last name"
+// @Param snils query string false "SNILS"
+// @Param sex query string false "Sex"
+// @Param birth-date-from query string false "Earliest birth date (YYYY-MM-DD)"
+// @Param birth-date-to query string false "Latest birth date (YYYY-MM-DD)"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Patient] "Patient list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patients [get]
 func (h *Handler) GetPatientList(ctx *gin.Context) {
-	patientList, err := h.services.Patient.GetPatientList()
+	var filter model.PatientFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	patientList, err := h.services.Patient.GetPatientList(getUser(ctx), filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, patientList)
 }
 
+// SearchPatients godoc
+// @Summary Search patients
+// @Description Searches patients by full name with typo tolerance, the whole SNILS, at least the last 4 digits of the phone and birth date (YYYY-MM-DD or DD.MM.YYYY). Words of the query may be combined, like "Иванов 01.05.1980". Results are ranked, best matches first, a SNILS match above a phone match. Doctors only find the patients of their panel.
+// @Tags Patient
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results, 20 by default and at most 100"
+// @Success 200 {array} []model.PatientSearchResult "Found patients"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /patients/search [get]
+func (h *Handler) SearchPatients(ctx *gin.Context) {
+	var search model.PatientSearch
+
+	if err := ctx.BindQuery(&search); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resultList, err := h.services.Patient.SearchPatientList(getUser(ctx), search)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resultList)
+}
+
 // GetPatientById godoc
 // @Summary Get patient by ID
 // @Description Retrieves a patient by ID.
@@ -61,6 +113,8 @@ func (h *Handler) GetPatientList(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Patient ID"
 // @Success 200 {object} model.Patient "Patient data"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patients/{id} [get]
 func (h *Handler) GetPatientById(ctx *gin.Context) {
@@ -70,9 +124,9 @@ func (h *Handler) GetPatientById(ctx *gin.Context) {
 		return
 	}
 
-	patient, err := h.services.Patient.GetPatientById(id)
+	patient, err := h.services.Patient.GetPatientById(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -88,6 +142,8 @@ func (h *Handler) GetPatientById(ctx *gin.Context) {
 // @Param input body model.Patient true "Patient data"
 // @Success 200 {object} model.Patient "Updated patient data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patients [put]
 func (h *Handler) UpdatePatient(ctx *gin.Context) {
@@ -98,9 +154,9 @@ func (h *Handler) UpdatePatient(ctx *gin.Context) {
 		return
 	}
 
-	updatedPatient, err := h.services.Patient.UpdatePatient(patient)
+	updatedPatient, err := h.services.Patient.UpdatePatient(getUser(ctx), patient)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -114,6 +170,8 @@ func (h *Handler) UpdatePatient(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Patient ID"
 // @Success 200 {string} string "Patient ID deleted"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patients/{id} [delete]
 func (h *Handler) DeletePatient(ctx *gin.Context) {
@@ -123,9 +181,9 @@ func (h *Handler) DeletePatient(ctx *gin.Context) {
 		return
 	}
 
-	err = h.services.Patient.DeletePatient(id)
+	err = h.services.Patient.DeletePatient(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -17,6 +17,8 @@ import (
 // @Param input body model.PatientCourse true "Patient course data"
 // @Success 200 {object} model.PatientCourse "Created patient course data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-courses [post]
 func (h *Handler) CreatePatientCourse(ctx *gin.Context) {
@@ -27,9 +29,9 @@ func (h *Handler) CreatePatientCourse(ctx *gin.Context) {
 		return
 	}
 
-	createdPatientCourse, err := h.services.PatientCourse.CreatePatientCourse(patientCourse)
+	createdPatientCourse, err := h.services.PatientCourse.CreatePatientCourse(getUser(ctx), patientCourse)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -41,13 +43,37 @@ func (h *Handler) CreatePatientCourse(ctx *gin.Context) {
 // @Description Retrieves a list of patient courses.
 // @Tags PatientCourse
 // @Produce json
-// @Success 200 {array} []model.PatientCourse "Patient course list"
+// @Param patient query int false "Patient ID"
+// @Param disease query string false "Disease ID"
+// @Param course query string false "Course ID"
+// @Param doctor query int false "Doctor ID"
+// @Param begin-date-from query string false "Earliest begin date (YYYY-MM-DD)"
+// @Param begin-date-to query string false "Latest begin date (YYYY-MM-DD)"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.PatientCourse] "Patient course list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-courses [get]
 func (h *Handler) GetPatientCourseList(ctx *gin.Context) {
-	patientCourseList, err := h.services.PatientCourse.GetPatientCourseList()
+	var filter model.PatientCourseFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	patientCourseList, err := h.services.PatientCourse.GetPatientCourseList(getUser(ctx), filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -61,6 +87,8 @@ func (h *Handler) GetPatientCourseList(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Patient course ID"
 // @Success 200 {object} model.PatientCourse "Patient course data"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-courses/{id} [get]
 func (h *Handler) GetPatientCourseById(ctx *gin.Context) {
@@ -70,9 +98,9 @@ func (h *Handler) GetPatientCourseById(ctx *gin.Context) {
 		return
 	}
 
-	patientCourse, err := h.services.PatientCourse.GetPatientCourseById(id)
+	patientCourse, err := h.services.PatientCourse.GetPatientCourseById(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -88,6 +116,8 @@ func (h *Handler) GetPatientCourseById(ctx *gin.Context) {
 // @Param input body model.PatientCourse true "Patient course data"
 // @Success 200 {object} model.PatientCourse "Updated patient course data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-courses [put]
 func (h *Handler) UpdatePatientCourse(ctx *gin.Context) {
@@ -98,9 +128,9 @@ func (h *Handler) UpdatePatientCourse(ctx *gin.Context) {
 		return
 	}
 
-	updatedPatientCourse, err := h.services.PatientCourse.UpdatePatientCourse(patientCourse)
+	updatedPatientCourse, err := h.services.PatientCourse.UpdatePatientCourse(getUser(ctx), patientCourse)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -114,6 +144,8 @@ func (h *Handler) UpdatePatientCourse(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Patient course ID"
 // @Success 200 {string} string "Patient course ID deleted"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-courses/{id} [delete]
 func (h *Handler) DeletePatientCourse(ctx *gin.Context) {
@@ -123,9 +155,9 @@ func (h *Handler) DeletePatientCourse(ctx *gin.Context) {
 		return
 	}
 
-	err = h.services.PatientCourse.DeletePatientCourse(id)
+	err = h.services.PatientCourse.DeletePatientCourse(getUser(ctx), id)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -9,8 +9,8 @@ import (
 )
 
 type PatientDiseaseResponse struct {
-	PatientId int `json:"patient_id"`
-	DiseaseId int `json:"disease_id"`
+	PatientId int    `json:"patient_id"`
+	DiseaseId string `json:"disease_id"`
 }
 
 // CreatePatientDisease godoc
@@ -22,6 +22,8 @@ type PatientDiseaseResponse struct {
 // @Param input body model.PatientDisease true "Patient disease data"
 // @Success 200 {object} model.PatientDisease "Created patient disease data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases [post]
 func (h *Handler) CreatePatientDisease(ctx *gin.Context) {
@@ -32,9 +34,9 @@ func (h *Handler) CreatePatientDisease(ctx *gin.Context) {
 		return
 	}
 
-	createdPatientDisease, err := h.services.PatientDisease.CreatePatientDisease(patientDisease)
+	createdPatientDisease, err := h.services.PatientDisease.CreatePatientDisease(getUser(ctx), patientDisease)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -46,13 +48,35 @@ func (h *Handler) CreatePatientDisease(ctx *gin.Context) {
 // @Description Retrieves a list of patient diseases.
 // @Tags PatientDisease
 // @Produce json
-// @Success 200 {array} []model.PatientDisease "Patient disease list"
+// @Param patient query int false "Patient ID"
+// @Param disease query string false "Disease ID"
+// @Param stage query string false "Stage"
+// @Param diagnosis query string false "Diagnosis ID"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.PatientDisease] "Patient disease list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases [get]
 func (h *Handler) GetPatientDiseaseList(ctx *gin.Context) {
-	patientDiseaseList, err := h.services.PatientDisease.GetPatientDiseaseList()
+	var filter model.PatientDiseaseFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	patientDiseaseList, err := h.services.PatientDisease.GetPatientDiseaseList(getUser(ctx), filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -61,52 +85,73 @@ func (h *Handler) GetPatientDiseaseList(ctx *gin.Context) {
 
 // GetPatientDiseaseListByPatient godoc
 // @Summary Get patient disease list by patient
-// @Description Retrieves a list of patient diseases by patient ID.
+// @Description Retrieves a page of the diseases of a patient.
 // @Tags PatientDisease
 // @Produce json
 // @Param patient_id path string true "Patient ID"
-// @Success 200 {array} []model.PatientDisease "Patient disease list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.PatientDisease] "Patient disease list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases/patient/{patient_id} [get]
 func (h *Handler) GetPatientDiseaseListByPatient(ctx *gin.Context) {
+	var listQuery model.ListQuery
+
 	patientId, err := strconv.Atoi(ctx.Param(patientContext))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	patientDisease, err := h.services.PatientDisease.GetPatientDiseaseListByPatient(patientId)
+	patientDiseaseList, err := h.services.PatientDisease.GetPatientDiseaseList(getUser(ctx), model.PatientDiseaseFilter{Patient: &patientId}, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, patientDisease)
+	ctx.JSON(http.StatusOK, patientDiseaseList)
 }
 
 // GetPatientDiseaseListByDisease godoc
 // @Summary Get patient disease list by disease
-// @Description Retrieves a list of patient diseases by disease ID.
+// @Description Retrieves a page of the patients with a disease among the patients the user has access to.
 // @Tags PatientDisease
 // @Produce json
 // @Param disease_id path string true "Disease ID"
-// @Success 200 {array} []model.PatientDisease "Patient disease list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.PatientDisease] "Patient disease list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases/disease/{disease_id} [get]
 func (h *Handler) GetPatientDiseaseListByDisease(ctx *gin.Context) {
-	diseaseId, err := strconv.Atoi(ctx.Param(diseaseContext))
-	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	patientDisease, err := h.services.PatientDisease.GetPatientDiseaseListByDisease(diseaseId)
+	filter := model.PatientDiseaseFilter{Disease: ctx.Param(diseaseContext)}
+	patientDiseaseList, err := h.services.PatientDisease.GetPatientDiseaseList(getUser(ctx), filter, listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, patientDisease)
+	ctx.JSON(http.StatusOK, patientDiseaseList)
 }
 
 // GetPatientDiseaseById godoc
@@ -117,6 +162,8 @@ func (h *Handler) GetPatientDiseaseListByDisease(ctx *gin.Context) {
 // @Param patient_id path string true "Patient ID"
 // @Param disease_id path string true "Disease ID"
 // @Success 200 {object} model.PatientDisease "Patient disease data"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases/{patient_id}/{disease_id} [get]
 func (h *Handler) GetPatientDiseaseById(ctx *gin.Context) {
@@ -125,15 +172,11 @@ func (h *Handler) GetPatientDiseaseById(ctx *gin.Context) {
 		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
-	diseaseId, err := strconv.Atoi(ctx.Param(diseaseContext))
-	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
-		return
-	}
+	diseaseId := ctx.Param(diseaseContext)
 
-	patientDisease, err := h.services.PatientDisease.GetPatientDiseaseById(patientId, diseaseId)
+	patientDisease, err := h.services.PatientDisease.GetPatientDiseaseById(getUser(ctx), patientId, diseaseId)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -149,6 +192,8 @@ func (h *Handler) GetPatientDiseaseById(ctx *gin.Context) {
 // @Param input body model.PatientDisease true "Patient disease data"
 // @Success 200 {object} model.PatientDisease "Updated patient disease data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases [put]
 func (h *Handler) UpdatePatientDisease(ctx *gin.Context) {
@@ -159,9 +204,9 @@ func (h *Handler) UpdatePatientDisease(ctx *gin.Context) {
 		return
 	}
 
-	updatedPatientDisease, err := h.services.PatientDisease.UpdatePatientDisease(patientDisease)
+	updatedPatientDisease, err := h.services.PatientDisease.UpdatePatientDisease(getUser(ctx), patientDisease)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -176,6 +221,8 @@ func (h *Handler) UpdatePatientDisease(ctx *gin.Context) {
 // @Param patient_id path string true "Patient ID"
 // @Param disease_id path string true "Disease ID"
 // @Success 200 {string} PatientDiseaseResponse "Patient disease ID deleted"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /patient-diseases/{patient_id}/{disease_id} [delete]
 func (h *Handler) DeletePatientDisease(ctx *gin.Context) {
@@ -184,15 +231,11 @@ func (h *Handler) DeletePatientDisease(ctx *gin.Context) {
 		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
 		return
 	}
-	diseaseId, err := strconv.Atoi(ctx.Param(diseaseContext))
-	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
-		return
-	}
+	diseaseId := ctx.Param(diseaseContext)
 
-	err = h.services.PatientDisease.DeletePatientDisease(patientId, diseaseId)
+	err = h.services.PatientDisease.DeletePatientDisease(getUser(ctx), patientId, diseaseId)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
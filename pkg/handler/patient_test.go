@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPatientById(t *testing.T) {
+	type mockBehavior func(s *mock.MockPatient, user service.UserData, id int)
+
+	testTable := []struct {
+		name           string
+		user           service.UserData
+		patientId      int
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			user:      service.UserData{Id: 7, Role: "doctor"},
+			patientId: 1,
+			mockBehavior: func(s *mock.MockPatient, user service.UserData, id int) {
+				s.EXPECT().GetPatientById(user, id).Return(model.Patient{Id: 1, LastName: "Ivanov"}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"id":1,"first-name":"","middle-name":"","last-name":"Ivanov","birth-date":null,"sex":"","snils":null,"user-id":{"Int64":0,"Valid":false},"phone":null}`,
+		},
+		{
+			name:      "Foreign patient",
+			user:      service.UserData{Id: 7, Role: "doctor"},
+			patientId: 2,
+			mockBehavior: func(s *mock.MockPatient, user service.UserData, id int) {
+				s.EXPECT().GetPatientById(user, id).Return(model.Patient{}, service.ErrForbidden)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"access to patient data is forbidden"}`,
+		},
+		{
+			name:      "Not found",
+			user:      service.UserData{Id: 1, Role: "admin"},
+			patientId: 3,
+			mockBehavior: func(s *mock.MockPatient, user service.UserData, id int) {
+				s.EXPECT().GetPatientById(user, id).Return(model.Patient{}, sql.ErrNoRows)
+			},
+			expectedStatus: 404,
+			expectedBody:   `{"message":"sql: no rows in result set"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			patient := mock.NewMockPatient(c)
+			testCase.mockBehavior(patient, testCase.user, testCase.patientId)
+
+			services := &service.Service{Patient: patient}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/patient/:id", func(ctx *gin.Context) {
+				ctx.Set(userContext, testCase.user.Id)
+				ctx.Set(roleContext, testCase.user.Role)
+			}, handler.GetPatientById)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/patient/"+fmt.Sprint(testCase.patientId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestGetPatientList(t *testing.T) {
+	type mockBehavior func(s *mock.MockPatient, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+
+	testTable := []struct {
+		name           string
+		query          string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "OK",
+			query: "?sex=F&birth-date-from=1960-01-01&limit=1&sort=-birth-date",
+			mockBehavior: func(s *mock.MockPatient, user service.UserData) {
+				filter := model.PatientFilter{Sex: "F", BirthDateFrom: "1960-01-01"}
+				listQuery := model.ListQuery{Limit: 1, Sort: "-birth-date", Total: true}
+				total := 2
+				s.EXPECT().GetPatientList(user, filter, listQuery).Return(model.Page[model.Patient]{
+					Items:      []model.Patient{{Id: 1, LastName: "Ivanova"}},
+					Total:      &total,
+					NextCursor: "MQ",
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"items":[{"id":1,"first-name":"","middle-name":"","last-name":"Ivanova","birth-date":null,"sex":"","snils":null,"user-id":{"Int64":0,"Valid":false},"phone":null}],"total":2,"next-cursor":"MQ"}`,
+		},
+		{
+			name:  "Without total",
+			query: "?total=false",
+			mockBehavior: func(s *mock.MockPatient, user service.UserData) {
+				s.EXPECT().GetPatientList(user, model.PatientFilter{}, model.ListQuery{}).Return(model.Page[model.Patient]{
+					Items: []model.Patient{},
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"items":[]}`,
+		},
+		{
+			name:           "Invalid date",
+			query:          "?birth-date-from=01.01.1960",
+			mockBehavior:   func(s *mock.MockPatient, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'PatientFilter.BirthDateFrom' Error:Field validation for 'BirthDateFrom' failed on the 'datetime' tag"}`,
+		},
+		{
+			name:  "Unknown sort field",
+			query: "?sort=weight",
+			mockBehavior: func(s *mock.MockPatient, user service.UserData) {
+				s.EXPECT().GetPatientList(user, model.PatientFilter{}, model.ListQuery{Sort: "weight", Total: true}).
+					Return(model.Page[model.Patient]{}, fmt.Errorf("%w: unknown sort field %q", service.ErrInvalidListQuery, "weight"))
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid list query: unknown sort field \"weight\""}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			patient := mock.NewMockPatient(c)
+			testCase.mockBehavior(patient, user)
+
+			services := &service.Service{Patient: patient}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/patients", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.GetPatientList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/patients"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestSearchPatients(t *testing.T) {
+	type mockBehavior func(s *mock.MockPatient, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+
+	testTable := []struct {
+		name           string
+		query          string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "OK",
+			query: "?q=%D0%98%D0%B2%D0%BE%D0%BD%D0%BE%D0%B2&limit=5",
+			mockBehavior: func(s *mock.MockPatient, user service.UserData) {
+				s.EXPECT().SearchPatientList(user, model.PatientSearch{Query: "Ивонов", Limit: 5}).Return([]model.PatientSearchResult{
+					{Patient: model.Patient{Id: 1, LastName: "Иванов"}, Rank: 0.5},
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `[{"id":1,"first-name":"","middle-name":"","last-name":"Иванов","birth-date":null,"sex":"","snils":null,"user-id":{"Int64":0,"Valid":false},"phone":null,"rank":0.5}]`,
+		},
+		{
+			name:           "Missing query",
+			query:          "",
+			mockBehavior:   func(s *mock.MockPatient, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'PatientSearch.Query' Error:Field validation for 'Query' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			patient := mock.NewMockPatient(c)
+			testCase.mockBehavior(patient, user)
+
+			services := &service.Service{Patient: patient}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/patient/search", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.SearchPatients)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/patient/search"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
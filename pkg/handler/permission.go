@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePermission godoc
+// @Summary Grant permission
+// @Description Allows a role to perform an action on a resource.
+// @Tags Permission
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body model.Permission true "Permission data"
+// @Success 200 {object} model.Permission "Created permission data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} ErrorResponse "Role already has the permission"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/permission [post]
+func (h *Handler) CreatePermission(ctx *gin.Context) {
+	var permission model.Permission
+
+	if err := ctx.BindJSON(&permission); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdPermission, err := h.services.Permission.CreatePermission(permission)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdPermission)
+}
+
+// GetPermissionList godoc
+// @Summary Get permission list
+// @Description Retrieves the role permission matrix.
+// @Tags Permission
+// @Produce json
+// @Security ApiKeyAuth
+// @Param role query string false "Role"
+// @Param resource query string false "Resource"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.Permission] "Permission list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/permission [get]
+func (h *Handler) GetPermissionList(ctx *gin.Context) {
+	var filter model.PermissionFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	permissionList, err := h.services.Permission.GetPermissionList(filter, listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permissionList)
+}
+
+// DeletePermission godoc
+// @Summary Revoke permission
+// @Description Revokes a role's permission to perform an action on a resource.
+// @Tags Permission
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body model.Permission true "Permission data"
+// @Success 200 {object} model.Permission "Revoked permission data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/permission [delete]
+func (h *Handler) DeletePermission(ctx *gin.Context) {
+	var permission model.Permission
+
+	if err := ctx.BindJSON(&permission); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.services.Permission.DeletePermission(permission)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, permission)
+}
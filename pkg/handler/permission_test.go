@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePermission(t *testing.T) {
+	type mockBehavior func(s *mock.MockPermission, permission model.Permission)
+
+	permission := model.Permission{Role: "doctor", Resource: "drug", Action: "read"}
+
+	testTable := []struct {
+		name           string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock.MockPermission, permission model.Permission) {
+				s.EXPECT().CreatePermission(permission).Return(permission, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"role":"doctor","resource":"drug","action":"read"}`,
+		},
+		{
+			name: "Duplicate",
+			mockBehavior: func(s *mock.MockPermission, permission model.Permission) {
+				s.EXPECT().CreatePermission(permission).
+					Return(model.Permission{}, fmt.Errorf("%w: doctor may already read drug", service.ErrPermissionExists))
+			},
+			expectedStatus: 409,
+			expectedBody:   `{"message":"permission already exists: doctor may already read drug"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			permissionService := mock.NewMockPermission(c)
+			testCase.mockBehavior(permissionService, permission)
+
+			services := &service.Service{Permission: permissionService}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/account/permission", handler.CreatePermission)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/account/permission", bytes.NewBufferString(`{"role":"doctor","resource":"drug","action":"read"}`))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"encoding/json"
 	"med/pkg/model"
+	"med/pkg/utils"
 	"net/http"
 	"strconv"
 
@@ -15,13 +17,15 @@ type ProcedureBloodCountResponse struct {
 
 // CreateProcedureBloodCount godoc
 // @Summary Create procedure blood count
-// @Description Creates a new procedure blood count entry.
+// @Description Creates a new procedure blood count entry. The value is converted to the unit of the blood count and stored in it. A value outside the possible range of the blood count is rejected, otherwise the result is flagged as low, normal or high against the normal range.
 // @Tags ProcedureBloodCount
 // @Accept json
 // @Produce json
 // @Param input body model.ProcedureBloodCount true "Procedure blood count data"
 // @Success 200 {object} model.ProcedureBloodCount "Created procedure blood count data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count [post]
 func (h *Handler) CreateProcedureBloodCount(ctx *gin.Context) {
@@ -32,27 +36,114 @@ func (h *Handler) CreateProcedureBloodCount(ctx *gin.Context) {
 		return
 	}
 
-	createdProcedureBloodCount, err := h.services.ProcedureBloodCount.CreateProcedureBloodCount(procedureBloodCount)
+	createdProcedureBloodCount, err := h.services.ProcedureBloodCount.CreateProcedureBloodCount(getUser(ctx), procedureBloodCount)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
 	ctx.JSON(http.StatusOK, createdProcedureBloodCount)
 }
 
+// ImportProcedureBloodCounts godoc
+// @Summary Import procedure blood counts
+// @Description Imports blood count results from a CSV or XLSX file, the first row of which is the header. The mapping binds the file columns to blood counts and units, without columns every column other than the procedure one is a blood count ID with values in the unit of the blood count. Every row is validated against the possible ranges of the blood counts and the existing procedures and results. The results are created in a single transaction and only if no row has errors, the report lists the errors by row.
+// @Tags ProcedureBloodCount
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param mapping formData string false "Column mapping as JSON"
+// @Param dry-run query bool false "Validate the file without saving the results"
+// @Success 200 {object} model.ImportReport "Import report"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 422 {object} model.ImportReport "Import report with the row errors"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /procedure-blood-count/import [post]
+func (h *Handler) ImportProcedureBloodCounts(ctx *gin.Context) {
+	var importQuery model.ImportQuery
+	var mapping model.ImportMapping
+
+	if err := ctx.BindQuery(&importQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if value := ctx.PostForm("mapping"); value != "" {
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	rows, err := utils.ReadTable(fileHeader.Filename, file)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.services.ProcedureBloodCount.ImportProcedureBloodCounts(getUser(ctx), rows, mapping, importQuery.DryRun)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
 // GetProcedureBloodCountList godoc
 // @Summary Get procedure blood count list
 // @Description Retrieves a list of procedure blood count entries.
 // @Tags ProcedureBloodCount
 // @Produce json
-// @Success 200 {array} []model.ProcedureBloodCount "Procedure blood count list"
+// @Param patient query int false "Patient ID"
+// @Param procedure query int false "Course procedure ID"
+// @Param blood-count query string false "Blood count ID"
+// @Param flag query string false "Flag of the result against the normal range" Enums(low, normal, high)
+// @Param abnormal query bool false "Low and high results only or normal results only"
+// @Param date-from query string false "Earliest procedure date (YYYY-MM-DD)"
+// @Param date-to query string false "Latest procedure date (YYYY-MM-DD)"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Param unit query string false "Unit measure ID to convert the values to"
+// @Success 200 {object} model.Page[model.ProcedureBloodCount] "Procedure blood count list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count [get]
 func (h *Handler) GetProcedureBloodCountList(ctx *gin.Context) {
-	procedureBloodCountList, err := h.services.ProcedureBloodCount.GetProcedureBloodCountList()
+	var filter model.ProcedureBloodCountFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	procedureBloodCountList, err := h.services.ProcedureBloodCount.GetProcedureBloodCountList(getUser(ctx), filter, listQuery, ctx.Query("unit"))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -61,48 +152,76 @@ func (h *Handler) GetProcedureBloodCountList(ctx *gin.Context) {
 
 // GetProcedureBloodCountListByProcedure godoc
 // @Summary Get procedure blood count list by procedure
-// @Description Retrieves a list of procedure blood count entries by procedure ID.
+// @Description Retrieves a page of the blood count results of a procedure.
 // @Tags ProcedureBloodCount
 // @Produce json
 // @Param procedure_id path string true "Procedure ID"
-// @Success 200 {array} []model.ProcedureBloodCount "Procedure blood count list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Param unit query string false "Unit measure ID to convert the values to"
+// @Success 200 {object} model.Page[model.ProcedureBloodCount] "Procedure blood count list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count/procedures/{procedure_id} [get]
 func (h *Handler) GetProcedureBloodCountListByProcedure(ctx *gin.Context) {
+	var listQuery model.ListQuery
+
 	procedureId, err := strconv.Atoi(ctx.Param(procedureContext))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	procedureBloodCount, err := h.services.ProcedureBloodCount.GetProcedureBloodCountListByProcedure(procedureId)
+	filter := model.ProcedureBloodCountFilter{Procedure: &procedureId}
+	procedureBloodCountList, err := h.services.ProcedureBloodCount.GetProcedureBloodCountList(getUser(ctx), filter, listQuery, ctx.Query("unit"))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, procedureBloodCount)
+	ctx.JSON(http.StatusOK, procedureBloodCountList)
 }
 
 // GetProcedureBloodCountListByBloodCount godoc
 // @Summary Get procedure blood count list by blood count
-// @Description Retrieves a list of procedure blood count entries by blood count ID.
+// @Description Retrieves a page of the results of a blood count.
 // @Tags ProcedureBloodCount
 // @Produce json
 // @Param blood_count_id path string true "Blood count ID"
-// @Success 200 {array} []model.ProcedureBloodCount "Procedure blood count list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Param unit query string false "Unit measure ID to convert the values to"
+// @Success 200 {object} model.Page[model.ProcedureBloodCount] "Procedure blood count list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count/blood-counts/{blood_count_id} [get]
 func (h *Handler) GetProcedureBloodCountListByBloodCount(ctx *gin.Context) {
-	bloodCountId := ctx.Param(bloodCountContext)
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	procedureBloodCount, err := h.services.ProcedureBloodCount.GetProcedureBloodCountListByBloodCount(bloodCountId)
+	filter := model.ProcedureBloodCountFilter{BloodCount: ctx.Param(bloodCountContext)}
+	procedureBloodCountList, err := h.services.ProcedureBloodCount.GetProcedureBloodCountList(getUser(ctx), filter, listQuery, ctx.Query("unit"))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, procedureBloodCount)
+	ctx.JSON(http.StatusOK, procedureBloodCountList)
 }
 
 // GetProcedureBloodCountById godoc
@@ -112,7 +231,11 @@ func (h *Handler) GetProcedureBloodCountListByBloodCount(ctx *gin.Context) {
 // @Produce json
 // @Param procedure_id path string true "Procedure ID"
 // @Param blood_count_id path string true "Blood count ID"
+// @Param unit query string false "Unit measure ID to convert the values to"
 // @Success 200 {object} model.ProcedureBloodCount "Procedure blood count data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count/procedures/{procedure_id}/blood-counts/{blood_count_id} [get]
 func (h *Handler) GetProcedureBloodCountById(ctx *gin.Context) {
@@ -123,9 +246,9 @@ func (h *Handler) GetProcedureBloodCountById(ctx *gin.Context) {
 	}
 	bloodCountId := ctx.Param(bloodCountContext)
 
-	procedureBloodCount, err := h.services.ProcedureBloodCount.GetProcedureBloodCountById(procedureId, bloodCountId)
+	procedureBloodCount, err := h.services.ProcedureBloodCount.GetProcedureBloodCountById(getUser(ctx), procedureId, bloodCountId, ctx.Query("unit"))
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -134,13 +257,15 @@ func (h *Handler) GetProcedureBloodCountById(ctx *gin.Context) {
 
 // UpdateProcedureBloodCount godoc
 // @Summary Update procedure blood count
-// @Description Updates an existing procedure blood count entry.
+// @Description Updates an existing procedure blood count entry. The value is checked and flagged as on creation.
 // @Tags ProcedureBloodCount
 // @Accept json
 // @Produce json
 // @Param input body model.ProcedureBloodCount true "Procedure blood count data"
 // @Success 200 {object} model.ProcedureBloodCount "Updated procedure blood count data"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count [put]
 func (h *Handler) UpdateProcedureBloodCount(ctx *gin.Context) {
@@ -151,9 +276,9 @@ func (h *Handler) UpdateProcedureBloodCount(ctx *gin.Context) {
 		return
 	}
 
-	updatedProcedureBloodCount, err := h.services.ProcedureBloodCount.UpdateProcedureBloodCount(procedureBloodCount)
+	updatedProcedureBloodCount, err := h.services.ProcedureBloodCount.UpdateProcedureBloodCount(getUser(ctx), procedureBloodCount)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -168,6 +293,8 @@ func (h *Handler) UpdateProcedureBloodCount(ctx *gin.Context) {
 // @Param procedure_id path string true "Procedure ID"
 // @Param blood_count_id path string true "Blood count ID"
 // @Success 200 {string} ProcedureBloodCountResponse "Procedure blood count entry deleted"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /procedure-blood-count/procedures/{procedure_id}/blood-counts/{blood_count_id} [delete]
 func (h *Handler) DeleteProcedureBloodCount(ctx *gin.Context) {
@@ -178,9 +305,9 @@ func (h *Handler) DeleteProcedureBloodCount(ctx *gin.Context) {
 	}
 	bloodCountId := ctx.Param(bloodCountContext)
 
-	err = h.services.ProcedureBloodCount.DeleteProcedureBloodCount(procedureId, bloodCountId)
+	err = h.services.ProcedureBloodCount.DeleteProcedureBloodCount(getUser(ctx), procedureId, bloodCountId)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateProcedureBloodCount(t *testing.T) {
+	type mockBehavior func(s *mock.MockProcedureBloodCount, user service.UserData, procedureBloodCount model.ProcedureBloodCount)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+	low, impossible := 1.5, 900.0
+
+	testTable := []struct {
+		name                string
+		inputBody           string
+		procedureBloodCount model.ProcedureBloodCount
+		mockBehavior        mockBehavior
+		expectedStatus      int
+		expectedBody        string
+	}{
+		{
+			name:                "OK",
+			inputBody:           `{"procedure":5,"blood-count":"HGB","value":1.5,"measure-code":"g/dl"}`,
+			procedureBloodCount: model.ProcedureBloodCount{Procedure: 5, BloodCount: "HGB", Value: &low, MeasureCode: "g/dl"},
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData, procedureBloodCount model.ProcedureBloodCount) {
+				created := procedureBloodCount
+				created.Flag = model.LowFlag
+				s.EXPECT().CreateProcedureBloodCount(user, procedureBloodCount).Return(created, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"value":1.5,"measure-code":"g/dl","procedure":5,"blood-count":"HGB","flag":"low"}`,
+		},
+		{
+			name:                "Impossible value",
+			inputBody:           `{"procedure":5,"blood-count":"HGB","value":900,"measure-code":"g/dl"}`,
+			procedureBloodCount: model.ProcedureBloodCount{Procedure: 5, BloodCount: "HGB", Value: &impossible, MeasureCode: "g/dl"},
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData, procedureBloodCount model.ProcedureBloodCount) {
+				s.EXPECT().CreateProcedureBloodCount(user, procedureBloodCount).
+					Return(model.ProcedureBloodCount{}, fmt.Errorf("%w: HGB value 900 is not in 0..25", service.ErrValueOutOfRange))
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"value is outside the possible range: HGB value 900 is not in 0..25"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			procedureBloodCount := mock.NewMockProcedureBloodCount(c)
+			testCase.mockBehavior(procedureBloodCount, user, testCase.procedureBloodCount)
+
+			services := &service.Service{ProcedureBloodCount: procedureBloodCount}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/procedure-blood-count", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.CreateProcedureBloodCount)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/procedure-blood-count", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestImportProcedureBloodCounts(t *testing.T) {
+	type mockBehavior func(s *mock.MockProcedureBloodCount, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+	rows := [][]string{{"Procedure", "Hb"}, {"5", "12,5"}}
+	mapping := model.ImportMapping{Procedure: "Procedure", Columns: []model.ImportColumn{{Header: "Hb", BloodCount: "HGB", MeasureCode: "g/dl"}}}
+
+	testTable := []struct {
+		name           string
+		fileName       string
+		mapping        string
+		query          string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "OK",
+			fileName: "results.csv",
+			mapping:  `{"procedure":"Procedure","columns":[{"header":"Hb","blood-count":"HGB","measure-code":"g/dl"}]}`,
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData) {
+				s.EXPECT().ImportProcedureBloodCounts(user, rows, mapping, false).
+					Return(model.ImportReport{Committed: true, Rows: 1, Results: 1, Errors: []model.ImportError{}}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"dry-run":false,"committed":true,"rows":1,"results":1,"errors":[]}`,
+		},
+		{
+			name:     "Row errors",
+			fileName: "results.csv",
+			mapping:  `{"procedure":"Procedure","columns":[{"header":"Hb","blood-count":"HGB","measure-code":"g/dl"}]}`,
+			query:    "?dry-run=true",
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData) {
+				s.EXPECT().ImportProcedureBloodCounts(user, rows, mapping, true).
+					Return(model.ImportReport{DryRun: true, Rows: 1, Errors: []model.ImportError{{Row: 2, Message: "course procedure 5 does not exist"}}}, nil)
+			},
+			expectedStatus: 422,
+			expectedBody:   `{"dry-run":true,"committed":false,"rows":1,"results":0,"errors":[{"row":2,"message":"course procedure 5 does not exist"}]}`,
+		},
+		{
+			name:           "Unsupported file",
+			fileName:       "results.txt",
+			mockBehavior:   func(s *mock.MockProcedureBloodCount, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"unsupported table format, expected .csv or .xlsx"}`,
+		},
+		{
+			name:           "Invalid mapping",
+			fileName:       "results.csv",
+			mapping:        `{"columns":`,
+			mockBehavior:   func(s *mock.MockProcedureBloodCount, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"unexpected end of JSON input"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			procedureBloodCount := mock.NewMockProcedureBloodCount(c)
+			testCase.mockBehavior(procedureBloodCount, user)
+
+			services := &service.Service{ProcedureBloodCount: procedureBloodCount}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/procedure-blood-count/import", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.ImportProcedureBloodCounts)
+
+			body := new(bytes.Buffer)
+			form := multipart.NewWriter(body)
+			if testCase.mapping != "" {
+				form.WriteField("mapping", testCase.mapping)
+			}
+			file, _ := form.CreateFormFile("file", testCase.fileName)
+			file.Write([]byte("Procedure,Hb\n5,\"12,5\"\n"))
+			form.Close()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/procedure-blood-count/import"+testCase.query, body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestGetProcedureBloodCountListByProcedure(t *testing.T) {
+	type mockBehavior func(s *mock.MockProcedureBloodCount, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+	procedureId := 5
+	value := 125.0
+
+	testTable := []struct {
+		name           string
+		path           string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "OK",
+			path: "/procedure-blood-count/procedure/5?limit=1&unit=g/l",
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData) {
+				total := 2
+				s.EXPECT().GetProcedureBloodCountList(user, model.ProcedureBloodCountFilter{Procedure: &procedureId}, model.ListQuery{Limit: 1, Total: true}, "g/l").
+					Return(model.Page[model.ProcedureBloodCount]{
+						Items:      []model.ProcedureBloodCount{{Procedure: 5, BloodCount: "HGB", Value: &value, MeasureCode: "g/l", Flag: model.NormalFlag}},
+						Total:      &total,
+						NextCursor: "WyJIR0IiLDVd",
+					}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"items":[{"value":125,"measure-code":"g/l","procedure":5,"blood-count":"HGB","flag":"normal"}],"total":2,"next-cursor":"WyJIR0IiLDVd"}`,
+		},
+		{
+			name: "Foreign procedure",
+			path: "/procedure-blood-count/procedure/5",
+			mockBehavior: func(s *mock.MockProcedureBloodCount, user service.UserData) {
+				s.EXPECT().GetProcedureBloodCountList(user, model.ProcedureBloodCountFilter{Procedure: &procedureId}, model.ListQuery{Total: true}, "").
+					Return(model.Page[model.ProcedureBloodCount]{}, service.ErrForbidden)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"access to patient data is forbidden"}`,
+		},
+		{
+			name:           "Invalid procedure ID",
+			path:           "/procedure-blood-count/procedure/x",
+			mockBehavior:   func(s *mock.MockProcedureBloodCount, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"strconv.Atoi: parsing \"x\": invalid syntax"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			procedureBloodCount := mock.NewMockProcedureBloodCount(c)
+			testCase.mockBehavior(procedureBloodCount, user)
+
+			services := &service.Service{ProcedureBloodCount: procedureBloodCount}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/procedure-blood-count/procedure/:procedure_id", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.GetProcedureBloodCountListByProcedure)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IssueRegistrationCode godoc
+// @Summary Issue patient registration code
+// @Description Creates a one-time code the patient uses to register an account. A new code replaces the previous one.
+// @Tags Patient
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {object} model.RegistrationCode "Registration code"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/patient/{id}/registration-code [post]
+func (h *Handler) IssueRegistrationCode(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	code, err := h.services.Registration.IssueRegistrationCode(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, code)
+}
+
+// InviteUser godoc
+// @Summary Invite staff user
+// @Description Emails an invitation to create a staff account. Allowed roles are admin, doctor and researcher.
+// @Tags Invitation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body model.Invitation true "Invitation data"
+// @Success 200 {object} model.Invitation "Sent invitation"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/invitation [post]
+func (h *Handler) InviteUser(ctx *gin.Context) {
+	var invitation model.Invitation
+
+	if err := ctx.BindJSON(&invitation); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sentInvitation, err := h.services.Registration.InviteUser(getUser(ctx), invitation)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sentInvitation)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept invitation
+// @Description Creates the invited staff account. A doctor record is created for invited doctors.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param input body model.InvitationAcceptance true "Invitation token and account data"
+// @Success 200 {object} string "User email"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/invitation/accept [post]
+func (h *Handler) AcceptInvitation(ctx *gin.Context) {
+	var acceptance model.InvitationAcceptance
+
+	if err := ctx.BindJSON(&acceptance); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	email, err := h.services.Registration.AcceptInvitation(acceptance)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"email": email,
+	})
+}
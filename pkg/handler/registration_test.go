@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAcceptInvitation(t *testing.T) {
+	type mockBehavior func(s *mock.MockRegistration, acceptance model.InvitationAcceptance)
+
+	testTable := []struct {
+		name            string
+		inputBody       string
+		inputAcceptance model.InvitationAcceptance
+		mockBehavior    mockBehavior
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"token": "invitation", "password": "pass", "first-name": "Ivan", "last-name": "Ivanov", "qualification": "oncologist"}`,
+			inputAcceptance: model.InvitationAcceptance{
+				Token:         "invitation",
+				Password:      "pass",
+				FirstName:     "Ivan",
+				LastName:      "Ivanov",
+				Qualification: "oncologist",
+			},
+			mockBehavior: func(s *mock.MockRegistration, acceptance model.InvitationAcceptance) {
+				s.EXPECT().AcceptInvitation(acceptance).Return("doctor@mail.ru", nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"email":"doctor@mail.ru"}`,
+		},
+		{
+			name:      "Invalid invitation",
+			inputBody: `{"token": "invitation", "password": "pass", "first-name": "Ivan", "last-name": "Ivanov"}`,
+			inputAcceptance: model.InvitationAcceptance{
+				Token:     "invitation",
+				Password:  "pass",
+				FirstName: "Ivan",
+				LastName:  "Ivanov",
+			},
+			mockBehavior: func(s *mock.MockRegistration, acceptance model.InvitationAcceptance) {
+				s.EXPECT().AcceptInvitation(acceptance).Return("", service.ErrInvalidInvitation)
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid invitation"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			registration := mock.NewMockRegistration(c)
+			testCase.mockBehavior(registration, testCase.inputAcceptance)
+
+			services := &service.Service{Registration: registration}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/invitation/accept", handler.AcceptInvitation)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/invitation/accept", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	services "med/pkg/service"
+
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
@@ -15,3 +21,30 @@ func newErrorResponse(ctx *gin.Context, statusCode int, message string) {
 	log.Error().Msg(message)
 	ctx.AbortWithStatusJSON(statusCode, ErrorResponse{Message: message})
 }
+
+// errorStatus maps errors returned by services to HTTP status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrForbidden), errors.Is(err, services.ErrUserLocked), errors.Is(err, services.ErrOwnAccount),
+		errors.Is(err, services.ErrNotCohortOwner), errors.Is(err, services.ErrNotExportOwner):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidPermission), errors.Is(err, services.ErrInvalidResetToken),
+		errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrInvalidRegistrationCode),
+		errors.Is(err, services.ErrInvalidInvitation), errors.Is(err, services.ErrInvalidListQuery),
+		errors.Is(err, services.ErrValueOutOfRange), errors.Is(err, services.ErrInvalidScoreInput),
+		errors.Is(err, services.ErrNoUnitConversion), errors.Is(err, services.ErrInvalidCohortDefinition),
+		errors.Is(err, services.ErrInvalidImport), errors.Is(err, services.ErrInvalidFHIRResource),
+		errors.Is(err, services.ErrInvalidFHIRSearch):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrExportNotReady), errors.Is(err, services.ErrPermissionExists):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidRefreshToken), errors.Is(err, services.ErrInvalidCredentials):
+		return http.StatusUnauthorized
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNoPseudonymKey):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPatientTimeline godoc
+// @Summary Get patient timeline
+// @Description Retrieves the history of the patient as a chronological event stream: recorded diagnoses, started and ended courses, performed procedures and blood count results flagged against the normal range.
+// @Tags Patient
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Success 200 {array} []model.TimelineEvent "Patient timeline"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /patients/{id}/timeline [get]
+func (h *Handler) GetPatientTimeline(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	eventList, err := h.services.Timeline.GetPatientTimeline(getUser(ctx), id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, eventList)
+}
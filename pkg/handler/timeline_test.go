@@ -0,0 +1,85 @@
+package handler
+
+import (
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPatientTimeline(t *testing.T) {
+	type mockBehavior func(s *mock.MockTimeline, user service.UserData)
+
+	user := service.UserData{Id: 7, Role: "doctor"}
+	patientCourse, procedure, value := 3, 5, 2.5
+
+	testTable := []struct {
+		name           string
+		patientId      string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			patientId: "1",
+			mockBehavior: func(s *mock.MockTimeline, user service.UserData) {
+				s.EXPECT().GetPatientTimeline(user, 1).Return([]model.TimelineEvent{
+					{Date: "2024-01-10", Type: model.DiagnosisRecordedEvent, Disease: "C50", Stage: "II"},
+					{Date: "2024-02-01", Type: model.BloodCountResultEvent, PatientCourse: &patientCourse, Procedure: &procedure, BloodCount: "CA15-3", Value: &value, Flag: model.NormalFlag},
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody: `[{"date":"2024-01-10","type":"diagnosis-recorded","disease":"C50","stage":"II"},` +
+				`{"date":"2024-02-01","type":"blood-count-result","patient-course":3,"procedure":5,"blood-count":"CA15-3","value":2.5,"flag":"normal"}]`,
+		},
+		{
+			name:      "Foreign patient",
+			patientId: "2",
+			mockBehavior: func(s *mock.MockTimeline, user service.UserData) {
+				s.EXPECT().GetPatientTimeline(user, 2).Return(nil, service.ErrForbidden)
+			},
+			expectedStatus: 403,
+			expectedBody:   `{"message":"access to patient data is forbidden"}`,
+		},
+		{
+			name:           "Invalid ID",
+			patientId:      "abc",
+			mockBehavior:   func(s *mock.MockTimeline, user service.UserData) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			timeline := mock.NewMockTimeline(c)
+			testCase.mockBehavior(timeline, user)
+
+			services := &service.Service{Timeline: timeline}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/patient/:id/timeline", func(ctx *gin.Context) {
+				ctx.Set(userContext, user.Id)
+				ctx.Set(roleContext, user.Role)
+			}, handler.GetPatientTimeline)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/patient/"+testCase.patientId+"/timeline", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
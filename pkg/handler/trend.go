@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPatientTrend godoc
+// @Summary Get patient blood count trend
+// @Description Retrieves the results of a blood count of the patient ordered by procedure date, with the rate of change per day, the percent change from the baseline and the streaks of consecutive out-of-range results. With a patient course the results are compared before, during and after the course.
+// @Tags Patient
+// @Produce json
+// @Param id path string true "Patient ID"
+// @Param blood-count query string true "Blood count ID"
+// @Param patient-course query int false "Patient course ID"
+// @Success 200 {object} model.Trend "Blood count trend"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /patients/{id}/trend [get]
+func (h *Handler) GetPatientTrend(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var query model.TrendQuery
+	if err := ctx.BindQuery(&query); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	trend, err := h.services.Trend.GetPatientTrend(getUser(ctx), id, query)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trend)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateUnitConversion godoc
+// @Summary Create unit conversion
+// @Description Creates a conversion between units, a value is converted as value * factor + offset. The reverse conversion is derived.
+// @Tags UnitConversion
+// @Accept json
+// @Produce json
+// @Param input body model.UnitConversion true "Unit conversion data"
+// @Success 200 {object} model.UnitConversion "Created unit conversion data"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /unit-conversion [post]
+func (h *Handler) CreateUnitConversion(ctx *gin.Context) {
+	var unitConversion model.UnitConversion
+
+	if err := ctx.BindJSON(&unitConversion); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdUnitConversion, err := h.services.UnitConversion.CreateUnitConversion(unitConversion)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdUnitConversion)
+}
+
+// GetUnitConversionList godoc
+// @Summary Get unit conversion list
+// @Description Retrieves a list of unit conversions.
+// @Tags UnitConversion
+// @Produce json
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.UnitConversion] "Unit conversion list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /unit-conversion [get]
+func (h *Handler) GetUnitConversionList(ctx *gin.Context) {
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	unitConversionList, err := h.services.UnitConversion.GetUnitConversionList(listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, unitConversionList)
+}
+
+// ConvertValue godoc
+// @Summary Convert value
+// @Description Converts a value between units with the stored conversion or the reverse of it.
+// @Tags UnitConversion
+// @Produce json
+// @Param value query number true "Value"
+// @Param from query string true "Unit measure ID of the value"
+// @Param to query string true "Unit measure ID to convert the value to"
+// @Success 200 {object} model.ConvertedValue "Converted value"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /unit-conversion/convert [get]
+func (h *Handler) ConvertValue(ctx *gin.Context) {
+	var query model.ConversionQuery
+
+	if err := ctx.BindQuery(&query); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	value, err := h.services.UnitConversion.ConvertValue(*query.Value, query.From, query.To)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.ConvertedValue{Value: value, MeasureCode: query.To})
+}
+
+// DeleteUnitConversion godoc
+// @Summary Delete unit conversion
+// @Description Deletes the conversion between the units.
+// @Tags UnitConversion
+// @Produce json
+// @Param from query string true "Unit measure ID to convert from"
+// @Param to query string true "Unit measure ID to convert to"
+// @Success 200 {object} model.UnitConversion "Deleted unit conversion units"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /unit-conversion [delete]
+func (h *Handler) DeleteUnitConversion(ctx *gin.Context) {
+	from, to := ctx.Query("from"), ctx.Query("to")
+	if from == "" || to == "" {
+		newErrorResponse(ctx, http.StatusBadRequest, "from and to units are required")
+		return
+	}
+
+	if err := h.services.UnitConversion.DeleteUnitConversion(from, to); err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.UnitConversion{From: from, To: to})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"fmt"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConvertValue(t *testing.T) {
+	type mockBehavior func(s *mock.MockUnitConversion)
+
+	testTable := []struct {
+		name           string
+		query          string
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "OK",
+			query: "?value=12.5&from=g_dl&to=g_l",
+			mockBehavior: func(s *mock.MockUnitConversion) {
+				s.EXPECT().ConvertValue(12.5, "g_dl", "g_l").Return(125.0, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"value":125,"measure-code":"g_l"}`,
+		},
+		{
+			name:  "No conversion",
+			query: "?value=1&from=g_l&to=mmol_l",
+			mockBehavior: func(s *mock.MockUnitConversion) {
+				s.EXPECT().ConvertValue(1.0, "g_l", "mmol_l").Return(0.0, fmt.Errorf("%w g_l and mmol_l", service.ErrNoUnitConversion))
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"no conversion between units g_l and mmol_l"}`,
+		},
+		{
+			name:           "Missing value",
+			query:          "?from=g_l&to=g_dl",
+			mockBehavior:   func(s *mock.MockUnitConversion) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'ConversionQuery.Value' Error:Field validation for 'Value' failed on the 'required' tag"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			unitConversion := mock.NewMockUnitConversion(c)
+			testCase.mockBehavior(unitConversion)
+
+			services := &service.Service{UnitConversion: unitConversion}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/unit-conversion/convert", handler.ConvertValue)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/unit-conversion/convert"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -40,13 +40,25 @@ func (h *Handler) CreateUnitMeasure(ctx *gin.Context) {
 // @Description Retrieves a list of unit measure entries.
 // @Tags UnitMeasure
 // @Produce json
-// @Success 200 {object} []model.UnitMeasure "Unit measure list"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.UnitMeasure] "Unit measure list"
+// @Failure 400 {object} ErrorResponse "Bad request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /unit-measure [get]
 func (h *Handler) GetUnitMeasureList(ctx *gin.Context) {
-	unitMeasureList, err := h.services.UnitMeasure.GetUnitMeasureList()
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	unitMeasureList, err := h.services.UnitMeasure.GetUnitMeasureList(listQuery)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newErrorResponse(ctx, errorStatus(err), err.Error())
 		return
 	}
 
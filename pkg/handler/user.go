@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"med/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateStaffUser godoc
+// @Summary Create staff user
+// @Description Creates an internal account for clinic staff. Allowed roles are admin, doctor and researcher.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body model.StaffUser true "Staff user data"
+// @Success 200 {object} model.UserProfile "Created user profile"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/user [post]
+func (h *Handler) CreateStaffUser(ctx *gin.Context) {
+	var staffUser model.StaffUser
+
+	if err := ctx.BindJSON(&staffUser); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdUser, err := h.services.User.CreateStaffUser(staffUser)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdUser)
+}
+
+// GetUserById godoc
+// @Summary Get user by ID
+// @Description Retrieves the user profile with the linked patient and doctor records.
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} model.UserProfile "User profile"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/user/{id} [get]
+func (h *Handler) GetUserById(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param(userContext))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.services.User.GetUserById(id)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// GetUserList godoc
+// @Summary Get user list
+// @Description Retrieves the list of all users, both internal staff and external users.
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param search query string false "Search string"
+// @Param role query string false "Role"
+// @Param locked query bool false "Locked users only or unlocked users only"
+// @Param limit query int false "Page size, 50 by default and at most 500"
+// @Param cursor query string false "Cursor of the page, next-cursor of the previous page"
+// @Param total query bool false "Count the items matching the filter, true by default"
+// @Param sort query string false "Comma-separated sort fields, prefixed with - for descending order"
+// @Success 200 {object} model.Page[model.UserProfile] "User list"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/user [get]
+func (h *Handler) GetUserList(ctx *gin.Context) {
+	var filter model.UserFilter
+	var listQuery model.ListQuery
+
+	if err := ctx.BindQuery(&filter); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ctx.BindQuery(&listQuery); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userList, err := h.services.User.GetUserList(filter, listQuery)
+	if err != nil {
+		newErrorResponse(ctx, errorStatus(err), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userList)
+}
+
+// MigrateLegacyUsers godoc
+// @Summary Migrate legacy users
+// @Description Moves users from the legacy internal_user and external_user tables into the unified user table. External users keep their ids, which patient and doctor records reference. Users moved by an earlier run are skipped. If an id or an email is taken by another user, nothing is moved and the conflicting rows are returned.
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} model.LegacyUserMigration "Number of migrated users and skipped rows"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} model.LegacyUserMigration "Conflicting rows, nothing is migrated"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /account/user/migrate [post]
+func (h *Handler) MigrateLegacyUsers(ctx *gin.Context) {
+	migration, err := h.services.User.MigrateLegacyUsers()
+	if err != nil {
+		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if len(migration.Conflicts) > 0 {
+		ctx.JSON(http.StatusConflict, migration)
+		return
+	}
+	ctx.JSON(http.StatusOK, migration)
+}
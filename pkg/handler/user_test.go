@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	model "med/pkg/model"
+	service "med/pkg/service"
+	mock "med/pkg/service/mock"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateStaffUser(t *testing.T) {
+	type mockBehavior func(s *mock.MockUser, staffUser model.StaffUser)
+
+	testTable := []struct {
+		name           string
+		inputBody      string
+		inputUser      model.StaffUser
+		mockBehavior   mockBehavior
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"email": "doctor@mail.ru", "password": "pass", "role": "doctor", "first-name": "Ivan", "last-name": "Ivanov"}`,
+			inputUser: model.StaffUser{
+				Email:     "doctor@mail.ru",
+				Password:  "pass",
+				Role:      "doctor",
+				FirstName: "Ivan",
+				LastName:  "Ivanov",
+			},
+			mockBehavior: func(s *mock.MockUser, staffUser model.StaffUser) {
+				s.EXPECT().CreateStaffUser(staffUser).Return(model.UserProfile{
+					Id:        1,
+					Email:     "doctor@mail.ru",
+					Role:      "doctor",
+					UserType:  "internal",
+					FirstName: "Ivan",
+					LastName:  "Ivanov",
+				}, nil)
+			},
+			expectedStatus: 200,
+			expectedBody:   `{"id":1,"email":"doctor@mail.ru","role":"doctor","user-type":"internal","first-name":"Ivan","middle-name":"","last-name":"Ivanov","phone":"","locked":false,"created-at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:           "Empty fields",
+			inputBody:      `{"email": "doctor@mail.ru", "password": "pass", "role": "doctor", "first-name": "Ivan"}`,
+			mockBehavior:   func(s *mock.MockUser, staffUser model.StaffUser) {},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"Key: 'StaffUser.LastName' Error:Field validation for 'LastName' failed on the 'required' tag"}`,
+		},
+		{
+			name:      "Patient role",
+			inputBody: `{"email": "patient@mail.ru", "password": "pass", "role": "patient", "first-name": "Ivan", "last-name": "Ivanov"}`,
+			inputUser: model.StaffUser{
+				Email:     "patient@mail.ru",
+				Password:  "pass",
+				Role:      "patient",
+				FirstName: "Ivan",
+				LastName:  "Ivanov",
+			},
+			mockBehavior: func(s *mock.MockUser, staffUser model.StaffUser) {
+				s.EXPECT().CreateStaffUser(staffUser).Return(model.UserProfile{}, service.ErrInvalidRole)
+			},
+			expectedStatus: 400,
+			expectedBody:   `{"message":"invalid role"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock.NewMockUser(c)
+			testCase.mockBehavior(user, testCase.inputUser)
+
+			services := &service.Service{User: user}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/user", handler.CreateStaffUser)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/user", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestMigrateLegacyUsers(t *testing.T) {
+	testTable := []struct {
+		name           string
+		migration      model.LegacyUserMigration
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "OK",
+			migration:      model.LegacyUserMigration{External: 2, Internal: 1, Skipped: []model.LegacyUserRow{}, Conflicts: []model.LegacyUserRow{}},
+			expectedStatus: 200,
+			expectedBody:   `{"external":2,"internal":1,"skipped":[],"conflicts":[]}`,
+		},
+		{
+			name: "Conflicts",
+			migration: model.LegacyUserMigration{Skipped: []model.LegacyUserRow{}, Conflicts: []model.LegacyUserRow{
+				{Table: "external_user", Id: 1, Email: "ivanova@mail.ru", Reason: "id is taken by admin@clinic.ru"},
+			}},
+			expectedStatus: 409,
+			expectedBody:   `{"external":0,"internal":0,"skipped":[],"conflicts":[{"table":"external_user","id":1,"email":"ivanova@mail.ru","reason":"id is taken by admin@clinic.ru"}]}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock.NewMockUser(c)
+			user.EXPECT().MigrateLegacyUsers().Return(testCase.migration, nil)
+
+			services := &service.Service{User: user}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/user/migrate", handler.MigrateLegacyUsers)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/user/migrate", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatus, w.Code)
+			assert.Equal(t, testCase.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+// AccountBloodCount is a blood count measured during one of the patient's procedures.
+type AccountBloodCount struct {
+	Procedure      int     `json:"procedure" db:"procedure"`
+	Date           string  `json:"date" db:"date"`
+	Course         string  `json:"course" db:"course"`
+	BloodCount     string  `json:"blood-count" db:"blood_count"`
+	Value          float64 `json:"value" db:"value"`
+	MeasureCode    string  `json:"measure-code" db:"measure_code"`
+	MinNormalValue float64 `json:"min-normal-value" db:"min_normal_value"`
+	MaxNormalValue float64 `json:"max-normal-value" db:"max_normal_value"`
+}
+
+// PanelPatient is a patient of the doctor's panel with current diseases and active courses.
+type PanelPatient struct {
+	Patient  Patient          `json:"patient"`
+	Diseases []PatientDisease `json:"diseases"`
+	Courses  []PatientCourse  `json:"courses"`
+}
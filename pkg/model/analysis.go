@@ -0,0 +1,24 @@
+package model
+
+// BloodCountResult is a blood count value to score. A value without a unit is taken to be in the unit of the blood count.
+type BloodCountResult struct {
+	BloodCount  string  `json:"blood-count" binding:"required"`
+	Value       float64 `json:"value"`
+	MeasureCode string  `json:"measure-code,omitempty"`
+}
+
+// ScoreInput is a set of blood count results scored without storing them.
+type ScoreInput struct {
+	Results []BloodCountResult `json:"results" binding:"required,min=1,dive"`
+}
+
+// DiseaseScore is the risk score of a disease computed from blood count results with the disease coefficients.
+// Score is from -1 to 1, positive scores support the disease. Matched is the number of the blood counts
+// of the disease with a result and Total is the number of the blood counts with a coefficient for the disease.
+type DiseaseScore struct {
+	Rank    int     `json:"rank"`
+	Disease string  `json:"disease"`
+	Score   float64 `json:"score"`
+	Matched int     `json:"matched"`
+	Total   int     `json:"total"`
+}
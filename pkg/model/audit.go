@@ -0,0 +1,28 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records who changed which entity and when, with the entity values before and after the change.
+type AuditEntry struct {
+	Id        int64           `json:"id" db:"id"`
+	UserId    int             `json:"user-id" db:"user_id"`
+	Action    string          `json:"action" db:"action"`
+	Entity    string          `json:"entity" db:"entity"`
+	EntityId  string          `json:"entity-id" db:"entity_id"`
+	Before    json.RawMessage `json:"before" db:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" db:"after" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created-at" db:"created_at"`
+}
+
+// AuditFilter filters the audit log. From and To are dates in YYYY-MM-DD format, both inclusive.
+type AuditFilter struct {
+	Entity   string `form:"entity"`
+	EntityId string `form:"entity-id"`
+	UserId   *int   `form:"user-id"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Limit    int    `form:"-"`
+}
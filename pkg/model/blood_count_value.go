@@ -7,3 +7,9 @@ type BloodCountValue struct {
 	Coefficient float32 `json:"coefficient" db:"coefficient" validate:"required"` // Coefficient value.
 	Description string  `json:"description,omitempty" db:"description"`           // Description of the blood count value. Optional.
 }
+
+// BloodCountValueFilter filters the blood count value list.
+type BloodCountValueFilter struct {
+	Disease    string `form:"disease"`
+	BloodCount string `form:"blood-count"`
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cohort is a saved cohort definition of a researcher, its statistics are computed whenever it is run.
+type Cohort struct {
+	Id          int              `json:"id" db:"id"`
+	Name        string           `json:"name" db:"name" binding:"required,max=100"`
+	Description string           `json:"description" db:"description" binding:"max=300"`
+	Definition  CohortDefinition `json:"definition" db:"definition"`
+	CreatedBy   int              `json:"created-by" db:"created_by"`
+	CreatedAt   time.Time        `json:"created-at" db:"created_at"`
+}
+
+// CohortDefinition selects the patient courses of a cohort. Every set criterion must match,
+// a list matches any of its values. The age is the age of the patient at the begin of the course.
+type CohortDefinition struct {
+	Diseases  []string `json:"diseases,omitempty"`
+	Stages    []string `json:"stages,omitempty"`
+	Diagnoses []string `json:"diagnoses,omitempty"`
+	Courses   []string `json:"courses,omitempty"`
+	Drugs     []string `json:"drugs,omitempty"`
+	Sex       string   `json:"sex,omitempty"`
+	MinAge    *int     `json:"min-age,omitempty" binding:"omitempty,min=0"`
+	MaxAge    *int     `json:"max-age,omitempty" binding:"omitempty,min=0"`
+}
+
+// Value stores the definition as JSON.
+func (d CohortDefinition) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Scan reads the definition from JSON.
+func (d *CohortDefinition) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, d)
+	case string:
+		return json.Unmarshal([]byte(src), d)
+	default:
+		return errors.New("cohort definition must be read from json")
+	}
+}
+
+// CohortFilter filters the cohort list.
+type CohortFilter struct {
+	CreatedBy *int `form:"created-by"`
+}
+
+// CohortStatisticsQuery sets the width of the time buckets in days after the course begin
+// and optionally the blood counts to compute statistics of.
+type CohortStatisticsQuery struct {
+	BucketDays  int      `form:"bucket-days" binding:"omitempty,min=1,max=3650"`
+	BloodCounts []string `form:"blood-count"`
+}
+
+// CohortCount is the number of patients with a value of a characteristic.
+type CohortCount struct {
+	Value    string `json:"value" db:"value"`
+	Patients int    `json:"patients" db:"patients"`
+}
+
+// CohortBloodCountStatistics describes the blood count results of the cohort taken in a time bucket,
+// the bucket covers the days from FromDay to ToDay after the course begin. The range is given by the 5th and 95th
+// percentiles instead of the minimum and maximum, which are results of single patients. They are null in buckets
+// too small to have a result below the 5th and above the 95th percentile.
+type CohortBloodCountStatistics struct {
+	BloodCount string   `json:"blood-count" db:"blood_count"`
+	Bucket     int      `json:"-" db:"bucket"`
+	FromDay    int      `json:"from-day" db:"-"`
+	ToDay      int      `json:"to-day" db:"-"`
+	Results    int      `json:"results" db:"results"`
+	Patients   int      `json:"patients" db:"patients"`
+	Mean       float64  `json:"mean" db:"mean"`
+	P5         *float64 `json:"p5" db:"p5"`
+	Q1         float64  `json:"q1" db:"q1"`
+	Median     float64  `json:"median" db:"median"`
+	Q3         float64  `json:"q3" db:"q3"`
+	P95        *float64 `json:"p95" db:"p95"`
+}
+
+// CohortStatistics are the aggregates of a cohort, they never identify single patients.
+// Privacy reports the groups merged or suppressed because they were too small.
+type CohortStatistics struct {
+	Cohort      int                          `json:"cohort"`
+	Patients    int                          `json:"patients" db:"patients"`
+	Courses     int                          `json:"courses" db:"courses"`
+	BucketDays  int                          `json:"bucket-days"`
+	Sex         []CohortCount                `json:"sex"`
+	Stages      []CohortCount                `json:"stages"`
+	BloodCounts []CohortBloodCountStatistics `json:"blood-counts"`
+	Privacy     PrivacyReport                `json:"privacy"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// ConsoleStatistics are the system counters shown in the admin console.
+// Active courses and procedures are counted for the period, patients and doctors in total.
+type ConsoleStatistics struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Patients      int    `json:"patients" db:"patients"`
+	Doctors       int    `json:"doctors" db:"doctors"`
+	ActiveCourses int    `json:"active-courses" db:"active_courses"`
+	Procedures    int    `json:"procedures" db:"procedures"`
+}
+
+// UserFilter filters the user list of the admin console.
+// Search is matched against email and names.
+type UserFilter struct {
+	Search string `form:"search"`
+	Role   string `form:"role"`
+	Locked *bool  `form:"locked"`
+}
+
+// RoleInput is the request body for changing the role of a user.
+type RoleInput struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// FailedLogin is a rejected login attempt.
+type FailedLogin struct {
+	Id        int64     `json:"id" db:"id"`
+	Email     string    `json:"email" db:"email"`
+	Ip        string    `json:"ip" db:"ip"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created-at" db:"created_at"`
+}
@@ -8,3 +8,8 @@ type Course struct {
 	Drug        string  `json:"drug" db:"drug"`
 	MeasureCode string  `json:"measure-code" db:"measure_code"`
 }
+
+// CourseFilter filters the course list.
+type CourseFilter struct {
+	Drug string `form:"drug"`
+}
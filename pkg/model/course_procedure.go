@@ -6,5 +6,13 @@ type CourseProcedure struct {
 	Doctor        int    `json:"doctor" db:"doctor"`
 	BeginDate     string `json:"begin-date" db:"begin_date"`
 	Period        int    `json:"period" db:"period"`
-	Result        int    `json:"result" db:"result"`
+	Result        string `json:"result" db:"result"`
+}
+
+// CourseProcedureFilter filters the course procedure list. Dates are in YYYY-MM-DD format, both inclusive.
+type CourseProcedureFilter struct {
+	PatientCourse *int   `form:"patient-course"`
+	Doctor        *int   `form:"doctor"`
+	BeginDateFrom string `form:"begin-date-from" binding:"omitempty,datetime=2006-01-02"`
+	BeginDateTo   string `form:"begin-date-to" binding:"omitempty,datetime=2006-01-02"`
 }
@@ -10,3 +10,10 @@ type Drug struct {
 	PrescribingOrder  string `json:"prescribing-order" db:"prescribing_order"`
 	Description       string `json:"description" db:"description"`
 }
+
+// DrugFilter filters the drug list.
+type DrugFilter struct {
+	DosageForm   string `form:"dosage-form"`
+	Country      string `form:"country"`
+	Manufacturer string `form:"manufacturer"`
+}
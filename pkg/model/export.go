@@ -0,0 +1,121 @@
+package model
+
+import "time"
+
+// Statuses of an export job
+const (
+	ExportPending = "pending"
+	ExportRunning = "running"
+	ExportDone    = "done"
+	ExportFailed  = "failed"
+)
+
+// Formats of the files in an export bundle
+const (
+	CSVExportFormat    = "csv"
+	NDJSONExportFormat = "ndjson"
+)
+
+// Generalizations of the patient birth date in an export
+const (
+	BirthYearExport = "year"
+	AgeBandExport   = "age-band"
+)
+
+// De-identifications of the course and procedure dates in an export
+const (
+	RelativeDates = "relative"
+	ShiftedDates  = "shifted"
+)
+
+// ExportJob builds a de-identified bundle of the dataset in the background.
+// The bundle can be downloaded once the status is done.
+type ExportJob struct {
+	Id          int        `json:"id" db:"id"`
+	Format      string     `json:"format" db:"format" binding:"required,oneof=csv ndjson"`
+	BirthDate   string     `json:"birth-date" db:"birth_date" binding:"required,oneof=year age-band"`
+	Dates       string     `json:"dates" db:"dates" binding:"omitempty,oneof=relative shifted"`
+	Status      string     `json:"status" db:"status"`
+	Error       string     `json:"error,omitempty" db:"error"`
+	RequestedBy int        `json:"requested-by" db:"requested_by"`
+	CreatedAt   time.Time  `json:"created-at" db:"created_at"`
+	FinishedAt  *time.Time `json:"finished-at,omitempty" db:"finished_at"`
+}
+
+// ExportJobFilter filters the export job list.
+type ExportJobFilter struct {
+	Status      string `form:"status" binding:"omitempty,oneof=pending running done failed"`
+	RequestedBy *int   `form:"requested-by"`
+}
+
+// ExportDataset holds the patients and their diseases, which are k-anonymized together before they are written.
+// Rows carry internal patient ids, which are replaced with pseudonyms, and no other direct identifiers.
+type ExportDataset struct {
+	Patients        []ExportPatient
+	PatientDiseases []PatientDisease
+}
+
+// ExportPatient is a patient without names, SNILS, phone and user account.
+// Course and procedure dates of the patient are exported relative to the first course date.
+type ExportPatient struct {
+	Id              int    `db:"id"`
+	BirthDate       string `db:"birth_date"`
+	Sex             string `db:"sex"`
+	FirstCourseDate string `db:"first_course_date"`
+}
+
+// ExportPatientCourse is a patient course without the treating doctor.
+type ExportPatientCourse struct {
+	Id        int    `db:"id"`
+	Patient   int    `db:"patient"`
+	Disease   string `db:"disease"`
+	Course    string `db:"course"`
+	BeginDate string `db:"begin_date"`
+	EndDate   string `db:"end_date"`
+	Diagnosis string `db:"diagnosis"`
+}
+
+// ExportCourseProcedure is a course procedure without the performing doctor and the free-text result.
+type ExportCourseProcedure struct {
+	Id            int    `db:"id"`
+	Patient       int    `db:"patient"`
+	PatientCourse int    `db:"patient_course"`
+	BeginDate     string `db:"begin_date"`
+}
+
+// ExportProcedureBloodCount is a blood count result with the patient of the procedure.
+type ExportProcedureBloodCount struct {
+	Patient     int      `db:"patient"`
+	Procedure   int      `db:"procedure"`
+	BloodCount  string   `db:"blood_count"`
+	Value       *float64 `db:"value"`
+	MeasureCode string   `db:"measure_code"`
+	Flag        string   `db:"flag"`
+}
+
+// ExportColumn describes a column of an exported file in the data dictionary.
+type ExportColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ExportFile describes an exported file in the data dictionary.
+type ExportFile struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Rows        int            `json:"rows"`
+	Columns     []ExportColumn `json:"columns"`
+}
+
+// ExportManifest is written to the bundle next to the files and documents them.
+type ExportManifest struct {
+	Job        int           `json:"job"`
+	CreatedAt  time.Time     `json:"created-at"`
+	Format     string        `json:"format"`
+	BirthDate  string        `json:"birth-date"`
+	Dates      string        `json:"dates"`
+	Pseudonyms string        `json:"pseudonyms"`
+	Files      []ExportFile  `json:"files"`
+	Privacy    PrivacyReport `json:"privacy"`
+}
@@ -0,0 +1,260 @@
+package model
+
+// FHIR R4 resources of the FHIR facade. They hold only the elements the OncoBase data maps to.
+const (
+	FHIRContentType = "application/fhir+json"
+
+	// SNILS is identified by its OID in the Russian FHIR profiles
+	FHIRSNILSSystem                 = "urn:oid:1.2.643.100.3"
+	FHIRBloodCountSystem            = "urn:oncobase:blood-count"
+	FHIRDiseaseSystem               = "urn:oncobase:disease"
+	FHIRDiagnosisSystem             = "urn:oncobase:diagnosis"
+	FHIRDrugSystem                  = "urn:oncobase:drug"
+	FHIRCourseSystem                = "urn:oncobase:course"
+	FHIRObservationCategorySystem   = "http://terminology.hl7.org/CodeSystem/observation-category"
+	FHIRInterpretationSystem        = "http://terminology.hl7.org/CodeSystem/v3-ObservationInterpretation"
+	FHIRReferenceRangeMeaningSystem = "http://terminology.hl7.org/CodeSystem/referencerange-meaning"
+)
+
+// Resource types of the FHIR facade
+const (
+	FHIRPatientType           = "Patient"
+	FHIRObservationType       = "Observation"
+	FHIRConditionType         = "Condition"
+	FHIRMedicationRequestType = "MedicationRequest"
+	FHIRMedicationType        = "Medication"
+	FHIRPractitionerType      = "Practitioner"
+	FHIRProcedureType         = "Procedure"
+	FHIRBundleType            = "Bundle"
+	FHIROperationOutcomeType  = "OperationOutcome"
+)
+
+type FHIRIdentifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value"`
+}
+
+type FHIRHumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+}
+
+type FHIRContactPoint struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+}
+
+type FHIRCoding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+type FHIRCodeableConcept struct {
+	Coding []FHIRCoding `json:"coding,omitempty"`
+	Text   string       `json:"text,omitempty"`
+}
+
+// FHIRReference refers to a resource as <type>/<id>
+type FHIRReference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+type FHIRQuantity struct {
+	Value *float64 `json:"value,omitempty"`
+	Unit  string   `json:"unit,omitempty"`
+	Code  string   `json:"code,omitempty"`
+}
+
+// FHIRPeriod has dates in YYYY-MM-DD format, an open period has no end
+type FHIRPeriod struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// FHIRPatient is a patient with SNILS as the identifier.
+type FHIRPatient struct {
+	ResourceType string             `json:"resourceType"`
+	Id           string             `json:"id,omitempty"`
+	Identifier   []FHIRIdentifier   `json:"identifier,omitempty"`
+	Name         []FHIRHumanName    `json:"name,omitempty"`
+	Telecom      []FHIRContactPoint `json:"telecom,omitempty"`
+	Gender       string             `json:"gender,omitempty"`
+	BirthDate    string             `json:"birthDate,omitempty"`
+}
+
+// FHIRObservation is a blood count result of a procedure, the ID is <procedure ID>-<blood count ID>.
+type FHIRObservation struct {
+	ResourceType      string                 `json:"resourceType"`
+	Id                string                 `json:"id,omitempty"`
+	Status            string                 `json:"status"`
+	Category          []FHIRCodeableConcept  `json:"category,omitempty"`
+	Code              FHIRCodeableConcept    `json:"code"`
+	Subject           *FHIRReference         `json:"subject,omitempty"`
+	PartOf            []FHIRReference        `json:"partOf,omitempty"`
+	EffectiveDateTime string                 `json:"effectiveDateTime,omitempty"`
+	ValueQuantity     *FHIRQuantity          `json:"valueQuantity,omitempty"`
+	Interpretation    []FHIRCodeableConcept  `json:"interpretation,omitempty"`
+	ReferenceRange    []FHIRObservationRange `json:"referenceRange,omitempty"`
+}
+
+type FHIRObservationRange struct {
+	Low  *FHIRQuantity        `json:"low,omitempty"`
+	High *FHIRQuantity        `json:"high,omitempty"`
+	Type *FHIRCodeableConcept `json:"type,omitempty"`
+	Text string               `json:"text,omitempty"`
+}
+
+// FHIRCondition is a disease of a patient, the ID is <patient ID>-<disease ID>.
+type FHIRCondition struct {
+	ResourceType string                  `json:"resourceType"`
+	Id           string                  `json:"id,omitempty"`
+	Code         *FHIRCodeableConcept    `json:"code,omitempty"`
+	Subject      FHIRReference           `json:"subject"`
+	Stage        []FHIRConditionStage    `json:"stage,omitempty"`
+	Evidence     []FHIRConditionEvidence `json:"evidence,omitempty"`
+}
+
+type FHIRConditionStage struct {
+	Summary *FHIRCodeableConcept `json:"summary,omitempty"`
+}
+
+type FHIRConditionEvidence struct {
+	Code []FHIRCodeableConcept `json:"code,omitempty"`
+}
+
+// FHIRMedicationRequest is a course of a patient. The course is the group identifier and sets the medication and the dose.
+type FHIRMedicationRequest struct {
+	ResourceType        string                `json:"resourceType"`
+	Id                  string                `json:"id,omitempty"`
+	Status              string                `json:"status"`
+	Intent              string                `json:"intent"`
+	GroupIdentifier     *FHIRIdentifier       `json:"groupIdentifier,omitempty"`
+	MedicationReference *FHIRReference        `json:"medicationReference,omitempty"`
+	Subject             FHIRReference         `json:"subject"`
+	Requester           *FHIRReference        `json:"requester,omitempty"`
+	ReasonCode          []FHIRCodeableConcept `json:"reasonCode,omitempty"`
+	ReasonReference     []FHIRReference       `json:"reasonReference,omitempty"`
+	DosageInstruction   []FHIRDosage          `json:"dosageInstruction,omitempty"`
+}
+
+type FHIRDosage struct {
+	Timing      *FHIRTiming       `json:"timing,omitempty"`
+	DoseAndRate []FHIRDoseAndRate `json:"doseAndRate,omitempty"`
+}
+
+type FHIRTiming struct {
+	Repeat *FHIRTimingRepeat `json:"repeat,omitempty"`
+}
+
+type FHIRTimingRepeat struct {
+	BoundsPeriod *FHIRPeriod `json:"boundsPeriod,omitempty"`
+}
+
+type FHIRDoseAndRate struct {
+	DoseQuantity *FHIRQuantity `json:"doseQuantity,omitempty"`
+}
+
+// FHIRMedication is a drug.
+type FHIRMedication struct {
+	ResourceType string                     `json:"resourceType"`
+	Id           string                     `json:"id,omitempty"`
+	Code         *FHIRCodeableConcept       `json:"code,omitempty"`
+	Manufacturer *FHIRReference             `json:"manufacturer,omitempty"`
+	Form         *FHIRCodeableConcept       `json:"form,omitempty"`
+	Ingredient   []FHIRMedicationIngredient `json:"ingredient,omitempty"`
+}
+
+type FHIRMedicationIngredient struct {
+	ItemCodeableConcept FHIRCodeableConcept `json:"itemCodeableConcept"`
+}
+
+// FHIRPractitioner is a doctor.
+type FHIRPractitioner struct {
+	ResourceType  string                          `json:"resourceType"`
+	Id            string                          `json:"id,omitempty"`
+	Name          []FHIRHumanName                 `json:"name,omitempty"`
+	Telecom       []FHIRContactPoint              `json:"telecom,omitempty"`
+	Qualification []FHIRPractitionerQualification `json:"qualification,omitempty"`
+}
+
+type FHIRPractitionerQualification struct {
+	Code FHIRCodeableConcept `json:"code"`
+}
+
+// FHIRBundle is a page of search results. NextCursor is the cursor of the next page, the handler turns it into the next link.
+type FHIRBundle struct {
+	ResourceType string            `json:"resourceType"`
+	Type         string            `json:"type"`
+	Total        *int              `json:"total,omitempty"`
+	Link         []FHIRBundleLink  `json:"link,omitempty"`
+	Entry        []FHIRBundleEntry `json:"entry"`
+	NextCursor   string            `json:"-"`
+}
+
+type FHIRBundleLink struct {
+	Relation string `json:"relation"`
+	Url      string `json:"url"`
+}
+
+type FHIRBundleEntry struct {
+	FullUrl  string      `json:"fullUrl"`
+	Resource interface{} `json:"resource"`
+}
+
+// FHIROperationOutcome reports an error of the FHIR facade.
+type FHIROperationOutcome struct {
+	ResourceType string      `json:"resourceType"`
+	Issue        []FHIRIssue `json:"issue"`
+}
+
+type FHIRIssue struct {
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	Diagnostics string `json:"diagnostics,omitempty"`
+}
+
+// FHIRSearch holds the paging parameters of a FHIR search. The bundle has the total only if _total is estimate or accurate.
+type FHIRSearch struct {
+	Count  int    `form:"_count"`
+	Cursor string `form:"_cursor"`
+	Total  string `form:"_total" binding:"omitempty,oneof=none estimate accurate"`
+}
+
+// FHIRPatientQuery holds the Patient search parameters. Identifier is a SNILS, optionally prefixed with the system and |,
+// name matches the beginning of a name part and birthdate is a date in YYYY-MM-DD format with an optional eq, ge, gt, le or lt prefix.
+type FHIRPatientQuery struct {
+	FHIRSearch
+	Identifier string   `form:"identifier"`
+	Name       string   `form:"name"`
+	Gender     string   `form:"gender"`
+	BirthDate  []string `form:"birthdate"`
+}
+
+// FHIRObservationQuery holds the Observation search parameters. Patient and subject are patient references,
+// code is a blood count ID and date is a procedure date with an optional prefix.
+type FHIRObservationQuery struct {
+	FHIRSearch
+	Patient string   `form:"patient"`
+	Subject string   `form:"subject"`
+	Code    string   `form:"code"`
+	Date    []string `form:"date"`
+}
+
+// FHIRConditionQuery holds the Condition search parameters, code is a disease ID.
+type FHIRConditionQuery struct {
+	FHIRSearch
+	Patient string `form:"patient"`
+	Subject string `form:"subject"`
+	Code    string `form:"code"`
+}
+
+// FHIRMedicationRequestQuery holds the MedicationRequest search parameters, requester is a practitioner reference.
+type FHIRMedicationRequestQuery struct {
+	FHIRSearch
+	Patient   string `form:"patient"`
+	Subject   string `form:"subject"`
+	Requester string `form:"requester"`
+}
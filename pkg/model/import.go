@@ -0,0 +1,40 @@
+package model
+
+// DefaultImportProcedureColumn is the header of the course procedure ID column of an import file
+const DefaultImportProcedureColumn = "procedure"
+
+// ImportMapping maps the columns of an import file to blood counts. Procedure is the header of the course procedure ID column.
+// Without columns every other column is a blood count with the blood count ID as the header and the value in the unit of the blood count.
+type ImportMapping struct {
+	Procedure string         `json:"procedure"`
+	Columns   []ImportColumn `json:"columns"`
+}
+
+// ImportColumn maps a column of an import file to a blood count, MeasureCode is the unit of the values, the unit of the blood count if empty.
+type ImportColumn struct {
+	Header      string `json:"header"`
+	BloodCount  string `json:"blood-count"`
+	MeasureCode string `json:"measure-code"`
+}
+
+// ImportQuery holds the import options, a dry run validates the file without saving the results.
+type ImportQuery struct {
+	DryRun bool `form:"dry-run"`
+}
+
+// ImportReport is the result of an import. Rows counts the data rows of the file and Results the blood count results in them.
+// The results are committed only if no row has errors.
+type ImportReport struct {
+	DryRun    bool          `json:"dry-run"`
+	Committed bool          `json:"committed"`
+	Rows      int           `json:"rows"`
+	Results   int           `json:"results"`
+	Errors    []ImportError `json:"errors"`
+}
+
+// ImportError is a problem in a row of an import file. Rows are numbered from 1, the header is row 1.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
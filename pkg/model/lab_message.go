@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+// HL7 v2 acknowledgment codes of MSA-1: the message is accepted, has errors in its data or is rejected
+const (
+	HL7AcceptAck = "AA"
+	HL7ErrorAck  = "AE"
+	HL7RejectAck = "AR"
+)
+
+// HL7 v2 error codes of ERR-3 (table 0357)
+const (
+	HL7MessageAccepted        = 0
+	HL7SegmentSequenceError   = 100
+	HL7RequiredFieldMissing   = 101
+	HL7DataTypeError          = 102
+	HL7TableValueNotFound     = 103
+	HL7UnsupportedMessageType = 200
+	HL7UnknownKeyIdentifier   = 204
+	HL7DuplicateKeyIdentifier = 205
+	HL7ApplicationError       = 207
+)
+
+// HL7 v2 error severities of ERR-4
+const (
+	HL7ErrorSeverity   = "E"
+	HL7WarningSeverity = "W"
+)
+
+// LabCodeMapping maps an observation code of lab analyzers to a blood count. System is the coding system of OBX-3,
+// an empty system matches codes of any system. MeasureCode is the unit of the analyzer values, OBX-6 is used without it.
+type LabCodeMapping struct {
+	System      string `json:"system" db:"system"`
+	Code        string `json:"code" db:"code" binding:"required"`
+	BloodCount  string `json:"blood-count" db:"blood_count" binding:"required"`
+	MeasureCode string `json:"measure-code" db:"measure_code"`
+}
+
+// LabMessage is a received HL7 v2 lab message. The sender and the control ID identify the message,
+// so that a message resent by the analyzer is acknowledged again without storing the results twice.
+type LabMessage struct {
+	SendingApplication string    `json:"sending-application" db:"sending_application"`
+	SendingFacility    string    `json:"sending-facility" db:"sending_facility"`
+	ControlId          string    `json:"control-id" db:"control_id"`
+	ReceivedBy         int       `json:"received-by" db:"received_by"`
+	ReceivedAt         time.Time `json:"received-at" db:"received_at"`
+	Procedures         int       `json:"procedures" db:"procedures"`
+	Results            int       `json:"results" db:"results"`
+}
+
+// LabProcedure is a course procedure created from an order of a lab message with its blood count results.
+type LabProcedure struct {
+	CourseProcedure CourseProcedure
+	Results         []ProcedureBloodCount
+}
+
+// LabMessageError is a problem of a lab message, located by the segment, its position in the message and the field.
+// Errors reject the message, warnings are reported with an accepted message.
+type LabMessageError struct {
+	Segment  string `json:"segment,omitempty"`
+	Sequence int    `json:"sequence,omitempty"`
+	Field    int    `json:"field,omitempty"`
+	Code     int    `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LabMessageAck is the acknowledgment of a lab message: the MSA-1 code, the ACK message in the ER7 encoding
+// and the problems reported in its ERR segments.
+type LabMessageAck struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Errors  []LabMessageError `json:"errors"`
+}
@@ -0,0 +1,20 @@
+package model
+
+// ListQuery is the page and order of a list, read from the query parameters.
+// Sort is a comma-separated list of field names, a field prefixed with "-" is sorted in descending order.
+// Cursor is the next-cursor of the previous page. Total asks for the number of items matching the filter,
+// which takes counting all of them, so clients paging through a large list may turn it off with total=false.
+type ListQuery struct {
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+	Total  bool   `form:"total,default=true"`
+}
+
+// Page is a page of a list with the cursor of the next page and, unless the list query turns it off, the number of items matching the filter.
+// NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"next-cursor,omitempty"`
+}
@@ -2,14 +2,43 @@ package model
 
 import "database/sql"
 
+// Sex codes of patients, an empty sex is not known
+const (
+	MaleSex   = "м"
+	FemaleSex = "ж"
+)
+
 type Patient struct {
 	Id         int           `json:"id" db:"id"`
 	FirstName  string        `json:"first-name" db:"first_name"`
 	MiddleName string        `json:"middle-name" db:"middle_name"`
 	LastName   string        `json:"last-name" db:"last_name"`
-	BirthDate  string        `json:"birth-date" db:"birth_date"`
+	BirthDate  *string       `json:"birth-date" db:"birth_date"`
 	Sex        string        `json:"sex" db:"sex"`
-	SNILS      string        `json:"snils" db:"snils"`
-	UserId     sql.NullInt64 `json:"user-id" db:"user_id"`
-	Phone      string        `json:"phone" db:"phone"`
+	SNILS      *string       `json:"snils" db:"snils"`
+	UserId     sql.NullInt64 `json:"user-id" db:"user_id" swaggertype:"object"`
+	Phone      *string       `json:"phone" db:"phone"`
+}
+
+// PatientFilter filters the patient list. Name matches the beginning of the first, middle or last name ignoring case.
+// Dates are in YYYY-MM-DD format, both inclusive.
+type PatientFilter struct {
+	Name          string `form:"name"`
+	SNILS         string `form:"snils"`
+	Sex           string `form:"sex"`
+	BirthDateFrom string `form:"birth-date-from" binding:"omitempty,datetime=2006-01-02"`
+	BirthDateTo   string `form:"birth-date-to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// PatientSearch is a patient search query. The query is matched against the full name with typo tolerance,
+// SNILS, phone and birth date in YYYY-MM-DD or DD.MM.YYYY format.
+type PatientSearch struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit"`
+}
+
+// PatientSearchResult is a found patient with the rank of the match, from 0 to 1 for an exact match.
+type PatientSearchResult struct {
+	Patient
+	Rank float64 `json:"rank" db:"rank"`
 }
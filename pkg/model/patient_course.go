@@ -1,12 +1,22 @@
 package model
 
 type PatientCourse struct {
-	Id        int    `json:"id" db:"phone"`
-	Patient   int    `json:"patient" db:"patient"`
-	Disease   string `json:"disease" db:"disease"`
-	Course    string `json:"course" db:"course"`
-	Doctor    int    `json:"doctor" db:"doctor"`
-	BeginDate string `json:"begin-date" db:"begin_date"`
-	EndDate   string `json:"end-date" db:"end_date"`
-	Diagnosis string `json:"diagnosis" db:"diagnosis"`
+	Id        int     `json:"id" db:"id"`
+	Patient   int     `json:"patient" db:"patient"`
+	Disease   *string `json:"disease" db:"disease"`
+	Course    string  `json:"course" db:"course"`
+	Doctor    int     `json:"doctor" db:"doctor"`
+	BeginDate string  `json:"begin-date" db:"begin_date"`
+	EndDate   *string `json:"end-date" db:"end_date"`
+	Diagnosis *string `json:"diagnosis" db:"diagnosis"`
+}
+
+// PatientCourseFilter filters the patient course list. Dates are in YYYY-MM-DD format, both inclusive.
+type PatientCourseFilter struct {
+	Patient       *int   `form:"patient"`
+	Disease       string `form:"disease"`
+	Course        string `form:"course"`
+	Doctor        *int   `form:"doctor"`
+	BeginDateFrom string `form:"begin-date-from" binding:"omitempty,datetime=2006-01-02"`
+	BeginDateTo   string `form:"begin-date-to" binding:"omitempty,datetime=2006-01-02"`
 }
@@ -1,8 +1,16 @@
 package model
 
 type PatientDisease struct {
-	Stage     string `json:"stage" db:"stage"`
-	Diagnosis string `json:"diagnosis" db:"diagnosis"`
-	Patient   int    `json:"patient" db:"patient"`
-	Disease   string `json:"disease" db:"disease"`
+	Stage     string  `json:"stage" db:"stage"`
+	Diagnosis *string `json:"diagnosis" db:"diagnosis"`
+	Patient   int     `json:"patient" db:"patient"`
+	Disease   string  `json:"disease" db:"disease"`
+}
+
+// PatientDiseaseFilter filters the patient disease list.
+type PatientDiseaseFilter struct {
+	Patient   *int   `form:"patient"`
+	Disease   string `form:"disease"`
+	Stage     string `form:"stage"`
+	Diagnosis string `form:"diagnosis"`
 }
@@ -0,0 +1,91 @@
+package model
+
+// Roles known to the permission matrix.
+const (
+	AdminRole      = "admin"
+	DoctorRole     = "doctor"
+	PatientRole    = "patient"
+	ResearcherRole = "researcher"
+)
+
+// Actions that can be granted on a resource.
+const (
+	ReadAction   = "read"
+	CreateAction = "create"
+	UpdateAction = "update"
+	DeleteAction = "delete"
+)
+
+// Resources protected by the permission matrix. Names match the route groups.
+const (
+	AnalysisResource            = "analysis"
+	BloodCountResource          = "blood-count"
+	BloodCountValueResource     = "blood-count-value"
+	CohortResource              = "cohort"
+	ConsoleResource             = "console"
+	CourseResource              = "course"
+	CourseProcedureResource     = "course-procedure"
+	DiagnosisResource           = "diagnosis"
+	DiseaseResource             = "disease"
+	DoctorResource              = "doctor"
+	DoctorPatientResource       = "doctor-patient"
+	DrugResource                = "drug"
+	ExportResource              = "export"
+	InvitationResource          = "invitation"
+	LabCodeMappingResource      = "lab-code-mapping"
+	LabMessageResource          = "lab-message"
+	PatientResource             = "patient"
+	PatientCourseResource       = "patient-course"
+	PatientDiseaseResource      = "patient-disease"
+	PermissionResource          = "permission"
+	ProcedureBloodCountResource = "procedure-blood-count"
+	SessionResource             = "session"
+	UnitConversionResource      = "unit-conversion"
+	UnitMeasureResource         = "unit-measure"
+	UserResource                = "user"
+)
+
+var (
+	Roles     = []string{AdminRole, DoctorRole, PatientRole, ResearcherRole}
+	Actions   = []string{ReadAction, CreateAction, UpdateAction, DeleteAction}
+	Resources = []string{
+		AnalysisResource,
+		BloodCountResource,
+		BloodCountValueResource,
+		CohortResource,
+		ConsoleResource,
+		CourseResource,
+		CourseProcedureResource,
+		DiagnosisResource,
+		DiseaseResource,
+		DoctorResource,
+		DoctorPatientResource,
+		DrugResource,
+		ExportResource,
+		InvitationResource,
+		LabCodeMappingResource,
+		LabMessageResource,
+		PatientResource,
+		PatientCourseResource,
+		PatientDiseaseResource,
+		PermissionResource,
+		ProcedureBloodCountResource,
+		SessionResource,
+		UnitConversionResource,
+		UnitMeasureResource,
+		UserResource,
+	}
+)
+
+// Permission allows a role to perform an action on a resource.
+type Permission struct {
+	Role     string `json:"role" db:"role" binding:"required"`
+	Resource string `json:"resource" db:"resource" binding:"required"`
+	Action   string `json:"action" db:"action" binding:"required"`
+}
+
+// PermissionFilter filters the permission list.
+type PermissionFilter struct {
+	Role     string `form:"role"`
+	Resource string `form:"resource"`
+}
@@ -0,0 +1,30 @@
+package model
+
+// Actions taken on cells below the k-anonymity threshold, and on ranges, which are trimmed to percentiles
+const (
+	MergedCell     = "merged"
+	SuppressedCell = "suppressed"
+	TrimmedRange   = "trimmed"
+)
+
+// OtherCell is the value of the cell the small cells are merged into.
+const OtherCell = "other"
+
+// SuppressedValue replaces quasi-identifier values suppressed in exports.
+const SuppressedValue = "*"
+
+// PrivacyReport lists what was merged or suppressed to keep every group at least K patients large.
+// Counts are only reported where they do not describe a small group.
+type PrivacyReport struct {
+	K          int                  `json:"k"`
+	Suppressed []PrivacySuppression `json:"suppressed"`
+}
+
+// PrivacySuppression is a field value merged or suppressed, or in exports the number of rows
+// whose field value was suppressed.
+type PrivacySuppression struct {
+	Field  string `json:"field"`
+	Value  string `json:"value,omitempty"`
+	Action string `json:"action"`
+	Rows   int    `json:"rows,omitempty"`
+}
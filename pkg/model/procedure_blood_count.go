@@ -1,8 +1,30 @@
 package model
 
+// ProcedureBloodCount is a blood count result of a procedure.
+// Flag is set from the normal range of the blood count when the result is saved, it is empty if there is no value.
 type ProcedureBloodCount struct {
-	Value       string `json:"value" db:"value"`
-	MeasureCode string `json:"measure-code" db:"measure_code"`
-	Procedure   int    `json:"procedure" db:"procedure"`
-	BloodCount  string `json:"blood-count" db:"blood_count"`
+	Value       *float64 `json:"value" db:"value"`
+	MeasureCode string   `json:"measure-code" db:"measure_code"`
+	Procedure   int      `json:"procedure" db:"procedure"`
+	BloodCount  string   `json:"blood-count" db:"blood_count"`
+	Flag        string   `json:"flag" db:"flag"`
 }
+
+// ProcedureBloodCountFilter filters the procedure blood count list. Abnormal selects low and high results,
+// dates are procedure dates in YYYY-MM-DD format, both inclusive.
+type ProcedureBloodCountFilter struct {
+	Patient    *int   `form:"patient"`
+	Procedure  *int   `form:"procedure"`
+	BloodCount string `form:"blood-count"`
+	Flag       string `form:"flag" binding:"omitempty,oneof=low normal high"`
+	Abnormal   *bool  `form:"abnormal"`
+	DateFrom   string `form:"date-from" binding:"omitempty,datetime=2006-01-02"`
+	DateTo     string `form:"date-to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// Flags of a blood count result against the normal range of the blood count
+const (
+	LowFlag    = "low"
+	NormalFlag = "normal"
+	HighFlag   = "high"
+)
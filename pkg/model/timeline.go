@@ -0,0 +1,31 @@
+package model
+
+// Types of the patient timeline events
+const (
+	DiagnosisRecordedEvent  = "diagnosis-recorded"
+	CourseStartedEvent      = "course-started"
+	CourseEndedEvent        = "course-ended"
+	ProcedurePerformedEvent = "procedure-performed"
+	BloodCountResultEvent   = "blood-count-result"
+)
+
+// TimelineEvent is an event in the history of a patient. Only the fields of the event type are set:
+// disease, stage and diagnosis for a diagnosis, course fields for course events, procedure fields
+// for procedures and blood count fields with the normal range flag for blood count results.
+// The date of a recorded diagnosis is the date it was entered or the start of its first course, empty if neither is known.
+type TimelineEvent struct {
+	Date          string   `json:"date" db:"date"`
+	Type          string   `json:"type" db:"type"`
+	Disease       string   `json:"disease,omitempty" db:"disease"`
+	Stage         string   `json:"stage,omitempty" db:"stage"`
+	Diagnosis     string   `json:"diagnosis,omitempty" db:"diagnosis"`
+	PatientCourse *int     `json:"patient-course,omitempty" db:"patient_course"`
+	Course        string   `json:"course,omitempty" db:"course"`
+	Doctor        *int     `json:"doctor,omitempty" db:"doctor"`
+	Procedure     *int     `json:"procedure,omitempty" db:"procedure"`
+	Result        string   `json:"result,omitempty" db:"result"`
+	BloodCount    string   `json:"blood-count,omitempty" db:"blood_count"`
+	Value         *float64 `json:"value,omitempty" db:"value"`
+	MeasureCode   string   `json:"measure-code,omitempty" db:"measure_code"`
+	Flag          string   `json:"flag,omitempty" db:"flag"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Tokens is the pair of tokens issued to a user on login and refresh.
+type Tokens struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh-token"`
+}
+
+// RefreshInput is the request body of the token refresh and logout endpoints.
+type RefreshInput struct {
+	RefreshToken string `json:"refresh-token" binding:"required"`
+}
+
+// RefreshToken is a long-lived session token stored server-side by its hash.
+type RefreshToken struct {
+	TokenHash string       `json:"-" db:"token_hash"`
+	UserId    int          `json:"user-id" db:"user_id"`
+	Role      string       `json:"role" db:"role"`
+	ExpiresAt time.Time    `json:"expires-at" db:"expires_at"`
+	CreatedAt time.Time    `json:"created-at" db:"created_at"`
+	RevokedAt sql.NullTime `json:"revoked-at" db:"revoked_at"`
+}
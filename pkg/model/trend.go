@@ -0,0 +1,66 @@
+package model
+
+// Phases of a trend point relative to the patient course the trend is scoped to
+const (
+	BeforeCoursePhase = "before"
+	DuringCoursePhase = "during"
+	AfterCoursePhase  = "after"
+)
+
+// TrendQuery selects the blood count of a trend and optionally the patient course to compare values before, during and after.
+type TrendQuery struct {
+	BloodCount    string `form:"blood-count" binding:"required"`
+	PatientCourse *int   `form:"patient-course"`
+}
+
+// TrendCourse is the period of a patient course. The end date is empty while the course goes on.
+type TrendCourse struct {
+	Id        int    `db:"id"`
+	Patient   int    `db:"patient"`
+	BeginDate string `db:"begin_date"`
+	EndDate   string `db:"end_date"`
+}
+
+// TrendPoint is a blood count result of a procedure. RateOfChange is the change per day from the previous result,
+// ChangeFromBaseline is the change from the baseline in percent.
+type TrendPoint struct {
+	Date               string   `json:"date" db:"date"`
+	Procedure          int      `json:"procedure" db:"procedure"`
+	PatientCourse      int      `json:"patient-course" db:"patient_course"`
+	Value              float64  `json:"value" db:"value"`
+	MeasureCode        string   `json:"measure-code" db:"measure_code"`
+	Flag               string   `json:"flag" db:"flag"`
+	Phase              string   `json:"phase,omitempty" db:"-"`
+	RateOfChange       *float64 `json:"rate-of-change,omitempty" db:"-"`
+	ChangeFromBaseline *float64 `json:"change-from-baseline,omitempty" db:"-"`
+}
+
+// TrendStreak is a run of consecutive out-of-range results with the same flag.
+type TrendStreak struct {
+	Flag   string `json:"flag"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Length int    `json:"length"`
+}
+
+// TrendPhase summarizes the results of a phase relative to the patient course.
+type TrendPhase struct {
+	Phase string   `json:"phase"`
+	Count int      `json:"count"`
+	First *float64 `json:"first,omitempty"`
+	Last  *float64 `json:"last,omitempty"`
+	Mean  *float64 `json:"mean,omitempty"`
+}
+
+// Trend is the time series of a blood count of a patient, ordered by procedure date.
+// The baseline is the first result, or with a patient course the last result before the course
+// and the first result of the course if there are none before it.
+type Trend struct {
+	Patient       int           `json:"patient"`
+	BloodCount    string        `json:"blood-count"`
+	PatientCourse *int          `json:"patient-course,omitempty"`
+	Baseline      *float64      `json:"baseline,omitempty"`
+	Points        []TrendPoint  `json:"points"`
+	Streaks       []TrendStreak `json:"streaks"`
+	Phases        []TrendPhase  `json:"phases,omitempty"`
+}
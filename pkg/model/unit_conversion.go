@@ -0,0 +1,33 @@
+package model
+
+// UnitConversion converts a value in the From unit to the To unit as value * Factor + Offset.
+// The reverse conversion is derived, so a pair of units needs a single conversion.
+type UnitConversion struct {
+	From   string  `json:"from" db:"from_unit" binding:"required"`
+	To     string  `json:"to" db:"to_unit" binding:"required,nefield=From"`
+	Factor float64 `json:"factor" db:"factor" binding:"required"`
+	Offset float64 `json:"offset" db:"offset"`
+}
+
+// Convert converts the value from the From unit to the To unit.
+func (c UnitConversion) Convert(value float64) float64 {
+	return value*c.Factor + c.Offset
+}
+
+// Reverse returns the conversion from the To unit to the From unit.
+func (c UnitConversion) Reverse() UnitConversion {
+	return UnitConversion{From: c.To, To: c.From, Factor: 1 / c.Factor, Offset: -c.Offset / c.Factor}
+}
+
+// ConversionQuery is a value to convert between units, read from the query parameters.
+type ConversionQuery struct {
+	Value *float64 `form:"value" binding:"required"`
+	From  string   `form:"from" binding:"required"`
+	To    string   `form:"to" binding:"required"`
+}
+
+// ConvertedValue is a value with its unit.
+type ConvertedValue struct {
+	Value       float64 `json:"value"`
+	MeasureCode string  `json:"measure-code"`
+}
@@ -1,13 +1,115 @@
 package model
 
+import "time"
+
+// User types
+const (
+	InternalUserType = "internal" // Clinic staff created by an administrator
+	ExternalUserType = "external" // Users registered by themselves
+)
+
 type User struct {
-	Id       int    `json:"id" db:"id"`
-	Email    string `json:"email" binding:"required" db:"email"`
-	Password string `json:"password" binding:"required"`
-	Role     string `json:"role" binding:"required"`
+	Id         int    `json:"id" db:"id"`
+	Email      string `json:"email" binding:"required" db:"email"`
+	Password   string `json:"password" binding:"required" db:"password"`
+	Role       string `json:"role" binding:"required" db:"role"`
+	UserType   string `json:"user-type" db:"user_type"`
+	FirstName  string `json:"first-name" db:"first_name"`
+	MiddleName string `json:"middle-name" db:"middle_name"`
+	LastName   string `json:"last-name" db:"last_name"`
+	Phone      string `json:"phone" db:"phone"`
+	Locked     bool   `json:"-" db:"locked"`
+}
+
+// StaffUser is the request body for creating an internal staff account.
+type StaffUser struct {
+	Email      string `json:"email" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	Role       string `json:"role" binding:"required"`
+	FirstName  string `json:"first-name" binding:"required"`
+	MiddleName string `json:"middle-name"`
+	LastName   string `json:"last-name" binding:"required"`
+	Phone      string `json:"phone"`
+}
+
+// UserProfile is a user without credentials, linked to the patient or doctor record of the user if there is one.
+type UserProfile struct {
+	Id         int       `json:"id" db:"id"`
+	Email      string    `json:"email" db:"email"`
+	Role       string    `json:"role" db:"role"`
+	UserType   string    `json:"user-type" db:"user_type"`
+	FirstName  string    `json:"first-name" db:"first_name"`
+	MiddleName string    `json:"middle-name" db:"middle_name"`
+	LastName   string    `json:"last-name" db:"last_name"`
+	Phone      string    `json:"phone" db:"phone"`
+	PatientId  int       `json:"patient-id,omitempty" db:"patient_id"`
+	DoctorId   int       `json:"doctor-id,omitempty" db:"doctor_id"`
+	Locked     bool      `json:"locked" db:"locked"`
+	CreatedAt  time.Time `json:"created-at" db:"created_at"`
+}
+
+// LegacyUserMigration reports how many rows were moved from the legacy user tables, the rows moved by an earlier run
+// and the rows whose id or email is taken by another user. Nothing is moved while there are conflicts.
+type LegacyUserMigration struct {
+	External  int             `json:"external"`
+	Internal  int             `json:"internal"`
+	Skipped   []LegacyUserRow `json:"skipped"`
+	Conflicts []LegacyUserRow `json:"conflicts"`
+}
+
+// LegacyUserRow is a row of a legacy user table that is not moved and the reason why.
+type LegacyUserRow struct {
+	Table  string `json:"table"`
+	Id     int    `json:"id"`
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
 }
 
 type AuthUser struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type ResetPasswordInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ConfirmResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// PatientRegistration is the request body for patient self-registration.
+// The account is linked to the patient record with the given SNILS if the registration code matches.
+type PatientRegistration struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	SNILS    string `json:"snils" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// RegistrationCode is a one-time code that lets a patient register an account.
+type RegistrationCode struct {
+	PatientId int       `json:"patient-id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires-at"`
+}
+
+// Invitation is an invitation to create a staff account with the given role.
+type Invitation struct {
+	Email     string    `json:"email" binding:"required"`
+	Role      string    `json:"role" binding:"required"`
+	ExpiresAt time.Time `json:"expires-at"`
+}
+
+// InvitationAcceptance is the request body for accepting an invitation.
+// Qualification is only stored for doctors.
+type InvitationAcceptance struct {
+	Token         string `json:"token" binding:"required"`
+	Password      string `json:"password" binding:"required"`
+	FirstName     string `json:"first-name" binding:"required"`
+	MiddleName    string `json:"middle-name"`
+	LastName      string `json:"last-name" binding:"required"`
+	Phone         string `json:"phone"`
+	Qualification string `json:"qualification"`
+}
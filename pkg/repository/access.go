@@ -0,0 +1,59 @@
+package repository
+
+import "fmt"
+
+type AccessRepository struct {
+	db DB
+}
+
+func NewAccessRepository(db DB) *AccessRepository {
+	return &AccessRepository{db: db}
+}
+
+// Check that the patient is linked to the doctor with given user ID
+func (r *AccessRepository) IsDoctorPatient(userId, patientId int) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s dp JOIN %s d ON d.id=dp.doctor WHERE d.user_id=$1 AND dp.patient=$2)", doctorPatientTable, doctorTable)
+	err := r.db.Get(&exists, query, userId, patientId)
+	return exists, err
+}
+
+// Check that the patient record belongs to the user with given ID
+func (r *AccessRepository) IsPatientUser(userId, patientId int) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE user_id=$1 AND id=$2)", patientTable)
+	err := r.db.Get(&exists, query, userId, patientId)
+	return exists, err
+}
+
+// Get ID list of patients linked to the doctor with given user ID
+func (r *AccessRepository) GetDoctorPatientIdList(userId int) ([]int, error) {
+	var patientIdList []int
+	query := fmt.Sprintf("SELECT dp.patient FROM %s dp JOIN %s d ON d.id=dp.doctor WHERE d.user_id=$1", doctorPatientTable, doctorTable)
+	err := r.db.Select(&patientIdList, query, userId)
+	return patientIdList, err
+}
+
+// Get ID list of patient records belonging to the user with given ID
+func (r *AccessRepository) GetUserPatientIdList(userId int) ([]int, error) {
+	var patientIdList []int
+	query := fmt.Sprintf("SELECT id FROM %s WHERE user_id=$1", patientTable)
+	err := r.db.Select(&patientIdList, query, userId)
+	return patientIdList, err
+}
+
+// Get ID of the patient the patient course belongs to
+func (r *AccessRepository) GetPatientIdByPatientCourse(patientCourseId int) (int, error) {
+	var patientId int
+	query := fmt.Sprintf("SELECT patient FROM %s WHERE id=$1", patientCourseTable)
+	err := r.db.Get(&patientId, query, patientCourseId)
+	return patientId, err
+}
+
+// Get ID of the patient the course procedure belongs to
+func (r *AccessRepository) GetPatientIdByCourseProcedure(procedureId int) (int, error) {
+	var patientId int
+	query := fmt.Sprintf("SELECT pc.patient FROM %s cp JOIN %s pc ON pc.id=cp.patient_course WHERE cp.id=$1", courseProcedureTable, patientCourseTable)
+	err := r.db.Get(&patientId, query, procedureId)
+	return patientId, err
+}
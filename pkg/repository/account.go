@@ -1,13 +1,81 @@
 package repository
 
 import (
-	"github.com/jmoiron/sqlx"
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/lib/pq"
 )
 
 type AccountRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewAccountRepository(db *sqlx.DB) *AccountRepository {
+func NewAccountRepository(db DB) *AccountRepository {
 	return &AccountRepository{db: db}
 }
+
+// Get blood counts of the patient linked to the user, ordered by procedure date
+func (r *AccountRepository) GetPatientBloodCountList(userId int) ([]model.AccountBloodCount, error) {
+	bloodCountList := []model.AccountBloodCount{}
+	query := fmt.Sprintf(`SELECT cp.id AS procedure, to_char(cp.begin_date, 'YYYY-MM-DD') AS date, pc.course,
+		pbc.blood_count, pbc.value, pbc.measure_code,
+		bc.min_normal_value, bc.max_normal_value
+		FROM %s pbc
+		JOIN %s bc ON bc.id=pbc.blood_count
+		JOIN %s cp ON cp.id=pbc.procedure
+		JOIN %s pc ON pc.id=cp.patient_course
+		JOIN %s p ON p.id=pc.patient
+		WHERE p.user_id=$1 AND pbc.value IS NOT NULL
+		ORDER BY cp.begin_date, cp.id, pbc.blood_count`,
+		procedureBloodCountTable, bloodCountTable, courseProcedureTable, patientCourseTable, patientTable)
+	err := r.db.Select(&bloodCountList, query, userId)
+	return bloodCountList, err
+}
+
+// Get attending doctors of the patient linked to the user
+func (r *AccountRepository) GetPatientDoctorList(userId int) ([]model.Doctor, error) {
+	doctorList := []model.Doctor{}
+	query := fmt.Sprintf(`SELECT d.id, d.first_name, d.middle_name, d.last_name, d.qualification, d.phone, d.user_id
+		FROM %s d
+		JOIN %s dp ON dp.doctor=d.id
+		JOIN %s p ON p.id=dp.patient
+		WHERE p.user_id=$1
+		ORDER BY d.last_name, d.first_name`, doctorTable, doctorPatientTable, patientTable)
+	err := r.db.Select(&doctorList, query, userId)
+	return doctorList, err
+}
+
+// Get patients of the doctor linked to the user
+func (r *AccountRepository) GetPanelPatientList(userId int) ([]model.Patient, error) {
+	patientList := []model.Patient{}
+	query := fmt.Sprintf(`SELECT p.id, p.first_name, p.middle_name, p.last_name,
+		to_char(p.birth_date, 'YYYY-MM-DD') AS birth_date, p.sex, p.snils, p.user_id, p.phone
+		FROM %s p
+		JOIN %s dp ON dp.patient=p.id
+		JOIN %s d ON d.id=dp.doctor
+		WHERE d.user_id=$1
+		ORDER BY p.last_name, p.first_name`, patientTable, doctorPatientTable, doctorTable)
+	err := r.db.Select(&patientList, query, userId)
+	return patientList, err
+}
+
+// Get diseases of the given patients
+func (r *AccountRepository) GetPatientDiseaseListByPatients(patientIds []int) ([]model.PatientDisease, error) {
+	patientDiseaseList := []model.PatientDisease{}
+	query := fmt.Sprintf(`SELECT patient, disease, stage, diagnosis
+		FROM %s WHERE patient = ANY($1)`, patientDiseaseTable)
+	err := r.db.Select(&patientDiseaseList, query, pq.Array(patientIds))
+	return patientDiseaseList, err
+}
+
+// Get courses of the given patients that have not ended yet
+func (r *AccountRepository) GetActivePatientCourseListByPatients(patientIds []int) ([]model.PatientCourse, error) {
+	patientCourseList := []model.PatientCourse{}
+	query := fmt.Sprintf(`SELECT id, patient, disease, course, doctor,
+		to_char(begin_date, 'YYYY-MM-DD') AS begin_date, to_char(end_date, 'YYYY-MM-DD') AS end_date, diagnosis
+		FROM %s WHERE patient = ANY($1) AND (end_date IS NULL OR end_date >= CURRENT_DATE)
+		ORDER BY begin_date`, patientCourseTable)
+	err := r.db.Select(&patientCourseList, query, pq.Array(patientIds))
+	return patientCourseList, err
+}
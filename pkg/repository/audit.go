@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type AuditRepository struct {
+	db DB
+}
+
+func NewAuditRepository(db DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Append entry to the audit log
+func (r *AuditRepository) CreateAuditEntry(entry model.AuditEntry) error {
+	query := fmt.Sprintf("INSERT INTO %s (user_id, action, entity, entity_id, before, after) VALUES ($1, $2, $3, $4, $5, $6)", auditLogTable)
+	_, err := r.db.Exec(query,
+		entry.UserId,
+		entry.Action,
+		entry.Entity,
+		entry.EntityId,
+		entry.Before,
+		entry.After,
+	)
+	return err
+}
+
+// Get the latest audit log entries matching the filter
+func (r *AuditRepository) GetAuditList(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	auditList := []model.AuditEntry{}
+	where := squirrel.And{}
+	if filter.Entity != "" {
+		where = append(where, squirrel.Eq{"entity": filter.Entity})
+	}
+	if filter.EntityId != "" {
+		where = append(where, squirrel.Eq{"entity_id": filter.EntityId})
+	}
+	if filter.UserId != nil {
+		where = append(where, squirrel.Eq{"user_id": *filter.UserId})
+	}
+	if filter.From != "" {
+		where = append(where, squirrel.Expr("created_at >= ?::date", filter.From))
+	}
+	if filter.To != "" {
+		where = append(where, squirrel.Expr("created_at < ?::date + 1", filter.To))
+	}
+
+	condition, args, err := where.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s ORDER BY created_at DESC, id DESC LIMIT ?", auditLogTable, condition)
+	query, err = squirrel.Dollar.ReplacePlaceholders(query)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Select(&auditList, query, append(args, filter.Limit)...)
+	return auditList, err
+}
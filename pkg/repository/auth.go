@@ -3,56 +3,37 @@ package repository
 import (
 	"fmt"
 	"med/pkg/model"
-
-	"github.com/jmoiron/sqlx"
 )
 
 type AuthorizationRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewAuthRepository(db *sqlx.DB) *AuthorizationRepository {
+func NewAuthRepository(db DB) *AuthorizationRepository {
 	return &AuthorizationRepository{db: db}
 }
 
-func (r *AuthorizationRepository) CreateUser(user model.User) (string, error) {
-	var email string
-	query := fmt.Sprintf("INSERT INTO %s (email, password, role) VALUES ($1, $2, $3) RETURNING email", externalUserTable)
-	row := r.db.QueryRow(query, user.Email, user.Password, user.Role)
-
-	if err := row.Scan(&email); err != nil {
-		return "", err
-	}
-	return email, nil
+// Get user from database by email
+func (r *AuthorizationRepository) GetUserByEmail(email string) (model.User, error) {
+	var user model.User
+	query := fmt.Sprintf("SELECT id, email, password, role, user_type, first_name, middle_name, last_name, COALESCE(phone, '') AS phone, locked_at IS NOT NULL AS locked FROM %s WHERE email=$1", userTable)
+	err := r.db.Get(&user, query, email)
+	return user, err
 }
 
-func (r *AuthorizationRepository) GetUser(email, password string) (model.User, error) {
-	var user model.User
-	query := fmt.Sprintf("SELECT * FROM %s WHERE email=$1 AND password=$2", internalUserTable)
-	err := r.db.Get(&user, query, email, password)
+// Update password hash of the user with given email
+func (r *AuthorizationRepository) UpdatePassword(email, password string) error {
+	query := fmt.Sprintf("UPDATE %s SET password=$1 WHERE email=$2", userTable)
+	result, err := r.db.Exec(query, password, email)
 	if err != nil {
-		query := fmt.Sprintf("SELECT * FROM %s WHERE email=$1 AND password=$2", externalUserTable)
-		err = r.db.Get(&user, query, email, password)
+		return err
 	}
-	return user, err
+	return checkRowsAffected(result)
 }
 
-// func (r *AuthorizationRepository) GetUser(email, password string) (model.User, error) {
-// 	var user model.User
-
-// 	// Create a squirrel.SelectBuilder for the internal user table
-// 	internalSelect := squirrel.Select("*").From(internalUserTable).Where(squirrel.Eq{"email": email, "password": password})
-
-// 	// Create a squirrel.SelectBuilder for the external user table
-// 	externalSelect := squirrel.Select("*").From(externalUserTable).Where(squirrel.Eq{"email": email, "password": password})
-
-// 	// Combine the two queries with OR
-// 	query, args, err := squirrel.Or{internalSelect, externalSelect}.ToSql()
-// 	if err != nil {
-// 		return user, err
-// 	}
-
-// 	// Execute the query
-// 	err = r.db.Get(&user, query, args...)
-// 	return user, err
-// }
+// Save rejected login attempt
+func (r *AuthorizationRepository) CreateFailedLogin(failedLogin model.FailedLogin) error {
+	query := fmt.Sprintf("INSERT INTO %s (email, ip, reason) VALUES ($1, $2, $3)", failedLoginTable)
+	_, err := r.db.Exec(query, failedLogin.Email, failedLogin.Ip, failedLogin.Reason)
+	return err
+}
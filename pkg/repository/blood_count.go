@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 )
 
 type BloodCountRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewBloodCountRepository(db *sqlx.DB) *BloodCountRepository {
+func NewBloodCountRepository(db DB) *BloodCountRepository {
 	return &BloodCountRepository{db: db}
 }
 
@@ -30,11 +31,13 @@ func (r *BloodCountRepository) CreateBloodCount(bloodCount model.BloodCount) (mo
 	return createdBloodCount, err
 }
 
-func (r *BloodCountRepository) GetBloodCountList() ([]model.BloodCount, error) {
-	var bloodCountList []model.BloodCount
-	query := fmt.Sprintf("SELECT * FROM %s", bloodCountTable)
-	err := r.db.Select(&bloodCountList, query)
-	return bloodCountList, err
+// Get page of blood counts matching the filter
+func (r *BloodCountRepository) GetBloodCountList(filter model.BloodCountFilter, listQuery model.ListQuery) (model.Page[model.BloodCount], error) {
+	where := squirrel.And{}
+	if filter.MeasureCode != "" {
+		where = append(where, squirrel.Eq{"measure_code": filter.MeasureCode})
+	}
+	return selectPage[model.BloodCount](r.db, bloodCountTable, []string{"id"}, where, listQuery)
 }
 
 func (r *BloodCountRepository) GetBloodCountById(id string) (model.BloodCount, error) {
@@ -44,6 +47,14 @@ func (r *BloodCountRepository) GetBloodCountById(id string) (model.BloodCount, e
 	return bloodCount, err
 }
 
+// Get blood counts with given IDs from database
+func (r *BloodCountRepository) GetBloodCountListByIds(ids []string) ([]model.BloodCount, error) {
+	var bloodCountList []model.BloodCount
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id = ANY($1)", bloodCountTable)
+	err := r.db.Select(&bloodCountList, query, pq.Array(ids))
+	return bloodCountList, err
+}
+
 func (r *BloodCountRepository) UpdateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error) {
 	var updatedBloodCount model.BloodCount
 	query := fmt.Sprintf("UPDATE %s SET description=$1, min_normal_value=$2, max_normal_value=$3, min_possible_value=$4, max_possible_value=$5, measure_code=$6 WHERE id=$7 RETURNING *", bloodCountTable)
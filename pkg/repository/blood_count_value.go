@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type BloodCountValueRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewBloodCountValueRepository(db *sqlx.DB) *BloodCountValueRepository {
+func NewBloodCountValueRepository(db DB) *BloodCountValueRepository {
 	return &BloodCountValueRepository{db: db}
 }
 
@@ -27,25 +27,24 @@ func (r *BloodCountValueRepository) CreateBloodCountValue(bloodCountValue model.
 	return createdBloodCountValue, err
 }
 
-func (r *BloodCountValueRepository) GetBloodCountValueList() ([]model.BloodCountValue, error) {
-	var bloodCountList []model.BloodCountValue
+// Get all blood count values from database
+func (r *BloodCountValueRepository) GetAllBloodCountValues() ([]model.BloodCountValue, error) {
+	var bloodCountValueList []model.BloodCountValue
 	query := fmt.Sprintf("SELECT * FROM %s", bloodCountValueTable)
-	err := r.db.Select(&bloodCountList, query)
-	return bloodCountList, err
+	err := r.db.Select(&bloodCountValueList, query)
+	return bloodCountValueList, err
 }
 
-func (r *BloodCountValueRepository) GetBloodCountValueListByDisease(diseaseId string) ([]model.BloodCountValue, error) {
-	var bloodCountList []model.BloodCountValue
-	query := fmt.Sprintf("SELECT * FROM %s WHERE disease=$1", bloodCountValueTable)
-	err := r.db.Select(&bloodCountList, query, diseaseId)
-	return bloodCountList, err
-}
-
-func (r *BloodCountValueRepository) GetBloodCountValueListByBloodCount(bloodCountId string) ([]model.BloodCountValue, error) {
-	var bloodCountList []model.BloodCountValue
-	query := fmt.Sprintf("SELECT * FROM %s WHERE blood_count=$1", bloodCountValueTable)
-	err := r.db.Select(&bloodCountList, query, bloodCountId)
-	return bloodCountList, err
+// Get page of blood count values matching the filter
+func (r *BloodCountValueRepository) GetBloodCountValueList(filter model.BloodCountValueFilter, listQuery model.ListQuery) (model.Page[model.BloodCountValue], error) {
+	where := squirrel.And{}
+	if filter.Disease != "" {
+		where = append(where, squirrel.Eq{"disease": filter.Disease})
+	}
+	if filter.BloodCount != "" {
+		where = append(where, squirrel.Eq{"blood_count": filter.BloodCount})
+	}
+	return selectPage[model.BloodCountValue](r.db, bloodCountValueTable, []string{"disease", "blood_count"}, where, listQuery)
 }
 
 func (r *BloodCountValueRepository) GetBloodCountValueById(diseaseId, bloodCountId string) (model.BloodCountValue, error) {
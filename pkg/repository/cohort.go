@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type CohortRepository struct {
+	db DB
+}
+
+func NewCohortRepository(db DB) *CohortRepository {
+	return &CohortRepository{db: db}
+}
+
+// Create cohort in database and get it from database
+func (r *CohortRepository) CreateCohort(cohort model.Cohort) (model.Cohort, error) {
+	var createdCohort model.Cohort
+	query := fmt.Sprintf("INSERT INTO %s (name, description, definition, created_by) VALUES ($1, $2, $3, $4) RETURNING *", cohortTable)
+	err := r.db.Get(&createdCohort, query,
+		cohort.Name,
+		cohort.Description,
+		cohort.Definition,
+		cohort.CreatedBy,
+	)
+	return createdCohort, err
+}
+
+// Get page of cohorts matching the filter
+func (r *CohortRepository) GetCohortList(filter model.CohortFilter, listQuery model.ListQuery) (model.Page[model.Cohort], error) {
+	where := squirrel.And{}
+	if filter.CreatedBy != nil {
+		where = append(where, squirrel.Eq{"created_by": *filter.CreatedBy})
+	}
+	return selectPage[model.Cohort](r.db, cohortTable, []string{"id"}, where, listQuery)
+}
+
+// Get cohort from database by ID
+func (r *CohortRepository) GetCohortById(id int) (model.Cohort, error) {
+	var cohort model.Cohort
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1", cohortTable)
+	err := r.db.Get(&cohort, query, id)
+	return cohort, err
+}
+
+// Update cohort data in database
+func (r *CohortRepository) UpdateCohort(cohort model.Cohort) (model.Cohort, error) {
+	var updatedCohort model.Cohort
+	query := fmt.Sprintf("UPDATE %s SET name=$1, description=$2, definition=$3 WHERE id=$4 RETURNING *", cohortTable)
+	err := r.db.Get(&updatedCohort, query,
+		cohort.Name,
+		cohort.Description,
+		cohort.Definition,
+		cohort.Id,
+	)
+	return updatedCohort, err
+}
+
+// Delete cohort from database
+func (r *CohortRepository) DeleteCohort(id int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1", cohortTable)
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+// Get statistics of the patient courses matching the definition. Blood count results are grouped
+// by blood count and by the number of days between the course begin and the procedure, divided by bucketDays
+func (r *CohortRepository) GetCohortStatistics(definition model.CohortDefinition, bucketDays int, bloodCounts []string) (model.CohortStatistics, error) {
+	statistics := model.CohortStatistics{
+		Sex:         []model.CohortCount{},
+		Stages:      []model.CohortCount{},
+		BloodCounts: []model.CohortBloodCountStatistics{},
+	}
+
+	members, args, err := cohortMembers(definition).ToSql()
+	if err != nil {
+		return statistics, err
+	}
+
+	query, err := cohortQuery(members, "SELECT count(DISTINCT patient) AS patients, count(*) AS courses FROM member")
+	if err != nil {
+		return statistics, err
+	}
+	if err := r.db.Get(&statistics, query, args...); err != nil {
+		return statistics, err
+	}
+
+	query, err = cohortQuery(members, "SELECT sex AS value, count(DISTINCT patient) AS patients FROM member GROUP BY sex ORDER BY sex")
+	if err != nil {
+		return statistics, err
+	}
+	if err := r.db.Select(&statistics.Sex, query, args...); err != nil {
+		return statistics, err
+	}
+
+	query, err = cohortQuery(members, "SELECT stage AS value, count(DISTINCT patient) AS patients FROM member GROUP BY stage ORDER BY stage")
+	if err != nil {
+		return statistics, err
+	}
+	if err := r.db.Select(&statistics.Stages, query, args...); err != nil {
+		return statistics, err
+	}
+
+	where := squirrel.And{squirrel.Expr("pbc.value IS NOT NULL"), squirrel.Expr("cp.begin_date >= m.begin_date")}
+	if len(bloodCounts) > 0 {
+		where = append(where, squirrel.Eq{"pbc.blood_count": bloodCounts})
+	}
+	condition, conditionArgs, err := where.ToSql()
+	if err != nil {
+		return statistics, err
+	}
+	query, err = cohortQuery(members, fmt.Sprintf(`SELECT pbc.blood_count, (cp.begin_date - m.begin_date) / ? AS bucket,
+	count(*) AS results, count(DISTINCT m.patient) AS patients, avg(pbc.value) AS mean,
+	percentile_cont(0.05) WITHIN GROUP (ORDER BY pbc.value) AS p5,
+	percentile_cont(0.25) WITHIN GROUP (ORDER BY pbc.value) AS q1,
+	percentile_cont(0.5) WITHIN GROUP (ORDER BY pbc.value) AS median,
+	percentile_cont(0.75) WITHIN GROUP (ORDER BY pbc.value) AS q3,
+	percentile_cont(0.95) WITHIN GROUP (ORDER BY pbc.value) AS p95
+FROM member m
+JOIN %s cp ON cp.patient_course = m.id
+JOIN %s pbc ON pbc.procedure = cp.id
+WHERE %s
+GROUP BY pbc.blood_count, bucket
+ORDER BY pbc.blood_count, bucket`, courseProcedureTable, procedureBloodCountTable, condition))
+	if err != nil {
+		return statistics, err
+	}
+	bucketArgs := append(append(append([]interface{}{}, args...), bucketDays), conditionArgs...)
+	if err := r.db.Select(&statistics.BloodCounts, query, bucketArgs...); err != nil {
+		return statistics, err
+	}
+
+	for i := range statistics.BloodCounts {
+		statistics.BloodCounts[i].FromDay = statistics.BloodCounts[i].Bucket * bucketDays
+		statistics.BloodCounts[i].ToDay = (statistics.BloodCounts[i].Bucket+1)*bucketDays - 1
+	}
+	statistics.BucketDays = bucketDays
+	return statistics, nil
+}
+
+// cohortMembers selects the patient courses matching the definition with the patient characteristics
+func cohortMembers(definition model.CohortDefinition) squirrel.SelectBuilder {
+	where := squirrel.And{}
+	if len(definition.Diseases) > 0 {
+		where = append(where, squirrel.Eq{"pc.disease": definition.Diseases})
+	}
+	if len(definition.Stages) > 0 {
+		where = append(where, squirrel.Eq{"pd.stage": definition.Stages})
+	}
+	if len(definition.Diagnoses) > 0 {
+		where = append(where, squirrel.Or{squirrel.Eq{"pc.diagnosis": definition.Diagnoses}, squirrel.Eq{"pd.diagnosis": definition.Diagnoses}})
+	}
+	if len(definition.Courses) > 0 {
+		where = append(where, squirrel.Eq{"pc.course": definition.Courses})
+	}
+	if len(definition.Drugs) > 0 {
+		where = append(where, squirrel.Eq{"c.drug": definition.Drugs})
+	}
+	if definition.Sex != "" {
+		where = append(where, squirrel.Eq{"p.sex": definition.Sex})
+	}
+	if definition.MinAge != nil {
+		where = append(where, squirrel.Expr("date_part('year', age(pc.begin_date, p.birth_date)) >= ?", *definition.MinAge))
+	}
+	if definition.MaxAge != nil {
+		where = append(where, squirrel.Expr("date_part('year', age(pc.begin_date, p.birth_date)) <= ?", *definition.MaxAge))
+	}
+
+	return squirrel.Select("pc.id", "pc.patient", "pc.begin_date", "p.sex", "COALESCE(pd.stage, '') AS stage").
+		From(patientCourseTable + " pc").
+		Join(patientTable + " p ON p.id = pc.patient").
+		Join(courseTable + " c ON c.id = pc.course").
+		LeftJoin(patientDiseaseTable + " pd ON pd.patient = pc.patient AND pd.disease = pc.disease").
+		Where(where)
+}
+
+// cohortQuery runs the statement over the cohort members, the arguments of the members come first
+func cohortQuery(members, statement string) (string, error) {
+	return squirrel.Dollar.ReplacePlaceholders(fmt.Sprintf("WITH member AS (%s) %s", members, statement))
+}
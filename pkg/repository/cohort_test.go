@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCohortMembers(t *testing.T) {
+	minAge, maxAge := 40, 65
+
+	testTable := []struct {
+		name          string
+		definition    model.CohortDefinition
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "Everyone",
+			definition:    model.CohortDefinition{},
+			expectedWhere: " WHERE (1=1)",
+		},
+		{
+			name:          "Disease and stage",
+			definition:    model.CohortDefinition{Diseases: []string{"C50", "C56"}, Stages: []string{"II"}},
+			expectedWhere: " WHERE (pc.disease IN (?,?) AND pd.stage IN (?))",
+			expectedArgs:  []interface{}{"C50", "C56", "II"},
+		},
+		{
+			name:          "Diagnosis",
+			definition:    model.CohortDefinition{Diagnoses: []string{"C50.4"}},
+			expectedWhere: " WHERE ((pc.diagnosis IN (?) OR pd.diagnosis IN (?)))",
+			expectedArgs:  []interface{}{"C50.4", "C50.4"},
+		},
+		{
+			name:          "Drug, sex and age",
+			definition:    model.CohortDefinition{Drugs: []string{"L01"}, Sex: "female", MinAge: &minAge, MaxAge: &maxAge},
+			expectedWhere: " WHERE (c.drug IN (?) AND p.sex = ? AND date_part('year', age(pc.begin_date, p.birth_date)) >= ? AND date_part('year', age(pc.begin_date, p.birth_date)) <= ?)",
+			expectedArgs:  []interface{}{"L01", "female", 40, 65},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			query, args, err := cohortMembers(testCase.definition).ToSql()
+
+			assert.NoError(t, err)
+			assert.Equal(t, "SELECT pc.id, pc.patient, pc.begin_date, p.sex, COALESCE(pd.stage, '') AS stage"+
+				" FROM onco_base.patient_course pc"+
+				" JOIN onco_base.patient p ON p.id = pc.patient"+
+				" JOIN onco_base.course c ON c.id = pc.course"+
+				" LEFT JOIN onco_base.patient_disease pd ON pd.patient = pc.patient AND pd.disease = pc.disease"+
+				testCase.expectedWhere, query)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
+
+func TestGetCohortStatistics(t *testing.T) {
+	db := testDB(t)
+	// 25 patients with one result each, 101 to 124 and an outlier of 400
+	mustExec(t, db,
+		"INSERT INTO onco_base.unit_measure (id, shorthand) VALUES ('g/l', 'g/l'), ('mg', 'mg')",
+		"INSERT INTO onco_base.drug (id, name, dosage_form, active_ingredients) VALUES ('L01', 'Doxorubicin', 'solution', 'doxorubicin')",
+		"INSERT INTO onco_base.course (id, period, frequency, dose, drug, measure_code) VALUES ('AC', 21, 1, 60, 'L01', 'mg')",
+		`INSERT INTO onco_base.blood_count (id, min_normal_value, max_normal_value, min_possible_value, max_possible_value, measure_code)
+VALUES ('HGB', 120, 160, 0, 500, 'g/l')`,
+		"INSERT INTO onco_base.doctor (id, first_name, middle_name, last_name) VALUES (1, 'Sergey', 'Ivanovich', 'Smirnov')",
+		"INSERT INTO onco_base.patient (id, last_name, sex) SELECT i, 'Patient ' || i, 'ж' FROM generate_series(1, 25) i",
+		"INSERT INTO onco_base.patient_course (id, patient, course, doctor, begin_date) SELECT i, i, 'AC', 1, '2024-01-01' FROM generate_series(1, 25) i",
+		"INSERT INTO onco_base.course_procedure (id, patient_course, begin_date, doctor) SELECT i, i, '2024-01-05', 1 FROM generate_series(1, 25) i",
+		`INSERT INTO onco_base.procedure_blood_count (procedure, blood_count, value, measure_code, flag)
+SELECT i, 'HGB', CASE WHEN i = 25 THEN 400 ELSE 100 + i END, 'g/l', 'normal' FROM generate_series(1, 25) i`,
+	)
+
+	statistics, err := NewCohortRepository(db).GetCohortStatistics(model.CohortDefinition{}, 30, nil)
+
+	assert.NoError(t, err)
+	if !assert.Len(t, statistics.BloodCounts, 1) {
+		return
+	}
+	bucket := statistics.BloodCounts[0]
+	assert.Equal(t, 25, bucket.Results)
+	assert.Equal(t, 25, bucket.Patients)
+	if !assert.NotNil(t, bucket.P5) || !assert.NotNil(t, bucket.P95) {
+		return
+	}
+	assert.InDelta(t, 102.2, *bucket.P5, 1e-9)
+	assert.InDelta(t, 123.8, *bucket.P95, 1e-9)
+
+	// Neither the lowest nor the highest result, each of a single patient, is emitted
+	for _, value := range []float64{bucket.Mean, *bucket.P5, bucket.Q1, bucket.Median, bucket.Q3, *bucket.P95} {
+		assert.NotEqual(t, 101.0, value)
+		assert.NotEqual(t, 400.0, value)
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"med/pkg/model"
+)
+
+type ConsoleRepository struct {
+	db DB
+}
+
+func NewConsoleRepository(db DB) *ConsoleRepository {
+	return &ConsoleRepository{db: db}
+}
+
+// Get system counters, active courses and procedures are counted for the period between the dates
+func (r *ConsoleRepository) GetStatistics(from, to string) (model.ConsoleStatistics, error) {
+	var statistics model.ConsoleStatistics
+	query := fmt.Sprintf(`SELECT
+		(SELECT count(*) FROM %s) AS patients,
+		(SELECT count(*) FROM %s) AS doctors,
+		(SELECT count(*) FROM %s WHERE begin_date <= $2 AND (end_date IS NULL OR end_date >= $1)) AS active_courses,
+		(SELECT count(*) FROM %s WHERE begin_date BETWEEN $1 AND $2) AS procedures`,
+		patientTable, doctorTable, patientCourseTable, courseProcedureTable)
+	err := r.db.Get(&statistics, query, from, to)
+	return statistics, err
+}
+
+// Lock or unlock the user, fails with sql.ErrNoRows if there is no such user
+func (r *ConsoleRepository) SetUserLocked(id int, locked bool) error {
+	query := fmt.Sprintf("UPDATE %s SET locked_at=CASE WHEN $2 THEN COALESCE(locked_at, now()) END WHERE id=$1", userTable)
+	result, err := r.db.Exec(query, id, locked)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// Change role of the user, fails with sql.ErrNoRows if there is no such user
+func (r *ConsoleRepository) UpdateUserRole(id int, role string) error {
+	query := fmt.Sprintf("UPDATE %s SET role=$2 WHERE id=$1", userTable)
+	result, err := r.db.Exec(query, id, role)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// Get the latest failed logins
+func (r *ConsoleRepository) GetFailedLoginList(limit int) ([]model.FailedLogin, error) {
+	failedLoginList := []model.FailedLogin{}
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY created_at DESC, id DESC LIMIT $1", failedLoginTable)
+	err := r.db.Select(&failedLoginList, query, limit)
+	return failedLoginList, err
+}
+
+// checkRowsAffected returns sql.ErrNoRows if the statement did not change any row
+func checkRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type CourseRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewCourseRepository(db *sqlx.DB) *CourseRepository {
+func NewCourseRepository(db DB) *CourseRepository {
 	return &CourseRepository{db: db}
 }
 
@@ -30,12 +30,13 @@ func (r *CourseRepository) CreateCourse(course model.Course) (model.Course, erro
 	return createdCourse, err
 }
 
-// Get course list from database
-func (r *CourseRepository) GetCourseList() ([]model.Course, error) {
-	var courseList []model.Course
-	query := fmt.Sprintf("SELECT * FROM %s", courseTable)
-	err := r.db.Select(&courseList, query)
-	return courseList, err
+// Get page of courses matching the filter
+func (r *CourseRepository) GetCourseList(filter model.CourseFilter, listQuery model.ListQuery) (model.Page[model.Course], error) {
+	where := squirrel.And{}
+	if filter.Drug != "" {
+		where = append(where, squirrel.Eq{"drug": filter.Drug})
+	}
+	return selectPage[model.Course](r.db, courseTable, []string{"id"}, where, listQuery)
 }
 
 // Get course from database by ID
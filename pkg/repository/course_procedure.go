@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type CourseProcedureRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewCourseProcedureRepository(db *sqlx.DB) *CourseProcedureRepository {
+func NewCourseProcedureRepository(db DB) *CourseProcedureRepository {
 	return &CourseProcedureRepository{db: db}
 }
 
@@ -29,22 +29,40 @@ func (r *CourseProcedureRepository) CreateCourseProcedure(courseProcedure model.
 	return createdCourseProcedure, err
 }
 
-// Get course procedure list from database
-func (r *CourseProcedureRepository) GetCourseProcedureList() ([]model.CourseProcedure, error) {
-	var courseProcedureList []model.CourseProcedure
-	query := fmt.Sprintf("SELECT * FROM %s", courseProcedureTable)
-	err := r.db.Select(&courseProcedureList, query)
-	return courseProcedureList, err
+// Get page of course procedures matching the filter
+func (r *CourseProcedureRepository) GetCourseProcedureList(filter model.CourseProcedureFilter, listQuery model.ListQuery) (model.Page[model.CourseProcedure], error) {
+	where := squirrel.And{}
+	if filter.PatientCourse != nil {
+		where = append(where, squirrel.Eq{"patient_course": *filter.PatientCourse})
+	}
+	if filter.Doctor != nil {
+		where = append(where, squirrel.Eq{"doctor": *filter.Doctor})
+	}
+	if filter.BeginDateFrom != "" {
+		where = append(where, squirrel.GtOrEq{"begin_date": filter.BeginDateFrom})
+	}
+	if filter.BeginDateTo != "" {
+		where = append(where, squirrel.LtOrEq{"begin_date": filter.BeginDateTo})
+	}
+	return selectPage[model.CourseProcedure](r.db, courseProcedureTable, []string{"id"}, where, listQuery)
 }
 
 // Get course procedure from database by id
-func (r *CourseProcedureRepository) GetCourseProcedureById(id string) (model.CourseProcedure, error) {
+func (r *CourseProcedureRepository) GetCourseProcedureById(id int) (model.CourseProcedure, error) {
 	var courseProcedure model.CourseProcedure
 	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1", courseProcedureTable)
 	err := r.db.Get(&courseProcedure, query, id)
 	return courseProcedure, err
 }
 
+// Get course procedure from database by id and lock it until the end of the transaction
+func (r *CourseProcedureRepository) GetCourseProcedureByIdForUpdate(id int) (model.CourseProcedure, error) {
+	var courseProcedure model.CourseProcedure
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1 FOR UPDATE", courseProcedureTable)
+	err := r.db.Get(&courseProcedure, query, id)
+	return courseProcedure, err
+}
+
 // Update course procedure fields in database and get it from database
 func (r *CourseProcedureRepository) UpdateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
 	var updatedCourseProcedure model.CourseProcedure
@@ -57,11 +75,11 @@ func (r *CourseProcedureRepository) UpdateCourseProcedure(courseProcedure model.
 		courseProcedure.Result,
 		courseProcedure.Id,
 	)
-	return courseProcedure, err
+	return updatedCourseProcedure, err
 }
 
 // Delete course procedure from database by id
-func (r *CourseProcedureRepository) DeleteCourseProcedure(id string) error {
+func (r *CourseProcedureRepository) DeleteCourseProcedure(id int) error {
 	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1", courseProcedureTable)
 	_, err := r.db.Exec(query, id)
 	return err
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type DiagnosisRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewDiagnosisRepository(db *sqlx.DB) *DiagnosisRepository {
+func NewDiagnosisRepository(db DB) *DiagnosisRepository {
 	return &DiagnosisRepository{db: db}
 }
 
@@ -26,12 +26,9 @@ func (r *DiagnosisRepository) CreateDiagnosis(diagnosis model.Diagnosis) (model.
 	return createdDiagnosis, err
 }
 
-// Get diagnosis list from database
-func (r *DiagnosisRepository) GetDiagnosisList() ([]model.Diagnosis, error) {
-	var diagnosisList []model.Diagnosis
-	query := fmt.Sprintf("SELECT * FROM %s", diagnosisTable)
-	err := r.db.Select(&diagnosisList, query)
-	return diagnosisList, err
+// Get page of diagnoses
+func (r *DiagnosisRepository) GetDiagnosisList(listQuery model.ListQuery) (model.Page[model.Diagnosis], error) {
+	return selectPage[model.Diagnosis](r.db, diagnosisTable, []string{"id"}, squirrel.And{}, listQuery)
 }
 
 // Get diagnosis from database by ID
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type DiseaseRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewDiseaseRepository(db *sqlx.DB) *DiseaseRepository {
+func NewDiseaseRepository(db DB) *DiseaseRepository {
 	return &DiseaseRepository{db: db}
 }
 
@@ -26,12 +26,9 @@ func (r *DiseaseRepository) CreateDisease(disease model.Disease) (model.Disease,
 	return createdDisease, err
 }
 
-// Get disease list from database
-func (r *DiseaseRepository) GetDiseaseList() ([]model.Disease, error) {
-	var diseaseList []model.Disease
-	query := fmt.Sprintf("SELECT * FROM %s", diseaseTable)
-	err := r.db.Select(&diseaseList, query)
-	return diseaseList, err
+// Get page of diseases
+func (r *DiseaseRepository) GetDiseaseList(listQuery model.ListQuery) (model.Page[model.Disease], error) {
+	return selectPage[model.Disease](r.db, diseaseTable, []string{"id"}, squirrel.And{}, listQuery)
 }
 
 // Get disease from database by ID
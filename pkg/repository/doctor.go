@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type DoctorRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewDoctorRepository(db *sqlx.DB) *DoctorRepository {
+func NewDoctorRepository(db DB) *DoctorRepository {
 	return &DoctorRepository{db: db}
 }
 
@@ -30,12 +30,9 @@ func (r *DoctorRepository) CreateDoctor(doctor model.Doctor) (model.Doctor, erro
 	return createdDoctor, err
 }
 
-// Get doctor list from database
-func (r *DoctorRepository) GetDoctorList() ([]model.Doctor, error) {
-	var doctorList []model.Doctor
-	query := fmt.Sprintf("SELECT * FROM %s", doctorTable)
-	err := r.db.Select(&doctorList, query)
-	return doctorList, err
+// Get page of doctors
+func (r *DoctorRepository) GetDoctorList(listQuery model.ListQuery) (model.Page[model.Doctor], error) {
+	return selectPage[model.Doctor](r.db, doctorTable, []string{"id"}, squirrel.And{}, listQuery)
 }
 
 // Get doctor from database by ID
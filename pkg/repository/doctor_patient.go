@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type DoctorPatientRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewDoctorPatientRepository(db *sqlx.DB) *DoctorPatientRepository {
+func NewDoctorPatientRepository(db DB) *DoctorPatientRepository {
 	return &DoctorPatientRepository{db: db}
 }
 
@@ -26,12 +26,16 @@ func (r *DoctorPatientRepository) CreateDoctorPatient(doctorPatient model.Doctor
 	return doctorPatient, err
 }
 
-// Get doctor patient list from database
-func (r *DoctorPatientRepository) GetDoctorPatientList(doctor_id int) ([]model.DoctorPatient, error) {
-	var doctorPatientList []model.DoctorPatient
-	query := fmt.Sprintf("SELECT * FROM %s WHERE doctor=$1", doctorPatientTable)
-	err := r.db.Select(&doctorPatientList, query, doctor_id)
-	return doctorPatientList, err
+// Add the patient to the panel of the doctor with given user ID
+func (r *DoctorPatientRepository) CreateDoctorPatientByUser(userId, patientId int) error {
+	query := fmt.Sprintf("INSERT INTO %s (patient, doctor) SELECT $1, id FROM %s WHERE user_id=$2", doctorPatientTable, doctorTable)
+	_, err := r.db.Exec(query, patientId, userId)
+	return err
+}
+
+// Get page of patients of the doctor from database
+func (r *DoctorPatientRepository) GetDoctorPatientList(doctorId int, listQuery model.ListQuery) (model.Page[model.DoctorPatient], error) {
+	return selectPage[model.DoctorPatient](r.db, doctorPatientTable, []string{"doctor", "patient"}, squirrel.Eq{"doctor": doctorId}, listQuery)
 }
 
 // Delete doctor patient data from database
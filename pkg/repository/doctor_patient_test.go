@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDoctorPatientByUser(t *testing.T) {
+	db := testDB(t)
+	mustExec(t, db,
+		"INSERT INTO onco_base.app_user (id, email, password, role, user_type) VALUES (2, 'doctor@clinic.ru', 'hash', 'doctor', 'internal')",
+		"INSERT INTO onco_base.doctor (id, first_name, middle_name, last_name, user_id) VALUES (1, 'Sergey', 'Ivanovich', 'Smirnov', 2)",
+		"INSERT INTO onco_base.patient (id, last_name, first_name, sex) VALUES (1, 'Иванова', 'Анна', 'F')",
+	)
+	repo := NewDoctorPatientRepository(db)
+	access := NewAccessRepository(db)
+
+	assert.NoError(t, repo.CreateDoctorPatientByUser(2, 1))
+
+	isDoctorPatient, err := access.IsDoctorPatient(2, 1)
+	assert.NoError(t, err)
+	assert.True(t, isDoctorPatient)
+}
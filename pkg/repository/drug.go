@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type DrugRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewDrugRepository(db *sqlx.DB) *DrugRepository {
+func NewDrugRepository(db DB) *DrugRepository {
 	return &DrugRepository{db: db}
 }
 
@@ -32,12 +32,19 @@ func (r *DrugRepository) CreateDrug(drug model.Drug) (model.Drug, error) {
 	return createdDrug, err
 }
 
-// Get drug list from database
-func (r *DrugRepository) GetDrugList() ([]model.Drug, error) {
-	var drugList []model.Drug
-	query := fmt.Sprintf("SELECT * FROM %s", drugTable)
-	err := r.db.Select(&drugList, query)
-	return drugList, err
+// Get page of drugs matching the filter
+func (r *DrugRepository) GetDrugList(filter model.DrugFilter, listQuery model.ListQuery) (model.Page[model.Drug], error) {
+	where := squirrel.And{}
+	if filter.DosageForm != "" {
+		where = append(where, squirrel.Eq{"dosage_form": filter.DosageForm})
+	}
+	if filter.Country != "" {
+		where = append(where, squirrel.Eq{"country": filter.Country})
+	}
+	if filter.Manufacturer != "" {
+		where = append(where, squirrel.Eq{"manufacturer": filter.Manufacturer})
+	}
+	return selectPage[model.Drug](r.db, drugTable, []string{"id"}, where, listQuery)
 }
 
 // Get drug from database by ID
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ExportRepository struct {
+	db DB
+}
+
+func NewExportRepository(db DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// Create export job in database and get it from database
+func (r *ExportRepository) CreateExportJob(job model.ExportJob) (model.ExportJob, error) {
+	var createdJob model.ExportJob
+	query := fmt.Sprintf("INSERT INTO %s (format, birth_date, dates, status, requested_by) VALUES ($1, $2, $3, $4, $5) RETURNING *", exportJobTable)
+	err := r.db.Get(&createdJob, query,
+		job.Format,
+		job.BirthDate,
+		job.Dates,
+		job.Status,
+		job.RequestedBy,
+	)
+	return createdJob, err
+}
+
+// Get page of export jobs matching the filter
+func (r *ExportRepository) GetExportJobList(filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error) {
+	where := squirrel.And{}
+	if filter.Status != "" {
+		where = append(where, squirrel.Eq{"status": filter.Status})
+	}
+	if filter.RequestedBy != nil {
+		where = append(where, squirrel.Eq{"requested_by": *filter.RequestedBy})
+	}
+	return selectPage[model.ExportJob](r.db, exportJobTable, []string{"id"}, where, listQuery)
+}
+
+// Get export job from database by ID
+func (r *ExportRepository) GetExportJobById(id int) (model.ExportJob, error) {
+	var job model.ExportJob
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1", exportJobTable)
+	err := r.db.Get(&job, query, id)
+	return job, err
+}
+
+// Update status of export job in database, finished jobs get the finish time
+func (r *ExportRepository) UpdateExportJobStatus(id int, status, jobError string) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, error=$2,
+	finished_at=CASE WHEN $1 IN ('done', 'failed') THEN now() END WHERE id=$3`, exportJobTable)
+	_, err := r.db.Exec(query, status, jobError, id)
+	return err
+}
+
+// Fail the jobs left pending or running when the server stopped, nothing finishes them any more
+func (r *ExportRepository) FailUnfinishedExportJobs(jobError string) (int, error) {
+	query := fmt.Sprintf("UPDATE %s SET status=$1, error=$2, finished_at=now() WHERE status IN ($3, $4)", exportJobTable)
+	result, err := r.db.Exec(query, model.ExportFailed, jobError, model.ExportPending, model.ExportRunning)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// Run fn on the exported tables in a single read-only snapshot
+func (r *ExportRepository) InExportSnapshot(fn func(snapshot ExportSnapshot) error) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY"); err != nil {
+		return err
+	}
+	if err := fn(&ExportRepository{db: tx}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Get the patients with the date of their first course and the patient diseases, dates are in YYYY-MM-DD format
+func (r *ExportRepository) GetExportDataset() (model.ExportDataset, error) {
+	dataset := model.ExportDataset{}
+
+	query := fmt.Sprintf(`SELECT p.id, COALESCE(to_char(p.birth_date, 'YYYY-MM-DD'), '') AS birth_date, p.sex,
+	COALESCE((SELECT to_char(min(pc.begin_date), 'YYYY-MM-DD') FROM %s pc WHERE pc.patient = p.id), '') AS first_course_date
+FROM %s p ORDER BY p.id`, patientCourseTable, patientTable)
+	if err := r.db.Select(&dataset.Patients, query); err != nil {
+		return dataset, err
+	}
+
+	query = fmt.Sprintf("SELECT patient, disease, stage, diagnosis FROM %s ORDER BY patient, disease", patientDiseaseTable)
+	err := r.db.Select(&dataset.PatientDiseases, query)
+	return dataset, err
+}
+
+// Pass the patient courses to fn one by one
+func (r *ExportRepository) EachExportPatientCourse(fn func(patientCourse model.ExportPatientCourse) error) error {
+	query := fmt.Sprintf(`SELECT id, patient, COALESCE(disease, '') AS disease, course, to_char(begin_date, 'YYYY-MM-DD') AS begin_date,
+	COALESCE(to_char(end_date, 'YYYY-MM-DD'), '') AS end_date, COALESCE(diagnosis, '') AS diagnosis
+FROM %s ORDER BY id`, patientCourseTable)
+	return eachRow(r.db, fn, query)
+}
+
+// Pass the course procedures with the patient of their course to fn one by one
+func (r *ExportRepository) EachExportCourseProcedure(fn func(procedure model.ExportCourseProcedure) error) error {
+	query := fmt.Sprintf(`SELECT cp.id, pc.patient, cp.patient_course, to_char(cp.begin_date, 'YYYY-MM-DD') AS begin_date
+FROM %s cp
+JOIN %s pc ON pc.id = cp.patient_course
+ORDER BY cp.id`, courseProcedureTable, patientCourseTable)
+	return eachRow(r.db, fn, query)
+}
+
+// Pass the blood count results with the patient of their procedure to fn one by one
+func (r *ExportRepository) EachExportProcedureBloodCount(fn func(result model.ExportProcedureBloodCount) error) error {
+	query := fmt.Sprintf(`SELECT pc.patient, pbc.procedure, pbc.blood_count, pbc.value, pbc.measure_code, pbc.flag
+FROM %s pbc
+JOIN %s cp ON cp.id = pbc.procedure
+JOIN %s pc ON pc.id = cp.patient_course
+ORDER BY pbc.procedure, pbc.blood_count`, procedureBloodCountTable, courseProcedureTable, patientCourseTable)
+	return eachRow(r.db, fn, query)
+}
+
+// eachRow scans the rows of the query one by one and passes them to fn, so the table is never held in memory
+func eachRow[T any](db DB, fn func(row T) error, query string, args ...interface{}) error {
+	rows, err := db.Queryx(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+type LabCodeMappingRepository struct {
+	db DB
+}
+
+func NewLabCodeMappingRepository(db DB) *LabCodeMappingRepository {
+	return &LabCodeMappingRepository{db: db}
+}
+
+// Create lab code mapping in database and get it from database
+func (r *LabCodeMappingRepository) CreateLabCodeMapping(labCodeMapping model.LabCodeMapping) (model.LabCodeMapping, error) {
+	var createdLabCodeMapping model.LabCodeMapping
+	query := fmt.Sprintf("INSERT INTO %s (system, code, blood_count, measure_code) VALUES ($1, $2, $3, $4) RETURNING *", labCodeMappingTable)
+	err := r.db.Get(&createdLabCodeMapping, query,
+		labCodeMapping.System,
+		labCodeMapping.Code,
+		labCodeMapping.BloodCount,
+		labCodeMapping.MeasureCode,
+	)
+	return createdLabCodeMapping, err
+}
+
+// Get page of lab code mappings
+func (r *LabCodeMappingRepository) GetLabCodeMappingList(listQuery model.ListQuery) (model.Page[model.LabCodeMapping], error) {
+	return selectPage[model.LabCodeMapping](r.db, labCodeMappingTable, []string{"system", "code"}, squirrel.And{}, listQuery)
+}
+
+// Get lab code mappings of the codes in any coding system
+func (r *LabCodeMappingRepository) GetLabCodeMappingListByCodes(codes []string) ([]model.LabCodeMapping, error) {
+	var labCodeMappingList []model.LabCodeMapping
+	query := fmt.Sprintf("SELECT * FROM %s WHERE code=ANY($1)", labCodeMappingTable)
+	err := r.db.Select(&labCodeMappingList, query, pq.Array(codes))
+	return labCodeMappingList, err
+}
+
+// Delete lab code mapping from database
+func (r *LabCodeMappingRepository) DeleteLabCodeMapping(system, code string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE system=$1 AND code=$2", labCodeMappingTable)
+	_, err := r.db.Exec(query, system, code)
+	return err
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+)
+
+type LabMessageRepository struct {
+	db DB
+}
+
+func NewLabMessageRepository(db DB) *LabMessageRepository {
+	return &LabMessageRepository{db: db}
+}
+
+// Get received lab message from database by its sender and control ID
+func (r *LabMessageRepository) GetLabMessage(sendingApplication, sendingFacility, controlId string) (model.LabMessage, error) {
+	var labMessage model.LabMessage
+	query := fmt.Sprintf("SELECT * FROM %s WHERE sending_application=$1 AND sending_facility=$2 AND control_id=$3", labMessageTable)
+	err := r.db.Get(&labMessage, query, sendingApplication, sendingFacility, controlId)
+	return labMessage, err
+}
+
+// Create the lab message with its course procedures and their results in a single transaction and get the created procedures.
+// A message that was already received violates the primary key, so nothing is stored twice.
+func (r *LabMessageRepository) CreateLabMessage(labMessage model.LabMessage, procedures []model.LabProcedure) ([]model.LabProcedure, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`INSERT INTO %s (sending_application, sending_facility, control_id, received_by, procedures, results)
+		VALUES ($1, $2, $3, $4, $5, $6)`, labMessageTable)
+	_, err = tx.Exec(query,
+		labMessage.SendingApplication,
+		labMessage.SendingFacility,
+		labMessage.ControlId,
+		labMessage.ReceivedBy,
+		labMessage.Procedures,
+		labMessage.Results,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	procedureQuery := fmt.Sprintf("INSERT INTO %s (patient_course, doctor, begin_date, period, result) VALUES ($1, $2, $3, $4, $5) RETURNING *", courseProcedureTable)
+	resultQuery := fmt.Sprintf("INSERT INTO %s (value, measure_code, procedure, blood_count, flag) VALUES ($1, $2, $3, $4, $5) RETURNING *", procedureBloodCountTable)
+	createdProcedures := make([]model.LabProcedure, 0, len(procedures))
+	for _, procedure := range procedures {
+		var created model.LabProcedure
+		err := tx.Get(&created.CourseProcedure, procedureQuery,
+			procedure.CourseProcedure.PatientCourse,
+			procedure.CourseProcedure.Doctor,
+			procedure.CourseProcedure.BeginDate,
+			procedure.CourseProcedure.Period,
+			procedure.CourseProcedure.Result,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range procedure.Results {
+			var createdResult model.ProcedureBloodCount
+			err := tx.Get(&createdResult, resultQuery,
+				result.Value,
+				result.MeasureCode,
+				created.CourseProcedure.Id,
+				result.BloodCount,
+				result.Flag,
+			)
+			if err != nil {
+				return nil, err
+			}
+			created.Results = append(created.Results, createdResult)
+		}
+		createdProcedures = append(createdProcedures, created)
+	}
+
+	return createdProcedures, tx.Commit()
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"reflect"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// ErrInvalidListQuery is returned for an unknown sort field or a malformed cursor.
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// orderColumn is a column of the ORDER BY clause of a list and the index of the model field read from it
+type orderColumn struct {
+	column     string
+	field      int
+	descending bool
+}
+
+// selectPage reads a page of the rows of a table or subquery matching the condition.
+// Rows are ordered by the sort fields of the list query and then by the key columns, which identify a row.
+// The cursor holds the values of these columns in the last row of the previous page, and a page starts right after that row,
+// so it takes an index scan instead of skipping the previous pages, and rows inserted meanwhile are neither skipped nor repeated.
+// The rows matching the condition are counted only if the list query asks for the total.
+func selectPage[T any](db DB, from string, key []string, where squirrel.Sqlizer, listQuery model.ListQuery) (model.Page[T], error) {
+	page := model.Page[T]{Items: []T{}}
+
+	order, err := orderBy[T](listQuery.Sort, key)
+	if err != nil {
+		return page, err
+	}
+	after, err := decodeCursor(listQuery.Cursor, len(order))
+	if err != nil {
+		return page, err
+	}
+
+	limit := listQuery.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	if listQuery.Total {
+		condition, args, err := where.ToSql()
+		if err != nil {
+			return page, err
+		}
+		query, err := squirrel.Dollar.ReplacePlaceholders(fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", from, condition))
+		if err != nil {
+			return page, err
+		}
+		var total int
+		if err := db.Get(&total, query, args...); err != nil {
+			return page, err
+		}
+		page.Total = &total
+	}
+
+	pageWhere := squirrel.And{where}
+	if after != nil {
+		pageWhere = append(pageWhere, afterRow(order, len(key), after))
+	}
+	condition, args, err := pageWhere.ToSql()
+	if err != nil {
+		return page, err
+	}
+
+	// One more row than the page holds tells whether there is a next page
+	query, err := squirrel.Dollar.ReplacePlaceholders(fmt.Sprintf("SELECT * FROM %s WHERE %s ORDER BY %s LIMIT ?", from, condition, orderClause(order)))
+	if err != nil {
+		return page, err
+	}
+	if err := db.Select(&page.Items, query, append(args, limit+1)...); err != nil {
+		return page, err
+	}
+
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		if page.NextCursor, err = encodeCursor(rowValues(page.Items[limit-1], order)); err != nil {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+// orderBy resolves the sort fields, which are the json names of the model fields, and the key columns to the order columns
+func orderBy[T any](sort string, key []string) ([]orderColumn, error) {
+	columns := map[string]int{}
+	fields := map[string]int{}
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		column := field.Tag.Get("db")
+		if column == "" || column == "-" {
+			continue
+		}
+		columns[column] = i
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+
+	var order []orderColumn
+	if sort != "" {
+		for _, name := range strings.Split(sort, ",") {
+			name = strings.TrimSpace(name)
+			descending := strings.HasPrefix(name, "-")
+			name = strings.TrimPrefix(name, "-")
+
+			field, ok := fields[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown sort field %q", ErrInvalidListQuery, name)
+			}
+			order = append(order, orderColumn{column: modelType.Field(field).Tag.Get("db"), field: field, descending: descending})
+		}
+	}
+	for _, column := range key {
+		field, ok := columns[column]
+		if !ok {
+			return nil, fmt.Errorf("key column %q has no field in %s", column, modelType)
+		}
+		order = append(order, orderColumn{column: column, field: field})
+	}
+
+	return order, nil
+}
+
+func orderClause(order []orderColumn) string {
+	clause := make([]string, len(order))
+	for i, column := range order {
+		clause[i] = column.column
+		if column.descending {
+			clause[i] += " DESC"
+		}
+	}
+	return strings.Join(clause, ", ")
+}
+
+// afterRow is the condition selecting the rows that come after the row with the values of the order columns.
+// Sort columns may be NULL, which comes after every value in ascending order and before it in descending order, as in PostgreSQL.
+// The key columns, which come last in ascending order and are never NULL, are compared as a row value.
+func afterRow(order []orderColumn, keyLength int, values []interface{}) squirrel.Sqlizer {
+	sortLength := len(order) - keyLength
+	condition := squirrel.Or{}
+	equal := squirrel.And{}
+
+	for i, column := range order[:sortLength] {
+		if after := afterValue(column, values[i]); after != nil {
+			condition = append(condition, append(append(squirrel.And{}, equal...), after))
+		}
+		equal = append(equal, squirrel.Eq{column.column: values[i]})
+	}
+
+	keyColumns := make([]string, keyLength)
+	for i, column := range order[sortLength:] {
+		keyColumns[i] = column.column
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", keyLength), ", ")
+	keyAfter := squirrel.Expr(fmt.Sprintf("(%s) > (%s)", strings.Join(keyColumns, ", "), placeholders), values[sortLength:]...)
+
+	return append(condition, append(equal, keyAfter))
+}
+
+// afterValue is the condition selecting the values of the column that come after the value, nil if none does
+func afterValue(column orderColumn, value interface{}) squirrel.Sqlizer {
+	switch {
+	case column.descending && value == nil:
+		return squirrel.NotEq{column.column: nil}
+	case column.descending:
+		return squirrel.Lt{column.column: value}
+	case value == nil:
+		return nil
+	default:
+		return squirrel.Or{squirrel.Gt{column.column: value}, squirrel.Eq{column.column: nil}}
+	}
+}
+
+// rowValues returns the values of the order columns in the row as they are passed to the database
+func rowValues(row interface{}, order []orderColumn) []interface{} {
+	rowValue := reflect.ValueOf(row)
+	values := make([]interface{}, len(order))
+	for i, column := range order {
+		field := rowValue.Field(column.field)
+		if valuer, ok := field.Interface().(driver.Valuer); ok {
+			values[i], _ = valuer.Value()
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		values[i] = field.Interface()
+	}
+	return values
+}
+
+// Cursors are opaque to clients, they hold the values of the order columns in the last row of the previous page
+func encodeCursor(values []interface{}) (string, error) {
+	content, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// decodeCursor returns the values of the order columns held by the cursor, nil for the first page.
+// Numbers are kept as json.Number, which is passed to the database as text, so no precision is lost.
+func decodeCursor(cursor string, length int) ([]interface{}, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var values []interface{}
+	if err := decoder.Decode(&values); err != nil || len(values) != length {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+	}
+	for _, value := range values {
+		switch value.(type) {
+		case nil, string, json.Number, bool:
+		default:
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+		}
+	}
+	return values, nil
+}
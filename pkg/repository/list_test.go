@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBy(t *testing.T) {
+	testTable := []struct {
+		name          string
+		sort          string
+		key           []string
+		expectedOrder string
+		expectedError string
+	}{
+		{
+			name:          "Key only",
+			key:           []string{"id"},
+			expectedOrder: "id",
+		},
+		{
+			name:          "Sort fields",
+			sort:          "-birth-date, last-name",
+			key:           []string{"id"},
+			expectedOrder: "birth_date DESC, last_name, id",
+		},
+		{
+			name:          "Unknown field",
+			sort:          "weight",
+			key:           []string{"id"},
+			expectedError: `invalid list query: unknown sort field "weight"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			order, err := orderBy[model.Patient](testCase.sort, testCase.key)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedOrder, orderClause(order))
+		})
+	}
+}
+
+func TestAfterRow(t *testing.T) {
+	testTable := []struct {
+		name              string
+		sort              string
+		values            []interface{}
+		expectedCondition string
+		expectedArgs      []interface{}
+	}{
+		{
+			name:              "Key only",
+			values:            []interface{}{json.Number("7")},
+			expectedCondition: "(((id) > (?)))",
+			expectedArgs:      []interface{}{json.Number("7")},
+		},
+		{
+			name:   "Sort fields",
+			sort:   "-birth-date,last-name",
+			values: []interface{}{"1980-05-01", "Ivanova", json.Number("7")},
+			expectedCondition: "((birth_date < ?) OR (birth_date = ? AND (last_name > ? OR last_name IS NULL)) OR " +
+				"(birth_date = ? AND last_name = ? AND (id) > (?)))",
+			expectedArgs: []interface{}{"1980-05-01", "1980-05-01", "Ivanova", "1980-05-01", "Ivanova", json.Number("7")},
+		},
+		{
+			name:              "Null in descending order",
+			sort:              "-birth-date",
+			values:            []interface{}{nil, json.Number("7")},
+			expectedCondition: "((birth_date IS NOT NULL) OR (birth_date IS NULL AND (id) > (?)))",
+			expectedArgs:      []interface{}{json.Number("7")},
+		},
+		{
+			name:              "Null in ascending order",
+			sort:              "birth-date",
+			values:            []interface{}{nil, json.Number("7")},
+			expectedCondition: "((birth_date IS NULL AND (id) > (?)))",
+			expectedArgs:      []interface{}{json.Number("7")},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			order, err := orderBy[model.Patient](testCase.sort, []string{"id"})
+			assert.NoError(t, err)
+
+			condition, args, err := afterRow(order, 1, testCase.values).ToSql()
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCondition, condition)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	birthDate := "1980-05-01"
+	order, err := orderBy[model.Patient]("-birth-date,user-id,last-name", []string{"id"})
+	assert.NoError(t, err)
+
+	values := rowValues(model.Patient{Id: 7, LastName: "Ivanova", BirthDate: &birthDate, UserId: sql.NullInt64{Int64: 3, Valid: true}}, order)
+	assert.Equal(t, []interface{}{"1980-05-01", int64(3), "Ivanova", 7}, values)
+	values = rowValues(model.Patient{Id: 8, LastName: "Petrova"}, order)
+	assert.Equal(t, []interface{}{nil, nil, "Petrova", 8}, values)
+
+	cursor, err := encodeCursor(values)
+	assert.NoError(t, err)
+	decoded, err := decodeCursor(cursor, len(order))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, nil, "Petrova", json.Number("8")}, decoded)
+
+	decoded, err = decodeCursor("", len(order))
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = decodeCursor("not a cursor", 1)
+	assert.ErrorIs(t, err, ErrInvalidListQuery)
+
+	// A cursor of a list in another order
+	_, err = decodeCursor(cursor, 1)
+	assert.ErrorIs(t, err, ErrInvalidListQuery)
+
+	cursor, err = encodeCursor([]interface{}{map[string]int{"id": 1}})
+	assert.NoError(t, err)
+	_, err = decodeCursor(cursor, 1)
+	assert.ErrorIs(t, err, ErrInvalidListQuery)
+}
@@ -3,16 +3,44 @@ package repository
 import (
 	"fmt"
 	"med/pkg/model"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/Masterminds/squirrel"
-	"github.com/jmoiron/sqlx"
 )
 
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+
+	// searchNameThreshold is the least word similarity of a matching name,
+	// a name with one typo in a six-letter word still matches
+	searchNameThreshold = 0.4
+
+	// searchName is the indexed normalized full name, see migration 0003_patient_search.
+	// Its lower() folds Cyrillic case only if the LC_CTYPE of the database knows Cyrillic, like ru_RU.UTF-8 or C.UTF-8;
+	// with the C locale capitalized names are not matched by the query, which is folded in Go
+	searchName = "onco_base.patient_search_name(last_name, first_name, middle_name)"
+
+	// snilsLength is the number of digits of a SNILS, which is matched as a whole
+	snilsLength = 11
+	// minPhoneSuffix is the least number of digits matched against the end of a phone,
+	// fewer digits match too many phones by chance
+	minPhoneSuffix = 4
+
+	searchSNILS = `regexp_replace(snils, '\D', '', 'g')`
+	searchPhone = `regexp_replace(phone, '\D', '', 'g')`
+)
+
+// Birth date formats accepted in a search query
+var searchDateLayouts = []string{"2006-01-02", "02.01.2006"}
+
 type PatientRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewPatientRepository(db *sqlx.DB) *PatientRepository {
+func NewPatientRepository(db DB) *PatientRepository {
 	return &PatientRepository{db: db}
 }
 
@@ -32,12 +60,183 @@ func (r *PatientRepository) CreatePatient(patient model.Patient) (model.Patient,
 	return createdPatient, err
 }
 
-// Get patient list from database
-func (r *PatientRepository) GetPatientList() ([]model.Patient, error) {
-	var patientList []model.Patient
-	query := fmt.Sprintf("SELECT * FROM %s", patientTable)
-	err := r.db.Select(&patientList, query)
-	return patientList, err
+// Get page of patients matching the filter, patientIds limits the list to the patients unless nil
+func (r *PatientRepository) GetPatientList(filter model.PatientFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.Patient], error) {
+	where := squirrel.And{}
+	if patientIds != nil {
+		where = append(where, squirrel.Eq{"id": patientIds})
+	}
+	if filter.Name != "" {
+		pattern := filter.Name + "%"
+		where = append(where, squirrel.Or{
+			squirrel.ILike{"first_name": pattern},
+			squirrel.ILike{"middle_name": pattern},
+			squirrel.ILike{"last_name": pattern},
+		})
+	}
+	if filter.SNILS != "" {
+		where = append(where, squirrel.Eq{"snils": filter.SNILS})
+	}
+	if filter.Sex != "" {
+		where = append(where, squirrel.Eq{"sex": filter.Sex})
+	}
+	if filter.BirthDateFrom != "" {
+		where = append(where, squirrel.GtOrEq{"birth_date": filter.BirthDateFrom})
+	}
+	if filter.BirthDateTo != "" {
+		where = append(where, squirrel.LtOrEq{"birth_date": filter.BirthDateTo})
+	}
+	return selectPage[model.Patient](r.db, patientTable, []string{"id"}, where, listQuery)
+}
+
+// Search patients by name, SNILS, phone and birth date, best matches first. Digits match a whole SNILS
+// or at least the last four digits of a phone. patientIds limits the search to the patients unless nil
+func (r *PatientRepository) SearchPatientList(search model.PatientSearch, patientIds []int) ([]model.PatientSearchResult, error) {
+	resultList := []model.PatientSearchResult{}
+
+	terms := parsePatientSearch(search.Query)
+	if terms.empty() {
+		return resultList, nil
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	selectPatients, ok := patientSearchQuery(terms, patientIds)
+	if !ok {
+		return resultList, nil
+	}
+	query, args, err := selectPatients.Limit(uint64(limit)).PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return resultList, err
+	}
+
+	tx, err := begin(r.db)
+	if err != nil {
+		return resultList, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)", fmt.Sprint(searchNameThreshold)); err != nil {
+		return resultList, err
+	}
+	if err := tx.Select(&resultList, query, args...); err != nil {
+		return resultList, err
+	}
+	return resultList, tx.Commit()
+}
+
+// patientSearchQuery selects the patients matching the search terms with their rank. It is false
+// if the digits are too few to match a phone and not a SNILS, so no patient can match
+func patientSearchQuery(terms patientSearchTerms, patientIds []int) (squirrel.SelectBuilder, bool) {
+	var ranks []interface{}
+	where := squirrel.And{}
+	if patientIds != nil {
+		where = append(where, squirrel.Eq{"id": patientIds})
+	}
+	if terms.name != "" {
+		ranks = append(ranks, squirrel.Expr("word_similarity(?, "+searchName+")", terms.name))
+		// <% uses pg_trgm.word_similarity_threshold and the trigram index
+		where = append(where, squirrel.Expr("? <% "+searchName, terms.name))
+	}
+	if terms.digits != "" {
+		// A whole SNILS ranks above the end of a phone. Phones are compared without the country code,
+		// the last ten digits are the subscriber number
+		var matches squirrel.Or
+		rank, rankArgs := "CASE", []interface{}{}
+		if len(terms.digits) == snilsLength {
+			matches = append(matches, squirrel.Expr(searchSNILS+" = ?", terms.digits))
+			rank += " WHEN " + searchSNILS + " = ? THEN 1"
+			rankArgs = append(rankArgs, terms.digits)
+		}
+		if len(terms.digits) >= minPhoneSuffix {
+			phone := terms.digits
+			if len(phone) > 10 {
+				phone = phone[len(phone)-10:]
+			}
+			matches = append(matches, squirrel.Expr(searchPhone+" LIKE '%' || ?", phone))
+			rank += " WHEN " + searchPhone + " LIKE '%' || ? THEN 0.5"
+			rankArgs = append(rankArgs, phone)
+		}
+		if len(matches) == 0 {
+			return squirrel.SelectBuilder{}, false
+		}
+		ranks = append(ranks, squirrel.Expr(rank+" ELSE 0 END", rankArgs...))
+		where = append(where, matches)
+	}
+	if terms.birthDate != "" {
+		where = append(where, squirrel.Eq{"birth_date": terms.birthDate})
+	}
+
+	rank := squirrel.Expr("1")
+	if len(ranks) > 0 {
+		rank = squirrel.Expr(strings.TrimSuffix(strings.Repeat("? + ", len(ranks)), " + "), ranks...)
+	}
+
+	return squirrel.Select("*").
+		Column(squirrel.Alias(rank, "rank")).
+		From(patientTable).
+		Where(where).
+		OrderBy("rank DESC", "id"), true
+}
+
+// patientSearchTerms is a search query split into the parts matched against different columns
+type patientSearchTerms struct {
+	name      string
+	digits    string
+	birthDate string
+}
+
+func (t patientSearchTerms) empty() bool {
+	return t.name == "" && t.digits == "" && t.birthDate == ""
+}
+
+// parsePatientSearch splits the query into words of the name, digits of SNILS or phone and a birth date.
+// Numbers may be split into several words, like "+7 (912) 345-67-89" or "123-456-789 00".
+func parsePatientSearch(query string) patientSearchTerms {
+	var (
+		terms  patientSearchTerms
+		name   []string
+		digits strings.Builder
+	)
+
+	for _, word := range strings.Fields(query) {
+		if date, ok := parseSearchDate(word); ok {
+			terms.birthDate = date
+			continue
+		}
+
+		number := strings.Map(func(r rune) rune {
+			if strings.ContainsRune("+-()", r) {
+				return -1
+			}
+			return r
+		}, word)
+		if number != "" && strings.IndexFunc(number, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+			digits.WriteString(number)
+			continue
+		}
+
+		name = append(name, strings.ReplaceAll(strings.ToLower(word), "ё", "е"))
+	}
+
+	terms.name = strings.Join(name, " ")
+	terms.digits = digits.String()
+	return terms
+}
+
+func parseSearchDate(word string) (string, bool) {
+	for _, layout := range searchDateLayouts {
+		if date, err := time.Parse(layout, word); err == nil {
+			return date.Format("2006-01-02"), true
+		}
+	}
+	return "", false
 }
 
 // Get patient from database by ID
@@ -48,6 +247,22 @@ func (r *PatientRepository) GetPatientById(id int) (model.Patient, error) {
 	return patient, err
 }
 
+// Get patient from database by id and lock it until the end of the transaction
+func (r *PatientRepository) GetPatientByIdForUpdate(id int) (model.Patient, error) {
+	var patient model.Patient
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1 FOR UPDATE", patientTable)
+	err := r.db.Get(&patient, query, id)
+	return patient, err
+}
+
+// Get patient from database by SNILS, compared by its digits so that the stored and the given formatting may differ
+func (r *PatientRepository) GetPatientBySNILS(snils string) (model.Patient, error) {
+	var patient model.Patient
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE regexp_replace(snils, '\D', '', 'g')=$1`, patientTable)
+	err := r.db.Get(&patient, query, snils)
+	return patient, err
+}
+
 // Update patient data in database
 func (r *PatientRepository) UpdatePatient(patient model.Patient) (model.Patient, error) {
 	var updatedPatient model.Patient
@@ -62,7 +277,8 @@ func (r *PatientRepository) UpdatePatient(patient model.Patient) (model.Patient,
 		Set("snils", patient.SNILS).
 		Set("phone", patient.Phone).
 		Where(squirrel.Eq{"id": patient.Id}).
-		Suffix("RETURNING *")
+		Suffix("RETURNING *").
+		PlaceholderFormat(squirrel.Dollar)
 
 	// Get the SQL query and arguments from the update builder
 	sql, args, err := updateBuilder.ToSql()
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type PatientCourseRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewPatientCourseRepository(db *sqlx.DB) *PatientCourseRepository {
+func NewPatientCourseRepository(db DB) *PatientCourseRepository {
 	return &PatientCourseRepository{db: db}
 }
 
@@ -31,11 +31,38 @@ func (r *PatientCourseRepository) CreatePatientCourse(patientCourse model.Patien
 	return createdPatientCourse, err
 }
 
-// Get patient course list from database
-func (r *PatientCourseRepository) GetPatientCourseList() ([]model.PatientCourse, error) {
+// Get page of patient courses matching the filter, patientIds limits the list to the patients unless nil
+func (r *PatientCourseRepository) GetPatientCourseList(filter model.PatientCourseFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.PatientCourse], error) {
+	where := squirrel.And{}
+	if patientIds != nil {
+		where = append(where, squirrel.Eq{"patient": patientIds})
+	}
+	if filter.Patient != nil {
+		where = append(where, squirrel.Eq{"patient": *filter.Patient})
+	}
+	if filter.Disease != "" {
+		where = append(where, squirrel.Eq{"disease": filter.Disease})
+	}
+	if filter.Course != "" {
+		where = append(where, squirrel.Eq{"course": filter.Course})
+	}
+	if filter.Doctor != nil {
+		where = append(where, squirrel.Eq{"doctor": *filter.Doctor})
+	}
+	if filter.BeginDateFrom != "" {
+		where = append(where, squirrel.GtOrEq{"begin_date": filter.BeginDateFrom})
+	}
+	if filter.BeginDateTo != "" {
+		where = append(where, squirrel.LtOrEq{"begin_date": filter.BeginDateTo})
+	}
+	return selectPage[model.PatientCourse](r.db, patientCourseTable, []string{"id"}, where, listQuery)
+}
+
+// Get courses of the patient that are under way on the date, a course without an end date has not ended
+func (r *PatientCourseRepository) GetPatientCourseListByDate(patientId int, date string) ([]model.PatientCourse, error) {
 	var patientCourseList []model.PatientCourse
-	query := fmt.Sprintf("SELECT * FROM %s ", patientCourseTable)
-	err := r.db.Select(&patientCourseList, query)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE patient=$1 AND begin_date<=$2 AND (end_date IS NULL OR end_date>=$2) ORDER BY id", patientCourseTable)
+	err := r.db.Select(&patientCourseList, query, patientId, date)
 	return patientCourseList, err
 }
 
@@ -47,6 +74,14 @@ func (r *PatientCourseRepository) GetPatientCourseById(patientCourseId int) (mod
 	return patientCourse, err
 }
 
+// Get patient course from database by id and lock it until the end of the transaction
+func (r *PatientCourseRepository) GetPatientCourseByIdForUpdate(patientCourseId int) (model.PatientCourse, error) {
+	var patientCourse model.PatientCourse
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id=$1 FOR UPDATE", patientCourseTable)
+	err := r.db.Get(&patientCourse, query, patientCourseId)
+	return patientCourse, err
+}
+
 // Update patient course data in database
 func (r *PatientCourseRepository) UpdatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error) {
 	var updatedPatientCourse model.PatientCourse
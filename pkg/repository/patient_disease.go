@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type PatientDiseaseRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewPatientDiseaseRepository(db *sqlx.DB) *PatientDiseaseRepository {
+func NewPatientDiseaseRepository(db DB) *PatientDiseaseRepository {
 	return &PatientDiseaseRepository{db: db}
 }
 
@@ -28,38 +28,43 @@ func (r *PatientDiseaseRepository) CreatePatientDisease(patientDisease model.Pat
 	return createdPatientDisease, err
 }
 
-// Get patient list from database
-func (r *PatientDiseaseRepository) GetPatientDiseaseList() ([]model.PatientDisease, error) {
-	var patientDiseaseList []model.PatientDisease
-	query := fmt.Sprintf("SELECT * FROM %s", patientDiseaseTable)
-	err := r.db.Select(&patientDiseaseList, query)
-	return patientDiseaseList, err
-}
-
-// Get patient list from database
-func (r *PatientDiseaseRepository) GetPatientDiseaseListByPatient(patientId int) ([]model.PatientDisease, error) {
-	var patientDiseaseList []model.PatientDisease
-	query := fmt.Sprintf("SELECT * FROM %s WHERE patient=$1", patientDiseaseTable)
-	err := r.db.Select(&patientDiseaseList, query, patientId)
-	return patientDiseaseList, err
-}
-
-// Get patient list from database
-func (r *PatientDiseaseRepository) GetPatientDiseaseListByDisease(diseaseId int) ([]model.PatientDisease, error) {
-	var patientDiseaseList []model.PatientDisease
-	query := fmt.Sprintf("SELECT * FROM %s WHERE disease=$1", patientDiseaseTable)
-	err := r.db.Select(&patientDiseaseList, query, diseaseId)
-	return patientDiseaseList, err
+// Get page of patient diseases matching the filter, patientIds limits the list to the patients unless nil
+func (r *PatientDiseaseRepository) GetPatientDiseaseList(filter model.PatientDiseaseFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.PatientDisease], error) {
+	where := squirrel.And{}
+	if patientIds != nil {
+		where = append(where, squirrel.Eq{"patient": patientIds})
+	}
+	if filter.Patient != nil {
+		where = append(where, squirrel.Eq{"patient": *filter.Patient})
+	}
+	if filter.Disease != "" {
+		where = append(where, squirrel.Eq{"disease": filter.Disease})
+	}
+	if filter.Stage != "" {
+		where = append(where, squirrel.Eq{"stage": filter.Stage})
+	}
+	if filter.Diagnosis != "" {
+		where = append(where, squirrel.Eq{"diagnosis": filter.Diagnosis})
+	}
+	return selectPage[model.PatientDisease](r.db, patientDiseaseTable, []string{"patient", "disease"}, where, listQuery)
 }
 
 // Get patient from database by ID
-func (r *PatientDiseaseRepository) GetPatientDiseaseById(patientId, diseaseId int) (model.PatientDisease, error) {
+func (r *PatientDiseaseRepository) GetPatientDiseaseById(patientId int, diseaseId string) (model.PatientDisease, error) {
 	var patientDisease model.PatientDisease
 	query := fmt.Sprintf("SELECT * FROM %s WHERE patient=$1 AND disease=$2", patientDiseaseTable)
 	err := r.db.Get(&patientDisease, query, patientId, diseaseId)
 	return patientDisease, err
 }
 
+// Get disease of the patient from database and lock it until the end of the transaction
+func (r *PatientDiseaseRepository) GetPatientDiseaseByIdForUpdate(patientId int, diseaseId string) (model.PatientDisease, error) {
+	var patientDisease model.PatientDisease
+	query := fmt.Sprintf("SELECT * FROM %s WHERE patient=$1 AND disease=$2 FOR UPDATE", patientDiseaseTable)
+	err := r.db.Get(&patientDisease, query, patientId, diseaseId)
+	return patientDisease, err
+}
+
 // Update patient data in database
 func (r *PatientDiseaseRepository) UpdatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error) {
 	var updatedPatientDisease model.PatientDisease
@@ -74,7 +79,7 @@ func (r *PatientDiseaseRepository) UpdatePatientDisease(patientDisease model.Pat
 }
 
 // Delete patient data from database
-func (r *PatientDiseaseRepository) DeletePatientDisease(patientId, diseaseId int) error {
+func (r *PatientDiseaseRepository) DeletePatientDisease(patientId int, diseaseId string) error {
 	query := fmt.Sprintf("DELETE FROM %s WHERE patient=$1 AND disease=$2", patientDiseaseTable)
 	_, err := r.db.Exec(query, patientId, diseaseId)
 	return err
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePatientSearch(t *testing.T) {
+	testTable := []struct {
+		name          string
+		query         string
+		expectedTerms patientSearchTerms
+	}{
+		{
+			name:          "Name",
+			query:         "Семёнов  Пётр",
+			expectedTerms: patientSearchTerms{name: "семенов петр"},
+		},
+		{
+			name:          "SNILS",
+			query:         "123-456-789 00",
+			expectedTerms: patientSearchTerms{digits: "12345678900"},
+		},
+		{
+			name:          "Phone",
+			query:         "+7 (912) 345-67-89",
+			expectedTerms: patientSearchTerms{digits: "79123456789"},
+		},
+		{
+			name:          "Name and birth date",
+			query:         "Иванов 01.05.1980",
+			expectedTerms: patientSearchTerms{name: "иванов", birthDate: "1980-05-01"},
+		},
+		{
+			name:          "ISO birth date",
+			query:         "1980-05-01",
+			expectedTerms: patientSearchTerms{birthDate: "1980-05-01"},
+		},
+		{
+			name:  "Blank",
+			query: "   ",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedTerms, parsePatientSearch(testCase.query))
+		})
+	}
+}
+
+func TestPatientSearchQuery(t *testing.T) {
+	const (
+		snils = `regexp_replace(snils, '\D', '', 'g')`
+		phone = `regexp_replace(phone, '\D', '', 'g')`
+	)
+
+	testTable := []struct {
+		name          string
+		terms         patientSearchTerms
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:  "Name",
+			terms: patientSearchTerms{name: "иванов"},
+			expectedQuery: "SELECT *, (word_similarity(?, " + searchName + ")) AS rank FROM onco_base.patient" +
+				" WHERE (? <% " + searchName + ") ORDER BY rank DESC, id",
+			expectedArgs: []interface{}{"иванов", "иванов"},
+		},
+		{
+			name:  "SNILS or phone",
+			terms: patientSearchTerms{digits: "12345678901"},
+			expectedQuery: "SELECT *, (CASE WHEN " + snils + " = ? THEN 1 WHEN " + phone + " LIKE '%' || ? THEN 0.5 ELSE 0 END) AS rank" +
+				" FROM onco_base.patient WHERE ((" + snils + " = ? OR " + phone + " LIKE '%' || ?)) ORDER BY rank DESC, id",
+			expectedArgs: []interface{}{"12345678901", "2345678901", "12345678901", "2345678901"},
+		},
+		{
+			name:  "Phone suffix with name",
+			terms: patientSearchTerms{name: "иванов", digits: "4567"},
+			expectedQuery: "SELECT *, (word_similarity(?, " + searchName + ") + CASE WHEN " + phone + " LIKE '%' || ? THEN 0.5 ELSE 0 END) AS rank" +
+				" FROM onco_base.patient WHERE (? <% " + searchName + " AND (" + phone + " LIKE '%' || ?)) ORDER BY rank DESC, id",
+			expectedArgs: []interface{}{"иванов", "4567", "иванов", "4567"},
+		},
+		{
+			name:          "Birth date",
+			terms:         patientSearchTerms{birthDate: "1980-05-01"},
+			expectedQuery: "SELECT *, (1) AS rank FROM onco_base.patient WHERE (birth_date = ?) ORDER BY rank DESC, id",
+			expectedArgs:  []interface{}{"1980-05-01"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			selectPatients, ok := patientSearchQuery(testCase.terms, nil)
+			assert.True(t, ok)
+
+			query, args, err := selectPatients.ToSql()
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+
+	// Too few digits for a phone suffix, which is not a SNILS either
+	_, ok := patientSearchQuery(patientSearchTerms{digits: "12"}, nil)
+	assert.False(t, ok)
+}
+
+func TestSearchPatientList(t *testing.T) {
+	db := testDB(t)
+	mustExec(t, db, `INSERT INTO onco_base.patient (id, last_name, first_name, snils, phone) VALUES
+(1, 'Иванов', 'Петр', '123-456-789 01', '+79120001111'),
+(2, 'Петрова', 'Анна', NULL, '+72345678901'),
+(3, 'Сидоров', 'Олег', '111-222-333 44', '+79990004567')`)
+	repo := NewPatientRepository(db)
+
+	testTable := []struct {
+		name          string
+		query         string
+		expectedIds   []int
+		expectedRanks []float64
+	}{
+		{name: "Too short for a phone", query: "1", expectedIds: []int{}},
+		{name: "Phone suffix", query: "4567", expectedIds: []int{3}, expectedRanks: []float64{0.5}},
+		{name: "SNILS above phone", query: "123-456-789 01", expectedIds: []int{1, 2}, expectedRanks: []float64{1, 0.5}},
+		{name: "Name with a typo", query: "Ивонов", expectedIds: []int{1}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			resultList, err := repo.SearchPatientList(model.PatientSearch{Query: testCase.query}, nil)
+
+			assert.NoError(t, err)
+			ids := []int{}
+			ranks := []float64{}
+			for _, result := range resultList {
+				ids = append(ids, result.Id)
+				ranks = append(ranks, result.Rank)
+			}
+			assert.Equal(t, testCase.expectedIds, ids)
+			if testCase.expectedRanks != nil {
+				assert.Equal(t, testCase.expectedRanks, ranks)
+			}
+		})
+	}
+}
+
+func TestUpdatePatient(t *testing.T) {
+	db := testDB(t)
+	mustExec(t, db, `INSERT INTO onco_base.patient (id, last_name, first_name, sex, phone) VALUES (1, 'Иванова', 'Анна', 'F', '+79120001111')`)
+	repo := NewPatientRepository(db)
+
+	// A patient as FHIR PUT Patient maps the resource, with no telecom the phone is cleared
+	birthDate, snils := "1980-05-01", "123-456-789 01"
+	updatedPatient, err := repo.UpdatePatient(model.Patient{Id: 1, LastName: "Петрова", FirstName: "Анна", BirthDate: &birthDate, Sex: model.FemaleSex, SNILS: &snils})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Петрова", updatedPatient.LastName)
+	assert.Equal(t, model.FemaleSex, updatedPatient.Sex)
+	assert.Equal(t, &snils, updatedPatient.SNILS)
+	assert.Nil(t, updatedPatient.Phone)
+	if assert.NotNil(t, updatedPatient.BirthDate) {
+		assert.True(t, strings.HasPrefix(*updatedPatient.BirthDate, birthDate), *updatedPatient.BirthDate)
+	}
+
+	_, err = repo.UpdatePatient(model.Patient{Id: 2, LastName: "Сидоров"})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
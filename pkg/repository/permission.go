@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type PermissionRepository struct {
+	db DB
+}
+
+func NewPermissionRepository(db DB) *PermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+// Create permission in database and get it from database, sql.ErrNoRows if the role already has the permission
+func (r *PermissionRepository) CreatePermission(permission model.Permission) (model.Permission, error) {
+	var createdPermission model.Permission
+	query := fmt.Sprintf("INSERT INTO %s (role, resource, action) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING RETURNING *", permissionTable)
+	err := r.db.Get(&createdPermission, query,
+		permission.Role,
+		permission.Resource,
+		permission.Action,
+	)
+	return createdPermission, err
+}
+
+// Get all permissions from database
+func (r *PermissionRepository) GetAllPermissions() ([]model.Permission, error) {
+	var permissionList []model.Permission
+	query := fmt.Sprintf("SELECT * FROM %s", permissionTable)
+	err := r.db.Select(&permissionList, query)
+	return permissionList, err
+}
+
+// Get page of permissions matching the filter
+func (r *PermissionRepository) GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error) {
+	where := squirrel.And{}
+	if filter.Role != "" {
+		where = append(where, squirrel.Eq{"role": filter.Role})
+	}
+	if filter.Resource != "" {
+		where = append(where, squirrel.Eq{"resource": filter.Resource})
+	}
+	return selectPage[model.Permission](r.db, permissionTable, []string{"role", "resource", "action"}, where, listQuery)
+}
+
+// Delete permission from database
+func (r *PermissionRepository) DeletePermission(permission model.Permission) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE role=$1 AND resource=$2 AND action=$3", permissionTable)
+	_, err := r.db.Exec(query,
+		permission.Role,
+		permission.Resource,
+		permission.Action,
+	)
+	return err
+}
@@ -7,11 +7,22 @@ import (
 )
 
 const (
+	userTable         = "onco_base.app_user"
 	externalUserTable = "onco_base.external_user"
 	internalUserTable = "onco_base.internal_user"
 
+	refreshTokenTable        = "onco_base.refresh_token"
+	revokedAccessTokenTable  = "onco_base.revoked_access_token"
+	userTokenRevocationTable = "onco_base.user_token_revocation"
+	passwordResetTokenTable  = "onco_base.password_reset_token"
+	registrationCodeTable    = "onco_base.registration_code"
+	invitationTable          = "onco_base.invitation"
+	failedLoginTable         = "onco_base.failed_login"
+	auditLogTable            = "onco_base.audit_log"
+
 	bloodCountTable          = "onco_base.blood_count"
 	bloodCountValueTable     = "onco_base.blood_count_value"
+	cohortTable              = "onco_base.cohort"
 	courseTable              = "onco_base.course"
 	courseProcedureTable     = "onco_base.course_procedure"
 	diagnosisTable           = "onco_base.diagnosis"
@@ -19,10 +30,15 @@ const (
 	doctorTable              = "onco_base.doctor"
 	doctorPatientTable       = "onco_base.doctor_patient"
 	drugTable                = "onco_base.drug"
+	exportJobTable           = "onco_base.export_job"
+	labCodeMappingTable      = "onco_base.lab_code_mapping"
+	labMessageTable          = "onco_base.lab_message"
 	patientTable             = "onco_base.patient"
 	patientCourseTable       = "onco_base.patient_course"
 	patientDiseaseTable      = "onco_base.patient_disease"
+	permissionTable          = "onco_base.role_permission"
 	procedureBloodCountTable = "onco_base.procedure_blood_count"
+	unitConversionTable      = "onco_base.unit_conversion"
 	unitMeasureTable         = "onco_base.unit_measure"
 )
 
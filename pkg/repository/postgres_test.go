@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"med/pkg/database"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDatabaseEnv names the data source of the database the repository tests run against.
+// The tests drop the onco_base schema, so it must never point to a database with real data.
+const testDatabaseEnv = "ONCOBASE_TEST_DATABASE"
+
+// testDB connects to the test database and migrates an empty schema, the test is skipped without the database
+func testDB(t *testing.T) *sqlx.DB {
+	dataSource := os.Getenv(testDatabaseEnv)
+	if dataSource == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	db, err := sqlx.Connect("postgres", dataSource)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("DROP SCHEMA IF EXISTS onco_base CASCADE; DROP TABLE IF EXISTS public.schema_migrations")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	migrator, err := database.NewMigrator(db)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, migrator.Up()) {
+		t.FailNow()
+	}
+	return db
+}
+
+// mustExec runs the statements that prepare the test data
+func mustExec(t *testing.T, db *sqlx.DB, queries ...string) {
+	for _, query := range queries {
+		if _, err := db.Exec(query); !assert.NoError(t, err, query) {
+			t.FailNow()
+		}
+	}
+}
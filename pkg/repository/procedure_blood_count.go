@@ -4,52 +4,67 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 )
 
 type ProcedureBloodCountRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewProcedureBloodCountRepository(db *sqlx.DB) *ProcedureBloodCountRepository {
+func NewProcedureBloodCountRepository(db DB) *ProcedureBloodCountRepository {
 	return &ProcedureBloodCountRepository{db: db}
 }
 
 // Create patient in database and get him from database
 func (r *ProcedureBloodCountRepository) CreateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
 	var createdProcedureBloodCount model.ProcedureBloodCount
-	query := fmt.Sprintf("INSERT INTO %s (value, measure_code, procedure, blood_count) VALUES ($1, $2, $3, $4) RETURNING *", procedureBloodCountTable)
+	query := fmt.Sprintf("INSERT INTO %s (value, measure_code, procedure, blood_count, flag) VALUES ($1, $2, $3, $4, $5) RETURNING *", procedureBloodCountTable)
 	err := r.db.Get(&createdProcedureBloodCount, query,
 		procedureBloodCount.Value,
 		procedureBloodCount.MeasureCode,
 		procedureBloodCount.Procedure,
 		procedureBloodCount.BloodCount,
+		procedureBloodCount.Flag,
 	)
 	return createdProcedureBloodCount, err
 }
 
-// Get patient list from database
-func (r *ProcedureBloodCountRepository) GetProcedureBloodCountList() ([]model.ProcedureBloodCount, error) {
-	var procedureBloodCountList []model.ProcedureBloodCount
-	query := fmt.Sprintf("SELECT * FROM %s", procedureBloodCountTable)
-	err := r.db.Select(&procedureBloodCountList, query)
-	return procedureBloodCountList, err
-}
-
-// Get patient list from database
-func (r *ProcedureBloodCountRepository) GetProcedureBloodCountListByProcedure(procedureId int) ([]model.ProcedureBloodCount, error) {
-	var procedureBloodCountList []model.ProcedureBloodCount
-	query := fmt.Sprintf("SELECT * FROM %s WHERE procedure=$1", procedureBloodCountTable)
-	err := r.db.Select(&procedureBloodCountList, query, procedureId)
-	return procedureBloodCountList, err
-}
-
-// Get patient list from database
-func (r *ProcedureBloodCountRepository) GetProcedureBloodCountListByBloodCount(bloodCountId string) ([]model.ProcedureBloodCount, error) {
-	var procedureBloodCountList []model.ProcedureBloodCount
-	query := fmt.Sprintf("SELECT * FROM %s WHERE bloodCount=$1", procedureBloodCountTable)
-	err := r.db.Select(&procedureBloodCountList, query, bloodCountId)
-	return procedureBloodCountList, err
+// Get page of procedure blood counts matching the filter, patientIds limits the list to the results of the patients unless nil
+func (r *ProcedureBloodCountRepository) GetProcedureBloodCountList(filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.ProcedureBloodCount], error) {
+	where := squirrel.And{}
+	if patientIds != nil {
+		where = append(where, squirrel.Expr(fmt.Sprintf("procedure IN (SELECT cp.id FROM %s cp JOIN %s pc ON pc.id = cp.patient_course WHERE pc.patient = ANY(?))",
+			courseProcedureTable, patientCourseTable), pq.Array(patientIds)))
+	}
+	if filter.Patient != nil {
+		where = append(where, squirrel.Expr(fmt.Sprintf("procedure IN (SELECT cp.id FROM %s cp JOIN %s pc ON pc.id = cp.patient_course WHERE pc.patient = ?)",
+			courseProcedureTable, patientCourseTable), *filter.Patient))
+	}
+	if filter.Procedure != nil {
+		where = append(where, squirrel.Eq{"procedure": *filter.Procedure})
+	}
+	if filter.BloodCount != "" {
+		where = append(where, squirrel.Eq{"blood_count": filter.BloodCount})
+	}
+	if filter.Flag != "" {
+		where = append(where, squirrel.Eq{"flag": filter.Flag})
+	}
+	if filter.Abnormal != nil {
+		abnormalFlags := []string{model.LowFlag, model.HighFlag}
+		if *filter.Abnormal {
+			where = append(where, squirrel.Eq{"flag": abnormalFlags})
+		} else {
+			where = append(where, squirrel.NotEq{"flag": abnormalFlags})
+		}
+	}
+	if filter.DateFrom != "" {
+		where = append(where, squirrel.Expr(fmt.Sprintf("procedure IN (SELECT id FROM %s WHERE begin_date >= ?)", courseProcedureTable), filter.DateFrom))
+	}
+	if filter.DateTo != "" {
+		where = append(where, squirrel.Expr(fmt.Sprintf("procedure IN (SELECT id FROM %s WHERE begin_date <= ?)", courseProcedureTable), filter.DateTo))
+	}
+	return selectPage[model.ProcedureBloodCount](r.db, procedureBloodCountTable, []string{"procedure", "blood_count"}, where, listQuery)
 }
 
 // Get patient from database by ID
@@ -60,13 +75,22 @@ func (r *ProcedureBloodCountRepository) GetProcedureBloodCountById(procedureId i
 	return procedureBloodCount, err
 }
 
+// Get blood count result of the procedure from database and lock it until the end of the transaction
+func (r *ProcedureBloodCountRepository) GetProcedureBloodCountByIdForUpdate(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error) {
+	var procedureBloodCount model.ProcedureBloodCount
+	query := fmt.Sprintf("SELECT * FROM %s WHERE procedure=$1 AND blood_count=$2 FOR UPDATE", procedureBloodCountTable)
+	err := r.db.Get(&procedureBloodCount, query, procedureId, bloodCountId)
+	return procedureBloodCount, err
+}
+
 // Update patient data in database
 func (r *ProcedureBloodCountRepository) UpdateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
 	var updatedProcedureBloodCount model.ProcedureBloodCount
-	query := fmt.Sprintf("UPDATE %s SET value=$1, measure_code=$2 WHERE procedure=$3 AND blood_count=$4 RETURNING *", procedureBloodCountTable)
+	query := fmt.Sprintf("UPDATE %s SET value=$1, measure_code=$2, flag=$3 WHERE procedure=$4 AND blood_count=$5 RETURNING *", procedureBloodCountTable)
 	err := r.db.Get(&updatedProcedureBloodCount, query,
 		procedureBloodCount.Value,
 		procedureBloodCount.MeasureCode,
+		procedureBloodCount.Flag,
 		procedureBloodCount.Procedure,
 		procedureBloodCount.BloodCount,
 	)
@@ -79,3 +103,47 @@ func (r *ProcedureBloodCountRepository) DeleteProcedureBloodCount(procedureId in
 	_, err := r.db.Exec(query, procedureId, bloodCountId)
 	return err
 }
+
+// Get IDs of the course procedures among the given IDs that exist in database
+func (r *ProcedureBloodCountRepository) GetExistingProcedureIds(procedureIds []int) ([]int, error) {
+	existingIds := []int{}
+	query := fmt.Sprintf("SELECT id FROM %s WHERE id = ANY($1)", courseProcedureTable)
+	err := r.db.Select(&existingIds, query, pq.Array(procedureIds))
+	return existingIds, err
+}
+
+// Get procedure blood counts of the given procedures from database
+func (r *ProcedureBloodCountRepository) GetProcedureBloodCountListByProcedures(procedureIds []int) ([]model.ProcedureBloodCount, error) {
+	procedureBloodCountList := []model.ProcedureBloodCount{}
+	query := fmt.Sprintf("SELECT * FROM %s WHERE procedure = ANY($1)", procedureBloodCountTable)
+	err := r.db.Select(&procedureBloodCountList, query, pq.Array(procedureIds))
+	return procedureBloodCountList, err
+}
+
+// Create procedure blood counts in database in a single transaction, either all of them are created or none
+func (r *ProcedureBloodCountRepository) CreateProcedureBloodCountList(procedureBloodCountList []model.ProcedureBloodCount) ([]model.ProcedureBloodCount, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("INSERT INTO %s (value, measure_code, procedure, blood_count, flag) VALUES ($1, $2, $3, $4, $5) RETURNING *", procedureBloodCountTable)
+	createdList := make([]model.ProcedureBloodCount, 0, len(procedureBloodCountList))
+	for _, procedureBloodCount := range procedureBloodCountList {
+		var createdProcedureBloodCount model.ProcedureBloodCount
+		err := tx.Get(&createdProcedureBloodCount, query,
+			procedureBloodCount.Value,
+			procedureBloodCount.MeasureCode,
+			procedureBloodCount.Procedure,
+			procedureBloodCount.BloodCount,
+			procedureBloodCount.Flag,
+		)
+		if err != nil {
+			return nil, err
+		}
+		createdList = append(createdList, createdProcedureBloodCount)
+	}
+
+	return createdList, tx.Commit()
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"time"
+)
+
+type RegistrationRepository struct {
+	db DB
+}
+
+func NewRegistrationRepository(db DB) *RegistrationRepository {
+	return &RegistrationRepository{db: db}
+}
+
+// Get id of the patient with given SNILS that is not linked to a user yet
+func (r *RegistrationRepository) GetUnregisteredPatientId(snils string) (int, error) {
+	var patientId int
+	query := fmt.Sprintf("SELECT id FROM %s WHERE snils=$1 AND user_id IS NULL", patientTable)
+	err := r.db.Get(&patientId, query, snils)
+	return patientId, err
+}
+
+// Save registration code of the patient, the previous code of the patient is replaced
+func (r *RegistrationRepository) SaveRegistrationCode(patientId int, codeHash string, expiresAt time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %s (patient_id, code_hash, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (patient_id) DO UPDATE SET code_hash=EXCLUDED.code_hash, attempts=0, expires_at=EXCLUDED.expires_at, created_at=now()`,
+		registrationCodeTable)
+	_, err := r.db.Exec(query, patientId, codeHash, expiresAt)
+	return err
+}
+
+// Check registration code of the patient, every check counts as an attempt.
+// Returns false if the code does not match, is expired or ran out of attempts
+func (r *RegistrationRepository) CheckRegistrationCode(patientId int, codeHash string, maxAttempts int) (bool, error) {
+	var match bool
+	query := fmt.Sprintf(`UPDATE %s SET attempts=attempts + 1
+		WHERE patient_id=$1 AND expires_at > now() AND attempts < $2 RETURNING code_hash=$3`, registrationCodeTable)
+	err := r.db.Get(&match, query, patientId, maxAttempts, codeHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return match, err
+}
+
+// Create patient user, link it to the patient record and drop the used registration code
+func (r *RegistrationRepository) RegisterPatient(user model.User, patientId int) (string, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var userId int
+	query := fmt.Sprintf("INSERT INTO %s (email, password, role, user_type) VALUES ($1, $2, $3, $4) RETURNING id", userTable)
+	if err = tx.Get(&userId, query, user.Email, user.Password, model.PatientRole, model.ExternalUserType); err != nil {
+		return "", err
+	}
+
+	// The patient could have been linked by a concurrent registration
+	query = fmt.Sprintf("UPDATE %s SET user_id=$1 WHERE id=$2 AND user_id IS NULL", patientTable)
+	linked, err := execCount(tx, query, userId, patientId)
+	if err != nil {
+		return "", err
+	}
+	if linked == 0 {
+		return "", sql.ErrNoRows
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE patient_id=$1", registrationCodeTable)
+	if _, err = tx.Exec(query, patientId); err != nil {
+		return "", err
+	}
+
+	return user.Email, tx.Commit()
+}
+
+// Create invitation in database
+func (r *RegistrationRepository) CreateInvitation(tokenHash string, invitation model.Invitation, invitedBy int) error {
+	query := fmt.Sprintf("INSERT INTO %s (token_hash, email, role, invited_by, expires_at) VALUES ($1, $2, $3, $4, $5)", invitationTable)
+	_, err := r.db.Exec(query, tokenHash, invitation.Email, invitation.Role, invitedBy, invitation.ExpiresAt)
+	return err
+}
+
+// Accept invitation and create internal user with the invited email and role.
+// A doctor record is created for invited doctors. Fails with sql.ErrNoRows if the invitation is unknown, accepted or expired
+func (r *RegistrationRepository) AcceptInvitation(tokenHash string, user model.User, qualification string) (string, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`UPDATE %s SET accepted_at=now()
+		WHERE token_hash=$1 AND accepted_at IS NULL AND expires_at > now() RETURNING email, role`, invitationTable)
+	if err = tx.QueryRowx(query, tokenHash).Scan(&user.Email, &user.Role); err != nil {
+		return "", err
+	}
+
+	var userId int
+	query = fmt.Sprintf(`INSERT INTO %s (email, password, role, user_type, first_name, middle_name, last_name, phone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')) RETURNING id`, userTable)
+	err = tx.Get(&userId, query,
+		user.Email,
+		user.Password,
+		user.Role,
+		model.InternalUserType,
+		user.FirstName,
+		user.MiddleName,
+		user.LastName,
+		user.Phone,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Role == model.DoctorRole {
+		query = fmt.Sprintf(`INSERT INTO %s (first_name, middle_name, last_name, qualification, phone, user_id)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`, doctorTable)
+		_, err = tx.Exec(query, user.FirstName, user.MiddleName, user.LastName, qualification, user.Phone, userId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return user.Email, tx.Commit()
+}
@@ -2,22 +2,42 @@ package repository
 
 import (
 	"med/pkg/model"
-
-	"github.com/jmoiron/sqlx"
+	"time"
 )
 
 type Authorization interface {
-	CreateUser(user model.User) (string, error)
-	GetUser(email, password string) (model.User, error)
+	GetUserByEmail(email string) (model.User, error)
+	UpdatePassword(email, password string) error
+	CreateFailedLogin(failedLogin model.FailedLogin) error
 }
 
 type Account interface {
+	GetPatientBloodCountList(userId int) ([]model.AccountBloodCount, error)
+	GetPatientDoctorList(userId int) ([]model.Doctor, error)
+	GetPanelPatientList(userId int) ([]model.Patient, error)
+	GetPatientDiseaseListByPatients(patientIds []int) ([]model.PatientDisease, error)
+	GetActivePatientCourseListByPatients(patientIds []int) ([]model.PatientCourse, error)
+}
+
+type Access interface {
+	IsDoctorPatient(userId, patientId int) (bool, error)
+	IsPatientUser(userId, patientId int) (bool, error)
+	GetDoctorPatientIdList(userId int) ([]int, error)
+	GetUserPatientIdList(userId int) ([]int, error)
+	GetPatientIdByPatientCourse(patientCourseId int) (int, error)
+	GetPatientIdByCourseProcedure(procedureId int) (int, error)
+}
+
+type Audit interface {
+	CreateAuditEntry(entry model.AuditEntry) error
+	GetAuditList(filter model.AuditFilter) ([]model.AuditEntry, error)
 }
 
 type BloodCount interface {
 	CreateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error)
 	GetBloodCountById(id string) (model.BloodCount, error)
-	GetBloodCountList() ([]model.BloodCount, error)
+	GetBloodCountList(filter model.BloodCountFilter, listQuery model.ListQuery) (model.Page[model.BloodCount], error)
+	GetBloodCountListByIds(ids []string) ([]model.BloodCount, error)
 	UpdateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error)
 	DeleteBloodCount(id string) error
 }
@@ -25,33 +45,49 @@ type BloodCount interface {
 type BloodCountValue interface {
 	CreateBloodCountValue(bloodCountValue model.BloodCountValue) (model.BloodCountValue, error)
 	GetBloodCountValueById(diseaseId, bloodCountId string) (model.BloodCountValue, error)
-	GetBloodCountValueListByDisease(diseaseId string) ([]model.BloodCountValue, error)
-	GetBloodCountValueListByBloodCount(bloodCountId string) ([]model.BloodCountValue, error)
-	GetBloodCountValueList() ([]model.BloodCountValue, error)
+	GetAllBloodCountValues() ([]model.BloodCountValue, error)
+	GetBloodCountValueList(filter model.BloodCountValueFilter, listQuery model.ListQuery) (model.Page[model.BloodCountValue], error)
 	UpdateBloodCountValue(bloodCountValue model.BloodCountValue) (model.BloodCountValue, error)
 	DeleteBloodCountValue(diseaseId, bloodCountId string) error
 }
 
+type Cohort interface {
+	CreateCohort(cohort model.Cohort) (model.Cohort, error)
+	GetCohortList(filter model.CohortFilter, listQuery model.ListQuery) (model.Page[model.Cohort], error)
+	GetCohortById(id int) (model.Cohort, error)
+	UpdateCohort(cohort model.Cohort) (model.Cohort, error)
+	DeleteCohort(id int) error
+	GetCohortStatistics(definition model.CohortDefinition, bucketDays int, bloodCounts []string) (model.CohortStatistics, error)
+}
+
+type Console interface {
+	GetStatistics(from, to string) (model.ConsoleStatistics, error)
+	SetUserLocked(id int, locked bool) error
+	UpdateUserRole(id int, role string) error
+	GetFailedLoginList(limit int) ([]model.FailedLogin, error)
+}
+
 type Course interface {
 	CreateCourse(course model.Course) (model.Course, error)
 	GetCourseById(id string) (model.Course, error)
-	GetCourseList() ([]model.Course, error)
+	GetCourseList(filter model.CourseFilter, listQuery model.ListQuery) (model.Page[model.Course], error)
 	UpdateCourse(course model.Course) (model.Course, error)
 	DeleteCourse(id string) error
 }
 
 type CourseProcedure interface {
 	CreateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
-	GetCourseProcedureById(id string) (model.CourseProcedure, error)
-	GetCourseProcedureList() ([]model.CourseProcedure, error)
+	GetCourseProcedureById(id int) (model.CourseProcedure, error)
+	GetCourseProcedureByIdForUpdate(id int) (model.CourseProcedure, error)
+	GetCourseProcedureList(filter model.CourseProcedureFilter, listQuery model.ListQuery) (model.Page[model.CourseProcedure], error)
 	UpdateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
-	DeleteCourseProcedure(id string) error
+	DeleteCourseProcedure(id int) error
 }
 
 type Diagnosis interface {
 	CreateDiagnosis(diagnosis model.Diagnosis) (model.Diagnosis, error)
 	GetDiagnosisById(id string) (model.Diagnosis, error)
-	GetDiagnosisList() ([]model.Diagnosis, error)
+	GetDiagnosisList(listQuery model.ListQuery) (model.Page[model.Diagnosis], error)
 	UpdateDiagnosis(diagnosis model.Diagnosis) (model.Diagnosis, error)
 	DeleteDiagnosis(id string) error
 }
@@ -59,7 +95,7 @@ type Diagnosis interface {
 type Disease interface {
 	CreateDisease(disease model.Disease) (model.Disease, error)
 	GetDiseaseById(id string) (model.Disease, error)
-	GetDiseaseList() ([]model.Disease, error)
+	GetDiseaseList(listQuery model.ListQuery) (model.Page[model.Disease], error)
 	UpdateDisease(disease model.Disease) (model.Disease, error)
 	DeleteDisease(id string) error
 }
@@ -67,29 +103,62 @@ type Disease interface {
 type Doctor interface {
 	CreateDoctor(doctor model.Doctor) (model.Doctor, error)
 	GetDoctorById(id int) (model.Doctor, error)
-	GetDoctorList() ([]model.Doctor, error)
+	GetDoctorList(listQuery model.ListQuery) (model.Page[model.Doctor], error)
 	UpdateDoctor(doctor model.Doctor) (model.Doctor, error)
 	DeleteDoctor(id int) error
 }
 
 type DoctorPatient interface {
 	CreateDoctorPatient(doctorPatient model.DoctorPatient) (model.DoctorPatient, error)
-	GetDoctorPatientList(doctor_id int) ([]model.DoctorPatient, error)
+	CreateDoctorPatientByUser(userId, patientId int) error
+	GetDoctorPatientList(doctorId int, listQuery model.ListQuery) (model.Page[model.DoctorPatient], error)
 	DeleteDoctorPatient(doctorPatient model.DoctorPatient) error
 }
 
 type Drug interface {
 	CreateDrug(drug model.Drug) (model.Drug, error)
 	GetDrugById(id string) (model.Drug, error)
-	GetDrugList() ([]model.Drug, error)
+	GetDrugList(filter model.DrugFilter, listQuery model.ListQuery) (model.Page[model.Drug], error)
 	UpdateDrug(drug model.Drug) (model.Drug, error)
 	DeleteDrug(id string) error
 }
 
+type Export interface {
+	CreateExportJob(job model.ExportJob) (model.ExportJob, error)
+	GetExportJobList(filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error)
+	GetExportJobById(id int) (model.ExportJob, error)
+	UpdateExportJobStatus(id int, status, jobError string) error
+	FailUnfinishedExportJobs(jobError string) (int, error)
+	InExportSnapshot(fn func(snapshot ExportSnapshot) error) error
+}
+
+// ExportSnapshot reads the exported tables in a single snapshot. Courses, procedures and results are streamed row by row
+type ExportSnapshot interface {
+	GetExportDataset() (model.ExportDataset, error)
+	EachExportPatientCourse(fn func(patientCourse model.ExportPatientCourse) error) error
+	EachExportCourseProcedure(fn func(procedure model.ExportCourseProcedure) error) error
+	EachExportProcedureBloodCount(fn func(result model.ExportProcedureBloodCount) error) error
+}
+
+type LabCodeMapping interface {
+	CreateLabCodeMapping(labCodeMapping model.LabCodeMapping) (model.LabCodeMapping, error)
+	GetLabCodeMappingList(listQuery model.ListQuery) (model.Page[model.LabCodeMapping], error)
+	GetLabCodeMappingListByCodes(codes []string) ([]model.LabCodeMapping, error)
+	DeleteLabCodeMapping(system, code string) error
+}
+
+type LabMessage interface {
+	GetLabMessage(sendingApplication, sendingFacility, controlId string) (model.LabMessage, error)
+	CreateLabMessage(labMessage model.LabMessage, procedures []model.LabProcedure) ([]model.LabProcedure, error)
+}
+
 type Patient interface {
 	CreatePatient(patient model.Patient) (model.Patient, error)
 	GetPatientById(id int) (model.Patient, error)
-	GetPatientList() ([]model.Patient, error)
+	GetPatientByIdForUpdate(id int) (model.Patient, error)
+	GetPatientBySNILS(snils string) (model.Patient, error)
+	GetPatientList(filter model.PatientFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.Patient], error)
+	SearchPatientList(search model.PatientSearch, patientIds []int) ([]model.PatientSearchResult, error)
 	UpdatePatient(patient model.Patient) (model.Patient, error)
 	DeletePatient(id int) error
 }
@@ -97,44 +166,106 @@ type Patient interface {
 type PatientCourse interface {
 	CreatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error)
 	GetPatientCourseById(id int) (model.PatientCourse, error)
-	GetPatientCourseList() ([]model.PatientCourse, error)
+	GetPatientCourseByIdForUpdate(id int) (model.PatientCourse, error)
+	GetPatientCourseListByDate(patientId int, date string) ([]model.PatientCourse, error)
+	GetPatientCourseList(filter model.PatientCourseFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.PatientCourse], error)
 	UpdatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error)
 	DeletePatientCourse(id int) error
 }
 
 type PatientDisease interface {
 	CreatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error)
-	GetPatientDiseaseById(patientId, diseaseId int) (model.PatientDisease, error)
-	GetPatientDiseaseListByPatient(patientId int) ([]model.PatientDisease, error)
-	GetPatientDiseaseListByDisease(diseaseId int) ([]model.PatientDisease, error)
-	GetPatientDiseaseList() ([]model.PatientDisease, error)
+	GetPatientDiseaseById(patientId int, diseaseId string) (model.PatientDisease, error)
+	GetPatientDiseaseByIdForUpdate(patientId int, diseaseId string) (model.PatientDisease, error)
+	GetPatientDiseaseList(filter model.PatientDiseaseFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.PatientDisease], error)
 	UpdatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error)
-	DeletePatientDisease(patientId, diseaseId int) error
+	DeletePatientDisease(patientId int, diseaseId string) error
+}
+
+type Permission interface {
+	CreatePermission(permission model.Permission) (model.Permission, error)
+	GetAllPermissions() ([]model.Permission, error)
+	GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error)
+	DeletePermission(permission model.Permission) error
 }
 
 type ProcedureBloodCount interface {
 	CreateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
 	GetProcedureBloodCountById(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error)
-	GetProcedureBloodCountListByProcedure(procedureId int) ([]model.ProcedureBloodCount, error)
-	GetProcedureBloodCountListByBloodCount(bloodCountId string) ([]model.ProcedureBloodCount, error)
-	GetProcedureBloodCountList() ([]model.ProcedureBloodCount, error)
+	GetProcedureBloodCountByIdForUpdate(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error)
+	GetProcedureBloodCountList(filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.ProcedureBloodCount], error)
 	UpdateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
 	DeleteProcedureBloodCount(procedureId int, bloodCountId string) error
+	GetExistingProcedureIds(procedureIds []int) ([]int, error)
+	GetProcedureBloodCountListByProcedures(procedureIds []int) ([]model.ProcedureBloodCount, error)
+	CreateProcedureBloodCountList(procedureBloodCountList []model.ProcedureBloodCount) ([]model.ProcedureBloodCount, error)
+}
+
+type Registration interface {
+	GetUnregisteredPatientId(snils string) (int, error)
+	SaveRegistrationCode(patientId int, codeHash string, expiresAt time.Time) error
+	CheckRegistrationCode(patientId int, codeHash string, maxAttempts int) (bool, error)
+	RegisterPatient(user model.User, patientId int) (string, error)
+	CreateInvitation(tokenHash string, invitation model.Invitation, invitedBy int) error
+	AcceptInvitation(tokenHash string, user model.User, qualification string) (string, error)
+}
+
+type Token interface {
+	CreateRefreshToken(refreshToken model.RefreshToken) error
+	GetRefreshToken(tokenHash string) (model.RefreshToken, error)
+	RevokeRefreshToken(tokenHash string) (bool, error)
+	RevokeUserTokens(userId int) error
+	RevokeAccessToken(tokenId string, expiresAt time.Time) error
+	IsAccessTokenRevoked(tokenId string, userId int, issuedAt time.Time) (bool, error)
+	CreatePasswordResetToken(tokenHash, email string, expiresAt time.Time) error
+	UsePasswordResetToken(tokenHash string) (string, error)
+}
+
+type User interface {
+	CreateStaffUser(user model.User) (model.UserProfile, error)
+	GetUserById(id int) (model.UserProfile, error)
+	GetUserByIdForUpdate(id int) (model.UserProfile, error)
+	GetUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error)
+	MigrateLegacyUsers() (model.LegacyUserMigration, error)
+}
+
+type Timeline interface {
+	GetPatientTimeline(patientId int) ([]model.TimelineEvent, error)
+}
+
+type Transaction interface {
+	InTransaction(fn func(repos *Repository) error) error
+}
+
+type Trend interface {
+	GetBloodCountSeries(patientId int, bloodCountId string) ([]model.TrendPoint, error)
+	GetTrendCourse(patientCourseId int) (model.TrendCourse, error)
+}
+
+type UnitConversion interface {
+	CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error)
+	GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error)
+	GetUnitConversion(from, to string) (model.UnitConversion, error)
+	DeleteUnitConversion(from, to string) error
 }
 
 type UnitMeasure interface {
 	CreateUnitMeasure(unitMeasure model.UnitMeasure) (model.UnitMeasure, error)
 	GetUnitMeasureById(id string) (model.UnitMeasure, error)
-	GetUnitMeasureList() ([]model.UnitMeasure, error)
+	GetUnitMeasureList(listQuery model.ListQuery) (model.Page[model.UnitMeasure], error)
 	UpdateUnitMeasure(unitMeasure model.UnitMeasure) (model.UnitMeasure, error)
 	DeleteUnitMeasure(id string) error
 }
 
 type Repository struct {
+	Access
 	Account
+	Audit
 	Authorization
 	BloodCountValue
 	BloodCount
+	Cohort
+	Console
 	Course
 	CourseProcedure
 	Diagnosis
@@ -142,18 +273,34 @@ type Repository struct {
 	Doctor
 	DoctorPatient
 	Drug
+	Export
+	LabCodeMapping
+	LabMessage
 	Patient
 	PatientCourse
 	PatientDisease
+	Permission
 	ProcedureBloodCount
+	Registration
+	Timeline
+	Token
+	Transaction
+	Trend
+	UnitConversion
 	UnitMeasure
+	User
 }
 
-func NewRepository(db *sqlx.DB) *Repository {
+func NewRepository(db DB) *Repository {
 	return &Repository{
+		Access:              NewAccessRepository(db),
+		Account:             NewAccountRepository(db),
+		Audit:               NewAuditRepository(db),
 		Authorization:       NewAuthRepository(db),
 		BloodCount:          NewBloodCountRepository(db),
 		BloodCountValue:     NewBloodCountValueRepository(db),
+		Cohort:              NewCohortRepository(db),
+		Console:             NewConsoleRepository(db),
 		Course:              NewCourseRepository(db),
 		CourseProcedure:     NewCourseProcedureRepository(db),
 		Diagnosis:           NewDiagnosisRepository(db),
@@ -161,10 +308,21 @@ func NewRepository(db *sqlx.DB) *Repository {
 		Doctor:              NewDoctorRepository(db),
 		DoctorPatient:       NewDoctorPatientRepository(db),
 		Drug:                NewDrugRepository(db),
+		Export:              NewExportRepository(db),
+		LabCodeMapping:      NewLabCodeMappingRepository(db),
+		LabMessage:          NewLabMessageRepository(db),
 		Patient:             NewPatientRepository(db),
 		PatientCourse:       NewPatientCourseRepository(db),
 		PatientDisease:      NewPatientDiseaseRepository(db),
+		Permission:          NewPermissionRepository(db),
 		ProcedureBloodCount: NewProcedureBloodCountRepository(db),
+		Registration:        NewRegistrationRepository(db),
+		Timeline:            NewTimelineRepository(db),
+		Token:               NewTokenRepository(db),
+		Transaction:         NewTransactionRepository(db),
+		Trend:               NewTrendRepository(db),
+		UnitConversion:      NewUnitConversionRepository(db),
 		UnitMeasure:         NewUnitMeasureRepository(db),
+		User:                NewUserRepository(db),
 	}
 }
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"med/pkg/model"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// schemaModels are the models read from the tables with SELECT *, so their db tags must match the table columns exactly
+var schemaModels = map[string]interface{}{
+	auditLogTable:            model.AuditEntry{},
+	bloodCountTable:          model.BloodCount{},
+	bloodCountValueTable:     model.BloodCountValue{},
+	cohortTable:              model.Cohort{},
+	courseTable:              model.Course{},
+	courseProcedureTable:     model.CourseProcedure{},
+	diagnosisTable:           model.Diagnosis{},
+	diseaseTable:             model.Disease{},
+	doctorTable:              model.Doctor{},
+	doctorPatientTable:       model.DoctorPatient{},
+	drugTable:                model.Drug{},
+	exportJobTable:           model.ExportJob{},
+	failedLoginTable:         model.FailedLogin{},
+	labCodeMappingTable:      model.LabCodeMapping{},
+	labMessageTable:          model.LabMessage{},
+	patientTable:             model.Patient{},
+	patientCourseTable:       model.PatientCourse{},
+	patientDiseaseTable:      model.PatientDisease{},
+	permissionTable:          model.Permission{},
+	procedureBloodCountTable: model.ProcedureBloodCount{},
+	refreshTokenTable:        model.RefreshToken{},
+	unitConversionTable:      model.UnitConversion{},
+	unitMeasureTable:         model.UnitMeasure{},
+}
+
+// Column data types in information_schema that a Go type can be read from
+var (
+	integerTypes = []string{"smallint", "integer", "bigint"}
+	floatTypes   = []string{"real", "double precision", "numeric"}
+	stringTypes  = []string{"character varying", "character", "text", "date"}
+	timeTypes    = []string{"timestamp with time zone", "timestamp without time zone", "date"}
+	jsonTypes    = []string{"json", "jsonb"}
+	boolTypes    = []string{"boolean"}
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// SchemaDriftError lists the differences between the models and the database schema.
+type SchemaDriftError struct {
+	Problems []string
+}
+
+func (e *SchemaDriftError) Error() string {
+	return "database schema does not match the models:\n\t" + strings.Join(e.Problems, "\n\t")
+}
+
+// schemaColumn is a table column in information_schema
+type schemaColumn struct {
+	DataType string
+	Nullable bool
+}
+
+// CheckSchema compares the db tags of the models with the table columns in information_schema.
+// It returns a SchemaDriftError if a column is missing on either side, has an incompatible type,
+// or is nullable while the model field cannot hold NULL.
+func CheckSchema(db *sqlx.DB) error {
+	var columns []struct {
+		Table      string `db:"table_name"`
+		Column     string `db:"column_name"`
+		DataType   string `db:"data_type"`
+		IsNullable string `db:"is_nullable"`
+	}
+	query := "SELECT table_schema || '.' || table_name AS table_name, column_name, data_type, is_nullable FROM information_schema.columns WHERE table_schema='onco_base'"
+	if err := db.Select(&columns, query); err != nil {
+		return err
+	}
+
+	tables := map[string]map[string]schemaColumn{}
+	for _, column := range columns {
+		if tables[column.Table] == nil {
+			tables[column.Table] = map[string]schemaColumn{}
+		}
+		tables[column.Table][column.Column] = schemaColumn{DataType: column.DataType, Nullable: column.IsNullable == "YES"}
+	}
+
+	if problems := compareSchema(schemaModels, tables); len(problems) > 0 {
+		return &SchemaDriftError{Problems: problems}
+	}
+	return nil
+}
+
+// compareSchema returns the differences between the models and the tables, given as columns by column name
+func compareSchema(models map[string]interface{}, tables map[string]map[string]schemaColumn) []string {
+	var problems []string
+
+	for table, tableModel := range models {
+		columns, ok := tables[table]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("table %s does not exist", table))
+			continue
+		}
+
+		modelType := reflect.TypeOf(tableModel)
+		fields := map[string]bool{}
+		for i := 0; i < modelType.NumField(); i++ {
+			field := modelType.Field(i)
+			column := field.Tag.Get("db")
+			if column == "" || column == "-" {
+				continue
+			}
+			fields[column] = true
+
+			info, ok := columns[column]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: column does not exist, but is tagged in model.%s", table, column, modelType.Name()))
+				continue
+			}
+			if !columnTypeMatches(field.Type, info.DataType) {
+				problems = append(problems, fmt.Sprintf("%s.%s: column is %s, but model.%s.%s is %s", table, column, info.DataType, modelType.Name(), field.Name, field.Type))
+			}
+			if info.Nullable && !nullable(field.Type) {
+				problems = append(problems, fmt.Sprintf("%s.%s: column is nullable, but model.%s.%s is %s", table, column, modelType.Name(), field.Name, field.Type))
+			}
+		}
+
+		for column := range columns {
+			if !fields[column] {
+				problems = append(problems, fmt.Sprintf("%s.%s: column has no field in model.%s", table, column, modelType.Name()))
+			}
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// nullable reports whether NULL can be read into the Go type, a pointer, an sql.Null type or raw JSON
+func nullable(goType reflect.Type) bool {
+	switch goType {
+	case reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}),
+		reflect.TypeOf(sql.NullFloat64{}), reflect.TypeOf(sql.NullString{}), reflect.TypeOf(sql.NullBool{}),
+		reflect.TypeOf(json.RawMessage{}):
+		return true
+	}
+	return goType.Kind() == reflect.Pointer
+}
+
+// columnTypeMatches reports whether a value of the column data type can be read into the Go type
+func columnTypeMatches(goType reflect.Type, dataType string) bool {
+	if goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+
+	var allowed []string
+	switch goType {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(sql.NullTime{}):
+		allowed = timeTypes
+	case reflect.TypeOf(json.RawMessage{}):
+		allowed = jsonTypes
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}):
+		allowed = integerTypes
+	case reflect.TypeOf(sql.NullFloat64{}):
+		allowed = floatTypes
+	case reflect.TypeOf(sql.NullString{}):
+		allowed = stringTypes
+	case reflect.TypeOf(sql.NullBool{}):
+		allowed = boolTypes
+	default:
+		switch goType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			allowed = integerTypes
+		case reflect.Float32, reflect.Float64:
+			allowed = floatTypes
+		case reflect.String:
+			allowed = stringTypes
+		case reflect.Bool:
+			allowed = boolTypes
+		case reflect.Struct:
+			// Other structs are stored as JSON and read with their Scan method
+			if reflect.PointerTo(goType).Implements(scannerType) {
+				allowed = jsonTypes
+			}
+		}
+	}
+
+	return slices.Contains(allowed, dataType)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareSchema(t *testing.T) {
+	models := map[string]interface{}{
+		procedureBloodCountTable: model.ProcedureBloodCount{},
+		courseProcedureTable:     model.CourseProcedure{},
+	}
+	column := func(dataType string) schemaColumn { return schemaColumn{DataType: dataType} }
+	nullableColumn := func(dataType string) schemaColumn { return schemaColumn{DataType: dataType, Nullable: true} }
+
+	testTable := []struct {
+		name             string
+		tables           map[string]map[string]schemaColumn
+		expectedProblems []string
+	}{
+		{
+			name: "OK",
+			tables: map[string]map[string]schemaColumn{
+				procedureBloodCountTable: {"procedure": column("integer"), "blood_count": column("character varying"), "value": nullableColumn("double precision"), "measure_code": column("character varying"), "flag": column("character varying")},
+				courseProcedureTable:     {"id": column("integer"), "patient_course": column("integer"), "begin_date": column("date"), "doctor": column("integer"), "period": column("integer"), "result": column("character varying")},
+			},
+		},
+		{
+			name: "Drift",
+			tables: map[string]map[string]schemaColumn{
+				procedureBloodCountTable: {"procedure": column("integer"), "blood_count": column("character varying"), "value": column("character varying"), "measure_code": column("character varying"), "flag": column("character varying"), "comment": column("text")},
+				courseProcedureTable:     {"id": column("integer"), "patient_course": column("integer"), "begin_date": column("date"), "doctor": column("integer"), "result": column("character varying")},
+			},
+			expectedProblems: []string{
+				"onco_base.course_procedure.period: column does not exist, but is tagged in model.CourseProcedure",
+				"onco_base.procedure_blood_count.comment: column has no field in model.ProcedureBloodCount",
+				"onco_base.procedure_blood_count.value: column is character varying, but model.ProcedureBloodCount.Value is *float64",
+			},
+		},
+		{
+			name: "Nullable",
+			tables: map[string]map[string]schemaColumn{
+				procedureBloodCountTable: {"procedure": column("integer"), "blood_count": column("character varying"), "value": nullableColumn("double precision"), "measure_code": nullableColumn("character varying"), "flag": column("character varying")},
+				courseProcedureTable:     {"id": column("integer"), "patient_course": column("integer"), "begin_date": column("date"), "doctor": column("integer"), "period": column("integer"), "result": nullableColumn("character varying")},
+			},
+			expectedProblems: []string{
+				"onco_base.course_procedure.result: column is nullable, but model.CourseProcedure.Result is string",
+				"onco_base.procedure_blood_count.measure_code: column is nullable, but model.ProcedureBloodCount.MeasureCode is string",
+			},
+		},
+		{
+			name: "Missing table",
+			tables: map[string]map[string]schemaColumn{
+				procedureBloodCountTable: {"procedure": column("integer"), "blood_count": column("character varying"), "value": column("real"), "measure_code": column("character varying"), "flag": column("character varying")},
+			},
+			expectedProblems: []string{"table onco_base.course_procedure does not exist"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedProblems, compareSchema(models, testCase.tables))
+		})
+	}
+}
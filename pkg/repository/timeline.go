@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+)
+
+type TimelineRepository struct {
+	db DB
+}
+
+func NewTimelineRepository(db DB) *TimelineRepository {
+	return &TimelineRepository{db: db}
+}
+
+// Get the events of the patient history from database in a single query, ordered by date,
+// diagnoses of unknown date come first.
+// Events of the same date follow the clinical order: diagnosis, course start, procedure, its results, course end
+func (r *TimelineRepository) GetPatientTimeline(patientId int) ([]model.TimelineEvent, error) {
+	eventList := []model.TimelineEvent{}
+	query := fmt.Sprintf(`SELECT date, type, disease, stage, diagnosis, patient_course, course, doctor, procedure, result, blood_count, value, measure_code, flag
+FROM (
+	SELECT COALESCE(to_char(COALESCE(a.created_at::date, c.begin_date), 'YYYY-MM-DD'), '') AS date, '%[6]s' AS type, 1 AS sequence,
+		pd.disease, pd.stage, COALESCE(pd.diagnosis, '') AS diagnosis,
+		NULL::int AS patient_course, '' AS course, NULL::int AS doctor, NULL::int AS procedure, '' AS result,
+		'' AS blood_count, NULL::float AS value, '' AS measure_code, '' AS flag
+	FROM %[1]s pd
+	LEFT JOIN (SELECT entity_id, min(created_at) AS created_at FROM %[5]s WHERE entity=$2 AND action=$3 GROUP BY entity_id) a
+		ON a.entity_id = pd.patient || '/' || pd.disease
+	LEFT JOIN (SELECT patient, disease, min(begin_date) AS begin_date FROM %[2]s GROUP BY patient, disease) c
+		ON c.patient = pd.patient AND c.disease = pd.disease
+	WHERE pd.patient = $1
+
+	UNION ALL
+
+	SELECT to_char(pc.begin_date, 'YYYY-MM-DD'), '%[7]s', 2,
+		COALESCE(pc.disease, ''), '', COALESCE(pc.diagnosis, ''),
+		pc.id, pc.course, pc.doctor, NULL, '',
+		'', NULL, '', ''
+	FROM %[2]s pc
+	WHERE pc.patient = $1
+
+	UNION ALL
+
+	SELECT to_char(pc.end_date, 'YYYY-MM-DD'), '%[8]s', 5,
+		COALESCE(pc.disease, ''), '', COALESCE(pc.diagnosis, ''),
+		pc.id, pc.course, pc.doctor, NULL, '',
+		'', NULL, '', ''
+	FROM %[2]s pc
+	WHERE pc.patient = $1 AND pc.end_date IS NOT NULL
+
+	UNION ALL
+
+	SELECT to_char(cp.begin_date, 'YYYY-MM-DD'), '%[9]s', 3,
+		COALESCE(pc.disease, ''), '', '',
+		pc.id, pc.course, cp.doctor, cp.id, cp.result,
+		'', NULL, '', ''
+	FROM %[3]s cp
+	JOIN %[2]s pc ON pc.id = cp.patient_course
+	WHERE pc.patient = $1
+
+	UNION ALL
+
+	SELECT to_char(cp.begin_date, 'YYYY-MM-DD'), '%[10]s', 4,
+		COALESCE(pc.disease, ''), '', '',
+		pc.id, pc.course, cp.doctor, cp.id, '',
+		pbc.blood_count, pbc.value, pbc.measure_code,
+		pbc.flag
+	FROM %[4]s pbc
+	JOIN %[3]s cp ON cp.id = pbc.procedure
+	JOIN %[2]s pc ON pc.id = cp.patient_course
+	WHERE pc.patient = $1
+) AS event
+ORDER BY date, sequence, patient_course NULLS FIRST, procedure NULLS FIRST, disease, blood_count`,
+		patientDiseaseTable, patientCourseTable, courseProcedureTable, procedureBloodCountTable, auditLogTable,
+		model.DiagnosisRecordedEvent, model.CourseStartedEvent, model.CourseEndedEvent, model.ProcedurePerformedEvent, model.BloodCountResultEvent,
+	)
+	err := r.db.Select(&eventList, query, patientId, model.PatientDiseaseResource, model.CreateAction)
+	return eventList, err
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+	"time"
+)
+
+type TokenRepository struct {
+	db DB
+}
+
+func NewTokenRepository(db DB) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+// Create refresh token in database
+func (r *TokenRepository) CreateRefreshToken(refreshToken model.RefreshToken) error {
+	query := fmt.Sprintf("INSERT INTO %s (token_hash, user_id, role, expires_at) VALUES ($1, $2, $3, $4)", refreshTokenTable)
+	_, err := r.db.Exec(query,
+		refreshToken.TokenHash,
+		refreshToken.UserId,
+		refreshToken.Role,
+		refreshToken.ExpiresAt,
+	)
+	return err
+}
+
+// Get refresh token from database by its hash
+func (r *TokenRepository) GetRefreshToken(tokenHash string) (model.RefreshToken, error) {
+	var refreshToken model.RefreshToken
+	query := fmt.Sprintf("SELECT * FROM %s WHERE token_hash=$1", refreshTokenTable)
+	err := r.db.Get(&refreshToken, query, tokenHash)
+	return refreshToken, err
+}
+
+// Revoke refresh token by its hash, returns false if the token was not active
+func (r *TokenRepository) RevokeRefreshToken(tokenHash string) (bool, error) {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at=now() WHERE token_hash=$1 AND revoked_at IS NULL", refreshTokenTable)
+	result, err := r.db.Exec(query, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// Revoke all refresh tokens of the user and reject access tokens issued before now
+func (r *TokenRepository) RevokeUserTokens(userId int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE %s SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL", refreshTokenTable)
+	if _, err = tx.Exec(query, userId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (user_id, revoked_at) VALUES ($1, now()) ON CONFLICT (user_id) DO UPDATE SET revoked_at=EXCLUDED.revoked_at", userTokenRevocationTable)
+	if _, err = tx.Exec(query, userId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Add access token ID to the blacklist until the token expires
+func (r *TokenRepository) RevokeAccessToken(tokenId string, expiresAt time.Time) error {
+	// Blacklisted tokens are useless once expired, so drop them on the way
+	query := fmt.Sprintf("DELETE FROM %s WHERE expires_at < now()", revokedAccessTokenTable)
+	if _, err := r.db.Exec(query); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING", revokedAccessTokenTable)
+	_, err := r.db.Exec(query, tokenId, expiresAt)
+	return err
+}
+
+// Check whether the access token was blacklisted or issued before the user's sessions were revoked.
+// The issue time of a token has second precision, so the revocation time is truncated to seconds as well,
+// otherwise a token issued right after a revocation in the same second would be rejected too.
+func (r *TokenRepository) IsAccessTokenRevoked(tokenId string, userId int, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE jti=$1) OR EXISTS (SELECT 1 FROM %s WHERE user_id=$2 AND date_trunc('second', revoked_at) > $3)",
+		revokedAccessTokenTable, userTokenRevocationTable)
+	err := r.db.Get(&revoked, query, tokenId, userId, issuedAt)
+	return revoked, err
+}
+
+// Create password reset token in database, unused reset tokens of the same email are invalidated
+func (r *TokenRepository) CreatePasswordResetToken(tokenHash, email string, expiresAt time.Time) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("UPDATE %s SET used_at=now() WHERE email=$1 AND used_at IS NULL", passwordResetTokenTable)
+	if _, err = tx.Exec(query, email); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (token_hash, email, expires_at) VALUES ($1, $2, $3)", passwordResetTokenTable)
+	if _, err = tx.Exec(query, tokenHash, email, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Mark password reset token as used and return its email, fails if the token is unknown, used or expired
+func (r *TokenRepository) UsePasswordResetToken(tokenHash string) (string, error) {
+	var email string
+	query := fmt.Sprintf("UPDATE %s SET used_at=now() WHERE token_hash=$1 AND used_at IS NULL AND expires_at > now() RETURNING email", passwordResetTokenTable)
+	err := r.db.Get(&email, query, tokenHash)
+	return email, err
+}
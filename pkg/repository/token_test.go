@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	db := testDB(t)
+	repo := NewTokenRepository(db)
+
+	// Revoke the sessions of user 1, as a password change does, and log in again at once
+	assert.NoError(t, repo.RevokeUserTokens(1))
+	var revokedAt time.Time
+	if !assert.NoError(t, db.Get(&revokedAt, "SELECT revoked_at FROM onco_base.user_token_revocation WHERE user_id=1")) {
+		t.FailNow()
+	}
+	assert.NoError(t, repo.RevokeAccessToken("blacklisted", revokedAt.Add(time.Hour)))
+
+	testTable := []struct {
+		name            string
+		tokenId         string
+		userId          int
+		issuedAt        time.Time
+		expectedRevoked bool
+	}{
+		// Token issue times are whole seconds
+		{name: "Issued in the second of the revocation", tokenId: "new", userId: 1, issuedAt: revokedAt.Truncate(time.Second)},
+		{name: "Issued after the revocation", tokenId: "later", userId: 1, issuedAt: revokedAt.Truncate(time.Second).Add(time.Second)},
+		{name: "Issued before the revocation", tokenId: "old", userId: 1, issuedAt: revokedAt.Truncate(time.Second).Add(-time.Second), expectedRevoked: true},
+		{name: "Blacklisted", tokenId: "blacklisted", userId: 1, issuedAt: revokedAt.Truncate(time.Second), expectedRevoked: true},
+		{name: "Other user", tokenId: "other", userId: 2, issuedAt: revokedAt.Truncate(time.Second).Add(-time.Hour)},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			revoked, err := repo.IsAccessTokenRevoked(testCase.tokenId, testCase.userId, testCase.issuedAt)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRevoked, revoked)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DB runs the queries of the repositories, it is the database or a transaction shared by the repositories of a unit of work
+type DB interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	QueryRowx(query string, args ...interface{}) *sqlx.Row
+	Queryx(query string, args ...interface{}) (*sqlx.Rows, error)
+}
+
+// txDB is a transaction started by a repository method
+type txDB interface {
+	DB
+	Commit() error
+	Rollback() error
+}
+
+// begin starts a transaction, or a savepoint when the repository already runs in a transaction,
+// so a method that needs a transaction of its own can be a part of a unit of work
+func begin(db DB) (txDB, error) {
+	switch db := db.(type) {
+	case *sqlx.DB:
+		return db.Beginx()
+	case *sqlx.Tx:
+		return newSavepoint(db)
+	case *savepoint:
+		return newSavepoint(db.Tx)
+	default:
+		return nil, fmt.Errorf("cannot begin a transaction on %T", db)
+	}
+}
+
+// savepoint is a nested transaction, it is released on commit and the changes after it are undone on rollback
+type savepoint struct {
+	*sqlx.Tx
+	done bool
+}
+
+// Savepoints of the same name shadow each other, so the latest one is released or rolled back to
+const savepointName = "repository_savepoint"
+
+func newSavepoint(tx *sqlx.Tx) (*savepoint, error) {
+	if _, err := tx.Exec("SAVEPOINT " + savepointName); err != nil {
+		return nil, err
+	}
+	return &savepoint{Tx: tx}, nil
+}
+
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Exec("RELEASE SAVEPOINT " + savepointName)
+	return err
+}
+
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Exec("ROLLBACK TO SAVEPOINT " + savepointName)
+	return err
+}
+
+type TransactionRepository struct {
+	db DB
+}
+
+func NewTransactionRepository(db DB) *TransactionRepository {
+	return &TransactionRepository{db: db}
+}
+
+// Run fn with repositories sharing one transaction, commit it if fn succeeds and roll it back otherwise.
+// In repositories that run in a transaction already fn runs in a savepoint of it.
+func (r *TransactionRepository) InTransaction(fn func(repos *Repository) error) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(NewRepository(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
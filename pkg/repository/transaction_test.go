@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+	"med/pkg/model"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInTransaction(t *testing.T) {
+	db := testDB(t)
+	mustExec(t, db, "INSERT INTO onco_base.app_user (id, email, password, role, user_type) VALUES (1, 'admin@clinic.ru', 'hash', 'admin', 'internal')")
+	repo := NewTransactionRepository(db)
+	count := func(table string) int {
+		var count int
+		assert.NoError(t, db.Get(&count, "SELECT count(*) FROM "+table))
+		return count
+	}
+
+	// A failed unit of work leaves neither the change nor its audit entry
+	err := repo.InTransaction(func(repos *Repository) error {
+		created, err := repos.Patient.CreatePatient(model.Patient{FirstName: "Anna", LastName: "Ivanova"})
+		if err != nil {
+			return err
+		}
+		err = repos.Audit.CreateAuditEntry(model.AuditEntry{UserId: 1, Action: model.CreateAction, Entity: model.PatientResource, EntityId: strconv.Itoa(created.Id)})
+		if err != nil {
+			return err
+		}
+		return errors.New("interrupted")
+	})
+	assert.EqualError(t, err, "interrupted")
+	assert.Equal(t, 0, count(patientTable))
+	assert.Equal(t, 0, count(auditLogTable))
+
+	// A method with a transaction of its own runs in a savepoint of the unit of work
+	err = repo.InTransaction(func(repos *Repository) error {
+		if _, err := repos.User.GetUserByIdForUpdate(1); err != nil {
+			return err
+		}
+		if err := repos.Token.RevokeUserTokens(1); err != nil {
+			return err
+		}
+		return repos.Audit.CreateAuditEntry(model.AuditEntry{UserId: 1, Action: model.UpdateAction, Entity: model.UserResource, EntityId: "1"})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count(userTokenRevocationTable))
+	assert.Equal(t, 1, count(auditLogTable))
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+)
+
+type TrendRepository struct {
+	db DB
+}
+
+func NewTrendRepository(db DB) *TrendRepository {
+	return &TrendRepository{db: db}
+}
+
+// Get results of the blood count of the patient from database, ordered by procedure date. Results without a value are skipped
+func (r *TrendRepository) GetBloodCountSeries(patientId int, bloodCountId string) ([]model.TrendPoint, error) {
+	pointList := []model.TrendPoint{}
+	query := fmt.Sprintf(`SELECT to_char(cp.begin_date, 'YYYY-MM-DD') AS date, cp.id AS procedure, pc.id AS patient_course,
+	pbc.value, pbc.measure_code, pbc.flag
+FROM %s pbc
+JOIN %s cp ON cp.id = pbc.procedure
+JOIN %s pc ON pc.id = cp.patient_course
+WHERE pc.patient = $1 AND pbc.blood_count = $2 AND pbc.value IS NOT NULL
+ORDER BY cp.begin_date, cp.id`, procedureBloodCountTable, courseProcedureTable, patientCourseTable)
+	err := r.db.Select(&pointList, query, patientId, bloodCountId)
+	return pointList, err
+}
+
+// Get period of the patient course from database
+func (r *TrendRepository) GetTrendCourse(patientCourseId int) (model.TrendCourse, error) {
+	var course model.TrendCourse
+	query := fmt.Sprintf(`SELECT id, patient, to_char(begin_date, 'YYYY-MM-DD') AS begin_date, COALESCE(to_char(end_date, 'YYYY-MM-DD'), '') AS end_date
+FROM %s WHERE id=$1`, patientCourseTable)
+	err := r.db.Get(&course, query, patientCourseId)
+	return course, err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"fmt"
+	"med/pkg/model"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type UnitConversionRepository struct {
+	db DB
+}
+
+func NewUnitConversionRepository(db DB) *UnitConversionRepository {
+	return &UnitConversionRepository{db: db}
+}
+
+// Create unit conversion in database and get it from database
+func (r *UnitConversionRepository) CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error) {
+	var createdUnitConversion model.UnitConversion
+	query := fmt.Sprintf(`INSERT INTO %s (from_unit, to_unit, factor, "offset") VALUES ($1, $2, $3, $4) RETURNING *`, unitConversionTable)
+	err := r.db.Get(&createdUnitConversion, query,
+		unitConversion.From,
+		unitConversion.To,
+		unitConversion.Factor,
+		unitConversion.Offset,
+	)
+	return createdUnitConversion, err
+}
+
+// Get page of unit conversions
+func (r *UnitConversionRepository) GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error) {
+	return selectPage[model.UnitConversion](r.db, unitConversionTable, []string{"from_unit", "to_unit"}, squirrel.And{}, listQuery)
+}
+
+// Get unit conversion from database by its units
+func (r *UnitConversionRepository) GetUnitConversion(from, to string) (model.UnitConversion, error) {
+	var unitConversion model.UnitConversion
+	query := fmt.Sprintf("SELECT * FROM %s WHERE from_unit=$1 AND to_unit=$2", unitConversionTable)
+	err := r.db.Get(&unitConversion, query, from, to)
+	return unitConversion, err
+}
+
+// Delete unit conversion from database
+func (r *UnitConversionRepository) DeleteUnitConversion(from, to string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE from_unit=$1 AND to_unit=$2", unitConversionTable)
+	_, err := r.db.Exec(query, from, to)
+	return err
+}
@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"med/pkg/model"
 
-	"github.com/jmoiron/sqlx"
+	"github.com/Masterminds/squirrel"
 )
 
 type UnitMeasureRepository struct {
-	db *sqlx.DB
+	db DB
 }
 
-func NewUnitMeasureRepository(db *sqlx.DB) *UnitMeasureRepository {
+func NewUnitMeasureRepository(db DB) *UnitMeasureRepository {
 	return &UnitMeasureRepository{db: db}
 }
 
@@ -28,12 +28,9 @@ func (r *UnitMeasureRepository) CreateUnitMeasure(unitMeasure model.UnitMeasure)
 	return createdUnitMeasure, err
 }
 
-// Get unit measure list from database
-func (r *UnitMeasureRepository) GetUnitMeasureList() ([]model.UnitMeasure, error) {
-	var unitMeasureList []model.UnitMeasure
-	query := fmt.Sprintf("SELECT * FROM %s", unitMeasureTable)
-	err := r.db.Select(&unitMeasureList, query)
-	return unitMeasureList, err
+// Get page of unit measures
+func (r *UnitMeasureRepository) GetUnitMeasureList(listQuery model.ListQuery) (model.Page[model.UnitMeasure], error) {
+	return selectPage[model.UnitMeasure](r.db, unitMeasureTable, []string{"id"}, squirrel.And{}, listQuery)
 }
 
 // Get unit measure from database by ID
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"med/pkg/model"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// userProfileQuery selects user profiles together with the linked patient and doctor records
+var userProfileQuery = fmt.Sprintf(`SELECT u.id, u.email, u.role, u.user_type, u.first_name, u.middle_name, u.last_name,
+	COALESCE(u.phone, '') AS phone, COALESCE(p.id, 0) AS patient_id, COALESCE(d.id, 0) AS doctor_id, u.created_at,
+	u.locked_at IS NOT NULL AS locked
+	FROM %s u LEFT JOIN %s p ON p.user_id=u.id LEFT JOIN %s d ON d.user_id=u.id`, userTable, patientTable, doctorTable)
+
+type UserRepository struct {
+	db DB
+}
+
+func NewUserRepository(db DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create internal staff user in database and get user's profile
+func (r *UserRepository) CreateStaffUser(user model.User) (model.UserProfile, error) {
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (email, password, role, user_type, first_name, middle_name, last_name, phone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')) RETURNING id`, userTable)
+	err := r.db.Get(&id, query,
+		user.Email,
+		user.Password,
+		user.Role,
+		model.InternalUserType,
+		user.FirstName,
+		user.MiddleName,
+		user.LastName,
+		user.Phone,
+	)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+	return r.GetUserById(id)
+}
+
+// Get user profile from database by id
+func (r *UserRepository) GetUserById(id int) (model.UserProfile, error) {
+	var user model.UserProfile
+	query := userProfileQuery + " WHERE u.id=$1"
+	err := r.db.Get(&user, query, id)
+	return user, err
+}
+
+// Get user profile from database by id and lock the user until the end of the transaction
+func (r *UserRepository) GetUserByIdForUpdate(id int) (model.UserProfile, error) {
+	var user model.UserProfile
+	query := userProfileQuery + " WHERE u.id=$1 FOR UPDATE OF u"
+	err := r.db.Get(&user, query, id)
+	return user, err
+}
+
+// Get page of user profiles matching the filter
+func (r *UserRepository) GetUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error) {
+	where := squirrel.And{}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		where = append(where, squirrel.Or{
+			squirrel.ILike{"email": pattern},
+			squirrel.ILike{"first_name": pattern},
+			squirrel.ILike{"middle_name": pattern},
+			squirrel.ILike{"last_name": pattern},
+		})
+	}
+	if filter.Role != "" {
+		where = append(where, squirrel.Eq{"role": filter.Role})
+	}
+	if filter.Locked != nil {
+		where = append(where, squirrel.Eq{"locked": *filter.Locked})
+	}
+	return selectPage[model.UserProfile](r.db, "("+userProfileQuery+") AS profile", []string{"id"}, where, listQuery)
+}
+
+// legacyUser is a row of the legacy user tables or of the unified user table
+type legacyUser struct {
+	Id         int            `db:"id"`
+	Email      string         `db:"email"`
+	Password   string         `db:"password"`
+	Role       string         `db:"role"`
+	UserType   string         `db:"user_type"`
+	FirstName  sql.NullString `db:"first_name"`
+	MiddleName sql.NullString `db:"middle_name"`
+	LastName   sql.NullString `db:"last_name"`
+	Phone      sql.NullString `db:"phone"`
+}
+
+// Move rows from the legacy internal_user and external_user tables into the unified user table.
+// External users keep their ids, because patient and doctor records reference them. An id or an email that is taken
+// by another user is a conflict, and nothing is moved while there are conflicts, so patient and doctor records are never
+// linked to a different account. Rows that were moved before are skipped, so the migration can be run again safely.
+func (r *UserRepository) MigrateLegacyUsers() (model.LegacyUserMigration, error) {
+	tx, err := begin(r.db)
+	if err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+	defer tx.Rollback()
+
+	// Users created while migrating could take the checked ids and emails
+	if _, err = tx.Exec(fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", userTable)); err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+
+	var existing, external, internal []legacyUser
+	query := fmt.Sprintf("SELECT id, email, password, role, user_type, phone FROM %s", userTable)
+	if err = tx.Select(&existing, query); err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+	exists, err := tableExists(tx, externalUserTable)
+	if err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+	if exists {
+		query := fmt.Sprintf("SELECT id, email, password, role FROM %s ORDER BY id", externalUserTable)
+		if err = tx.Select(&external, query); err != nil {
+			return model.LegacyUserMigration{}, err
+		}
+	}
+	exists, err = tableExists(tx, internalUserTable)
+	if err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+	if exists {
+		query := fmt.Sprintf("SELECT id, email, password, role, first_name, middle_name, last_name, phone FROM %s ORDER BY id", internalUserTable)
+		if err = tx.Select(&internal, query); err != nil {
+			return model.LegacyUserMigration{}, err
+		}
+	}
+
+	externalCopies, internalCopies, migration := planLegacyUserMigration(existing, external, internal)
+	if len(migration.Conflicts) > 0 {
+		return migration, nil
+	}
+
+	for _, user := range externalCopies {
+		query := fmt.Sprintf("INSERT INTO %s (id, email, password, role, user_type) VALUES ($1, $2, $3, $4, $5)", userTable)
+		if _, err = tx.Exec(query, user.Id, user.Email, user.Password, user.Role, model.ExternalUserType); err != nil {
+			return model.LegacyUserMigration{}, err
+		}
+	}
+
+	// Copied ids bypass the sequence, so move it past them before inserting new rows
+	query = fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s", userTable, userTable)
+	if _, err = tx.Exec(query); err != nil {
+		return model.LegacyUserMigration{}, err
+	}
+
+	for _, user := range internalCopies {
+		query := fmt.Sprintf(`INSERT INTO %s (email, password, role, user_type, first_name, middle_name, last_name, phone)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, userTable)
+		_, err = tx.Exec(query, user.Email, user.Password, user.Role, model.InternalUserType,
+			user.FirstName.String, user.MiddleName.String, user.LastName.String, user.Phone)
+		if err != nil {
+			return model.LegacyUserMigration{}, err
+		}
+	}
+
+	// Databases created before the unified table still reference the legacy table
+	for _, table := range []string{patientTable, doctorTable} {
+		query := fmt.Sprintf(`ALTER TABLE %[1]s DROP CONSTRAINT IF EXISTS %[2]s_user_id_fkey,
+			ADD CONSTRAINT %[2]s_user_id_fkey FOREIGN KEY (user_id) REFERENCES %[3]s (id)`, table, tableName(table), userTable)
+		if _, err = tx.Exec(query); err != nil {
+			return model.LegacyUserMigration{}, err
+		}
+	}
+
+	return migration, tx.Commit()
+}
+
+// planLegacyUserMigration splits the legacy users into the rows to copy, the rows copied before and the conflicting rows.
+// An external user was copied before if its id is taken by a user with the same email, an internal user
+// if its email is taken by an internal user with the same password hash.
+func planLegacyUserMigration(existing, external, internal []legacyUser) ([]legacyUser, []legacyUser, model.LegacyUserMigration) {
+	migration := model.LegacyUserMigration{Skipped: []model.LegacyUserRow{}, Conflicts: []model.LegacyUserRow{}}
+	ids := map[int]legacyUser{}
+	emails := map[string]legacyUser{}
+	phones := map[string]bool{}
+	claim := func(user legacyUser) {
+		if user.Id != 0 {
+			ids[user.Id] = user
+		}
+		emails[strings.ToLower(user.Email)] = user
+		if user.Phone.Valid {
+			phones[user.Phone.String] = true
+		}
+	}
+	for _, user := range existing {
+		claim(user)
+	}
+
+	var externalCopies, internalCopies []legacyUser
+	for _, user := range external {
+		row := model.LegacyUserRow{Table: tableName(externalUserTable), Id: user.Id, Email: user.Email}
+		owner, idTaken := ids[user.Id]
+		_, emailTaken := emails[strings.ToLower(user.Email)]
+
+		switch {
+		case idTaken && strings.EqualFold(owner.Email, user.Email):
+			row.Reason = "already migrated"
+			migration.Skipped = append(migration.Skipped, row)
+		case idTaken:
+			row.Reason = fmt.Sprintf("id is taken by %s", owner.Email)
+			migration.Conflicts = append(migration.Conflicts, row)
+		case emailTaken:
+			row.Reason = "email is taken by another user"
+			migration.Conflicts = append(migration.Conflicts, row)
+		default:
+			user.UserType = model.ExternalUserType
+			claim(user)
+			externalCopies = append(externalCopies, user)
+		}
+	}
+
+	for _, user := range internal {
+		row := model.LegacyUserRow{Table: tableName(internalUserTable), Id: user.Id, Email: user.Email}
+		owner, emailTaken := emails[strings.ToLower(user.Email)]
+
+		switch {
+		case emailTaken && owner.UserType == model.InternalUserType && owner.Password == user.Password:
+			row.Reason = "already migrated"
+			migration.Skipped = append(migration.Skipped, row)
+		case emailTaken:
+			row.Reason = "email is taken by another user"
+			migration.Conflicts = append(migration.Conflicts, row)
+		case user.Phone.Valid && phones[user.Phone.String]:
+			row.Reason = "phone is taken by another user"
+			migration.Conflicts = append(migration.Conflicts, row)
+		default:
+			// Internal users get new ids, the legacy ids are referenced by nothing
+			copied := user
+			copied.Id = 0
+			copied.UserType = model.InternalUserType
+			claim(copied)
+			internalCopies = append(internalCopies, user)
+		}
+	}
+
+	if len(migration.Conflicts) == 0 {
+		migration.External, migration.Internal = len(externalCopies), len(internalCopies)
+	}
+	return externalCopies, internalCopies, migration
+}
+
+// tableExists reports whether the schema-qualified table exists
+func tableExists(tx txDB, table string) (bool, error) {
+	var exists bool
+	err := tx.Get(&exists, "SELECT to_regclass($1) IS NOT NULL", table)
+	return exists, err
+}
+
+// execCount executes the query and returns the number of affected rows
+func execCount(tx txDB, query string, args ...interface{}) (int, error) {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// tableName strips the schema from the table name
+func tableName(table string) string {
+	return strings.TrimPrefix(table, "onco_base.")
+}
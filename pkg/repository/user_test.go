@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanLegacyUserMigration(t *testing.T) {
+	// The admin that starts the migration is already in the unified table, usually with id 1
+	existing := []legacyUser{
+		{Id: 1, Email: "admin@clinic.ru", Password: "hash-admin", UserType: model.InternalUserType},
+		{Id: 2, Email: "Patient@mail.ru", Password: "hash-2", UserType: model.ExternalUserType},
+		{Id: 3, Email: "doctor@clinic.ru", Password: "hash-doctor", UserType: model.InternalUserType, Phone: sql.NullString{String: "+79120000000", Valid: true}},
+	}
+
+	testTable := []struct {
+		name             string
+		external         []legacyUser
+		internal         []legacyUser
+		expectedExternal []legacyUser
+		expectedInternal []legacyUser
+		expected         model.LegacyUserMigration
+	}{
+		{
+			name:     "Copied",
+			external: []legacyUser{{Id: 5, Email: "new@mail.ru", Password: "hash-5", Role: model.PatientRole}},
+			internal: []legacyUser{{Id: 1, Email: "nurse@clinic.ru", Password: "hash-nurse", Role: model.DoctorRole}},
+			expectedExternal: []legacyUser{
+				{Id: 5, Email: "new@mail.ru", Password: "hash-5", Role: model.PatientRole, UserType: model.ExternalUserType},
+			},
+			expectedInternal: []legacyUser{{Id: 1, Email: "nurse@clinic.ru", Password: "hash-nurse", Role: model.DoctorRole}},
+			expected:         model.LegacyUserMigration{External: 1, Internal: 1, Skipped: []model.LegacyUserRow{}, Conflicts: []model.LegacyUserRow{}},
+		},
+		{
+			name:             "Migrated before",
+			external:         []legacyUser{{Id: 2, Email: "patient@mail.ru", Password: "hash-2"}},
+			internal:         []legacyUser{{Id: 7, Email: "doctor@clinic.ru", Password: "hash-doctor"}},
+			expectedExternal: nil,
+			expected: model.LegacyUserMigration{
+				Skipped: []model.LegacyUserRow{
+					{Table: "external_user", Id: 2, Email: "patient@mail.ru", Reason: "already migrated"},
+					{Table: "internal_user", Id: 7, Email: "doctor@clinic.ru", Reason: "already migrated"},
+				},
+				Conflicts: []model.LegacyUserRow{},
+			},
+		},
+		{
+			name: "Conflicts",
+			external: []legacyUser{
+				{Id: 1, Email: "ivanov@mail.ru", Password: "hash-1"},
+				{Id: 6, Email: "admin@clinic.ru", Password: "hash-6"},
+				{Id: 8, Email: "fine@mail.ru", Password: "hash-8"},
+			},
+			internal: []legacyUser{
+				{Id: 2, Email: "fine@mail.ru", Password: "hash-fine"},
+				{Id: 3, Email: "doctor@clinic.ru", Password: "other-hash"},
+				{Id: 4, Email: "surgeon@clinic.ru", Password: "hash-4", Phone: sql.NullString{String: "+79120000000", Valid: true}},
+			},
+			expectedExternal: []legacyUser{{Id: 8, Email: "fine@mail.ru", Password: "hash-8", UserType: model.ExternalUserType}},
+			expected: model.LegacyUserMigration{
+				Skipped: []model.LegacyUserRow{},
+				Conflicts: []model.LegacyUserRow{
+					{Table: "external_user", Id: 1, Email: "ivanov@mail.ru", Reason: "id is taken by admin@clinic.ru"},
+					{Table: "external_user", Id: 6, Email: "admin@clinic.ru", Reason: "email is taken by another user"},
+					{Table: "internal_user", Id: 2, Email: "fine@mail.ru", Reason: "email is taken by another user"},
+					{Table: "internal_user", Id: 3, Email: "doctor@clinic.ru", Reason: "email is taken by another user"},
+					{Table: "internal_user", Id: 4, Email: "surgeon@clinic.ru", Reason: "phone is taken by another user"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			external, internal, migration := planLegacyUserMigration(existing, testCase.external, testCase.internal)
+
+			assert.Equal(t, testCase.expectedExternal, external)
+			assert.Equal(t, testCase.expectedInternal, internal)
+			assert.Equal(t, testCase.expected, migration)
+		})
+	}
+}
+
+func TestMigrateLegacyUsers(t *testing.T) {
+	db := testDB(t)
+	mustExec(t, db,
+		"INSERT INTO onco_base.app_user (id, email, password, role, user_type) VALUES (1, 'admin@clinic.ru', 'hash', 'admin', 'internal')",
+		"SELECT setval(pg_get_serial_sequence('onco_base.app_user', 'id'), 1)",
+		`CREATE TABLE onco_base.external_user (id SERIAL PRIMARY KEY, email VARCHAR(60) NOT NULL UNIQUE,
+			password VARCHAR(255) NOT NULL, role VARCHAR(30) NOT NULL)`,
+		`CREATE TABLE onco_base.internal_user (id SERIAL PRIMARY KEY, first_name VARCHAR(30), middle_name VARCHAR(30),
+			last_name VARCHAR(30), email VARCHAR(60) NOT NULL UNIQUE, phone VARCHAR(12) UNIQUE, password VARCHAR(30) NOT NULL,
+			role VARCHAR(30) NOT NULL)`,
+		"INSERT INTO onco_base.external_user (id, email, password, role) VALUES (1, 'ivanova@mail.ru', 'hash-1', 'patient'), (2, 'petrov@mail.ru', 'hash-2', 'patient')",
+		"INSERT INTO onco_base.internal_user (email, password, role, last_name) VALUES ('doctor@clinic.ru', 'hash-3', 'doctor', 'Smirnov')",
+	)
+	repo := NewUserRepository(db)
+
+	// External user 1 collides with the admin, so nothing is moved
+	migration, err := repo.MigrateLegacyUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, []model.LegacyUserRow{{Table: "external_user", Id: 1, Email: "ivanova@mail.ru", Reason: "id is taken by admin@clinic.ru"}},
+		migration.Conflicts)
+	var count int
+	assert.NoError(t, db.Get(&count, "SELECT count(*) FROM onco_base.app_user"))
+	assert.Equal(t, 1, count)
+
+	// Once the conflict is resolved, the users are moved and a second run skips them
+	mustExec(t, db, "UPDATE onco_base.external_user SET id=10 WHERE id=1")
+	migration, err = repo.MigrateLegacyUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, model.LegacyUserMigration{External: 2, Internal: 1, Skipped: []model.LegacyUserRow{}, Conflicts: []model.LegacyUserRow{}}, migration)
+
+	migration, err = repo.MigrateLegacyUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, migration.External+migration.Internal)
+	assert.Len(t, migration.Skipped, 3)
+	assert.Empty(t, migration.Conflicts)
+
+	var emails []string
+	assert.NoError(t, db.Select(&emails, "SELECT email FROM onco_base.app_user ORDER BY id"))
+	assert.Equal(t, []string{"admin@clinic.ru", "petrov@mail.ru", "ivanova@mail.ru", "doctor@clinic.ru"}, emails)
+}
@@ -13,7 +13,6 @@ func createAccountRoutes[G Group](route G, handlers *handler.Handler) *gin.Route
 		account.GET("/blood-count", handlers.PatientIdentity, handlers.AccountHandler.BloodCount)
 		account.GET("/doctors", handlers.PatientIdentity, handlers.AccountHandler.Doctors)
 		account.GET("/patients-data", handlers.DoctorIdentity, handlers.AccountHandler.PatientData)
-		account.GET("/console", handlers.AdminIdentity, handlers.AccountHandler.Console)
 	}
 	return account
 }
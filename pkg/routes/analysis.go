@@ -0,0 +1,16 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createAnalysisRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	analysis := route.Group("/analysis", handlers.UserIdentity, handlers.CheckPermissions(model.AnalysisResource))
+	{
+		analysis.POST("/score", handlers.ScoreBloodCounts)
+	}
+	return analysis
+}
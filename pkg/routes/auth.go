@@ -11,7 +11,11 @@ func createAuthRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGr
 	{
 		auth.POST("/login", handlers.LogIn)
 		auth.POST("/registry", handlers.Registry)
+		auth.POST("/invitation/accept", handlers.AcceptInvitation)
+		auth.POST("/refresh", handlers.Refresh)
 		auth.POST("/logout", handlers.LogOut)
+		auth.POST("/reset-password", handlers.ResetPassword)
+		auth.POST("/reset-password/confirm", handlers.ConfirmResetPassword)
 	}
 	return auth
 }
@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createBloodCountRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	bloodCount := route.Group("/blood-count")
+	bloodCount := route.Group("/blood-count", handlers.UserIdentity, handlers.CheckPermissions(model.BloodCountResource))
 	{
 		bloodCount.POST("/", handlers.CreateBloodCount)
 		bloodCount.GET("/", handlers.GetBloodCountList)
@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createBloodCountValueRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	bloodCountValue := route.Group("/blood-count-value")
+	bloodCountValue := route.Group("/blood-count-value", handlers.UserIdentity, handlers.CheckPermissions(model.BloodCountValueResource))
 	{
 		bloodCountValue.POST("/", handlers.CreateBloodCountValue)
 		bloodCountValue.GET("/", handlers.GetBloodCountValueList)
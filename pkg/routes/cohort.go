@@ -0,0 +1,21 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createCohortRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	cohort := route.Group("/cohort", handlers.UserIdentity, handlers.CheckPermissions(model.CohortResource))
+	{
+		cohort.POST("/", handlers.CreateCohort)
+		cohort.GET("/", handlers.GetCohortList)
+		cohort.GET("/:id", handlers.GetCohortById)
+		cohort.GET("/:id/statistics", handlers.GetCohortStatistics)
+		cohort.PUT("/", handlers.UpdateCohort)
+		cohort.DELETE("/:id", handlers.DeleteCohort)
+	}
+	return cohort
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createConsoleRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	console := route.Group("/console", handlers.CheckPermissions(model.ConsoleResource))
+	{
+		console.GET("", handlers.AccountHandler.Console)
+		console.GET("/users", handlers.AccountHandler.SearchUsers)
+		console.PUT("/users/:id/lock", handlers.AccountHandler.LockUser)
+		console.DELETE("/users/:id/lock", handlers.AccountHandler.UnlockUser)
+		console.PUT("/users/:id/role", handlers.AccountHandler.ChangeUserRole)
+		console.GET("/failed-logins", handlers.AccountHandler.FailedLogins)
+		console.GET("/changes", handlers.AccountHandler.Changes)
+	}
+	return console
+}
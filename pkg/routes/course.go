@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createCourseRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	course := route.Group("/course")
+	course := route.Group("/course", handlers.UserIdentity, handlers.CheckPermissions(model.CourseResource))
 	{
 		course.POST("/", handlers.CreateCourse)
 		course.GET("/", handlers.GetCourseList)
@@ -0,0 +1,21 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createCourseProcedureRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	courseProcedure := route.Group("/course-procedure", handlers.UserIdentity, handlers.CheckPermissions(model.CourseProcedureResource))
+	{
+		courseProcedure.POST("/", handlers.CreateCourseProcedure)
+		courseProcedure.GET("/", handlers.GetCourseProcedureList)
+		courseProcedure.GET("/:id", handlers.GetCourseProcedureById)
+		courseProcedure.GET("/:id/risk", handlers.GetProcedureRisk)
+		courseProcedure.PUT("/:id", handlers.UpdateCourseProcedure)
+		courseProcedure.DELETE("/:id", handlers.DeleteCourseProcedure)
+	}
+	return courseProcedure
+}
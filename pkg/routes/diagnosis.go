@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createDiagnosisRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	diagnosis := route.Group("/diagnosis")
+	diagnosis := route.Group("/diagnosis", handlers.UserIdentity, handlers.CheckPermissions(model.DiagnosisResource))
 	{
 		diagnosis.POST("/", handlers.CreateDiagnosis)
 		diagnosis.GET("/", handlers.GetDiagnosisList)
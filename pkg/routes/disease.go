@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createDiseaseRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	disease := route.Group("/disease")
+	disease := route.Group("/disease", handlers.UserIdentity, handlers.CheckPermissions(model.DiseaseResource))
 	{
 		disease.POST("/", handlers.CreateDisease)
 		disease.GET("/", handlers.GetDiseaseList)
@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createDoctorRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	doctor := route.Group("/doctor")
+	doctor := route.Group("/doctor", handlers.UserIdentity, handlers.CheckPermissions(model.DoctorResource))
 	{
 		doctor.POST("/", handlers.CreateDoctor)
 		doctor.GET("/", handlers.GetDoctorList)
@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createDoctorPatientRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	doctorPatient := route.Group("/doctor-patient")
+	doctorPatient := route.Group("/doctor-patient", handlers.UserIdentity, handlers.CheckPermissions(model.DoctorPatientResource))
 	{
 		doctorPatient.POST("/", handlers.CreateDoctorPatient)
 		doctorPatient.GET("/:doctor_id", handlers.GetDoctorPatientList)
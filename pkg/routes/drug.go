@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createDrugRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	drug := route.Group("/drug")
+	drug := route.Group("/drug", handlers.UserIdentity, handlers.CheckPermissions(model.DrugResource))
 	{
 		drug.POST("/", handlers.CreateDrug)
 		drug.GET("/", handlers.GetDrugList)
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createExportRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	export := route.Group("/export", handlers.UserIdentity, handlers.CheckPermissions(model.ExportResource))
+	{
+		export.POST("/", handlers.CreateExportJob)
+		export.GET("/", handlers.GetExportJobList)
+		export.GET("/:id", handlers.GetExportJobById)
+		export.GET("/:id/download", handlers.DownloadExport)
+	}
+	return export
+}
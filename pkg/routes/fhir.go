@@ -0,0 +1,59 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// createFHIRRoutes adds the FHIR R4 facade, its resources are checked with the permissions of the data they map to
+func createFHIRRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	fhir := route.Group("/fhir", handlers.UserIdentity)
+	{
+		patient := fhir.Group("/Patient", handlers.CheckPermissions(model.PatientResource))
+		{
+			patient.POST("", handlers.CreateFHIRPatient)
+			patient.GET("", handlers.SearchFHIRPatients)
+			patient.GET("/:id", handlers.GetFHIRPatient)
+			patient.PUT("/:id", handlers.UpdateFHIRPatient)
+		}
+
+		observation := fhir.Group("/Observation", handlers.CheckPermissions(model.ProcedureBloodCountResource))
+		{
+			observation.POST("", handlers.CreateFHIRObservation)
+			observation.GET("", handlers.SearchFHIRObservations)
+			observation.GET("/:id", handlers.GetFHIRObservation)
+			observation.PUT("/:id", handlers.UpdateFHIRObservation)
+		}
+
+		condition := fhir.Group("/Condition", handlers.CheckPermissions(model.PatientDiseaseResource))
+		{
+			condition.POST("", handlers.CreateFHIRCondition)
+			condition.GET("", handlers.SearchFHIRConditions)
+			condition.GET("/:id", handlers.GetFHIRCondition)
+			condition.PUT("/:id", handlers.UpdateFHIRCondition)
+		}
+
+		medicationRequest := fhir.Group("/MedicationRequest", handlers.CheckPermissions(model.PatientCourseResource))
+		{
+			medicationRequest.POST("", handlers.CreateFHIRMedicationRequest)
+			medicationRequest.GET("", handlers.SearchFHIRMedicationRequests)
+			medicationRequest.GET("/:id", handlers.GetFHIRMedicationRequest)
+			medicationRequest.PUT("/:id", handlers.UpdateFHIRMedicationRequest)
+		}
+
+		medication := fhir.Group("/Medication", handlers.CheckPermissions(model.DrugResource))
+		{
+			medication.GET("", handlers.SearchFHIRMedications)
+			medication.GET("/:id", handlers.GetFHIRMedication)
+		}
+
+		practitioner := fhir.Group("/Practitioner", handlers.CheckPermissions(model.DoctorResource))
+		{
+			practitioner.GET("", handlers.SearchFHIRPractitioners)
+			practitioner.GET("/:id", handlers.GetFHIRPractitioner)
+		}
+	}
+	return fhir
+}
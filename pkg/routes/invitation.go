@@ -0,0 +1,16 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createInvitationRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	invitation := route.Group("/invitation", handlers.CheckPermissions(model.InvitationResource))
+	{
+		invitation.POST("/", handlers.InviteUser)
+	}
+	return invitation
+}
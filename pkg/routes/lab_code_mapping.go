@@ -0,0 +1,18 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createLabCodeMappingRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	labCodeMapping := route.Group("/lab-code-mapping", handlers.UserIdentity, handlers.CheckPermissions(model.LabCodeMappingResource))
+	{
+		labCodeMapping.POST("/", handlers.CreateLabCodeMapping)
+		labCodeMapping.GET("/", handlers.GetLabCodeMappingList)
+		labCodeMapping.DELETE("/", handlers.DeleteLabCodeMapping)
+	}
+	return labCodeMapping
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createLabMessageRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	labMessage := route.Group("/lab-message", handlers.UserIdentity, handlers.CheckPermissions(model.LabMessageResource))
+	{
+		labMessage.POST("/", handlers.IngestLabMessage)
+	}
+	return labMessage
+}
@@ -2,18 +2,23 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createPatientsRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	patient := route.Group("/patient")
+	patient := route.Group("/patient", handlers.CheckPermissions(model.PatientResource))
 	{
 		patient.POST("/", handlers.CreatePatient)
 		patient.GET("/", handlers.GetPatientList)
+		patient.GET("/search", handlers.SearchPatients)
 		patient.GET("/:id", handlers.GetPatientById)
+		patient.GET("/:id/timeline", handlers.GetPatientTimeline)
+		patient.GET("/:id/trend", handlers.GetPatientTrend)
 		patient.PUT("/:id", handlers.UpdatePatient)
 		patient.DELETE("/:id", handlers.DeletePatient)
+		patient.POST("/:id/registration-code", handlers.IssueRegistrationCode)
 	}
 	return patient
 }
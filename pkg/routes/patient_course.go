@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createPatientCourseRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	patientCourse := route.Group("/patient-course")
+	patientCourse := route.Group("/patient-course", handlers.UserIdentity, handlers.CheckPermissions(model.PatientCourseResource))
 	{
 		patientCourse.POST("/", handlers.CreatePatientCourse)
 		patientCourse.GET("/", handlers.GetPatientCourseList)
@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createPatientDiseaseRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	patientDisease := route.Group("/patient-disease")
+	patientDisease := route.Group("/patient-disease", handlers.UserIdentity, handlers.CheckPermissions(model.PatientDiseaseResource))
 	{
 		patientDisease.POST("/", handlers.CreatePatientDisease)
 		patientDisease.GET("/", handlers.GetPatientDiseaseList)
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createPermissionRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	permission := route.Group("/permission", handlers.CheckPermissions(model.PermissionResource))
+	{
+		permission.POST("/", handlers.CreatePermission)
+		permission.GET("/", handlers.GetPermissionList)
+		permission.DELETE("/", handlers.DeletePermission)
+	}
+	return permission
+}
@@ -2,14 +2,16 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createProcedureBloodCountRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	procedureBloodCount := route.Group("/procedure-blood-count")
+	procedureBloodCount := route.Group("/procedure-blood-count", handlers.UserIdentity, handlers.CheckPermissions(model.ProcedureBloodCountResource))
 	{
 		procedureBloodCount.POST("/", handlers.CreateProcedureBloodCount)
+		procedureBloodCount.POST("/import", handlers.ImportProcedureBloodCounts)
 		procedureBloodCount.GET("/", handlers.GetProcedureBloodCountList)
 		procedureBloodCount.GET("/procedure/:procedure_id", handlers.GetProcedureBloodCountListByProcedure)
 		procedureBloodCount.GET("/blood-count/:blood_count_id", handlers.GetProcedureBloodCountListByBloodCount)
@@ -18,23 +18,36 @@ func InitRoutes(handlers *handler.Handler) *gin.Engine {
 	createAuthRoutes(router, handlers)
 
 	account := createAccountRoutes(router, handlers)
+	createPermissionRoutes(account, handlers)
+	createSessionRoutes(account, handlers)
+	createUserRoutes(account, handlers)
+	createInvitationRoutes(account, handlers)
+	createConsoleRoutes(account, handlers)
 
+	createAnalysisRoutes(router, handlers)
 	createBloodCountRoutes(router, handlers)
 	createBloodCountValueRoutes(router, handlers)
+	createCohortRoutes(router, handlers)
 
 	createCourseRoutes(router, handlers)
+	createCourseProcedureRoutes(router, handlers)
 
 	createDiagnosisRoutes(router, handlers)
 	createDiseaseRoutes(router, handlers)
 	createDoctorRoutes(router, handlers)
 	createDoctorPatientRoutes(router, handlers)
 	createDrugRoutes(router, handlers)
+	createExportRoutes(router, handlers)
+	createFHIRRoutes(router, handlers)
+	createLabCodeMappingRoutes(router, handlers)
+	createLabMessageRoutes(router, handlers)
 
 	createPatientsRoutes(account, handlers)
 	createPatientCourseRoutes(router, handlers)
 	createPatientDiseaseRoutes(router, handlers)
 	createProcedureBloodCountRoutes(router, handlers)
 
+	createUnitConversionRoutes(router, handlers)
 	createUnitMeasureRoutes(router, handlers)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	return router
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createSessionRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	session := route.Group("/session", handlers.CheckPermissions(model.SessionResource))
+	{
+		session.DELETE("/:id", handlers.RevokeUserSessions)
+	}
+	return session
+}
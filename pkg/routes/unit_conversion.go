@@ -0,0 +1,19 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createUnitConversionRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	unitConversion := route.Group("/unit-conversion", handlers.UserIdentity, handlers.CheckPermissions(model.UnitConversionResource))
+	{
+		unitConversion.POST("/", handlers.CreateUnitConversion)
+		unitConversion.GET("/", handlers.GetUnitConversionList)
+		unitConversion.GET("/convert", handlers.ConvertValue)
+		unitConversion.DELETE("/", handlers.DeleteUnitConversion)
+	}
+	return unitConversion
+}
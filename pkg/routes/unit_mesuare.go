@@ -2,12 +2,13 @@ package route
 
 import (
 	"med/pkg/handler"
+	"med/pkg/model"
 
 	"github.com/gin-gonic/gin"
 )
 
 func createUnitMeasureRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
-	unitMeasure := route.Group("/unit-measure")
+	unitMeasure := route.Group("/unit-measure", handlers.UserIdentity, handlers.CheckPermissions(model.UnitMeasureResource))
 	{
 		unitMeasure.POST("/", handlers.CreateUnitMeasure)
 		unitMeasure.GET("/", handlers.GetUnitMeasureList)
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"med/pkg/handler"
+	"med/pkg/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createUserRoutes[G Group](route G, handlers *handler.Handler) *gin.RouterGroup {
+	user := route.Group("/user", handlers.CheckPermissions(model.UserResource))
+	{
+		user.POST("/", handlers.CreateStaffUser)
+		user.GET("/", handlers.GetUserList)
+		user.GET("/:id", handlers.GetUserById)
+		user.POST("/migrate", handlers.MigrateLegacyUsers)
+	}
+	return user
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+// ErrForbidden is returned when the user is not allowed to access the requested patient data.
+var ErrForbidden = errors.New("access to patient data is forbidden")
+
+// AccessService resolves whether a user may access a patient's records.
+// Admins see every patient, doctors see the patients linked to them through doctor_patient
+// and patients see the records attached to their own user account.
+type AccessService struct {
+	repo repository.Access
+}
+
+func NewAccessService(repo repository.Access) *AccessService {
+	return &AccessService{repo: repo}
+}
+
+func (s *AccessService) CheckPatientAccess(user UserData, patientId int) error {
+	var (
+		allowed bool
+		err     error
+	)
+
+	switch user.Role {
+	case model.AdminRole:
+		return nil
+	case model.DoctorRole:
+		allowed, err = s.repo.IsDoctorPatient(user.Id, patientId)
+	case model.PatientRole:
+		allowed, err = s.repo.IsPatientUser(user.Id, patientId)
+	}
+
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (s *AccessService) CheckPatientCourseAccess(user UserData, patientCourseId int) error {
+	if user.Role == model.AdminRole {
+		return nil
+	}
+
+	patientId, err := s.repo.GetPatientIdByPatientCourse(patientCourseId)
+	if err != nil {
+		return err
+	}
+	return s.CheckPatientAccess(user, patientId)
+}
+
+func (s *AccessService) CheckCourseProcedureAccess(user UserData, procedureId int) error {
+	if user.Role == model.AdminRole {
+		return nil
+	}
+
+	patientId, err := s.repo.GetPatientIdByCourseProcedure(procedureId)
+	if err != nil {
+		return err
+	}
+	return s.CheckPatientAccess(user, patientId)
+}
+
+// GetAccessiblePatientIdList returns the IDs of patients visible to the user.
+// The boolean result is true when the user may see every patient.
+func (s *AccessService) GetAccessiblePatientIdList(user UserData) ([]int, bool, error) {
+	switch user.Role {
+	case model.AdminRole:
+		return nil, true, nil
+	case model.DoctorRole:
+		patientIdList, err := s.repo.GetDoctorPatientIdList(user.Id)
+		return patientIdList, false, err
+	case model.PatientRole:
+		patientIdList, err := s.repo.GetUserPatientIdList(user.Id)
+		return patientIdList, false, err
+	}
+	return nil, false, ErrForbidden
+}
+
+// accessiblePatientIds returns the IDs of patients visible to the user, or nil if the user may see every patient.
+func accessiblePatientIds(access Access, user UserData) ([]int, error) {
+	patientIdList, all, err := access.GetAccessiblePatientIdList(user)
+	if err != nil || all {
+		return nil, err
+	}
+	if patientIdList == nil {
+		return []int{}, nil
+	}
+	return patientIdList, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// accessRepository links doctor 7 to patient 1 and user 9 to patient 2.
+// Course 10 and procedure 100 are of patient 1, course 20 and procedure 200 of patient 2
+type accessRepository struct {
+	doctorPatients    map[int][]int
+	userPatients      map[int][]int
+	coursePatients    map[int]int
+	procedurePatients map[int]int
+	lookups           int
+}
+
+func newAccessRepository() *accessRepository {
+	return &accessRepository{
+		doctorPatients:    map[int][]int{7: {1}},
+		userPatients:      map[int][]int{9: {2}},
+		coursePatients:    map[int]int{10: 1, 20: 2},
+		procedurePatients: map[int]int{100: 1, 200: 2},
+	}
+}
+
+func (r *accessRepository) IsDoctorPatient(userId, patientId int) (bool, error) {
+	return slices.Contains(r.doctorPatients[userId], patientId), nil
+}
+
+func (r *accessRepository) IsPatientUser(userId, patientId int) (bool, error) {
+	return slices.Contains(r.userPatients[userId], patientId), nil
+}
+
+func (r *accessRepository) GetDoctorPatientIdList(userId int) ([]int, error) {
+	return r.doctorPatients[userId], nil
+}
+
+func (r *accessRepository) GetUserPatientIdList(userId int) ([]int, error) {
+	return r.userPatients[userId], nil
+}
+
+func (r *accessRepository) GetPatientIdByPatientCourse(patientCourseId int) (int, error) {
+	r.lookups++
+	patientId, ok := r.coursePatients[patientCourseId]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return patientId, nil
+}
+
+func (r *accessRepository) GetPatientIdByCourseProcedure(procedureId int) (int, error) {
+	r.lookups++
+	patientId, ok := r.procedurePatients[procedureId]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return patientId, nil
+}
+
+func TestCheckAccess(t *testing.T) {
+	admin := UserData{Id: 1, Role: model.AdminRole}
+	doctor := UserData{Id: 7, Role: model.DoctorRole}
+	patient := UserData{Id: 9, Role: model.PatientRole}
+	researcher := UserData{Id: 11, Role: model.ResearcherRole}
+
+	testTable := []struct {
+		name          string
+		user          UserData
+		record        string
+		id            int
+		expectedError error
+	}{
+		{name: "Admin, any patient", user: admin, record: "patient", id: 2},
+		{name: "Admin, any course", user: admin, record: "course", id: 20},
+		{name: "Admin, any procedure", user: admin, record: "procedure", id: 200},
+		{name: "Doctor, own patient", user: doctor, record: "patient", id: 1},
+		{name: "Doctor, own course", user: doctor, record: "course", id: 10},
+		{name: "Doctor, own procedure", user: doctor, record: "procedure", id: 100},
+		{name: "Doctor, foreign patient", user: doctor, record: "patient", id: 2, expectedError: ErrForbidden},
+		{name: "Doctor, foreign course", user: doctor, record: "course", id: 20, expectedError: ErrForbidden},
+		{name: "Doctor, foreign procedure", user: doctor, record: "procedure", id: 200, expectedError: ErrForbidden},
+		{name: "Doctor, missing course", user: doctor, record: "course", id: 30, expectedError: sql.ErrNoRows},
+		{name: "Patient, own record", user: patient, record: "patient", id: 2},
+		{name: "Patient, own course", user: patient, record: "course", id: 20},
+		{name: "Patient, own procedure", user: patient, record: "procedure", id: 200},
+		{name: "Patient, foreign record", user: patient, record: "patient", id: 1, expectedError: ErrForbidden},
+		{name: "Patient, foreign course", user: patient, record: "course", id: 10, expectedError: ErrForbidden},
+		{name: "Patient, foreign procedure", user: patient, record: "procedure", id: 100, expectedError: ErrForbidden},
+		{name: "Researcher, patient", user: researcher, record: "patient", id: 1, expectedError: ErrForbidden},
+		{name: "Researcher, course", user: researcher, record: "course", id: 10, expectedError: ErrForbidden},
+		{name: "Researcher, procedure", user: researcher, record: "procedure", id: 100, expectedError: ErrForbidden},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := newAccessRepository()
+			service := NewAccessService(repo)
+
+			var err error
+			switch testCase.record {
+			case "patient":
+				err = service.CheckPatientAccess(testCase.user, testCase.id)
+			case "course":
+				err = service.CheckPatientCourseAccess(testCase.user, testCase.id)
+			case "procedure":
+				err = service.CheckCourseProcedureAccess(testCase.user, testCase.id)
+			}
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			// Admins see every record without looking up its patient
+			if testCase.user.Role == model.AdminRole {
+				assert.Zero(t, repo.lookups)
+			}
+		})
+	}
+}
+
+func TestAccessiblePatientIds(t *testing.T) {
+	testTable := []struct {
+		name          string
+		user          UserData
+		expectedIds   []int
+		expectedError error
+	}{
+		{name: "Admin", user: UserData{Id: 1, Role: model.AdminRole}},
+		{name: "Doctor", user: UserData{Id: 7, Role: model.DoctorRole}, expectedIds: []int{1}},
+		{name: "Doctor without patients", user: UserData{Id: 8, Role: model.DoctorRole}, expectedIds: []int{}},
+		{name: "Patient", user: UserData{Id: 9, Role: model.PatientRole}, expectedIds: []int{2}},
+		{name: "Researcher", user: UserData{Id: 11, Role: model.ResearcherRole}, expectedError: ErrForbidden},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			service := NewAccessService(newAccessRepository())
+
+			// nil lets the repositories select every patient, an empty list selects none
+			patientIds, err := accessiblePatientIds(service, testCase.user)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedIds, patientIds)
+		})
+	}
+}
@@ -1,7 +1,79 @@
 package services
 
-import "med/pkg/repository"
+import (
+	"med/pkg/model"
+	"med/pkg/repository"
+)
 
+// AccountService resolves the account data of the authenticated user from the user id.
 type AccountService struct {
-	repo repository.Authorization
+	repo repository.Account
+}
+
+func NewAccountService(repo repository.Account) *AccountService {
+	return &AccountService{repo: repo}
+}
+
+// GetBloodCountList returns the patient's own blood counts over time.
+func (s *AccountService) GetBloodCountList(user UserData) ([]model.AccountBloodCount, error) {
+	if user.Role != model.PatientRole {
+		return nil, ErrForbidden
+	}
+	return s.repo.GetPatientBloodCountList(user.Id)
+}
+
+// GetDoctorList returns the patient's attending doctors.
+func (s *AccountService) GetDoctorList(user UserData) ([]model.Doctor, error) {
+	if user.Role != model.PatientRole {
+		return nil, ErrForbidden
+	}
+	return s.repo.GetPatientDoctorList(user.Id)
+}
+
+// GetPatientPanel returns the doctor's patients with their current diseases and active courses.
+func (s *AccountService) GetPatientPanel(user UserData) ([]model.PanelPatient, error) {
+	if user.Role != model.DoctorRole {
+		return nil, ErrForbidden
+	}
+
+	patientList, err := s.repo.GetPanelPatientList(user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	patientIds := make([]int, len(patientList))
+	panel := make([]model.PanelPatient, len(patientList))
+	panelIndex := make(map[int]int, len(patientList))
+	for i, patient := range patientList {
+		patientIds[i] = patient.Id
+		panel[i] = model.PanelPatient{
+			Patient:  patient,
+			Diseases: []model.PatientDisease{},
+			Courses:  []model.PatientCourse{},
+		}
+		panelIndex[patient.Id] = i
+	}
+	if len(patientIds) == 0 {
+		return panel, nil
+	}
+
+	diseaseList, err := s.repo.GetPatientDiseaseListByPatients(patientIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, disease := range diseaseList {
+		i := panelIndex[disease.Patient]
+		panel[i].Diseases = append(panel[i].Diseases, disease)
+	}
+
+	courseList, err := s.repo.GetActivePatientCourseListByPatients(patientIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, course := range courseList {
+		i := panelIndex[course.Patient]
+		panel[i].Courses = append(panel[i].Courses, course)
+	}
+
+	return panel, nil
 }
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"sort"
+)
+
+// ErrInvalidScoreInput is returned for results of unknown or repeated blood counts.
+var ErrInvalidScoreInput = errors.New("invalid blood count results")
+
+// AnalysisService scores diseases from blood count results. Every result is normalized against the ranges
+// of its blood count and weighted with the coefficients of the diseases in blood_count_value.
+type AnalysisService struct {
+	bloodCountRepo          repository.BloodCount
+	bloodCountValueRepo     repository.BloodCountValue
+	procedureBloodCountRepo repository.ProcedureBloodCount
+	conversions             repository.UnitConversion
+	access                  Access
+}
+
+func NewAnalysisService(bloodCountRepo repository.BloodCount, bloodCountValueRepo repository.BloodCountValue,
+	procedureBloodCountRepo repository.ProcedureBloodCount, conversions repository.UnitConversion, access Access) *AnalysisService {
+	return &AnalysisService{
+		bloodCountRepo:          bloodCountRepo,
+		bloodCountValueRepo:     bloodCountValueRepo,
+		procedureBloodCountRepo: procedureBloodCountRepo,
+		conversions:             conversions,
+		access:                  access,
+	}
+}
+
+// GetProcedureRisk scores diseases from the blood count results of the course procedure. Results without a value are skipped.
+func (s *AnalysisService) GetProcedureRisk(user UserData, procedureId int) ([]model.DiseaseScore, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, procedureId); err != nil {
+		return nil, err
+	}
+
+	procedureBloodCountList, err := s.procedureBloodCountRepo.GetProcedureBloodCountListByProcedures([]int{procedureId})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []model.BloodCountResult
+	for _, procedureBloodCount := range procedureBloodCountList {
+		if procedureBloodCount.Value != nil {
+			results = append(results, model.BloodCountResult{
+				BloodCount:  procedureBloodCount.BloodCount,
+				Value:       *procedureBloodCount.Value,
+				MeasureCode: procedureBloodCount.MeasureCode,
+			})
+		}
+	}
+	return s.ScoreBloodCounts(results)
+}
+
+// ScoreBloodCounts scores diseases from the results, best supported diseases first.
+func (s *AnalysisService) ScoreBloodCounts(results []model.BloodCountResult) ([]model.DiseaseScore, error) {
+	if len(results) == 0 {
+		return []model.DiseaseScore{}, nil
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.BloodCount)
+	}
+	bloodCountList, err := s.bloodCountRepo.GetBloodCountListByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+	bloodCounts := make(map[string]model.BloodCount, len(bloodCountList))
+	for _, bloodCount := range bloodCountList {
+		bloodCounts[bloodCount.Id] = bloodCount
+	}
+
+	deviations := make(map[string]float64, len(results))
+	for _, result := range results {
+		bloodCount, ok := bloodCounts[result.BloodCount]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown blood count %s", ErrInvalidScoreInput, result.BloodCount)
+		}
+		if _, ok := deviations[result.BloodCount]; ok {
+			return nil, fmt.Errorf("%w: repeated blood count %s", ErrInvalidScoreInput, result.BloodCount)
+		}
+		// The ranges are expressed in the unit of the blood count
+		value := result.Value
+		if result.MeasureCode != "" {
+			if value, err = convertValue(s.conversions, value, result.MeasureCode, bloodCount.MeasureCode); err != nil {
+				return nil, err
+			}
+		}
+		// Impossible values are rejected like on saving a procedure result
+		if _, err := flagBloodCount(bloodCount, &value); err != nil {
+			return nil, err
+		}
+		deviations[result.BloodCount] = normalizeBloodCount(bloodCount, value)
+	}
+
+	coefficients, err := s.bloodCountValueRepo.GetAllBloodCountValues()
+	if err != nil {
+		return nil, err
+	}
+	return scoreDiseases(coefficients, deviations), nil
+}
+
+// normalizeBloodCount returns the deviation of the value from the normal range of the blood count: 0 inside the range,
+// down to -1 at the minimal possible value and up to 1 at the maximal possible value.
+func normalizeBloodCount(bloodCount model.BloodCount, value float64) float64 {
+	minNormal, maxNormal := bloodCount.MinNormalValue, bloodCount.MaxNormalValue
+	minPossible, maxPossible := bloodCount.MinPossibleValue, bloodCount.MaxPossibleValue
+
+	switch {
+	case value < minNormal:
+		if minNormal <= minPossible {
+			return -1
+		}
+		return -min((minNormal-value)/(minNormal-minPossible), 1)
+	case value > maxNormal:
+		if maxPossible <= maxNormal {
+			return 1
+		}
+		return min((value-maxNormal)/(maxPossible-maxNormal), 1)
+	default:
+		return 0
+	}
+}
+
+// scoreDiseases weights the deviations of the blood counts with the coefficients of every disease.
+// The score of a disease is the weighted mean of the deviations of its blood counts with a result,
+// so a positive coefficient means high values support the disease and a negative one means low values do.
+// Diseases without any result are left out.
+func scoreDiseases(coefficients []model.BloodCountValue, deviations map[string]float64) []model.DiseaseScore {
+	type sum struct {
+		weighted, weights float64
+		matched, total    int
+	}
+	sums := map[string]*sum{}
+	for _, coefficient := range coefficients {
+		diseaseSum, ok := sums[coefficient.Disease]
+		if !ok {
+			diseaseSum = &sum{}
+			sums[coefficient.Disease] = diseaseSum
+		}
+		diseaseSum.total++
+
+		deviation, ok := deviations[coefficient.BloodCount]
+		if !ok {
+			continue
+		}
+		weight := float64(coefficient.Coefficient)
+		diseaseSum.weighted += weight * deviation
+		diseaseSum.weights += math.Abs(weight)
+		diseaseSum.matched++
+	}
+
+	scores := []model.DiseaseScore{}
+	for disease, diseaseSum := range sums {
+		if diseaseSum.matched == 0 {
+			continue
+		}
+		score := 0.0
+		if diseaseSum.weights > 0 {
+			score = diseaseSum.weighted / diseaseSum.weights
+		}
+		scores = append(scores, model.DiseaseScore{Disease: disease, Score: score, Matched: diseaseSum.matched, Total: diseaseSum.total})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Disease < scores[j].Disease
+	})
+	for i := range scores {
+		scores[i].Rank = i + 1
+	}
+	return scores
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeBloodCount(t *testing.T) {
+	bloodCount := model.BloodCount{MinPossibleValue: 0, MinNormalValue: 10, MaxNormalValue: 20, MaxPossibleValue: 40}
+
+	testTable := []struct {
+		name              string
+		value             float64
+		expectedDeviation float64
+	}{
+		{name: "Normal", value: 15, expectedDeviation: 0},
+		{name: "Normal bound", value: 20, expectedDeviation: 0},
+		{name: "Low", value: 5, expectedDeviation: -0.5},
+		{name: "Minimal", value: 0, expectedDeviation: -1},
+		{name: "High", value: 25, expectedDeviation: 0.25},
+		{name: "Maximal", value: 40, expectedDeviation: 1},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedDeviation, normalizeBloodCount(bloodCount, testCase.value))
+		})
+	}
+}
+
+func TestScoreDiseases(t *testing.T) {
+	coefficients := []model.BloodCountValue{
+		{Disease: "C50", BloodCount: "CA15-3", Coefficient: 3},
+		{Disease: "C50", BloodCount: "CEA", Coefficient: 1},
+		{Disease: "C56", BloodCount: "CA125", Coefficient: 2},
+		{Disease: "C56", BloodCount: "CEA", Coefficient: 1},
+		{Disease: "D50", BloodCount: "HGB", Coefficient: -1},
+		{Disease: "C18", BloodCount: "CA19-9", Coefficient: 1},
+	}
+	deviations := map[string]float64{"CA15-3": 0.5, "CEA": 0.25, "HGB": -0.5}
+
+	expectedScores := []model.DiseaseScore{
+		{Rank: 1, Disease: "D50", Score: 0.5, Matched: 1, Total: 1},
+		{Rank: 2, Disease: "C50", Score: 0.4375, Matched: 2, Total: 2},
+		{Rank: 3, Disease: "C56", Score: 0.25, Matched: 1, Total: 2},
+	}
+	assert.Equal(t, expectedScores, scoreDiseases(coefficients, deviations))
+}
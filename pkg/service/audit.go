@@ -0,0 +1,32 @@
+package services
+
+import (
+	"encoding/json"
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+// recordAudit appends a change of the entity made by the user to the audit log.
+// before is nil for created entities and after is nil for deleted ones.
+func recordAudit(audit repository.Audit, user UserData, action, entity, entityId string, before, after interface{}) error {
+	entry := model.AuditEntry{
+		UserId:   user.Id,
+		Action:   action,
+		Entity:   entity,
+		EntityId: entityId,
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+
+	return audit.CreateAuditEntry(entry)
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// auditTransaction runs the unit of work on the repositories in memory.
+// The audit entries of a unit of work are kept only if it succeeds, as a rolled back transaction leaves none.
+type auditTransaction struct {
+	repos   repository.Repository
+	entries []model.AuditEntry
+}
+
+func (t *auditTransaction) InTransaction(fn func(repos *repository.Repository) error) error {
+	var pending auditLog
+	repos := t.repos
+	repos.Audit = &pending
+	if err := fn(&repos); err != nil {
+		return err
+	}
+	t.entries = append(t.entries, pending...)
+	return nil
+}
+
+type auditLog []model.AuditEntry
+
+func (l *auditLog) CreateAuditEntry(entry model.AuditEntry) error {
+	*l = append(*l, entry)
+	return nil
+}
+
+func (l *auditLog) GetAuditList(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	return *l, nil
+}
+
+// auditPatientRepository keeps the patients in memory and fails the updates with updateError
+type auditPatientRepository struct {
+	repository.Patient
+	patients    map[int]model.Patient
+	updateError error
+}
+
+func (r *auditPatientRepository) CreatePatient(patient model.Patient) (model.Patient, error) {
+	patient.Id = len(r.patients) + 1
+	r.patients[patient.Id] = patient
+	return patient, nil
+}
+
+func (r *auditPatientRepository) GetPatientByIdForUpdate(id int) (model.Patient, error) {
+	patient, ok := r.patients[id]
+	if !ok {
+		return model.Patient{}, sql.ErrNoRows
+	}
+	return patient, nil
+}
+
+func (r *auditPatientRepository) UpdatePatient(patient model.Patient) (model.Patient, error) {
+	if r.updateError != nil {
+		return model.Patient{}, r.updateError
+	}
+	r.patients[patient.Id] = patient
+	return patient, nil
+}
+
+func (r *auditPatientRepository) DeletePatient(id int) error {
+	delete(r.patients, id)
+	return nil
+}
+
+// auditAccess allows access to every patient
+type auditAccess struct {
+	Access
+}
+
+func (a auditAccess) CheckPatientAccess(user UserData, patientId int) error {
+	return nil
+}
+
+func TestPatientServiceAudit(t *testing.T) {
+	user := UserData{Id: 7, Role: model.DoctorRole}
+	stored := model.Patient{Id: 1, FirstName: "Anna", LastName: "Ivanova", BirthDate: stringPointer("1980-05-01"), Sex: "ж", SNILS: stringPointer("12345678901")}
+	updated := stored
+	updated.Phone = stringPointer("+79001234567")
+	created := model.Patient{FirstName: "Petr", LastName: "Petrov", BirthDate: stringPointer("1975-02-03"), Sex: "м", SNILS: stringPointer("10987654321")}
+
+	testTable := []struct {
+		name            string
+		change          func(service *PatientService) error
+		updateError     error
+		expectedError   string
+		expectedEntries []model.AuditEntry
+	}{
+		{
+			name: "Create",
+			change: func(service *PatientService) error {
+				_, err := service.CreatePatient(user, created)
+				return err
+			},
+			expectedEntries: []model.AuditEntry{{UserId: 7, Action: model.CreateAction, Entity: model.PatientResource, EntityId: "2",
+				After: auditJSON(t, model.Patient{Id: 2, FirstName: "Petr", LastName: "Petrov", BirthDate: stringPointer("1975-02-03"), Sex: "м", SNILS: stringPointer("10987654321")})}},
+		},
+		{
+			name: "Update",
+			change: func(service *PatientService) error {
+				_, err := service.UpdatePatient(user, updated)
+				return err
+			},
+			expectedEntries: []model.AuditEntry{{UserId: 7, Action: model.UpdateAction, Entity: model.PatientResource, EntityId: "1",
+				Before: auditJSON(t, stored), After: auditJSON(t, updated)}},
+		},
+		{
+			name: "Failed update",
+			change: func(service *PatientService) error {
+				_, err := service.UpdatePatient(user, updated)
+				return err
+			},
+			updateError:   errors.New("connection reset"),
+			expectedError: "connection reset",
+		},
+		{
+			name: "Missing",
+			change: func(service *PatientService) error {
+				_, err := service.UpdatePatient(user, model.Patient{Id: 5})
+				return err
+			},
+			expectedError: sql.ErrNoRows.Error(),
+		},
+		{
+			name: "Delete",
+			change: func(service *PatientService) error {
+				return service.DeletePatient(user, 1)
+			},
+			expectedEntries: []model.AuditEntry{{UserId: 7, Action: model.DeleteAction, Entity: model.PatientResource, EntityId: "1",
+				Before: auditJSON(t, stored)}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &auditPatientRepository{patients: map[int]model.Patient{1: stored}, updateError: testCase.updateError}
+			tx := &auditTransaction{repos: repository.Repository{Patient: repo, DoctorPatient: &panelRepository{}}}
+			service := NewPatientService(repo, auditAccess{}, tx)
+
+			err := testCase.change(service)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedEntries, tx.entries)
+		})
+	}
+}
+
+// auditUserRepository keeps the users in memory and counts the revocations of their tokens
+type auditUserRepository struct {
+	repository.User
+	repository.Console
+	repository.Token
+	users   map[int]model.UserProfile
+	revoked []int
+}
+
+func (r *auditUserRepository) GetUserByIdForUpdate(id int) (model.UserProfile, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return model.UserProfile{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (r *auditUserRepository) SetUserLocked(id int, locked bool) error {
+	user := r.users[id]
+	user.Locked = locked
+	r.users[id] = user
+	return nil
+}
+
+func (r *auditUserRepository) UpdateUserRole(id int, role string) error {
+	user := r.users[id]
+	user.Role = role
+	r.users[id] = user
+	return nil
+}
+
+func (r *auditUserRepository) RevokeUserTokens(userId int) error {
+	r.revoked = append(r.revoked, userId)
+	return nil
+}
+
+func TestConsoleServiceAudit(t *testing.T) {
+	admin := UserData{Id: 1, Role: model.AdminRole}
+
+	testTable := []struct {
+		name            string
+		change          func(service *ConsoleService) error
+		expectedRevoked []int
+		expectedEntry   model.AuditEntry
+	}{
+		{
+			name:            "Lock",
+			change:          func(service *ConsoleService) error { return service.LockUser(admin, 2) },
+			expectedRevoked: []int{2},
+			expectedEntry: model.AuditEntry{UserId: 1, Action: model.UpdateAction, Entity: model.UserResource, EntityId: "2",
+				Before: json.RawMessage(`{"locked":false}`), After: json.RawMessage(`{"locked":true}`)},
+		},
+		{
+			name:            "Lock locked",
+			change:          func(service *ConsoleService) error { return service.LockUser(admin, 3) },
+			expectedRevoked: []int{3},
+			expectedEntry: model.AuditEntry{UserId: 1, Action: model.UpdateAction, Entity: model.UserResource, EntityId: "3",
+				Before: json.RawMessage(`{"locked":true}`), After: json.RawMessage(`{"locked":true}`)},
+		},
+		{
+			name:   "Unlock",
+			change: func(service *ConsoleService) error { return service.UnlockUser(admin, 3) },
+			expectedEntry: model.AuditEntry{UserId: 1, Action: model.UpdateAction, Entity: model.UserResource, EntityId: "3",
+				Before: json.RawMessage(`{"locked":true}`), After: json.RawMessage(`{"locked":false}`)},
+		},
+		{
+			name: "Role",
+			change: func(service *ConsoleService) error {
+				_, err := service.ChangeUserRole(admin, 2, model.ResearcherRole)
+				return err
+			},
+			expectedRevoked: []int{2},
+			expectedEntry: model.AuditEntry{UserId: 1, Action: model.UpdateAction, Entity: model.UserResource, EntityId: "2",
+				Before: json.RawMessage(`{"role":"doctor"}`), After: json.RawMessage(`{"role":"researcher"}`)},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &auditUserRepository{users: map[int]model.UserProfile{
+				2: {Id: 2, Role: model.DoctorRole},
+				3: {Id: 3, Role: model.DoctorRole, Locked: true},
+			}}
+			tx := &auditTransaction{repos: repository.Repository{User: repo, Console: repo, Token: repo}}
+			service := NewConsoleService(repo, repo, &auditLog{}, tx)
+
+			err := testCase.change(service)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRevoked, repo.revoked)
+			assert.Equal(t, []model.AuditEntry{testCase.expectedEntry}, tx.entries)
+		})
+	}
+}
+
+func auditJSON(t *testing.T, value interface{}) json.RawMessage {
+	content, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return content
+}
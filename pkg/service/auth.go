@@ -1,55 +1,231 @@
 package services
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"med/pkg/model"
 	"med/pkg/repository"
 	"med/pkg/utils"
+	"time"
 )
 
+var (
+	// ErrInvalidCredentials is returned when the email is unknown or the password does not match.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrUserLocked is returned when a locked user tries to log in.
+	ErrUserLocked = errors.New("user account is locked")
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRevokedToken is returned when an access token has been revoked.
+	ErrRevokedToken = errors.New("token has been revoked")
+	// ErrInvalidResetToken is returned when a password reset token is unknown, expired or already used.
+	ErrInvalidResetToken = errors.New("invalid password reset token")
+)
+
+// dummyPasswordHash is checked when the email is unknown, so a rejected login costs the same argon2id run
+// whether or not the email is registered. It is the hash of "dummy password" with the current parameters.
+const dummyPasswordHash = "$argon2id$v=19$m=65536,t=1,p=4$gMNwQ+obeKiZ6zJoAXS6Vw$s6WnugqQ56ziJVz/9kC0uPObYhcICVie9OHfNqSitMQ"
+
 type UserData struct {
-	Id   int
-	Role string
+	Id        int
+	Role      string
+	TokenId   string
+	ExpiresAt time.Time
 }
 
 type AuthorizationService struct {
-	repo repository.Authorization
-	salt []byte
+	repo      repository.Authorization
+	tokenRepo repository.Token
+	mailer    utils.Mailer
+}
+
+func NewAuthService(repo repository.Authorization, tokenRepo repository.Token, mailer utils.Mailer) *AuthorizationService {
+	return &AuthorizationService{repo: repo, tokenRepo: tokenRepo, mailer: mailer}
 }
 
-func NewAuthService(repo repository.Authorization) *AuthorizationService {
-	return &AuthorizationService{repo: repo, salt: utils.Salt}
+// GenerateToken checks the user's credentials and issues a new token pair.
+// Outdated password hashes are replaced with the current format after a successful check.
+// Rejected attempts are saved with the client IP.
+func (s *AuthorizationService) GenerateToken(email, password, ip string) (model.Tokens, error) {
+	user, err := s.repo.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// The result is ignored, the check only keeps the response time of unknown emails the same
+			_, _, _ = utils.VerifyPassword(dummyPasswordHash, password)
+			return model.Tokens{}, s.rejectLogin(email, ip, ErrInvalidCredentials)
+		}
+		return model.Tokens{}, err
+	}
+
+	match, needsRehash, err := utils.VerifyPassword(user.Password, password)
+	if err != nil {
+		return model.Tokens{}, err
+	}
+	if !match {
+		return model.Tokens{}, s.rejectLogin(email, ip, ErrInvalidCredentials)
+	}
+	// Checked after the password, so the lock does not reveal that the email is registered
+	if user.Locked {
+		return model.Tokens{}, s.rejectLogin(email, ip, ErrUserLocked)
+	}
+
+	if needsRehash {
+		passwordHash, err := utils.GeneratePasswordHash(password)
+		if err != nil {
+			return model.Tokens{}, err
+		}
+		if err := s.repo.UpdatePassword(email, passwordHash); err != nil {
+			return model.Tokens{}, err
+		}
+	}
+
+	return s.generateTokens(user.Id, user.Role)
 }
 
-func (s *AuthorizationService) CreateUser(user model.User) (string, error) {
-	user.Password = s.generatePasswordHash(user.Password)
-	return s.repo.CreateUser(user)
+// RefreshToken exchanges a valid refresh token for a new token pair.
+// The used refresh token is revoked, so every refresh token can be used only once.
+func (s *AuthorizationService) RefreshToken(refreshToken string) (model.Tokens, error) {
+	tokenHash := utils.HashToken(refreshToken)
+
+	storedToken, err := s.tokenRepo.GetRefreshToken(tokenHash)
+	if err != nil || storedToken.RevokedAt.Valid || storedToken.ExpiresAt.Before(time.Now()) {
+		return model.Tokens{}, ErrInvalidRefreshToken
+	}
+
+	revoked, err := s.tokenRepo.RevokeRefreshToken(tokenHash)
+	if err != nil {
+		return model.Tokens{}, err
+	}
+	// Lost a race with a concurrent refresh or logout
+	if !revoked {
+		return model.Tokens{}, ErrInvalidRefreshToken
+	}
+
+	return s.generateTokens(storedToken.UserId, storedToken.Role)
+}
+
+// LogOut revokes the refresh token and blacklists the access token until it expires.
+func (s *AuthorizationService) LogOut(user UserData, refreshToken string) error {
+	if refreshToken != "" {
+		if _, err := s.tokenRepo.RevokeRefreshToken(utils.HashToken(refreshToken)); err != nil {
+			return err
+		}
+	}
+	return s.tokenRepo.RevokeAccessToken(user.TokenId, user.ExpiresAt)
+}
+
+// RevokeUserSessions revokes every refresh and access token issued to the user.
+func (s *AuthorizationService) RevokeUserSessions(userId int) error {
+	return s.tokenRepo.RevokeUserTokens(userId)
+}
+
+// RequestPasswordReset emails a single-use password reset token to the user.
+// Unknown emails are ignored, so the response does not reveal which emails are registered.
+func (s *AuthorizationService) RequestPasswordReset(email string) error {
+	if _, err := s.repo.GetUserByEmail(email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	resetToken, err := utils.GenerateResetToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.tokenRepo.CreatePasswordResetToken(utils.HashToken(resetToken), email, time.Now().Add(utils.ResetTokenTTL))
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Use this token to reset your OncoBase password: %s\r\nThe token expires in %s. If you did not request a password reset, ignore this email.", resetToken, utils.ResetTokenTTL)
+	return s.mailer.SendEmail([]string{email}, "OncoBase password reset", body)
 }
 
-func (s *AuthorizationService) GenerateToken(email, password string) (string, error) {
-	user, err := s.repo.GetUser(email, s.generatePasswordHash(password))
+// ResetPassword sets a new password using a reset token and revokes all sessions of the user.
+func (s *AuthorizationService) ResetPassword(resetToken, password string) error {
+	email, err := s.tokenRepo.UsePasswordResetToken(utils.HashToken(resetToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	user, err := s.repo.GetUserByEmail(email)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	token, err := utils.GenerateJWT(user)
+	passwordHash, err := utils.GeneratePasswordHash(password)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := s.repo.UpdatePassword(email, passwordHash); err != nil {
+		return err
 	}
-	return token, nil
+
+	return s.tokenRepo.RevokeUserTokens(user.Id)
 }
 
-func (*AuthorizationService) ParseToken(token string) (*UserData, error) {
+func (s *AuthorizationService) ParseToken(token string) (*UserData, error) {
 	claims, err := utils.ParseToken(token)
 	if err != nil {
 		return nil, err
 	}
+	if claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return nil, errors.New("invalid token claims")
+	}
+
+	revoked, err := s.tokenRepo.IsAccessTokenRevoked(claims.ID, claims.UserId, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrRevokedToken
+	}
 
 	return &UserData{
-		Id:   claims.UserId,
-		Role: claims.UserRole,
+		Id:        claims.UserId,
+		Role:      claims.UserRole,
+		TokenId:   claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
 	}, nil
 }
 
-func (s *AuthorizationService) generatePasswordHash(password string) string {
-	return utils.HashPassword(password, s.salt)
+// rejectLogin saves the failed login and returns the reason, or the error of saving it.
+func (s *AuthorizationService) rejectLogin(email, ip string, reason error) error {
+	err := s.repo.CreateFailedLogin(model.FailedLogin{Email: email, Ip: ip, Reason: reason.Error()})
+	if err != nil {
+		return err
+	}
+	return reason
+}
+
+// generateTokens issues a new access token and stores a new refresh token for the user.
+func (s *AuthorizationService) generateTokens(userId int, role string) (model.Tokens, error) {
+	accessToken, err := utils.GenerateJWT(model.User{Id: userId, Role: role})
+	if err != nil {
+		return model.Tokens{}, err
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return model.Tokens{}, err
+	}
+
+	err = s.tokenRepo.CreateRefreshToken(model.RefreshToken{
+		TokenHash: utils.HashToken(refreshToken),
+		UserId:    userId,
+		Role:      role,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	})
+	if err != nil {
+		return model.Tokens{}, err
+	}
+
+	return model.Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
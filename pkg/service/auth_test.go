@@ -0,0 +1,47 @@
+package services
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"med/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// authorizationRepository knows no users and keeps the rejected logins
+type authorizationRepository struct {
+	failedLogins []model.FailedLogin
+}
+
+func (r *authorizationRepository) GetUserByEmail(email string) (model.User, error) {
+	return model.User{}, sql.ErrNoRows
+}
+
+func (r *authorizationRepository) UpdatePassword(email, password string) error {
+	return nil
+}
+
+func (r *authorizationRepository) CreateFailedLogin(failedLogin model.FailedLogin) error {
+	r.failedLogins = append(r.failedLogins, failedLogin)
+	return nil
+}
+
+func TestDummyPasswordHash(t *testing.T) {
+	// The dummy hash must cost as much as the hashes of real users
+	match, needsRehash, err := utils.VerifyPassword(dummyPasswordHash, "dummy password")
+
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.False(t, needsRehash)
+}
+
+func TestGenerateTokenUnknownEmail(t *testing.T) {
+	repo := &authorizationRepository{}
+	service := NewAuthService(repo, nil, nil)
+
+	_, err := service.GenerateToken("unknown@example.com", "dummy password", "127.0.0.1")
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Equal(t, []model.FailedLogin{{Email: "unknown@example.com", Ip: "127.0.0.1", Reason: ErrInvalidCredentials.Error()}}, repo.failedLogins)
+}
@@ -19,8 +19,8 @@ func (s *BloodCountService) CreateBloodCount(bloodCount model.BloodCount) (model
 func (s *BloodCountService) GetBloodCountById(id string) (model.BloodCount, error) {
 	return s.repo.GetBloodCountById(id)
 }
-func (s *BloodCountService) GetBloodCountList() ([]model.BloodCount, error) {
-	return s.repo.GetBloodCountList()
+func (s *BloodCountService) GetBloodCountList(filter model.BloodCountFilter, listQuery model.ListQuery) (model.Page[model.BloodCount], error) {
+	return s.repo.GetBloodCountList(filter, listQuery)
 }
 func (s *BloodCountService) UpdateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error) {
 	return s.repo.UpdateBloodCount(bloodCount)
@@ -19,14 +19,8 @@ func (s *BloodCountValueService) CreateBloodCountValue(bloodCountValue model.Blo
 func (s *BloodCountValueService) GetBloodCountValueById(diseaseId, bloodCountId string) (model.BloodCountValue, error) {
 	return s.repo.GetBloodCountValueById(diseaseId, bloodCountId)
 }
-func (s *BloodCountValueService) GetBloodCountValueListByDisease(diseaseId string) ([]model.BloodCountValue, error) {
-	return s.repo.GetBloodCountValueListByDisease(diseaseId)
-}
-func (s *BloodCountValueService) GetBloodCountValueListByBloodCount(bloodCountId string) ([]model.BloodCountValue, error) {
-	return s.repo.GetBloodCountValueListByBloodCount(bloodCountId)
-}
-func (s *BloodCountValueService) GetBloodCountValueList() ([]model.BloodCountValue, error) {
-	return s.repo.GetBloodCountValueList()
+func (s *BloodCountValueService) GetBloodCountValueList(filter model.BloodCountValueFilter, listQuery model.ListQuery) (model.Page[model.BloodCountValue], error) {
+	return s.repo.GetBloodCountValueList(filter, listQuery)
 }
 func (s *BloodCountValueService) UpdateBloodCountValue(bloodCountValue model.BloodCountValue) (model.BloodCountValue, error) {
 	return s.repo.UpdateBloodCountValue(bloodCountValue)
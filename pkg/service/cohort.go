@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+const defaultBucketDays = 30
+
+var (
+	// ErrInvalidCohortDefinition is returned for a definition with contradicting criteria.
+	ErrInvalidCohortDefinition = errors.New("invalid cohort definition")
+	// ErrNotCohortOwner is returned when a user other than the author or an administrator changes a cohort.
+	ErrNotCohortOwner = errors.New("cohort belongs to another user")
+)
+
+type CohortService struct {
+	repo repository.Cohort
+	k    int
+}
+
+func NewCohortService(repo repository.Cohort, k int) *CohortService {
+	return &CohortService{repo: repo, k: k}
+}
+
+func (s *CohortService) CreateCohort(user UserData, cohort model.Cohort) (model.Cohort, error) {
+	if err := validateCohortDefinition(cohort.Definition); err != nil {
+		return model.Cohort{}, err
+	}
+	cohort.CreatedBy = user.Id
+	return s.repo.CreateCohort(cohort)
+}
+func (s *CohortService) GetCohortList(filter model.CohortFilter, listQuery model.ListQuery) (model.Page[model.Cohort], error) {
+	return s.repo.GetCohortList(filter, listQuery)
+}
+func (s *CohortService) GetCohortById(id int) (model.Cohort, error) {
+	return s.repo.GetCohortById(id)
+}
+func (s *CohortService) UpdateCohort(user UserData, cohort model.Cohort) (model.Cohort, error) {
+	if err := validateCohortDefinition(cohort.Definition); err != nil {
+		return model.Cohort{}, err
+	}
+	if err := s.checkCohortOwner(user, cohort.Id); err != nil {
+		return model.Cohort{}, err
+	}
+	return s.repo.UpdateCohort(cohort)
+}
+func (s *CohortService) DeleteCohort(user UserData, id int) error {
+	if err := s.checkCohortOwner(user, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteCohort(id)
+}
+
+// GetCohortStatistics runs the saved cohort definition and returns the aggregates of the matching patient courses.
+// Groups of fewer than k patients are merged or suppressed.
+func (s *CohortService) GetCohortStatistics(id int, query model.CohortStatisticsQuery) (model.CohortStatistics, error) {
+	cohort, err := s.repo.GetCohortById(id)
+	if err != nil {
+		return model.CohortStatistics{}, err
+	}
+
+	bucketDays := query.BucketDays
+	if bucketDays <= 0 {
+		bucketDays = defaultBucketDays
+	}
+
+	statistics, err := s.repo.GetCohortStatistics(cohort.Definition, bucketDays, query.BloodCounts)
+	if err != nil {
+		return model.CohortStatistics{}, err
+	}
+	statistics.Cohort = cohort.Id
+	statistics.Privacy = anonymizeCohortStatistics(&statistics, s.k)
+	return statistics, nil
+}
+
+// checkCohortOwner allows the author of the cohort and administrators to change it
+func (s *CohortService) checkCohortOwner(user UserData, id int) error {
+	cohort, err := s.repo.GetCohortById(id)
+	if err != nil {
+		return err
+	}
+	if cohort.CreatedBy != user.Id && user.Role != model.AdminRole {
+		return ErrNotCohortOwner
+	}
+	return nil
+}
+
+func validateCohortDefinition(definition model.CohortDefinition) error {
+	if definition.MinAge != nil && definition.MaxAge != nil && *definition.MinAge > *definition.MaxAge {
+		return fmt.Errorf("%w: min-age %d is greater than max-age %d", ErrInvalidCohortDefinition, *definition.MinAge, *definition.MaxAge)
+	}
+	return nil
+}
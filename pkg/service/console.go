@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"slices"
+	"strconv"
+)
+
+// ErrOwnAccount is returned when an administrator tries to lock or change the role of their own account.
+var ErrOwnAccount = errors.New("cannot change own account")
+
+type ConsoleService struct {
+	repo     repository.Console
+	userRepo repository.User
+	audit    repository.Audit
+	tx       repository.Transaction
+}
+
+func NewConsoleService(repo repository.Console, userRepo repository.User, audit repository.Audit, tx repository.Transaction) *ConsoleService {
+	return &ConsoleService{repo: repo, userRepo: userRepo, audit: audit, tx: tx}
+}
+
+// GetStatistics returns system counters for the period between the dates in YYYY-MM-DD format.
+func (s *ConsoleService) GetStatistics(from, to string) (model.ConsoleStatistics, error) {
+	statistics, err := s.repo.GetStatistics(from, to)
+	statistics.From, statistics.To = from, to
+	return statistics, err
+}
+
+func (s *ConsoleService) SearchUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error) {
+	return s.userRepo.GetUserList(filter, listQuery)
+}
+
+// LockUser locks the user account and revokes all sessions of the user.
+func (s *ConsoleService) LockUser(admin UserData, id int) error {
+	return s.setUserLocked(admin, id, true)
+}
+
+// UnlockUser unlocks the user account.
+func (s *ConsoleService) UnlockUser(admin UserData, id int) error {
+	return s.setUserLocked(admin, id, false)
+}
+
+// ChangeUserRole changes the role of the user. Sessions of the user are revoked, because tokens carry the role.
+func (s *ConsoleService) ChangeUserRole(admin UserData, id int, role string) (model.UserProfile, error) {
+	if admin.Id == id {
+		return model.UserProfile{}, ErrOwnAccount
+	}
+	if !slices.Contains(model.Roles, role) {
+		return model.UserProfile{}, ErrInvalidRole
+	}
+
+	var user model.UserProfile
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if user, err = repos.User.GetUserByIdForUpdate(id); err != nil {
+			return err
+		}
+		if err := repos.Console.UpdateUserRole(id, role); err != nil {
+			return err
+		}
+		if err := repos.Token.RevokeUserTokens(id); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, admin, model.UpdateAction, model.UserResource, strconv.Itoa(id),
+			map[string]string{"role": user.Role}, map[string]string{"role": role})
+	})
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+
+	user.Role = role
+	return user, nil
+}
+
+func (s *ConsoleService) GetFailedLoginList(limit int) ([]model.FailedLogin, error) {
+	return s.repo.GetFailedLoginList(limit)
+}
+
+// GetChangeList returns the latest entries of the audit log matching the filter.
+func (s *ConsoleService) GetChangeList(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	return s.audit.GetAuditList(filter)
+}
+
+func (s *ConsoleService) setUserLocked(admin UserData, id int, locked bool) error {
+	if admin.Id == id {
+		return ErrOwnAccount
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		user, err := repos.User.GetUserByIdForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Console.SetUserLocked(id, locked); err != nil {
+			return err
+		}
+		if locked {
+			if err := repos.Token.RevokeUserTokens(id); err != nil {
+				return err
+			}
+		}
+		return recordAudit(repos.Audit, admin, model.UpdateAction, model.UserResource, strconv.Itoa(id),
+			map[string]bool{"locked": user.Locked}, map[string]bool{"locked": locked})
+	})
+}
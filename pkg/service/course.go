@@ -19,8 +19,8 @@ func (s *CourseService) CreateCourse(course model.Course) (model.Course, error)
 func (s *CourseService) GetCourseById(id string) (model.Course, error) {
 	return s.repo.GetCourseById(id)
 }
-func (s *CourseService) GetCourseList() ([]model.Course, error) {
-	return s.repo.GetCourseList()
+func (s *CourseService) GetCourseList(filter model.CourseFilter, listQuery model.ListQuery) (model.Page[model.Course], error) {
+	return s.repo.GetCourseList(filter, listQuery)
 }
 func (s *CourseService) UpdateCourse(course model.Course) (model.Course, error) {
 	return s.repo.UpdateCourse(course)
@@ -3,28 +3,88 @@ package services
 import (
 	"med/pkg/model"
 	"med/pkg/repository"
+	"strconv"
 )
 
 type CourseProcedureService struct {
-	repo repository.CourseProcedure
+	repo   repository.CourseProcedure
+	access Access
+	tx     repository.Transaction
 }
 
-func NewCourseProcedureService(repo repository.CourseProcedure) *CourseProcedureService {
-	return &CourseProcedureService{repo: repo}
+func NewCourseProcedureService(repo repository.CourseProcedure, access Access, tx repository.Transaction) *CourseProcedureService {
+	return &CourseProcedureService{repo: repo, access: access, tx: tx}
 }
 
-func (s *CourseProcedureService) CreateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
-	return s.repo.CreateCourseProcedure(courseProcedure)
+func (s *CourseProcedureService) CreateCourseProcedure(user UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+	if err := s.access.CheckPatientCourseAccess(user, courseProcedure.PatientCourse); err != nil {
+		return model.CourseProcedure{}, err
+	}
+
+	var createdCourseProcedure model.CourseProcedure
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if createdCourseProcedure, err = repos.CourseProcedure.CreateCourseProcedure(courseProcedure); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.CreateAction, model.CourseProcedureResource, strconv.Itoa(createdCourseProcedure.Id), nil, createdCourseProcedure)
+	})
+	if err != nil {
+		return model.CourseProcedure{}, err
+	}
+	return createdCourseProcedure, nil
 }
-func (s *CourseProcedureService) GetCourseProcedureById(id string) (model.CourseProcedure, error) {
+func (s *CourseProcedureService) GetCourseProcedureById(user UserData, id int) (model.CourseProcedure, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, id); err != nil {
+		return model.CourseProcedure{}, err
+	}
 	return s.repo.GetCourseProcedureById(id)
 }
-func (s *CourseProcedureService) GetCourseProcedureList() ([]model.CourseProcedure, error) {
-	return s.repo.GetCourseProcedureList()
+
+// GetCourseProcedureList returns every course procedure, so it is reserved for admins.
+func (s *CourseProcedureService) GetCourseProcedureList(user UserData, filter model.CourseProcedureFilter, listQuery model.ListQuery) (model.Page[model.CourseProcedure], error) {
+	if user.Role != model.AdminRole {
+		return model.Page[model.CourseProcedure]{}, ErrForbidden
+	}
+	return s.repo.GetCourseProcedureList(filter, listQuery)
 }
-func (s *CourseProcedureService) UpdateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
-	return s.repo.UpdateCourseProcedure(courseProcedure)
+func (s *CourseProcedureService) UpdateCourseProcedure(user UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, courseProcedure.Id); err != nil {
+		return model.CourseProcedure{}, err
+	}
+	if err := s.access.CheckPatientCourseAccess(user, courseProcedure.PatientCourse); err != nil {
+		return model.CourseProcedure{}, err
+	}
+
+	var updatedCourseProcedure model.CourseProcedure
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		courseProcedureBefore, err := repos.CourseProcedure.GetCourseProcedureByIdForUpdate(courseProcedure.Id)
+		if err != nil {
+			return err
+		}
+		if updatedCourseProcedure, err = repos.CourseProcedure.UpdateCourseProcedure(courseProcedure); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.UpdateAction, model.CourseProcedureResource, strconv.Itoa(courseProcedure.Id), courseProcedureBefore, updatedCourseProcedure)
+	})
+	if err != nil {
+		return model.CourseProcedure{}, err
+	}
+	return updatedCourseProcedure, nil
 }
-func (s *CourseProcedureService) DeleteCourseProcedure(id string) error {
-	return s.repo.DeleteCourseProcedure(id)
+func (s *CourseProcedureService) DeleteCourseProcedure(user UserData, id int) error {
+	if err := s.access.CheckCourseProcedureAccess(user, id); err != nil {
+		return err
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		courseProcedure, err := repos.CourseProcedure.GetCourseProcedureByIdForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := repos.CourseProcedure.DeleteCourseProcedure(id); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.DeleteAction, model.CourseProcedureResource, strconv.Itoa(id), courseProcedure, nil)
+	})
 }
@@ -19,8 +19,8 @@ func (s *DiagnosisService) CreateDiagnosis(diagnosis model.Diagnosis) (model.Dia
 func (s *DiagnosisService) GetDiagnosisById(id string) (model.Diagnosis, error) {
 	return s.repo.GetDiagnosisById(id)
 }
-func (s *DiagnosisService) GetDiagnosisList() ([]model.Diagnosis, error) {
-	return s.repo.GetDiagnosisList()
+func (s *DiagnosisService) GetDiagnosisList(listQuery model.ListQuery) (model.Page[model.Diagnosis], error) {
+	return s.repo.GetDiagnosisList(listQuery)
 }
 func (s *DiagnosisService) UpdateDiagnosis(diagnosis model.Diagnosis) (model.Diagnosis, error) {
 	return s.repo.UpdateDiagnosis(diagnosis)
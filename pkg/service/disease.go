@@ -19,8 +19,8 @@ func (s *DiseaseService) CreateDisease(disease model.Disease) (model.Disease, er
 func (s *DiseaseService) GetDiseaseById(id string) (model.Disease, error) {
 	return s.repo.GetDiseaseById(id)
 }
-func (s *DiseaseService) GetDiseaseList() ([]model.Disease, error) {
-	return s.repo.GetDiseaseList()
+func (s *DiseaseService) GetDiseaseList(listQuery model.ListQuery) (model.Page[model.Disease], error) {
+	return s.repo.GetDiseaseList(listQuery)
 }
 func (s *DiseaseService) UpdateDisease(disease model.Disease) (model.Disease, error) {
 	return s.repo.UpdateDisease(disease)
@@ -19,8 +19,8 @@ func (s *DoctorService) CreateDoctor(doctor model.Doctor) (model.Doctor, error)
 func (s *DoctorService) GetDoctorById(id int) (model.Doctor, error) {
 	return s.repo.GetDoctorById(id)
 }
-func (s *DoctorService) GetDoctorList() ([]model.Doctor, error) {
-	return s.repo.GetDoctorList()
+func (s *DoctorService) GetDoctorList(listQuery model.ListQuery) (model.Page[model.Doctor], error) {
+	return s.repo.GetDoctorList(listQuery)
 }
 func (s *DoctorService) UpdateDoctor(doctor model.Doctor) (model.Doctor, error) {
 	return s.repo.UpdateDoctor(doctor)
@@ -16,8 +16,8 @@ func NewDoctorPatientService(repo repository.DoctorPatient) *DoctorPatientServic
 func (s *DoctorPatientService) CreateDoctorPatient(doctorPatient model.DoctorPatient) (model.DoctorPatient, error) {
 	return s.repo.CreateDoctorPatient(doctorPatient)
 }
-func (s *DoctorPatientService) GetDoctorPatientList(doctor_id int) ([]model.DoctorPatient, error) {
-	return s.repo.GetDoctorPatientList(doctor_id)
+func (s *DoctorPatientService) GetDoctorPatientList(doctorId int, listQuery model.ListQuery) (model.Page[model.DoctorPatient], error) {
+	return s.repo.GetDoctorPatientList(doctorId, listQuery)
 }
 func (s *DoctorPatientService) DeleteDoctorPatient(doctorId, patientId int) error {
 	return s.repo.DeleteDoctorPatient(model.DoctorPatient{Patient: patientId, Doctor: doctorId})
@@ -19,8 +19,8 @@ func (s *DrugService) CreateDrug(drug model.Drug) (model.Drug, error) {
 func (s *DrugService) GetDrugById(id string) (model.Drug, error) {
 	return s.repo.GetDrugById(id)
 }
-func (s *DrugService) GetDrugList() ([]model.Drug, error) {
-	return s.repo.GetDrugList()
+func (s *DrugService) GetDrugList(filter model.DrugFilter, listQuery model.ListQuery) (model.Page[model.Drug], error) {
+	return s.repo.GetDrugList(filter, listQuery)
 }
 func (s *DrugService) UpdateDrug(drug model.Drug) (model.Drug, error) {
 	return s.repo.UpdateDrug(drug)
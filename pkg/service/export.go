@@ -0,0 +1,493 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"med/pkg/config"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrExportNotReady is returned when downloading the bundle of an export job that is not done.
+	ErrExportNotReady = errors.New("export is not ready")
+	// ErrNoPseudonymKey is returned when exports are requested without a configured pseudonym key.
+	ErrNoPseudonymKey = errors.New("export pseudonym key is not configured")
+	// ErrNotExportOwner is returned when a user other than the requester or an administrator reads an export job.
+	ErrNotExportOwner = errors.New("export belongs to another user")
+)
+
+// maxDateShift is the largest number of days the shifted dates of a patient are moved by
+const maxDateShift = 182
+
+// exportDictionary describes the exported files. Rows are written with the columns in this order.
+// Date columns of courses and procedures depend on the date de-identification of the job.
+func exportDictionary(dates string) []model.ExportFile {
+	return []model.ExportFile{
+		{
+			Name:        "patients",
+			Description: "Patients without names, SNILS, phone and user account",
+			Columns: []model.ExportColumn{
+				{Name: "patient", Type: "string", Description: "Patient pseudonym, stable between exports with the same key"},
+				{Name: "birth_date", Type: "string", Description: "Birth year, or age band in years at the export date, * if suppressed for k-anonymity"},
+				{Name: "sex", Type: "string", Description: "Sex, * if suppressed for k-anonymity"},
+			},
+		},
+		{
+			Name:        "patient_diseases",
+			Description: "Diseases of the patients",
+			Columns: []model.ExportColumn{
+				{Name: "patient", Type: "string", Description: "Patient pseudonym"},
+				{Name: "disease", Type: "string", Description: "Disease ID"},
+				{Name: "stage", Type: "string", Description: "Disease stage, * if suppressed for k-anonymity"},
+				{Name: "diagnosis", Type: "string", Description: "Diagnosis ID"},
+			},
+		},
+		{
+			Name:        "patient_courses",
+			Description: "Treatment courses of the patients",
+			Columns: []model.ExportColumn{
+				{Name: "patient_course", Type: "string", Description: "Patient course pseudonym"},
+				{Name: "patient", Type: "string", Description: "Patient pseudonym"},
+				{Name: "disease", Type: "string", Description: "Disease ID"},
+				{Name: "course", Type: "string", Description: "Course ID"},
+				dateColumn("begin", "Course begin", dates),
+				dateColumn("end", "Course end, empty while the course goes on", dates),
+				{Name: "diagnosis", Type: "string", Description: "Diagnosis ID"},
+			},
+		},
+		{
+			Name:        "course_procedures",
+			Description: "Procedures of the patient courses without the free-text result",
+			Columns: []model.ExportColumn{
+				{Name: "procedure", Type: "string", Description: "Procedure pseudonym"},
+				{Name: "patient_course", Type: "string", Description: "Patient course pseudonym"},
+				dateColumn("begin", "Procedure", dates),
+			},
+		},
+		{
+			Name:        "procedure_blood_counts",
+			Description: "Blood count results of the procedures",
+			Columns: []model.ExportColumn{
+				{Name: "procedure", Type: "string", Description: "Procedure pseudonym"},
+				{Name: "blood_count", Type: "string", Description: "Blood count ID"},
+				{Name: "value", Type: "number", Description: "Result value in the unit of the blood count, empty if there is no value"},
+				{Name: "measure_code", Type: "string", Description: "Unit measure ID of the value"},
+				{Name: "flag", Type: "string", Description: "low, normal or high against the normal range of the blood count"},
+			},
+		},
+	}
+}
+
+func dateColumn(name, description, dates string) model.ExportColumn {
+	if dates == model.ShiftedDates {
+		return model.ExportColumn{
+			Name:        name + "_date",
+			Type:        "date",
+			Description: description + " date, YYYY-MM-DD, shifted by the same secret number of days for all dates of the patient",
+		}
+	}
+	return model.ExportColumn{Name: name + "_day", Type: "integer", Description: description + " day, days since the first course of the patient"}
+}
+
+type ExportService struct {
+	repo repository.Export
+	dir  string
+	key  []byte
+	k    int
+}
+
+func NewExportService(repo repository.Export, exportConfig config.ConfigExport, k int) *ExportService {
+	return &ExportService{repo: repo, dir: exportConfig.Dir, key: []byte(exportConfig.PseudonymKey), k: k}
+}
+
+// CreateExportJob queues an export of the dataset, the bundle is built in the background.
+func (s *ExportService) CreateExportJob(user UserData, job model.ExportJob) (model.ExportJob, error) {
+	if len(s.key) == 0 {
+		return model.ExportJob{}, ErrNoPseudonymKey
+	}
+
+	if job.Dates == "" {
+		job.Dates = model.RelativeDates
+	}
+	job.Status = model.ExportPending
+	job.RequestedBy = user.Id
+	createdJob, err := s.repo.CreateExportJob(job)
+	if err != nil {
+		return model.ExportJob{}, err
+	}
+
+	go s.runExport(createdJob)
+	return createdJob, nil
+}
+
+// GetExportJobList lists the export jobs of the user, administrators see the jobs of every user.
+func (s *ExportService) GetExportJobList(user UserData, filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error) {
+	if user.Role != model.AdminRole {
+		if filter.RequestedBy != nil && *filter.RequestedBy != user.Id {
+			return model.Page[model.ExportJob]{}, ErrNotExportOwner
+		}
+		filter.RequestedBy = &user.Id
+	}
+	return s.repo.GetExportJobList(filter, listQuery)
+}
+func (s *ExportService) GetExportJobById(user UserData, id int) (model.ExportJob, error) {
+	return s.getOwnExportJob(user, id)
+}
+
+// GetExportBundle returns the path of the bundle of a finished export job.
+func (s *ExportService) GetExportBundle(user UserData, id int) (string, error) {
+	job, err := s.getOwnExportJob(user, id)
+	if err != nil {
+		return "", err
+	}
+	if job.Status != model.ExportDone {
+		return "", fmt.Errorf("%w: job is %s", ErrExportNotReady, job.Status)
+	}
+	return s.bundlePath(job.Id), nil
+}
+
+// getOwnExportJob returns the export job if the user requested it or is an administrator.
+func (s *ExportService) getOwnExportJob(user UserData, id int) (model.ExportJob, error) {
+	job, err := s.repo.GetExportJobById(id)
+	if err != nil {
+		return model.ExportJob{}, err
+	}
+	if job.RequestedBy != user.Id && user.Role != model.AdminRole {
+		return model.ExportJob{}, ErrNotExportOwner
+	}
+	return job, nil
+}
+
+// FailUnfinishedExportJobs marks the jobs left pending or running by a stopped server failed, it is called on startup
+func (s *ExportService) FailUnfinishedExportJobs() (int, error) {
+	return s.repo.FailUnfinishedExportJobs("export was interrupted by a server restart")
+}
+
+// runExport builds the bundle of the job and records the outcome in the job status. It runs in the background,
+// so a panic fails the job instead of the server
+func (s *ExportService) runExport(job model.ExportJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Int("job", job.Id).Msgf("export panicked: %v", r)
+			s.setExportJobStatus(job.Id, model.ExportFailed, fmt.Sprintf("export panicked: %v", r))
+		}
+	}()
+
+	if !s.setExportJobStatus(job.Id, model.ExportRunning, "") {
+		return
+	}
+	if err := s.writeBundle(job); err != nil {
+		s.setExportJobStatus(job.Id, model.ExportFailed, err.Error())
+		return
+	}
+	s.setExportJobStatus(job.Id, model.ExportDone, "")
+}
+
+// setExportJobStatus records the status of the job and logs a failure, there is no caller to return it to
+func (s *ExportService) setExportJobStatus(id int, status, jobError string) bool {
+	if err := s.repo.UpdateExportJobStatus(id, status, jobError); err != nil {
+		log.Error().Err(err).Int("job", id).Msgf("error occured on export job status update to %s", status)
+		return false
+	}
+	return true
+}
+
+// writeBundle writes the de-identified files and the manifest into a zip archive. The archive
+// is written to a temporary file first, so a bundle is either complete or missing
+func (s *ExportService) writeBundle(job model.ExportJob) error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(s.dir, "export-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	manifest := model.ExportManifest{
+		Job:        job.Id,
+		CreatedAt:  job.CreatedAt,
+		Format:     job.Format,
+		BirthDate:  job.BirthDate,
+		Dates:      job.Dates,
+		Pseudonyms: "Patient, patient course and procedure IDs are replaced with the first 16 hex digits of HMAC-SHA256 of the entity and the ID with the export key",
+	}
+
+	err = s.repo.InExportSnapshot(func(snapshot repository.ExportSnapshot) error {
+		dataset, err := snapshot.GetExportDataset()
+		if err != nil {
+			return err
+		}
+		for i := range dataset.Patients {
+			dataset.Patients[i].BirthDate = generalizeBirthDate(dataset.Patients[i].BirthDate, job.BirthDate, job.CreatedAt)
+		}
+		manifest.Privacy = anonymizeExport(&dataset, s.k)
+
+		rows := newExportRows(dataset, s.key, job.Dates)
+		tables := []func(write func(row []interface{}) error) error{
+			func(write func(row []interface{}) error) error {
+				for _, patient := range dataset.Patients {
+					if err := write(rows.patient(patient)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			func(write func(row []interface{}) error) error {
+				for _, patientDisease := range dataset.PatientDiseases {
+					if err := write(rows.patientDisease(patientDisease)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			func(write func(row []interface{}) error) error {
+				return snapshot.EachExportPatientCourse(func(patientCourse model.ExportPatientCourse) error {
+					return rows.write(patientCourse.Patient, write, rows.patientCourse(patientCourse))
+				})
+			},
+			func(write func(row []interface{}) error) error {
+				return snapshot.EachExportCourseProcedure(func(procedure model.ExportCourseProcedure) error {
+					return rows.write(procedure.Patient, write, rows.courseProcedure(procedure))
+				})
+			},
+			func(write func(row []interface{}) error) error {
+				return snapshot.EachExportProcedureBloodCount(func(result model.ExportProcedureBloodCount) error {
+					return rows.write(result.Patient, write, rows.procedureBloodCount(result))
+				})
+			},
+		}
+
+		for i, exportFile := range exportDictionary(job.Dates) {
+			entry, err := archive.Create(exportFile.Name + "." + job.Format)
+			if err != nil {
+				return err
+			}
+			writer, err := newExportWriter(entry, job.Format, exportFile.Columns)
+			if err != nil {
+				return err
+			}
+			if err := tables[i](writer.Write); err != nil {
+				return err
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			exportFile.Rows = writer.rows
+			manifest.Files = append(manifest.Files, exportFile)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := archive.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.bundlePath(job.Id))
+}
+
+func (s *ExportService) bundlePath(id int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("export-%d.zip", id))
+}
+
+// exportRows builds the rows of the exported files in the columns of the export dictionary.
+// Ids are replaced with pseudonyms and course and procedure dates are de-identified per patient
+type exportRows struct {
+	key      []byte
+	dates    string
+	patients map[int]model.ExportPatient
+}
+
+func newExportRows(dataset model.ExportDataset, key []byte, dates string) exportRows {
+	patients := make(map[int]model.ExportPatient, len(dataset.Patients))
+	for _, patient := range dataset.Patients {
+		patients[patient.Id] = patient
+	}
+	return exportRows{key: key, dates: dates, patients: patients}
+}
+
+// write writes the row of the patient, rows of the patients removed for k-anonymity are skipped
+func (e exportRows) write(patientId int, write func(row []interface{}) error, row []interface{}) error {
+	if _, ok := e.patients[patientId]; !ok {
+		return nil
+	}
+	return write(row)
+}
+
+func (e exportRows) patient(patient model.ExportPatient) []interface{} {
+	return []interface{}{utils.Pseudonym(e.key, patient.Id), patient.BirthDate, patient.Sex}
+}
+
+func (e exportRows) patientDisease(patientDisease model.PatientDisease) []interface{} {
+	return []interface{}{
+		utils.Pseudonym(e.key, patientDisease.Patient), patientDisease.Disease, patientDisease.Stage, stringValue(patientDisease.Diagnosis),
+	}
+}
+
+func (e exportRows) patientCourse(patientCourse model.ExportPatientCourse) []interface{} {
+	return []interface{}{
+		utils.EntityPseudonym(e.key, "patient_course", patientCourse.Id), utils.Pseudonym(e.key, patientCourse.Patient),
+		patientCourse.Disease, patientCourse.Course, e.date(patientCourse.Patient, patientCourse.BeginDate),
+		e.date(patientCourse.Patient, patientCourse.EndDate), patientCourse.Diagnosis,
+	}
+}
+
+func (e exportRows) courseProcedure(procedure model.ExportCourseProcedure) []interface{} {
+	return []interface{}{
+		utils.EntityPseudonym(e.key, "procedure", procedure.Id), utils.EntityPseudonym(e.key, "patient_course", procedure.PatientCourse),
+		e.date(procedure.Patient, procedure.BeginDate),
+	}
+}
+
+func (e exportRows) procedureBloodCount(result model.ExportProcedureBloodCount) []interface{} {
+	return []interface{}{
+		utils.EntityPseudonym(e.key, "procedure", result.Procedure), result.BloodCount, result.Value, result.MeasureCode, result.Flag,
+	}
+}
+
+// date returns the days since the first course of the patient or the date shifted for the patient, nil for no date
+func (e exportRows) date(patientId int, date string) interface{} {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil
+	}
+	if e.dates == model.ShiftedDates {
+		return day.AddDate(0, 0, utils.DateShift(e.key, patientId, maxDateShift)).Format(time.DateOnly)
+	}
+	firstDay, err := time.Parse(time.DateOnly, e.patients[patientId].FirstCourseDate)
+	if err != nil {
+		return nil
+	}
+	return int(day.Sub(firstDay).Hours() / 24)
+}
+
+// generalizeBirthDate keeps the birth year, or the 10-year age band at the export date with ages from 90 merged
+func generalizeBirthDate(birthDate, generalization string, exportedAt time.Time) string {
+	date, err := time.Parse(time.DateOnly, birthDate)
+	if err != nil {
+		return ""
+	}
+	if generalization == model.BirthYearExport {
+		return strconv.Itoa(date.Year())
+	}
+
+	age := exportedAt.Year() - date.Year()
+	if exportedAt.Month() < date.Month() || exportedAt.Month() == date.Month() && exportedAt.Day() < date.Day() {
+		age--
+	}
+	if age >= 90 {
+		return "90+"
+	}
+	band := age / 10 * 10
+	return fmt.Sprintf("%d-%d", band, band+9)
+}
+
+// exportWriter writes the rows of an exported file as CSV with a header or as one JSON object per line and counts them
+type exportWriter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	columns []model.ExportColumn
+	rows    int
+}
+
+func newExportWriter(w io.Writer, format string, columns []model.ExportColumn) (*exportWriter, error) {
+	writer := &exportWriter{w: w, columns: columns}
+	if format == model.NDJSONExportFormat {
+		return writer, nil
+	}
+
+	writer.csv = csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	return writer, writer.csv.Write(header)
+}
+
+func (w *exportWriter) Write(row []interface{}) error {
+	w.rows++
+	if w.csv != nil {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = csvValue(value)
+		}
+		return w.csv.Write(record)
+	}
+
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		name, _ := json.Marshal(column.Name)
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			return err
+		}
+		line.Write(name)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	_, err := w.w.Write(line.Bytes())
+	return err
+}
+
+// Flush writes the buffered CSV rows
+func (w *exportWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func csvValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case *float64:
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"med/pkg/config"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// exportRepository serves a fixed dataset and records the statuses of the job
+type exportRepository struct {
+	dataset              model.ExportDataset
+	patientCourses       []model.ExportPatientCourse
+	courseProcedures     []model.ExportCourseProcedure
+	procedureBloodCounts []model.ExportProcedureBloodCount
+	statuses             []string
+	statusError          error
+	panics               bool
+}
+
+func (r *exportRepository) CreateExportJob(job model.ExportJob) (model.ExportJob, error) {
+	return job, nil
+}
+
+func (r *exportRepository) GetExportJobList(filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error) {
+	return model.Page[model.ExportJob]{}, nil
+}
+
+func (r *exportRepository) GetExportJobById(id int) (model.ExportJob, error) {
+	return model.ExportJob{Id: id, Status: r.statuses[len(r.statuses)-1]}, nil
+}
+
+func (r *exportRepository) UpdateExportJobStatus(id int, status, jobError string) error {
+	if r.statusError != nil {
+		return r.statusError
+	}
+	r.statuses = append(r.statuses, status)
+	return nil
+}
+
+func (r *exportRepository) FailUnfinishedExportJobs(jobError string) (int, error) {
+	return 0, nil
+}
+
+func (r *exportRepository) InExportSnapshot(fn func(snapshot repository.ExportSnapshot) error) error {
+	return fn(r)
+}
+
+// The dataset is copied, as the service changes it while building the bundle
+func (r *exportRepository) GetExportDataset() (model.ExportDataset, error) {
+	if r.panics {
+		panic("dataset is broken")
+	}
+	return model.ExportDataset{
+		Patients:        slices.Clone(r.dataset.Patients),
+		PatientDiseases: slices.Clone(r.dataset.PatientDiseases),
+	}, nil
+}
+
+func (r *exportRepository) EachExportPatientCourse(fn func(patientCourse model.ExportPatientCourse) error) error {
+	return eachExportRow(r.patientCourses, fn)
+}
+
+func (r *exportRepository) EachExportCourseProcedure(fn func(procedure model.ExportCourseProcedure) error) error {
+	return eachExportRow(r.courseProcedures, fn)
+}
+
+func (r *exportRepository) EachExportProcedureBloodCount(fn func(result model.ExportProcedureBloodCount) error) error {
+	return eachExportRow(r.procedureBloodCounts, fn)
+}
+
+func eachExportRow[T any](rows []T, fn func(row T) error) error {
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRunExport(t *testing.T) {
+	value := 31.5
+	repo := &exportRepository{
+		dataset: model.ExportDataset{
+			Patients: []model.ExportPatient{
+				{Id: 1, BirthDate: "1975-06-15", Sex: "female", FirstCourseDate: "2024-01-10"},
+				{Id: 2, BirthDate: "", Sex: "male"},
+			},
+			PatientDiseases: []model.PatientDisease{{Patient: 1, Disease: "C50", Stage: "II", Diagnosis: stringPointer("C50.4")}},
+		},
+		// Rows of patient 4 are skipped as if the patient was removed for k-anonymity
+		patientCourses: []model.ExportPatientCourse{
+			{Id: 3, Patient: 1, Disease: "C50", Course: "AC", BeginDate: "2024-01-10"},
+			{Id: 6, Patient: 4, Disease: "C61", Course: "ADT", BeginDate: "2024-02-01"},
+		},
+		courseProcedures: []model.ExportCourseProcedure{
+			{Id: 5, Patient: 1, PatientCourse: 3, BeginDate: "2024-01-20"},
+			{Id: 7, Patient: 4, PatientCourse: 6, BeginDate: "2024-02-02"},
+		},
+		procedureBloodCounts: []model.ExportProcedureBloodCount{
+			{Patient: 1, Procedure: 5, BloodCount: "CA15-3", Value: &value, MeasureCode: "U/ml", Flag: "normal"},
+			{Patient: 4, Procedure: 7, BloodCount: "PSA", Value: &value, MeasureCode: "ng/ml", Flag: "high"},
+		},
+	}
+	key := []byte("export key")
+	service := NewExportService(repo, config.ConfigExport{Dir: t.TempDir(), PseudonymKey: string(key)}, 1)
+	pseudonym := utils.Pseudonym(key, 1)
+	course := utils.EntityPseudonym(key, "patient_course", 3)
+	procedure := utils.EntityPseudonym(key, "procedure", 5)
+	shift := utils.DateShift(key, 1, maxDateShift)
+	createdAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name          string
+		job           model.ExportJob
+		expectedFiles map[string]string
+		expectedRows  []int
+	}{
+		{
+			name: "CSV by age band with relative dates",
+			job:  model.ExportJob{Id: 7, Format: model.CSVExportFormat, BirthDate: model.AgeBandExport, Dates: model.RelativeDates, CreatedAt: createdAt},
+			expectedFiles: map[string]string{
+				"patients.csv":               "patient,birth_date,sex\n" + pseudonym + ",40-49,female\n" + utils.Pseudonym(key, 2) + ",,male\n",
+				"patient_diseases.csv":       "patient,disease,stage,diagnosis\n" + pseudonym + ",C50,II,C50.4\n",
+				"patient_courses.csv":        "patient_course,patient,disease,course,begin_day,end_day,diagnosis\n" + course + "," + pseudonym + ",C50,AC,0,,\n",
+				"course_procedures.csv":      "procedure,patient_course,begin_day\n" + procedure + "," + course + ",10\n",
+				"procedure_blood_counts.csv": "procedure,blood_count,value,measure_code,flag\n" + procedure + ",CA15-3,31.5,U/ml,normal\n",
+			},
+			expectedRows: []int{2, 1, 1, 1, 1},
+		},
+		{
+			name: "NDJSON by year with shifted dates",
+			job:  model.ExportJob{Id: 8, Format: model.NDJSONExportFormat, BirthDate: model.BirthYearExport, Dates: model.ShiftedDates, CreatedAt: createdAt},
+			expectedFiles: map[string]string{
+				"patients.ndjson": `{"patient":"` + pseudonym + `","birth_date":"1975","sex":"female"}` + "\n" +
+					`{"patient":"` + utils.Pseudonym(key, 2) + `","birth_date":"","sex":"male"}` + "\n",
+				"patient_courses.ndjson": `{"patient_course":"` + course + `","patient":"` + pseudonym + `","disease":"C50","course":"AC","begin_date":"` +
+					time.Date(2024, 1, 10+shift, 0, 0, 0, 0, time.UTC).Format(time.DateOnly) + `","end_date":null,"diagnosis":""}` + "\n",
+				"course_procedures.ndjson": `{"procedure":"` + procedure + `","patient_course":"` + course + `","begin_date":"` +
+					time.Date(2024, 1, 20+shift, 0, 0, 0, 0, time.UTC).Format(time.DateOnly) + `"}` + "\n",
+				"procedure_blood_counts.ndjson": `{"procedure":"` + procedure + `","blood_count":"CA15-3","value":31.5,"measure_code":"U/ml","flag":"normal"}` + "\n",
+			},
+			expectedRows: []int{2, 1, 1, 1, 1},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo.statuses = nil
+			service.runExport(testCase.job)
+			assert.Equal(t, []string{model.ExportRunning, model.ExportDone}, repo.statuses)
+
+			path, err := service.GetExportBundle(UserData{Id: 1, Role: model.AdminRole}, testCase.job.Id)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, filepath.Join(service.dir, fmt.Sprintf("export-%d.zip", testCase.job.Id)), path)
+
+			archive, err := zip.OpenReader(path)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer archive.Close()
+
+			files := map[string]string{}
+			for _, file := range archive.File {
+				reader, err := file.Open()
+				if !assert.NoError(t, err) {
+					return
+				}
+				content, err := io.ReadAll(reader)
+				reader.Close()
+				assert.NoError(t, err)
+				files[file.Name] = string(content)
+			}
+
+			for name, content := range testCase.expectedFiles {
+				assert.Equal(t, content, files[name], name)
+			}
+
+			var manifest model.ExportManifest
+			assert.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+			assert.Equal(t, testCase.job.Id, manifest.Job)
+			assert.Equal(t, testCase.job.Dates, manifest.Dates)
+			assert.Len(t, manifest.Files, 5)
+			assert.Equal(t, "patients", manifest.Files[0].Name)
+			for i, rows := range testCase.expectedRows {
+				assert.Equal(t, rows, manifest.Files[i].Rows, manifest.Files[i].Name)
+			}
+		})
+	}
+
+	// Only the bundles are left, temporary files are removed
+	entries, err := os.ReadDir(service.dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestRunExportFailure(t *testing.T) {
+	key := "export key"
+	job := model.ExportJob{Id: 9, Format: model.CSVExportFormat, BirthDate: model.BirthYearExport, Dates: model.RelativeDates}
+
+	t.Run("Panic", func(t *testing.T) {
+		repo := &exportRepository{panics: true}
+		service := NewExportService(repo, config.ConfigExport{Dir: t.TempDir(), PseudonymKey: key}, 1)
+
+		assert.NotPanics(t, func() { service.runExport(job) })
+		assert.Equal(t, []string{model.ExportRunning, model.ExportFailed}, repo.statuses)
+	})
+
+	t.Run("Status not updated", func(t *testing.T) {
+		repo := &exportRepository{statusError: errors.New("connection refused")}
+		service := NewExportService(repo, config.ConfigExport{Dir: t.TempDir(), PseudonymKey: key}, 1)
+
+		service.runExport(job)
+
+		// The bundle is not built for a job that cannot be marked running
+		entries, err := os.ReadDir(service.dir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestGeneralizeBirthDate(t *testing.T) {
+	exportedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name           string
+		birthDate      string
+		generalization string
+		expected       string
+	}{
+		{name: "Year", birthDate: "1975-06-15", generalization: model.BirthYearExport, expected: "1975"},
+		{name: "Band", birthDate: "1975-06-15", generalization: model.AgeBandExport, expected: "40-49"},
+		{name: "Birthday on export date", birthDate: "1975-03-01", generalization: model.AgeBandExport, expected: "50-59"},
+		{name: "Day before birthday", birthDate: "1975-03-02", generalization: model.AgeBandExport, expected: "40-49"},
+		{name: "Oldest merged", birthDate: "1930-01-01", generalization: model.AgeBandExport, expected: "90+"},
+		{name: "Unknown", birthDate: "", generalization: model.AgeBandExport, expected: ""},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, generalizeBirthDate(testCase.birthDate, testCase.generalization, exportedAt))
+		})
+	}
+}
+
+// exportJobRepository keeps export jobs and records the filter of the job list
+type exportJobRepository struct {
+	repository.Export
+	jobs   []model.ExportJob
+	filter model.ExportJobFilter
+}
+
+func (r *exportJobRepository) GetExportJobList(filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error) {
+	r.filter = filter
+	return model.Page[model.ExportJob]{Items: []model.ExportJob{}}, nil
+}
+
+func (r *exportJobRepository) GetExportJobById(id int) (model.ExportJob, error) {
+	for _, job := range r.jobs {
+		if job.Id == id {
+			return job, nil
+		}
+	}
+	return model.ExportJob{}, sql.ErrNoRows
+}
+
+func TestExportJobOwner(t *testing.T) {
+	admin := UserData{Id: 1, Role: model.AdminRole}
+	researcher := UserData{Id: 4, Role: model.ResearcherRole}
+	otherResearcher := UserData{Id: 5, Role: model.ResearcherRole}
+	repo := &exportJobRepository{jobs: []model.ExportJob{{Id: 7, Status: model.ExportDone, RequestedBy: researcher.Id}}}
+	service := NewExportService(repo, config.ConfigExport{Dir: "/exports", PseudonymKey: "export key"}, 1)
+
+	t.Run("Job", func(t *testing.T) {
+		job, err := service.GetExportJobById(researcher, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, job.Id)
+
+		_, err = service.GetExportJobById(admin, 7)
+		assert.NoError(t, err)
+
+		_, err = service.GetExportJobById(otherResearcher, 7)
+		assert.ErrorIs(t, err, ErrNotExportOwner)
+	})
+
+	t.Run("Bundle", func(t *testing.T) {
+		path, err := service.GetExportBundle(researcher, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("/exports", "export-7.zip"), path)
+
+		_, err = service.GetExportBundle(otherResearcher, 7)
+		assert.ErrorIs(t, err, ErrNotExportOwner)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		_, err := service.GetExportJobList(researcher, model.ExportJobFilter{}, model.ListQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, &researcher.Id, repo.filter.RequestedBy)
+
+		_, err = service.GetExportJobList(researcher, model.ExportJobFilter{RequestedBy: &otherResearcher.Id}, model.ListQuery{})
+		assert.ErrorIs(t, err, ErrNotExportOwner)
+
+		_, err = service.GetExportJobList(admin, model.ExportJobFilter{}, model.ListQuery{})
+		assert.NoError(t, err)
+		assert.Nil(t, repo.filter.RequestedBy)
+	})
+}
@@ -0,0 +1,1038 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidFHIRResource is returned for a FHIR resource that does not map to the OncoBase data.
+	ErrInvalidFHIRResource = errors.New("invalid FHIR resource")
+	// ErrInvalidFHIRSearch is returned for a malformed FHIR search parameter.
+	ErrInvalidFHIRSearch = errors.New("invalid FHIR search parameter")
+)
+
+// FHIRService is a FHIR R4 facade over the services of the patient data, so the access rules,
+// the audit log and the unit conversion of blood count results apply to FHIR clients as well.
+type FHIRService struct {
+	patient             Patient
+	patientDisease      PatientDisease
+	patientCourse       PatientCourse
+	courseProcedure     CourseProcedure
+	procedureBloodCount ProcedureBloodCount
+	bloodCount          BloodCount
+	course              Course
+	drug                Drug
+	doctor              Doctor
+}
+
+func NewFHIRService(patient Patient, patientDisease PatientDisease, patientCourse PatientCourse, courseProcedure CourseProcedure,
+	procedureBloodCount ProcedureBloodCount, bloodCount BloodCount, course Course, drug Drug, doctor Doctor) *FHIRService {
+	return &FHIRService{
+		patient:             patient,
+		patientDisease:      patientDisease,
+		patientCourse:       patientCourse,
+		courseProcedure:     courseProcedure,
+		procedureBloodCount: procedureBloodCount,
+		bloodCount:          bloodCount,
+		course:              course,
+		drug:                drug,
+		doctor:              doctor,
+	}
+}
+
+func (s *FHIRService) GetFHIRPatient(user UserData, id string) (model.FHIRPatient, error) {
+	patientId, err := fhirIntId(id)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+
+	patient, err := s.patient.GetPatientById(user, patientId)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+	return fhirPatient(patient), nil
+}
+func (s *FHIRService) SearchFHIRPatients(user UserData, query model.FHIRPatientQuery) (model.FHIRBundle, error) {
+	filter := model.PatientFilter{Name: query.Name}
+	if query.Gender != "" {
+		sex, ok := sexFromFHIR(query.Gender)
+		if !ok {
+			return model.FHIRBundle{}, fmt.Errorf("%w: unknown gender %q", ErrInvalidFHIRSearch, query.Gender)
+		}
+		// Patients of other and unknown gender have no sex code to filter by
+		if sex == "" {
+			return newFHIRBundle(nil, ""), nil
+		}
+		filter.Sex = sex
+	}
+	if query.Identifier != "" {
+		system, value := fhirToken(query.Identifier)
+		if system != "" && system != model.FHIRSNILSSystem {
+			return newFHIRBundle(nil, ""), nil
+		}
+		filter.SNILS = value
+	}
+
+	var err error
+	if filter.BirthDateFrom, filter.BirthDateTo, err = fhirDateRange("birthdate", query.BirthDate); err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	page, err := s.patient.GetPatientList(user, filter, fhirListQuery(query.FHIRSearch))
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, patient := range page.Items {
+		resource := fhirPatient(patient)
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+func (s *FHIRService) CreateFHIRPatient(user UserData, resource model.FHIRPatient) (model.FHIRPatient, error) {
+	patient, err := patientFromFHIR(resource)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+
+	createdPatient, err := s.patient.CreatePatient(user, patient)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+	return fhirPatient(createdPatient), nil
+}
+func (s *FHIRService) UpdateFHIRPatient(user UserData, id string, resource model.FHIRPatient) (model.FHIRPatient, error) {
+	patientId, err := fhirIntId(id)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+	if err := checkFHIRId(resource.Id, id); err != nil {
+		return model.FHIRPatient{}, err
+	}
+
+	patient, err := patientFromFHIR(resource)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+	patient.Id = patientId
+
+	updatedPatient, err := s.patient.UpdatePatient(user, patient)
+	if err != nil {
+		return model.FHIRPatient{}, err
+	}
+	return fhirPatient(updatedPatient), nil
+}
+
+func (s *FHIRService) GetFHIRObservation(user UserData, id string) (model.FHIRObservation, error) {
+	procedureId, bloodCountId, err := fhirCompositeId(id)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+
+	procedureBloodCount, err := s.procedureBloodCount.GetProcedureBloodCountById(user, procedureId, bloodCountId, "")
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+	return s.newObservationBuilder(user).observation(procedureBloodCount)
+}
+func (s *FHIRService) SearchFHIRObservations(user UserData, query model.FHIRObservationQuery) (model.FHIRBundle, error) {
+	var filter model.ProcedureBloodCountFilter
+
+	patientId, err := fhirPatientParameter(query.Patient, query.Subject)
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+	filter.Patient = patientId
+
+	if query.Code != "" {
+		system, code := fhirToken(query.Code)
+		if system != "" && system != model.FHIRBloodCountSystem {
+			return newFHIRBundle(nil, ""), nil
+		}
+		filter.BloodCount = code
+	}
+	if filter.DateFrom, filter.DateTo, err = fhirDateRange("date", query.Date); err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	page, err := s.procedureBloodCount.GetProcedureBloodCountList(user, filter, fhirListQuery(query.FHIRSearch), "")
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	builder := s.newObservationBuilder(user)
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, procedureBloodCount := range page.Items {
+		resource, err := builder.observation(procedureBloodCount)
+		if err != nil {
+			return model.FHIRBundle{}, err
+		}
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+func (s *FHIRService) CreateFHIRObservation(user UserData, resource model.FHIRObservation) (model.FHIRObservation, error) {
+	procedureBloodCount, err := procedureBloodCountFromFHIR(resource)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+
+	createdProcedureBloodCount, err := s.procedureBloodCount.CreateProcedureBloodCount(user, procedureBloodCount)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+	return s.newObservationBuilder(user).observation(createdProcedureBloodCount)
+}
+func (s *FHIRService) UpdateFHIRObservation(user UserData, id string, resource model.FHIRObservation) (model.FHIRObservation, error) {
+	procedureId, bloodCountId, err := fhirCompositeId(id)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+	if err := checkFHIRId(resource.Id, id); err != nil {
+		return model.FHIRObservation{}, err
+	}
+
+	procedureBloodCount, err := procedureBloodCountFromFHIR(resource)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+	if procedureBloodCount.Procedure != procedureId || procedureBloodCount.BloodCount != bloodCountId {
+		return model.FHIRObservation{}, fmt.Errorf("%w: procedure and code do not match the ID %s", ErrInvalidFHIRResource, id)
+	}
+
+	updatedProcedureBloodCount, err := s.procedureBloodCount.UpdateProcedureBloodCount(user, procedureBloodCount)
+	if err != nil {
+		return model.FHIRObservation{}, err
+	}
+	return s.newObservationBuilder(user).observation(updatedProcedureBloodCount)
+}
+
+func (s *FHIRService) GetFHIRCondition(user UserData, id string) (model.FHIRCondition, error) {
+	patientId, diseaseId, err := fhirCompositeId(id)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+
+	patientDisease, err := s.patientDisease.GetPatientDiseaseById(user, patientId, diseaseId)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+	return fhirCondition(patientDisease), nil
+}
+func (s *FHIRService) SearchFHIRConditions(user UserData, query model.FHIRConditionQuery) (model.FHIRBundle, error) {
+	var filter model.PatientDiseaseFilter
+
+	patientId, err := fhirPatientParameter(query.Patient, query.Subject)
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+	filter.Patient = patientId
+
+	if query.Code != "" {
+		system, code := fhirToken(query.Code)
+		if system != "" && system != model.FHIRDiseaseSystem {
+			return newFHIRBundle(nil, ""), nil
+		}
+		filter.Disease = code
+	}
+
+	page, err := s.patientDisease.GetPatientDiseaseList(user, filter, fhirListQuery(query.FHIRSearch))
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, patientDisease := range page.Items {
+		resource := fhirCondition(patientDisease)
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+func (s *FHIRService) CreateFHIRCondition(user UserData, resource model.FHIRCondition) (model.FHIRCondition, error) {
+	patientDisease, err := patientDiseaseFromFHIR(resource)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+
+	createdPatientDisease, err := s.patientDisease.CreatePatientDisease(user, patientDisease)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+	return fhirCondition(createdPatientDisease), nil
+}
+func (s *FHIRService) UpdateFHIRCondition(user UserData, id string, resource model.FHIRCondition) (model.FHIRCondition, error) {
+	patientId, diseaseId, err := fhirCompositeId(id)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+	if err := checkFHIRId(resource.Id, id); err != nil {
+		return model.FHIRCondition{}, err
+	}
+
+	patientDisease, err := patientDiseaseFromFHIR(resource)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+	if patientDisease.Patient != patientId || patientDisease.Disease != diseaseId {
+		return model.FHIRCondition{}, fmt.Errorf("%w: subject and code do not match the ID %s", ErrInvalidFHIRResource, id)
+	}
+
+	updatedPatientDisease, err := s.patientDisease.UpdatePatientDisease(user, patientDisease)
+	if err != nil {
+		return model.FHIRCondition{}, err
+	}
+	return fhirCondition(updatedPatientDisease), nil
+}
+
+func (s *FHIRService) GetFHIRMedicationRequest(user UserData, id string) (model.FHIRMedicationRequest, error) {
+	patientCourseId, err := fhirIntId(id)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+
+	patientCourse, err := s.patientCourse.GetPatientCourseById(user, patientCourseId)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+	return s.medicationRequest(patientCourse, map[string]model.Course{})
+}
+func (s *FHIRService) SearchFHIRMedicationRequests(user UserData, query model.FHIRMedicationRequestQuery) (model.FHIRBundle, error) {
+	var filter model.PatientCourseFilter
+
+	patientId, err := fhirPatientParameter(query.Patient, query.Subject)
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+	filter.Patient = patientId
+
+	if query.Requester != "" {
+		doctorId, err := parseFHIRReference(query.Requester, model.FHIRPractitionerType)
+		if err != nil {
+			return model.FHIRBundle{}, fmt.Errorf("%w: requester %q", ErrInvalidFHIRSearch, query.Requester)
+		}
+		filter.Doctor = &doctorId
+	}
+
+	page, err := s.patientCourse.GetPatientCourseList(user, filter, fhirListQuery(query.FHIRSearch))
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	courses := map[string]model.Course{}
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, patientCourse := range page.Items {
+		resource, err := s.medicationRequest(patientCourse, courses)
+		if err != nil {
+			return model.FHIRBundle{}, err
+		}
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+func (s *FHIRService) CreateFHIRMedicationRequest(user UserData, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error) {
+	patientCourse, err := patientCourseFromFHIR(resource)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+
+	createdPatientCourse, err := s.patientCourse.CreatePatientCourse(user, patientCourse)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+	return s.medicationRequest(createdPatientCourse, map[string]model.Course{})
+}
+func (s *FHIRService) UpdateFHIRMedicationRequest(user UserData, id string, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error) {
+	patientCourseId, err := fhirIntId(id)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+	if err := checkFHIRId(resource.Id, id); err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+
+	patientCourse, err := patientCourseFromFHIR(resource)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+	patientCourse.Id = patientCourseId
+
+	updatedPatientCourse, err := s.patientCourse.UpdatePatientCourse(user, patientCourse)
+	if err != nil {
+		return model.FHIRMedicationRequest{}, err
+	}
+	return s.medicationRequest(updatedPatientCourse, map[string]model.Course{})
+}
+
+// medicationRequest maps the patient course with its course, courses caches the courses shared by patient courses
+func (s *FHIRService) medicationRequest(patientCourse model.PatientCourse, courses map[string]model.Course) (model.FHIRMedicationRequest, error) {
+	course, ok := courses[patientCourse.Course]
+	if !ok {
+		var err error
+		if course, err = s.course.GetCourseById(patientCourse.Course); err != nil {
+			return model.FHIRMedicationRequest{}, err
+		}
+		courses[patientCourse.Course] = course
+	}
+	return fhirMedicationRequest(patientCourse, course), nil
+}
+
+func (s *FHIRService) GetFHIRMedication(id string) (model.FHIRMedication, error) {
+	drug, err := s.drug.GetDrugById(id)
+	if err != nil {
+		return model.FHIRMedication{}, err
+	}
+	return fhirMedication(drug), nil
+}
+func (s *FHIRService) SearchFHIRMedications(search model.FHIRSearch) (model.FHIRBundle, error) {
+	page, err := s.drug.GetDrugList(model.DrugFilter{}, fhirListQuery(search))
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, drug := range page.Items {
+		resource := fhirMedication(drug)
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+
+func (s *FHIRService) GetFHIRPractitioner(id string) (model.FHIRPractitioner, error) {
+	doctorId, err := fhirIntId(id)
+	if err != nil {
+		return model.FHIRPractitioner{}, err
+	}
+
+	doctor, err := s.doctor.GetDoctorById(doctorId)
+	if err != nil {
+		return model.FHIRPractitioner{}, err
+	}
+	return fhirPractitioner(doctor), nil
+}
+func (s *FHIRService) SearchFHIRPractitioners(search model.FHIRSearch) (model.FHIRBundle, error) {
+	page, err := s.doctor.GetDoctorList(fhirListQuery(search))
+	if err != nil {
+		return model.FHIRBundle{}, err
+	}
+
+	bundle := newFHIRBundle(page.Total, page.NextCursor)
+	for _, doctor := range page.Items {
+		resource := fhirPractitioner(doctor)
+		addFHIREntry(&bundle, resource.ResourceType, resource.Id, resource)
+	}
+	return bundle, nil
+}
+
+// observationBuilder maps blood count results to observations. Results of a search share procedures and blood counts,
+// so they are read once.
+type observationBuilder struct {
+	service     *FHIRService
+	user        UserData
+	procedures  map[int]observationProcedure
+	bloodCounts map[string]model.BloodCount
+}
+
+// observationProcedure is the date and the patient of a procedure
+type observationProcedure struct {
+	date    string
+	patient int
+}
+
+func (s *FHIRService) newObservationBuilder(user UserData) *observationBuilder {
+	return &observationBuilder{
+		service:     s,
+		user:        user,
+		procedures:  map[int]observationProcedure{},
+		bloodCounts: map[string]model.BloodCount{},
+	}
+}
+
+func (b *observationBuilder) observation(procedureBloodCount model.ProcedureBloodCount) (model.FHIRObservation, error) {
+	procedure, ok := b.procedures[procedureBloodCount.Procedure]
+	if !ok {
+		courseProcedure, err := b.service.courseProcedure.GetCourseProcedureById(b.user, procedureBloodCount.Procedure)
+		if err != nil {
+			return model.FHIRObservation{}, err
+		}
+		patientCourse, err := b.service.patientCourse.GetPatientCourseById(b.user, courseProcedure.PatientCourse)
+		if err != nil {
+			return model.FHIRObservation{}, err
+		}
+		procedure = observationProcedure{date: courseProcedure.BeginDate, patient: patientCourse.Patient}
+		b.procedures[procedureBloodCount.Procedure] = procedure
+	}
+
+	bloodCount, ok := b.bloodCounts[procedureBloodCount.BloodCount]
+	if !ok {
+		var err error
+		if bloodCount, err = b.service.bloodCount.GetBloodCountById(procedureBloodCount.BloodCount); err != nil {
+			return model.FHIRObservation{}, err
+		}
+		b.bloodCounts[procedureBloodCount.BloodCount] = bloodCount
+	}
+
+	return fhirObservation(procedureBloodCount, bloodCount, procedure.date, procedure.patient), nil
+}
+
+func fhirPatient(patient model.Patient) model.FHIRPatient {
+	resource := model.FHIRPatient{
+		ResourceType: model.FHIRPatientType,
+		Id:           strconv.Itoa(patient.Id),
+		Name:         fhirName(patient.LastName, patient.FirstName, patient.MiddleName),
+		Gender:       fhirGender(patient.Sex),
+		BirthDate:    fhirDate(stringValue(patient.BirthDate)),
+	}
+	if patient.SNILS != nil {
+		resource.Identifier = []model.FHIRIdentifier{{System: model.FHIRSNILSSystem, Value: *patient.SNILS}}
+	}
+	if patient.Phone != nil {
+		resource.Telecom = []model.FHIRContactPoint{{System: "phone", Value: *patient.Phone}}
+	}
+	return resource
+}
+
+// patientFromFHIR reads the patient from the resource. The gender is stored as the FHIR code.
+func patientFromFHIR(resource model.FHIRPatient) (model.Patient, error) {
+	if err := checkFHIRResourceType(resource.ResourceType, model.FHIRPatientType); err != nil {
+		return model.Patient{}, err
+	}
+
+	var patient model.Patient
+	for _, identifier := range resource.Identifier {
+		if identifier.System == model.FHIRSNILSSystem || identifier.System == "" {
+			patient.SNILS = optionalString(identifier.Value)
+			break
+		}
+	}
+	patient.LastName, patient.FirstName, patient.MiddleName = nameFromFHIR(resource.Name)
+	for _, contactPoint := range resource.Telecom {
+		if contactPoint.System == "phone" {
+			patient.Phone = optionalString(contactPoint.Value)
+			break
+		}
+	}
+
+	sex, ok := sexFromFHIR(resource.Gender)
+	if !ok {
+		return model.Patient{}, fmt.Errorf("%w: unknown gender %q", ErrInvalidFHIRResource, resource.Gender)
+	}
+	patient.Sex = sex
+
+	if resource.BirthDate != "" {
+		if _, err := time.Parse(time.DateOnly, resource.BirthDate); err != nil {
+			return model.Patient{}, fmt.Errorf("%w: birth date %q is not in YYYY-MM-DD format", ErrInvalidFHIRResource, resource.BirthDate)
+		}
+		patient.BirthDate = &resource.BirthDate
+	}
+	return patient, nil
+}
+
+func fhirObservation(procedureBloodCount model.ProcedureBloodCount, bloodCount model.BloodCount, date string, patientId int) model.FHIRObservation {
+	resource := model.FHIRObservation{
+		ResourceType: model.FHIRObservationType,
+		Id:           fmt.Sprintf("%d-%s", procedureBloodCount.Procedure, procedureBloodCount.BloodCount),
+		Status:       "final",
+		Category: []model.FHIRCodeableConcept{{
+			Coding: []model.FHIRCoding{{System: model.FHIRObservationCategorySystem, Code: "laboratory", Display: "Laboratory"}},
+		}},
+		Code: model.FHIRCodeableConcept{
+			Coding: []model.FHIRCoding{{System: model.FHIRBloodCountSystem, Code: bloodCount.Id, Display: bloodCount.Description}},
+			Text:   bloodCount.Description,
+		},
+		Subject:           &model.FHIRReference{Reference: fhirReference(model.FHIRPatientType, strconv.Itoa(patientId))},
+		PartOf:            []model.FHIRReference{{Reference: fhirReference(model.FHIRProcedureType, strconv.Itoa(procedureBloodCount.Procedure))}},
+		EffectiveDateTime: fhirDate(date),
+		ReferenceRange: []model.FHIRObservationRange{{
+			Low:  fhirQuantity(bloodCount.MinNormalValue, bloodCount.MeasureCode),
+			High: fhirQuantity(bloodCount.MaxNormalValue, bloodCount.MeasureCode),
+			Type: &model.FHIRCodeableConcept{
+				Coding: []model.FHIRCoding{{System: model.FHIRReferenceRangeMeaningSystem, Code: "normal", Display: "Normal Range"}},
+			},
+		}},
+	}
+
+	if procedureBloodCount.Value != nil {
+		resource.ValueQuantity = fhirQuantity(*procedureBloodCount.Value, procedureBloodCount.MeasureCode)
+	}
+
+	interpretations := map[string]model.FHIRCoding{
+		model.LowFlag:    {System: model.FHIRInterpretationSystem, Code: "L", Display: "Low"},
+		model.NormalFlag: {System: model.FHIRInterpretationSystem, Code: "N", Display: "Normal"},
+		model.HighFlag:   {System: model.FHIRInterpretationSystem, Code: "H", Display: "High"},
+	}
+	if coding, ok := interpretations[procedureBloodCount.Flag]; ok {
+		resource.Interpretation = []model.FHIRCodeableConcept{{Coding: []model.FHIRCoding{coding}}}
+	}
+	return resource
+}
+
+// procedureBloodCountFromFHIR reads the blood count result from the resource. The procedure is the part of reference
+// and the blood count is the code. The value is converted to the unit of the blood count and flagged when it is saved.
+func procedureBloodCountFromFHIR(resource model.FHIRObservation) (model.ProcedureBloodCount, error) {
+	if err := checkFHIRResourceType(resource.ResourceType, model.FHIRObservationType); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+
+	var procedureBloodCount model.ProcedureBloodCount
+	for _, reference := range resource.PartOf {
+		if procedureId, ok := strings.CutPrefix(reference.Reference, model.FHIRProcedureType+"/"); ok {
+			procedureBloodCount.Procedure, _ = strconv.Atoi(procedureId)
+			break
+		}
+	}
+	if procedureBloodCount.Procedure <= 0 {
+		return model.ProcedureBloodCount{}, fmt.Errorf("%w: partOf needs a Procedure reference", ErrInvalidFHIRResource)
+	}
+
+	procedureBloodCount.BloodCount = fhirCode(resource.Code, model.FHIRBloodCountSystem)
+	if procedureBloodCount.BloodCount == "" {
+		return model.ProcedureBloodCount{}, fmt.Errorf("%w: code needs a blood count coding", ErrInvalidFHIRResource)
+	}
+
+	if resource.ValueQuantity != nil {
+		procedureBloodCount.Value = resource.ValueQuantity.Value
+		procedureBloodCount.MeasureCode = resource.ValueQuantity.Code
+		if procedureBloodCount.MeasureCode == "" {
+			procedureBloodCount.MeasureCode = resource.ValueQuantity.Unit
+		}
+	}
+	return procedureBloodCount, nil
+}
+
+func fhirCondition(patientDisease model.PatientDisease) model.FHIRCondition {
+	resource := model.FHIRCondition{
+		ResourceType: model.FHIRConditionType,
+		Id:           fmt.Sprintf("%d-%s", patientDisease.Patient, patientDisease.Disease),
+		Code: &model.FHIRCodeableConcept{
+			Coding: []model.FHIRCoding{{System: model.FHIRDiseaseSystem, Code: patientDisease.Disease}},
+		},
+		Subject: model.FHIRReference{Reference: fhirReference(model.FHIRPatientType, strconv.Itoa(patientDisease.Patient))},
+	}
+	if patientDisease.Stage != "" {
+		resource.Stage = []model.FHIRConditionStage{{Summary: &model.FHIRCodeableConcept{Text: patientDisease.Stage}}}
+	}
+	if patientDisease.Diagnosis != nil {
+		resource.Evidence = []model.FHIRConditionEvidence{{Code: []model.FHIRCodeableConcept{{
+			Coding: []model.FHIRCoding{{System: model.FHIRDiagnosisSystem, Code: *patientDisease.Diagnosis}},
+		}}}}
+	}
+	return resource
+}
+
+// patientDiseaseFromFHIR reads the disease of the patient from the resource. The stage is the text of the stage summary
+// and the diagnosis is the evidence code.
+func patientDiseaseFromFHIR(resource model.FHIRCondition) (model.PatientDisease, error) {
+	if err := checkFHIRResourceType(resource.ResourceType, model.FHIRConditionType); err != nil {
+		return model.PatientDisease{}, err
+	}
+
+	patientId, err := parseFHIRReference(resource.Subject.Reference, model.FHIRPatientType)
+	if err != nil {
+		return model.PatientDisease{}, fmt.Errorf("%w: subject needs a Patient reference", ErrInvalidFHIRResource)
+	}
+
+	patientDisease := model.PatientDisease{Patient: patientId}
+	if resource.Code != nil {
+		patientDisease.Disease = fhirCode(*resource.Code, model.FHIRDiseaseSystem)
+	}
+	if patientDisease.Disease == "" {
+		return model.PatientDisease{}, fmt.Errorf("%w: code needs a disease coding", ErrInvalidFHIRResource)
+	}
+
+	for _, stage := range resource.Stage {
+		if stage.Summary == nil {
+			continue
+		}
+		if patientDisease.Stage = stage.Summary.Text; patientDisease.Stage == "" && len(stage.Summary.Coding) > 0 {
+			patientDisease.Stage = stage.Summary.Coding[0].Code
+		}
+		break
+	}
+	for _, evidence := range resource.Evidence {
+		for _, code := range evidence.Code {
+			if diagnosis := fhirCode(code, model.FHIRDiagnosisSystem); diagnosis != "" && patientDisease.Diagnosis == nil {
+				patientDisease.Diagnosis = &diagnosis
+			}
+		}
+	}
+	return patientDisease, nil
+}
+
+func fhirMedicationRequest(patientCourse model.PatientCourse, course model.Course) model.FHIRMedicationRequest {
+	status := "active"
+	if patientCourse.EndDate != nil {
+		status = "completed"
+	}
+
+	resource := model.FHIRMedicationRequest{
+		ResourceType:        model.FHIRMedicationRequestType,
+		Id:                  strconv.Itoa(patientCourse.Id),
+		Status:              status,
+		Intent:              "order",
+		GroupIdentifier:     &model.FHIRIdentifier{System: model.FHIRCourseSystem, Value: course.Id},
+		MedicationReference: &model.FHIRReference{Reference: fhirReference(model.FHIRMedicationType, course.Drug)},
+		Subject:             model.FHIRReference{Reference: fhirReference(model.FHIRPatientType, strconv.Itoa(patientCourse.Patient))},
+		Requester:           &model.FHIRReference{Reference: fhirReference(model.FHIRPractitionerType, strconv.Itoa(patientCourse.Doctor))},
+		DosageInstruction: []model.FHIRDosage{{
+			Timing: &model.FHIRTiming{Repeat: &model.FHIRTimingRepeat{
+				BoundsPeriod: &model.FHIRPeriod{Start: fhirDate(patientCourse.BeginDate), End: fhirDate(stringValue(patientCourse.EndDate))},
+			}},
+			DoseAndRate: []model.FHIRDoseAndRate{{DoseQuantity: fhirQuantity(float64(course.Dose), course.MeasureCode)}},
+		}},
+	}
+	if patientCourse.Disease != nil {
+		resource.ReasonReference = []model.FHIRReference{{
+			Reference: fhirReference(model.FHIRConditionType, fmt.Sprintf("%d-%s", patientCourse.Patient, *patientCourse.Disease)),
+		}}
+	}
+	if patientCourse.Diagnosis != nil {
+		resource.ReasonCode = []model.FHIRCodeableConcept{{
+			Coding: []model.FHIRCoding{{System: model.FHIRDiagnosisSystem, Code: *patientCourse.Diagnosis}},
+		}}
+	}
+	return resource
+}
+
+// patientCourseFromFHIR reads the patient course from the resource. The course is the group identifier, the doctor is the requester,
+// the disease is the Condition reason reference, the diagnosis is the reason code and the dates are the bounds of the dosage timing.
+func patientCourseFromFHIR(resource model.FHIRMedicationRequest) (model.PatientCourse, error) {
+	if err := checkFHIRResourceType(resource.ResourceType, model.FHIRMedicationRequestType); err != nil {
+		return model.PatientCourse{}, err
+	}
+
+	var (
+		patientCourse model.PatientCourse
+		err           error
+	)
+	if patientCourse.Patient, err = parseFHIRReference(resource.Subject.Reference, model.FHIRPatientType); err != nil {
+		return model.PatientCourse{}, fmt.Errorf("%w: subject needs a Patient reference", ErrInvalidFHIRResource)
+	}
+	if resource.Requester == nil {
+		return model.PatientCourse{}, fmt.Errorf("%w: requester needs a Practitioner reference", ErrInvalidFHIRResource)
+	}
+	if patientCourse.Doctor, err = parseFHIRReference(resource.Requester.Reference, model.FHIRPractitionerType); err != nil {
+		return model.PatientCourse{}, fmt.Errorf("%w: requester needs a Practitioner reference", ErrInvalidFHIRResource)
+	}
+
+	if resource.GroupIdentifier == nil || resource.GroupIdentifier.Value == "" ||
+		(resource.GroupIdentifier.System != "" && resource.GroupIdentifier.System != model.FHIRCourseSystem) {
+		return model.PatientCourse{}, fmt.Errorf("%w: groupIdentifier needs a course", ErrInvalidFHIRResource)
+	}
+	patientCourse.Course = resource.GroupIdentifier.Value
+
+	for _, reference := range resource.ReasonReference {
+		conditionId, ok := strings.CutPrefix(reference.Reference, model.FHIRConditionType+"/")
+		if !ok {
+			continue
+		}
+		patientId, diseaseId, err := fhirCompositeId(conditionId)
+		if err != nil || patientId != patientCourse.Patient {
+			return model.PatientCourse{}, fmt.Errorf("%w: reason reference %q is not a condition of the subject", ErrInvalidFHIRResource, reference.Reference)
+		}
+		patientCourse.Disease = &diseaseId
+		break
+	}
+	for _, reason := range resource.ReasonCode {
+		if patientCourse.Diagnosis = optionalString(fhirCode(reason, model.FHIRDiagnosisSystem)); patientCourse.Diagnosis != nil {
+			break
+		}
+	}
+
+	for _, dosage := range resource.DosageInstruction {
+		if dosage.Timing != nil && dosage.Timing.Repeat != nil && dosage.Timing.Repeat.BoundsPeriod != nil {
+			patientCourse.BeginDate = dosage.Timing.Repeat.BoundsPeriod.Start
+			patientCourse.EndDate = optionalString(dosage.Timing.Repeat.BoundsPeriod.End)
+			break
+		}
+	}
+	if _, err := time.Parse(time.DateOnly, patientCourse.BeginDate); err != nil {
+		return model.PatientCourse{}, fmt.Errorf("%w: dosage timing needs a start date in YYYY-MM-DD format", ErrInvalidFHIRResource)
+	}
+	if patientCourse.EndDate != nil {
+		if _, err := time.Parse(time.DateOnly, *patientCourse.EndDate); err != nil {
+			return model.PatientCourse{}, fmt.Errorf("%w: end date %q is not in YYYY-MM-DD format", ErrInvalidFHIRResource, *patientCourse.EndDate)
+		}
+	}
+	return patientCourse, nil
+}
+
+func fhirMedication(drug model.Drug) model.FHIRMedication {
+	resource := model.FHIRMedication{
+		ResourceType: model.FHIRMedicationType,
+		Id:           drug.Id,
+		Code: &model.FHIRCodeableConcept{
+			Coding: []model.FHIRCoding{{System: model.FHIRDrugSystem, Code: drug.Id, Display: drug.Name}},
+			Text:   drug.Name,
+		},
+	}
+	if drug.Manufacturer != "" {
+		resource.Manufacturer = &model.FHIRReference{Display: drug.Manufacturer}
+	}
+	if drug.DosageForm != "" {
+		resource.Form = &model.FHIRCodeableConcept{Text: drug.DosageForm}
+	}
+	for _, ingredient := range strings.Split(drug.ActiveIngredients, ",") {
+		if ingredient = strings.TrimSpace(ingredient); ingredient != "" {
+			resource.Ingredient = append(resource.Ingredient, model.FHIRMedicationIngredient{ItemCodeableConcept: model.FHIRCodeableConcept{Text: ingredient}})
+		}
+	}
+	return resource
+}
+
+func fhirPractitioner(doctor model.Doctor) model.FHIRPractitioner {
+	resource := model.FHIRPractitioner{
+		ResourceType: model.FHIRPractitionerType,
+		Id:           strconv.Itoa(doctor.Id),
+		Name:         fhirName(doctor.LastName, doctor.FirstName, doctor.MiddleName),
+	}
+	if doctor.Phone != nil {
+		resource.Telecom = []model.FHIRContactPoint{{System: "phone", Value: *doctor.Phone}}
+	}
+	if doctor.Qualification != "" {
+		resource.Qualification = []model.FHIRPractitionerQualification{{Code: model.FHIRCodeableConcept{Text: doctor.Qualification}}}
+	}
+	return resource
+}
+
+// fhirName is the official name with the first and middle names as the given names
+func fhirName(lastName, firstName, middleName string) []model.FHIRHumanName {
+	name := model.FHIRHumanName{Use: "official", Family: lastName}
+	for _, given := range []string{firstName, middleName} {
+		if given != "" {
+			name.Given = append(name.Given, given)
+		}
+	}
+	if name.Family == "" && len(name.Given) == 0 {
+		return nil
+	}
+	return []model.FHIRHumanName{name}
+}
+
+// nameFromFHIR returns the last, first and middle names from the official name, or the first name if none is official
+func nameFromFHIR(names []model.FHIRHumanName) (string, string, string) {
+	if len(names) == 0 {
+		return "", "", ""
+	}
+
+	name := names[0]
+	for _, official := range names {
+		if official.Use == "official" {
+			name = official
+			break
+		}
+	}
+
+	var firstName, middleName string
+	if len(name.Given) > 0 {
+		firstName = name.Given[0]
+	}
+	if len(name.Given) > 1 {
+		middleName = strings.Join(name.Given[1:], " ")
+	}
+	return name.Family, firstName, middleName
+}
+
+// fhirGender maps the stored sex to the FHIR administrative gender, the sex may be stored as a FHIR code,
+// a letter or a Russian word
+func fhirGender(sex string) string {
+	switch strings.ToLower(strings.TrimSpace(sex)) {
+	case "":
+		return ""
+	case "male", "m", "м", "муж", "мужской":
+		return "male"
+	case "female", "f", "ж", "жен", "женский":
+		return "female"
+	case "other":
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+// sexFromFHIR maps the FHIR administrative gender to the sex code of a patient. The sex codes only tell male
+// and female apart, other and unknown gender are stored as an unknown sex
+func sexFromFHIR(gender string) (string, bool) {
+	switch gender {
+	case "male":
+		return model.MaleSex, true
+	case "female":
+		return model.FemaleSex, true
+	case "", "other", "unknown":
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// fhirDate cuts the time off a date read from a date column
+func fhirDate(date string) string {
+	if len(date) > len(time.DateOnly) {
+		return date[:len(time.DateOnly)]
+	}
+	return date
+}
+
+func fhirQuantity(value float64, measureCode string) *model.FHIRQuantity {
+	return &model.FHIRQuantity{Value: &value, Unit: measureCode, Code: measureCode}
+}
+
+func fhirReference(resourceType, id string) string {
+	return resourceType + "/" + id
+}
+
+// fhirCode returns the code of the coding in the system, or of the first coding without a system
+func fhirCode(concept model.FHIRCodeableConcept, system string) string {
+	for _, coding := range concept.Coding {
+		if coding.System == system {
+			return coding.Code
+		}
+	}
+	for _, coding := range concept.Coding {
+		if coding.System == "" {
+			return coding.Code
+		}
+	}
+	return ""
+}
+
+// parseFHIRReference returns the ID of a reference to a resource of the type, given as <type>/<id> or as the ID alone
+func parseFHIRReference(reference, resourceType string) (int, error) {
+	id := strings.TrimPrefix(reference, resourceType+"/")
+	return strconv.Atoi(id)
+}
+
+// fhirPatientParameter returns the patient ID of the patient or subject search parameter, nil if neither is given
+func fhirPatientParameter(patient, subject string) (*int, error) {
+	reference := patient
+	if reference == "" {
+		reference = subject
+	}
+	if reference == "" {
+		return nil, nil
+	}
+
+	patientId, err := parseFHIRReference(reference, model.FHIRPatientType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: patient %q", ErrInvalidFHIRSearch, reference)
+	}
+	return &patientId, nil
+}
+
+// fhirToken splits a token search parameter into the system and the code, the system is empty without |
+func fhirToken(token string) (string, string) {
+	if system, code, ok := strings.Cut(token, "|"); ok {
+		return system, code
+	}
+	return "", token
+}
+
+// fhirDateRange turns date search parameters with eq, ge, gt, le and lt prefixes into an inclusive range in YYYY-MM-DD format
+func fhirDateRange(parameter string, values []string) (string, string, error) {
+	var from, to string
+	for _, value := range values {
+		prefix, date := "eq", value
+		if len(value) > 2 && value[0] >= 'a' && value[0] <= 'z' {
+			prefix, date = value[:2], value[2:]
+		}
+
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %s %q is not a date in YYYY-MM-DD format", ErrInvalidFHIRSearch, parameter, value)
+		}
+
+		switch prefix {
+		case "eq":
+			from, to = date, date
+		case "ge":
+			from = date
+		case "gt":
+			from = day.AddDate(0, 0, 1).Format(time.DateOnly)
+		case "le":
+			to = date
+		case "lt":
+			to = day.AddDate(0, 0, -1).Format(time.DateOnly)
+		default:
+			return "", "", fmt.Errorf("%w: %s prefix %q is not supported", ErrInvalidFHIRSearch, parameter, prefix)
+		}
+	}
+	return from, to, nil
+}
+
+// fhirIntId parses a numeric resource ID, any other ID does not exist
+func fhirIntId(id string) (int, error) {
+	value, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, sql.ErrNoRows
+	}
+	return value, nil
+}
+
+// fhirCompositeId parses a resource ID of the form <number>-<code>, any other ID does not exist
+func fhirCompositeId(id string) (int, string, error) {
+	number, code, ok := strings.Cut(id, "-")
+	if !ok || code == "" {
+		return 0, "", sql.ErrNoRows
+	}
+	value, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, "", sql.ErrNoRows
+	}
+	return value, code, nil
+}
+
+func checkFHIRResourceType(resourceType, expected string) error {
+	if resourceType != expected {
+		return fmt.Errorf("%w: resourceType is %q, expected %s", ErrInvalidFHIRResource, resourceType, expected)
+	}
+	return nil
+}
+
+// checkFHIRId checks that the ID of an updated resource is either empty or the ID of the URL
+func checkFHIRId(resourceId, id string) error {
+	if resourceId != "" && resourceId != id {
+		return fmt.Errorf("%w: resource ID %s does not match the ID %s", ErrInvalidFHIRResource, resourceId, id)
+	}
+	return nil
+}
+
+func fhirListQuery(search model.FHIRSearch) model.ListQuery {
+	return model.ListQuery{Limit: search.Count, Cursor: search.Cursor, Total: search.Total == "estimate" || search.Total == "accurate"}
+}
+
+func newFHIRBundle(total *int, nextCursor string) model.FHIRBundle {
+	return model.FHIRBundle{
+		ResourceType: model.FHIRBundleType,
+		Type:         "searchset",
+		Total:        total,
+		Entry:        []model.FHIRBundleEntry{},
+		NextCursor:   nextCursor,
+	}
+}
+
+// addFHIREntry adds the resource to the bundle, the full URL is relative to the FHIR base URL
+func addFHIREntry(bundle *model.FHIRBundle, resourceType, id string, resource interface{}) {
+	bundle.Entry = append(bundle.Entry, model.FHIRBundleEntry{FullUrl: fhirReference(resourceType, id), Resource: resource})
+}
+
+// optionalString is nil for an empty value, which is stored as NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// stringValue is the value or an empty string for nil
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
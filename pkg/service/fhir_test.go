@@ -0,0 +1,295 @@
+package services
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatientFromFHIR(t *testing.T) {
+	patient := model.Patient{Id: 1, FirstName: "Ivan", MiddleName: "Ivanovich", LastName: "Ivanov", BirthDate: stringPointer("1970-01-02T00:00:00Z"),
+		Sex: "м", SNILS: stringPointer("112-233-445 95"), Phone: stringPointer("+79990000000")}
+
+	testTable := []struct {
+		name            string
+		resource        model.FHIRPatient
+		expectedPatient model.Patient
+		expectedError   string
+	}{
+		{
+			name:     "Round trip",
+			resource: fhirPatient(patient),
+			expectedPatient: model.Patient{FirstName: "Ivan", MiddleName: "Ivanovich", LastName: "Ivanov", BirthDate: stringPointer("1970-01-02"),
+				Sex: "м", SNILS: stringPointer("112-233-445 95"), Phone: stringPointer("+79990000000")},
+		},
+		{
+			name:            "Female",
+			resource:        model.FHIRPatient{ResourceType: model.FHIRPatientType, Gender: "female"},
+			expectedPatient: model.Patient{Sex: "ж"},
+		},
+		{
+			name:            "Other gender",
+			resource:        model.FHIRPatient{ResourceType: model.FHIRPatientType, Gender: "other"},
+			expectedPatient: model.Patient{},
+		},
+		{
+			name:          "Wrong resource type",
+			resource:      model.FHIRPatient{ResourceType: model.FHIRObservationType},
+			expectedError: `invalid FHIR resource: resourceType is "Observation", expected Patient`,
+		},
+		{
+			name:          "Unknown gender",
+			resource:      model.FHIRPatient{ResourceType: model.FHIRPatientType, Gender: "m"},
+			expectedError: `invalid FHIR resource: unknown gender "m"`,
+		},
+		{
+			name:          "Invalid birth date",
+			resource:      model.FHIRPatient{ResourceType: model.FHIRPatientType, BirthDate: "02.01.1970"},
+			expectedError: `invalid FHIR resource: birth date "02.01.1970" is not in YYYY-MM-DD format`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			patient, err := patientFromFHIR(testCase.resource)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.ErrorIs(t, err, ErrInvalidFHIRResource)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedPatient, patient)
+		})
+	}
+}
+
+func TestFHIRObservation(t *testing.T) {
+	value := 100.0
+	bloodCount := model.BloodCount{Id: "PLT", Description: "Platelets", MeasureCode: "10^9/l", MinNormalValue: 150, MaxNormalValue: 400}
+	procedureBloodCount := model.ProcedureBloodCount{Value: &value, MeasureCode: "10^9/l", Procedure: 5, BloodCount: "PLT", Flag: model.LowFlag}
+
+	resource := fhirObservation(procedureBloodCount, bloodCount, "2024-03-01T00:00:00Z", 2)
+
+	assert.Equal(t, "5-PLT", resource.Id)
+	assert.Equal(t, "Patient/2", resource.Subject.Reference)
+	assert.Equal(t, "2024-03-01", resource.EffectiveDateTime)
+	assert.Equal(t, "L", resource.Interpretation[0].Coding[0].Code)
+	assert.Equal(t, 150.0, *resource.ReferenceRange[0].Low.Value)
+	assert.Equal(t, 400.0, *resource.ReferenceRange[0].High.Value)
+
+	parsed, err := procedureBloodCountFromFHIR(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProcedureBloodCount{Value: &value, MeasureCode: "10^9/l", Procedure: 5, BloodCount: "PLT"}, parsed)
+}
+
+func TestProcedureBloodCountFromFHIR(t *testing.T) {
+	value := 12.5
+
+	testTable := []struct {
+		name                        string
+		resource                    model.FHIRObservation
+		expectedProcedureBloodCount model.ProcedureBloodCount
+		expectedError               string
+	}{
+		{
+			name: "Unit as the measure code",
+			resource: model.FHIRObservation{
+				ResourceType:  model.FHIRObservationType,
+				PartOf:        []model.FHIRReference{{Reference: "Procedure/3"}},
+				Code:          model.FHIRCodeableConcept{Coding: []model.FHIRCoding{{System: "http://loinc.org", Code: "718-7"}, {Code: "HGB"}}},
+				ValueQuantity: &model.FHIRQuantity{Value: &value, Unit: "g/dl"},
+			},
+			expectedProcedureBloodCount: model.ProcedureBloodCount{Value: &value, MeasureCode: "g/dl", Procedure: 3, BloodCount: "HGB"},
+		},
+		{
+			name: "No procedure",
+			resource: model.FHIRObservation{
+				ResourceType: model.FHIRObservationType,
+				PartOf:       []model.FHIRReference{{Reference: "Procedure/x"}},
+				Code:         model.FHIRCodeableConcept{Coding: []model.FHIRCoding{{Code: "HGB"}}},
+			},
+			expectedError: "invalid FHIR resource: partOf needs a Procedure reference",
+		},
+		{
+			name: "No blood count",
+			resource: model.FHIRObservation{
+				ResourceType: model.FHIRObservationType,
+				PartOf:       []model.FHIRReference{{Reference: "Procedure/3"}},
+				Code:         model.FHIRCodeableConcept{Coding: []model.FHIRCoding{{System: "http://loinc.org", Code: "718-7"}}},
+			},
+			expectedError: "invalid FHIR resource: code needs a blood count coding",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			procedureBloodCount, err := procedureBloodCountFromFHIR(testCase.resource)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedProcedureBloodCount, procedureBloodCount)
+		})
+	}
+}
+
+func TestPatientCourseFromFHIR(t *testing.T) {
+	patientCourse := model.PatientCourse{Id: 7, Patient: 2, Disease: stringPointer("C50"), Course: "FAC", BeginDate: "2024-01-10", Doctor: 3, Diagnosis: stringPointer("C50.1")}
+	course := model.Course{Id: "FAC", Drug: "DOX", Dose: 60, MeasureCode: "mg"}
+
+	testTable := []struct {
+		name                  string
+		resource              model.FHIRMedicationRequest
+		expectedPatientCourse model.PatientCourse
+		expectedError         string
+	}{
+		{
+			name:                  "Round trip",
+			resource:              fhirMedicationRequest(patientCourse, course),
+			expectedPatientCourse: model.PatientCourse{Patient: 2, Disease: stringPointer("C50"), Course: "FAC", BeginDate: "2024-01-10", Doctor: 3, Diagnosis: stringPointer("C50.1")},
+		},
+		{
+			name: "Condition of another patient",
+			resource: func() model.FHIRMedicationRequest {
+				resource := fhirMedicationRequest(patientCourse, course)
+				resource.ReasonReference = []model.FHIRReference{{Reference: "Condition/4-C50"}}
+				return resource
+			}(),
+			expectedError: `invalid FHIR resource: reason reference "Condition/4-C50" is not a condition of the subject`,
+		},
+		{
+			name: "No requester",
+			resource: model.FHIRMedicationRequest{
+				ResourceType: model.FHIRMedicationRequestType,
+				Subject:      model.FHIRReference{Reference: "Patient/2"},
+			},
+			expectedError: "invalid FHIR resource: requester needs a Practitioner reference",
+		},
+		{
+			name: "No start date",
+			resource: model.FHIRMedicationRequest{
+				ResourceType:    model.FHIRMedicationRequestType,
+				Subject:         model.FHIRReference{Reference: "Patient/2"},
+				Requester:       &model.FHIRReference{Reference: "Practitioner/3"},
+				GroupIdentifier: &model.FHIRIdentifier{Value: "FAC"},
+			},
+			expectedError: "invalid FHIR resource: dosage timing needs a start date in YYYY-MM-DD format",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			patientCourse, err := patientCourseFromFHIR(testCase.resource)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedPatientCourse, patientCourse)
+		})
+	}
+}
+
+func TestFHIRDateRange(t *testing.T) {
+	testTable := []struct {
+		name          string
+		values        []string
+		expectedFrom  string
+		expectedTo    string
+		expectedError string
+	}{
+		{
+			name:         "Equal",
+			values:       []string{"2024-03-01"},
+			expectedFrom: "2024-03-01",
+			expectedTo:   "2024-03-01",
+		},
+		{
+			name:         "Exclusive bounds",
+			values:       []string{"gt2024-02-28", "lt2024-03-01"},
+			expectedFrom: "2024-02-29",
+			expectedTo:   "2024-02-29",
+		},
+		{
+			name:         "Lower bound",
+			values:       []string{"ge2024-03-01"},
+			expectedFrom: "2024-03-01",
+		},
+		{
+			name:          "Unsupported prefix",
+			values:        []string{"sa2024-03-01"},
+			expectedError: `invalid FHIR search parameter: date prefix "sa" is not supported`,
+		},
+		{
+			name:          "Not a date",
+			values:        []string{"2024"},
+			expectedError: `invalid FHIR search parameter: date "2024" is not a date in YYYY-MM-DD format`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			from, to, err := fhirDateRange("date", testCase.values)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.ErrorIs(t, err, ErrInvalidFHIRSearch)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedFrom, from)
+			assert.Equal(t, testCase.expectedTo, to)
+		})
+	}
+}
+
+// fhirPatients records the filter of the patient list
+type fhirPatients struct {
+	Patient
+	filter *model.PatientFilter
+}
+
+func (p fhirPatients) GetPatientList(user UserData, filter model.PatientFilter, listQuery model.ListQuery) (model.Page[model.Patient], error) {
+	*p.filter = filter
+	return model.Page[model.Patient]{Items: []model.Patient{{Id: 1, Sex: filter.Sex}}}, nil
+}
+
+func TestSearchFHIRPatientsByGender(t *testing.T) {
+	testTable := []struct {
+		name           string
+		gender         string
+		expectedFilter *model.PatientFilter
+		expectedError  string
+	}{
+		{name: "Male", gender: "male", expectedFilter: &model.PatientFilter{Sex: "м"}},
+		{name: "Female", gender: "female", expectedFilter: &model.PatientFilter{Sex: "ж"}},
+		{name: "Other", gender: "other"},
+		{name: "Local code", gender: "ж", expectedError: `invalid FHIR search parameter: unknown gender "ж"`},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			patients := fhirPatients{filter: &model.PatientFilter{}}
+			service := &FHIRService{patient: patients}
+
+			bundle, err := service.SearchFHIRPatients(UserData{Id: 1, Role: model.AdminRole}, model.FHIRPatientQuery{Gender: testCase.gender})
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			if testCase.expectedFilter == nil {
+				assert.Empty(t, bundle.Entry)
+				return
+			}
+			assert.Equal(t, testCase.expectedFilter, patients.filter)
+			assert.Len(t, bundle.Entry, 1)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidImport is returned for an import file that has no header row.
+var ErrInvalidImport = errors.New("invalid import file")
+
+// importColumn is a mapped column of an import file with its blood count and the conversion of its values to the unit of the blood count
+type importColumn struct {
+	index      int
+	header     string
+	bloodCount model.BloodCount
+	conversion model.UnitConversion
+}
+
+// importRow is a data row of an import file with its row number, 1 is the header
+type importRow struct {
+	number    int
+	cells     []string
+	procedure int
+}
+
+// ImportProcedureBloodCounts validates the rows of an import file, the first row being the header, and creates the blood count results in them.
+// Every problem is reported with its row, the results are created in a single transaction and only if no row has errors and it is not a dry run.
+func (s *ProcedureBloodCountService) ImportProcedureBloodCounts(user UserData, rows [][]string, mapping model.ImportMapping, dryRun bool) (model.ImportReport, error) {
+	report := model.ImportReport{DryRun: dryRun, Errors: []model.ImportError{}}
+	if len(rows) == 0 {
+		return report, fmt.Errorf("%w: no header row", ErrInvalidImport)
+	}
+
+	procedureIndex, columns, err := s.importColumns(rows[0], mapping, &report)
+	if err != nil || len(report.Errors) > 0 {
+		return report, err
+	}
+
+	dataRows, procedureIds := importRows(rows, procedureIndex, &report)
+	report.Rows += len(dataRows)
+
+	procedureErrors, existing, err := s.checkImportProcedures(user, procedureIds)
+	if err != nil {
+		return report, err
+	}
+
+	var procedureBloodCountList []model.ProcedureBloodCount
+	imported := map[string]bool{}
+	for _, row := range dataRows {
+		if message, ok := procedureErrors[row.procedure]; ok {
+			report.Errors = append(report.Errors, model.ImportError{Row: row.number, Column: rows[0][procedureIndex], Message: message})
+			continue
+		}
+
+		for _, column := range columns {
+			procedureBloodCount, ok, err := importValue(row, column)
+			if err != nil {
+				report.Errors = append(report.Errors, model.ImportError{Row: row.number, Column: column.header, Message: err.Error()})
+				continue
+			}
+			if !ok {
+				continue
+			}
+
+			key := procedureBloodCountEntityId(row.procedure, column.bloodCount.Id)
+			switch {
+			case existing[key]:
+				report.Errors = append(report.Errors, model.ImportError{Row: row.number, Column: column.header, Message: "result already exists"})
+			case imported[key]:
+				report.Errors = append(report.Errors, model.ImportError{Row: row.number, Column: column.header, Message: "duplicate result in the file"})
+			default:
+				imported[key] = true
+				procedureBloodCountList = append(procedureBloodCountList, procedureBloodCount)
+			}
+		}
+	}
+	report.Results = len(procedureBloodCountList)
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+
+	if dryRun || len(report.Errors) > 0 || len(procedureBloodCountList) == 0 {
+		return report, nil
+	}
+
+	err = s.tx.InTransaction(func(repos *repository.Repository) error {
+		createdList, err := repos.ProcedureBloodCount.CreateProcedureBloodCountList(procedureBloodCountList)
+		if err != nil {
+			return err
+		}
+		for _, created := range createdList {
+			err := recordAudit(repos.Audit, user, model.CreateAction, model.ProcedureBloodCountResource,
+				procedureBloodCountEntityId(created.Procedure, created.BloodCount), nil, created)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// importColumns resolves the procedure column and the mapped blood count columns from the header.
+// Problems of the header and the mapping are added to the report as errors of row 1.
+func (s *ProcedureBloodCountService) importColumns(header []string, mapping model.ImportMapping, report *model.ImportReport) (int, []importColumn, error) {
+	headerError := func(column, message string) {
+		report.Errors = append(report.Errors, model.ImportError{Row: 1, Column: column, Message: message})
+	}
+
+	indexes := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := indexes[name]; ok {
+			headerError(name, "duplicate column")
+			continue
+		}
+		indexes[name] = i
+	}
+
+	procedureHeader := mapping.Procedure
+	if procedureHeader == "" {
+		procedureHeader = model.DefaultImportProcedureColumn
+	}
+	procedureIndex, ok := indexes[procedureHeader]
+	if !ok {
+		headerError(procedureHeader, "column not found")
+	}
+
+	mappedColumns := mapping.Columns
+	if len(mappedColumns) == 0 {
+		for _, name := range header {
+			if name = strings.TrimSpace(name); name != "" && name != procedureHeader {
+				mappedColumns = append(mappedColumns, model.ImportColumn{Header: name, BloodCount: name})
+			}
+		}
+	}
+
+	var bloodCountIds []string
+	for _, column := range mappedColumns {
+		bloodCountIds = append(bloodCountIds, column.BloodCount)
+	}
+	bloodCountList, err := s.bloodCountRepo.GetBloodCountListByIds(bloodCountIds)
+	if err != nil {
+		return 0, nil, err
+	}
+	bloodCounts := map[string]model.BloodCount{}
+	for _, bloodCount := range bloodCountList {
+		bloodCounts[bloodCount.Id] = bloodCount
+	}
+
+	var columns []importColumn
+	mapped := map[string]bool{}
+	for _, mappedColumn := range mappedColumns {
+		index, ok := indexes[mappedColumn.Header]
+		switch {
+		case mappedColumn.Header == "" || mappedColumn.BloodCount == "":
+			headerError(mappedColumn.Header, "column mapping needs a header and a blood count")
+			continue
+		case !ok:
+			headerError(mappedColumn.Header, "column not found")
+			continue
+		case mapped[mappedColumn.BloodCount]:
+			headerError(mappedColumn.Header, fmt.Sprintf("blood count %s is mapped to several columns", mappedColumn.BloodCount))
+			continue
+		}
+		mapped[mappedColumn.BloodCount] = true
+
+		bloodCount, ok := bloodCounts[mappedColumn.BloodCount]
+		if !ok {
+			headerError(mappedColumn.Header, fmt.Sprintf("unknown blood count %s", mappedColumn.BloodCount))
+			continue
+		}
+
+		measureCode := mappedColumn.MeasureCode
+		if measureCode == "" {
+			measureCode = bloodCount.MeasureCode
+		}
+		conversion, err := findUnitConversion(s.conversions, measureCode, bloodCount.MeasureCode)
+		if errors.Is(err, ErrNoUnitConversion) {
+			headerError(mappedColumn.Header, err.Error())
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+
+		columns = append(columns, importColumn{index: index, header: mappedColumn.Header, bloodCount: bloodCount, conversion: conversion})
+	}
+	if len(columns) == 0 && len(report.Errors) == 0 {
+		headerError("", "no blood count columns")
+	}
+
+	return procedureIndex, columns, nil
+}
+
+// importRows returns the data rows that are not empty with their procedure IDs.
+// Rows with a malformed procedure ID are added to the report as errors.
+func importRows(rows [][]string, procedureIndex int, report *model.ImportReport) ([]importRow, []int) {
+	var (
+		dataRows     []importRow
+		procedureIds []int
+	)
+	seen := map[int]bool{}
+
+	for i, cells := range rows[1:] {
+		if isEmptyRow(cells) {
+			continue
+		}
+		number := i + 2
+
+		cell := importCell(cells, procedureIndex)
+		procedure, err := strconv.Atoi(cell)
+		if err != nil {
+			report.Rows++
+			report.Errors = append(report.Errors, model.ImportError{Row: number, Column: rows[0][procedureIndex],
+				Message: fmt.Sprintf("invalid procedure ID %q", cell)})
+			continue
+		}
+
+		dataRows = append(dataRows, importRow{number: number, cells: cells, procedure: procedure})
+		if !seen[procedure] {
+			seen[procedure] = true
+			procedureIds = append(procedureIds, procedure)
+		}
+	}
+	return dataRows, procedureIds
+}
+
+// checkImportProcedures returns the error messages of the procedures that do not exist or the user has no access to,
+// and the results of the procedures that are already stored by their audit log IDs.
+func (s *ProcedureBloodCountService) checkImportProcedures(user UserData, procedureIds []int) (map[int]string, map[string]bool, error) {
+	procedureErrors := map[int]string{}
+	existing := map[string]bool{}
+	if len(procedureIds) == 0 {
+		return procedureErrors, existing, nil
+	}
+
+	existingIds, err := s.repo.GetExistingProcedureIds(procedureIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := map[int]bool{}
+	for _, id := range existingIds {
+		found[id] = true
+	}
+
+	for _, id := range procedureIds {
+		if !found[id] {
+			procedureErrors[id] = fmt.Sprintf("course procedure %d does not exist", id)
+			continue
+		}
+		err := s.access.CheckCourseProcedureAccess(user, id)
+		if errors.Is(err, ErrForbidden) {
+			procedureErrors[id] = err.Error()
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	procedureBloodCountList, err := s.repo.GetProcedureBloodCountListByProcedures(existingIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, procedureBloodCount := range procedureBloodCountList {
+		existing[procedureBloodCountEntityId(procedureBloodCount.Procedure, procedureBloodCount.BloodCount)] = true
+	}
+	return procedureErrors, existing, nil
+}
+
+// importValue reads the value of the column in the row, converts it to the unit of the blood count and flags it.
+// An empty cell has no result.
+func importValue(row importRow, column importColumn) (model.ProcedureBloodCount, bool, error) {
+	cell := importCell(row.cells, column.index)
+	if cell == "" {
+		return model.ProcedureBloodCount{}, false, nil
+	}
+
+	// Spreadsheets in a Russian locale write the decimal separator as a comma
+	value, err := strconv.ParseFloat(strings.Replace(cell, ",", ".", 1), 64)
+	// ParseFloat also reads NaN and Inf, which are not results
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return model.ProcedureBloodCount{}, false, fmt.Errorf("invalid value %q", cell)
+	}
+	value = column.conversion.Convert(value)
+
+	flag, err := flagBloodCount(column.bloodCount, &value)
+	if err != nil {
+		return model.ProcedureBloodCount{}, false, err
+	}
+
+	return model.ProcedureBloodCount{
+		Value:       &value,
+		MeasureCode: column.bloodCount.MeasureCode,
+		Procedure:   row.procedure,
+		BloodCount:  column.bloodCount.Id,
+		Flag:        flag,
+	}, true, nil
+}
+
+// importCell returns the trimmed cell of the row, rows may be shorter than the header
+func importCell(cells []string, index int) string {
+	if index >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[index])
+}
+
+func isEmptyRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"med/pkg/model"
+	"med/pkg/repository"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// importRepository keeps the procedures and their results in memory, the embedded interfaces are not used by the import
+type importRepository struct {
+	repository.ProcedureBloodCount
+	repository.BloodCount
+	procedureIds []int
+	existing     []model.ProcedureBloodCount
+	bloodCounts  []model.BloodCount
+	created      []model.ProcedureBloodCount
+}
+
+func (r *importRepository) GetExistingProcedureIds(procedureIds []int) ([]int, error) {
+	var existingIds []int
+	for _, id := range procedureIds {
+		if slices.Contains(r.procedureIds, id) {
+			existingIds = append(existingIds, id)
+		}
+	}
+	return existingIds, nil
+}
+
+func (r *importRepository) GetProcedureBloodCountListByProcedures(procedureIds []int) ([]model.ProcedureBloodCount, error) {
+	return r.existing, nil
+}
+
+func (r *importRepository) CreateProcedureBloodCountList(procedureBloodCountList []model.ProcedureBloodCount) ([]model.ProcedureBloodCount, error) {
+	r.created = procedureBloodCountList
+	return procedureBloodCountList, nil
+}
+
+func (r *importRepository) GetBloodCountListByIds(ids []string) ([]model.BloodCount, error) {
+	var bloodCountList []model.BloodCount
+	for _, bloodCount := range r.bloodCounts {
+		if slices.Contains(ids, bloodCount.Id) {
+			bloodCountList = append(bloodCountList, bloodCount)
+		}
+	}
+	return bloodCountList, nil
+}
+
+// importAccess forbids access to the listed procedures
+type importAccess struct {
+	Access
+	forbidden []int
+}
+
+func (a importAccess) CheckCourseProcedureAccess(user UserData, procedureId int) error {
+	if slices.Contains(a.forbidden, procedureId) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func TestImportProcedureBloodCounts(t *testing.T) {
+	user := UserData{Id: 1, Role: model.DoctorRole}
+	mapping := model.ImportMapping{
+		Procedure: "Procedure",
+		Columns: []model.ImportColumn{
+			{Header: "Hb, g/dl", BloodCount: "HGB", MeasureCode: "g/dl"},
+			{Header: "PLT", BloodCount: "PLT"},
+		},
+	}
+
+	testTable := []struct {
+		name            string
+		rows            [][]string
+		mapping         model.ImportMapping
+		dryRun          bool
+		expectedReport  model.ImportReport
+		expectedCreated []model.ProcedureBloodCount
+		expectedError   string
+	}{
+		{
+			name: "OK",
+			rows: [][]string{
+				{"Procedure", "Hb, g/dl", "PLT"},
+				{"1", "12,5", "100"},
+				{"", "", ""},
+				{"5", "", "500"},
+			},
+			mapping:        mapping,
+			expectedReport: model.ImportReport{Committed: true, Rows: 2, Results: 3, Errors: []model.ImportError{}},
+			expectedCreated: []model.ProcedureBloodCount{
+				{Value: floatPointer(125), MeasureCode: "g/l", Procedure: 1, BloodCount: "HGB", Flag: model.NormalFlag},
+				{Value: floatPointer(100), MeasureCode: "10^9/l", Procedure: 1, BloodCount: "PLT", Flag: model.LowFlag},
+				{Value: floatPointer(500), MeasureCode: "10^9/l", Procedure: 5, BloodCount: "PLT", Flag: model.HighFlag},
+			},
+		},
+		{
+			name: "Dry run",
+			rows: [][]string{
+				{"Procedure", "Hb, g/dl", "PLT"},
+				{"1", "12.5", "200"},
+			},
+			mapping:        mapping,
+			dryRun:         true,
+			expectedReport: model.ImportReport{DryRun: true, Rows: 1, Results: 2, Errors: []model.ImportError{}},
+		},
+		{
+			name: "Blood count IDs as headers",
+			rows: [][]string{
+				{"procedure", "HGB"},
+				{"2", "130"},
+			},
+			expectedReport: model.ImportReport{Committed: true, Rows: 1, Results: 1, Errors: []model.ImportError{}},
+			expectedCreated: []model.ProcedureBloodCount{
+				{Value: floatPointer(130), MeasureCode: "g/l", Procedure: 2, BloodCount: "HGB", Flag: model.NormalFlag},
+			},
+		},
+		{
+			name: "Row errors",
+			rows: [][]string{
+				{"Procedure", "Hb, g/dl", "PLT"},
+				{"1", "abc", "2000"},
+				{"x", "12", "200"},
+				{"3", "12", "200"},
+				{"4", "12", "200"},
+				{"2", "13", "150"},
+				{"2", "13", ""},
+			},
+			mapping: mapping,
+			expectedReport: model.ImportReport{Rows: 6, Results: 1, Errors: []model.ImportError{
+				{Row: 2, Column: "Hb, g/dl", Message: `invalid value "abc"`},
+				{Row: 2, Column: "PLT", Message: "value is outside the possible range: PLT value 2000 is not in 0..1000"},
+				{Row: 3, Column: "Procedure", Message: `invalid procedure ID "x"`},
+				{Row: 4, Column: "Procedure", Message: "course procedure 3 does not exist"},
+				{Row: 5, Column: "Procedure", Message: "access to patient data is forbidden"},
+				{Row: 6, Column: "PLT", Message: "result already exists"},
+				{Row: 7, Column: "Hb, g/dl", Message: "duplicate result in the file"},
+			}},
+		},
+		{
+			name: "Non-finite values",
+			rows: [][]string{
+				{"Procedure", "Hb, g/dl", "PLT"},
+				{"1", "NaN", "Inf"},
+				{"5", "", "-inf"},
+			},
+			mapping: mapping,
+			expectedReport: model.ImportReport{Rows: 2, Errors: []model.ImportError{
+				{Row: 2, Column: "Hb, g/dl", Message: `invalid value "NaN"`},
+				{Row: 2, Column: "PLT", Message: `invalid value "Inf"`},
+				{Row: 3, Column: "PLT", Message: `invalid value "-inf"`},
+			}},
+		},
+		{
+			name: "Mapping errors",
+			rows: [][]string{
+				{"Procedure", "Hb, g/dl", "PLT", "PLT"},
+			},
+			mapping: model.ImportMapping{
+				Procedure: "Procedure",
+				Columns: []model.ImportColumn{
+					{Header: "Hb, g/dl", BloodCount: "HGB", MeasureCode: "mmol/l"},
+					{Header: "WBC", BloodCount: "WBC"},
+					{Header: "PLT", BloodCount: "RBC"},
+				},
+			},
+			expectedReport: model.ImportReport{Errors: []model.ImportError{
+				{Row: 1, Column: "PLT", Message: "duplicate column"},
+				{Row: 1, Column: "Hb, g/dl", Message: "no conversion between units mmol/l and g/l"},
+				{Row: 1, Column: "WBC", Message: "column not found"},
+				{Row: 1, Column: "PLT", Message: "unknown blood count RBC"},
+			}},
+		},
+		{
+			name:           "No procedure column",
+			rows:           [][]string{{"HGB"}, {"130"}},
+			expectedReport: model.ImportReport{Errors: []model.ImportError{{Row: 1, Column: "procedure", Message: "column not found"}}},
+		},
+		{
+			name:          "Empty file",
+			expectedError: "invalid import file: no header row",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &importRepository{
+				procedureIds: []int{1, 2, 4, 5},
+				existing:     []model.ProcedureBloodCount{{Procedure: 2, BloodCount: "PLT"}},
+				bloodCounts: []model.BloodCount{
+					{Id: "HGB", MeasureCode: "g/l", MinNormalValue: 120, MaxNormalValue: 160, MaxPossibleValue: 250},
+					{Id: "PLT", MeasureCode: "10^9/l", MinNormalValue: 150, MaxNormalValue: 400, MaxPossibleValue: 1000},
+				},
+			}
+			conversions := conversionRepository{{From: "g/dl", To: "g/l", Factor: 10}}
+			tx := &auditTransaction{repos: repository.Repository{ProcedureBloodCount: repo}}
+			service := NewProcedureBloodCountService(repo, repo, conversions, importAccess{forbidden: []int{4}}, tx)
+
+			report, err := service.ImportProcedureBloodCounts(user, testCase.rows, testCase.mapping, testCase.dryRun)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.ErrorIs(t, err, ErrInvalidImport)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedReport, report)
+			assert.Equal(t, testCase.expectedCreated, repo.created)
+			assert.Len(t, tx.entries, len(testCase.expectedCreated))
+		})
+	}
+}
+
+func floatPointer(value float64) *float64 {
+	return &value
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
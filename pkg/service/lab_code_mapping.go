@@ -0,0 +1,24 @@
+package services
+
+import (
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+type LabCodeMappingService struct {
+	repo repository.LabCodeMapping
+}
+
+func NewLabCodeMappingService(repo repository.LabCodeMapping) *LabCodeMappingService {
+	return &LabCodeMappingService{repo: repo}
+}
+
+func (s *LabCodeMappingService) CreateLabCodeMapping(labCodeMapping model.LabCodeMapping) (model.LabCodeMapping, error) {
+	return s.repo.CreateLabCodeMapping(labCodeMapping)
+}
+func (s *LabCodeMappingService) GetLabCodeMappingList(listQuery model.ListQuery) (model.Page[model.LabCodeMapping], error) {
+	return s.repo.GetLabCodeMappingList(listQuery)
+}
+func (s *LabCodeMappingService) DeleteLabCodeMapping(system, code string) error {
+	return s.repo.DeleteLabCodeMapping(system, code)
+}
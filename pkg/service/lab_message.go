@@ -0,0 +1,609 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// labPatientAuthority is the assigning authority of PID-3 identifiers that are OncoBase patient IDs
+	labPatientAuthority = "OncoBase"
+	// labSNILSAuthority is the OID of SNILS, an assigning authority of PID-3 identifiers that are SNILS
+	labSNILSAuthority = "1.2.643.100.3"
+	// labAckApplication is the sending application of acknowledgments to messages without a receiving application
+	labAckApplication = "OncoBase"
+	// labAckVersion is the HL7 version of acknowledgments to messages without a version
+	labAckVersion = "2.5"
+)
+
+// labSNILSIdentifierTypes are the PID-3 identifier types of SNILS, SS being the social security number of table 0203
+var labSNILSIdentifierTypes = []string{"SNILS", "SS"}
+
+// hl7ErrorTexts are the descriptions of the HL7 v2 error codes (table 0357)
+var hl7ErrorTexts = map[int]string{
+	model.HL7MessageAccepted:        "Message accepted",
+	model.HL7SegmentSequenceError:   "Segment sequence error",
+	model.HL7RequiredFieldMissing:   "Required field missing",
+	model.HL7DataTypeError:          "Data type error",
+	model.HL7TableValueNotFound:     "Table value not found",
+	model.HL7UnsupportedMessageType: "Unsupported message type",
+	model.HL7UnknownKeyIdentifier:   "Unknown key identifier",
+	model.HL7DuplicateKeyIdentifier: "Duplicate key identifier",
+	model.HL7ApplicationError:       "Application internal error",
+}
+
+type LabMessageService struct {
+	repo              repository.LabMessage
+	codeMappings      repository.LabCodeMapping
+	patientRepo       repository.Patient
+	patientCourseRepo repository.PatientCourse
+	bloodCountRepo    repository.BloodCount
+	conversions       repository.UnitConversion
+	access            Access
+	tx                repository.Transaction
+}
+
+func NewLabMessageService(repo repository.LabMessage, codeMappings repository.LabCodeMapping, patientRepo repository.Patient,
+	patientCourseRepo repository.PatientCourse, bloodCountRepo repository.BloodCount, conversions repository.UnitConversion,
+	access Access, tx repository.Transaction) *LabMessageService {
+	return &LabMessageService{repo: repo, codeMappings: codeMappings, patientRepo: patientRepo, patientCourseRepo: patientCourseRepo,
+		bloodCountRepo: bloodCountRepo, conversions: conversions, access: access, tx: tx}
+}
+
+// labSegment is a segment of a lab message with its occurrence number among the segments of its name, which locates its problems
+type labSegment struct {
+	utils.HL7Segment
+	sequence int
+}
+
+// labOrder is an OBR segment with the PID segment it follows and the OBX segments that follow it
+type labOrder struct {
+	pid          labSegment
+	obr          labSegment
+	observations []labSegment
+}
+
+// labCode is an observation code in its coding system
+type labCode struct {
+	system string
+	code   string
+}
+
+// labIngestion collects the problems of a lab message while its orders are turned into course procedures
+type labIngestion struct {
+	*LabMessageService
+	user        UserData
+	problems    []model.LabMessageError
+	patients    map[int]*model.Patient
+	mappings    map[labCode]model.LabCodeMapping
+	bloodCounts map[string]model.BloodCount
+}
+
+// IngestLabMessage stores the blood count results of an HL7 v2 ORU^R01 message and acknowledges it.
+// Every order (OBR) becomes a course procedure of the patient (PID) in the patient course of OBR-2, or in the course
+// under way on the observation date, with the results (OBX) of the codes mapped to blood counts.
+// The message is stored in a single transaction and only if it has no errors, otherwise its errors are acknowledged with AE.
+// A malformed or unsupported message is rejected with AR, as is a message that fails on an internal error, which is returned as well.
+func (s *LabMessageService) IngestLabMessage(user UserData, message string) (model.LabMessageAck, error) {
+	parsed, err := utils.ParseHL7(message)
+	if err != nil {
+		return labMessageAck(utils.HL7Segment{}, model.HL7RejectAck, []model.LabMessageError{{
+			Segment: "MSH", Sequence: 1, Code: model.HL7SegmentSequenceError, Severity: model.HL7ErrorSeverity, Message: err.Error(),
+		}}), nil
+	}
+	header := labSegment{HL7Segment: parsed.Header(), sequence: 1}
+
+	if header.Component(9, 1) != "ORU" || header.Component(9, 2) != "R01" {
+		return labMessageAck(header.HL7Segment, model.HL7RejectAck, []model.LabMessageError{
+			header.problem(9, model.HL7UnsupportedMessageType, model.HL7ErrorSeverity,
+				fmt.Sprintf("unsupported message type %s, expected ORU^R01", header.Delimiters.Unescape(header.Field(9)))),
+		}), nil
+	}
+	labMessage := model.LabMessage{
+		SendingApplication: header.Component(3, 1),
+		SendingFacility:    header.Component(4, 1),
+		ControlId:          header.Component(10, 1),
+		ReceivedBy:         user.Id,
+	}
+	if labMessage.ControlId == "" {
+		return labMessageAck(header.HL7Segment, model.HL7RejectAck, []model.LabMessageError{
+			header.problem(10, model.HL7RequiredFieldMissing, model.HL7ErrorSeverity, "message control ID is missing"),
+		}), nil
+	}
+
+	// An analyzer resends a message it got no acknowledgment of, its results are stored already
+	_, err = s.repo.GetLabMessage(labMessage.SendingApplication, labMessage.SendingFacility, labMessage.ControlId)
+	if err == nil {
+		return labMessageAck(header.HL7Segment, model.HL7AcceptAck, nil), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return labInternalError(header.HL7Segment, err)
+	}
+
+	ingestion := &labIngestion{LabMessageService: s, user: user, patients: map[int]*model.Patient{}}
+	procedures, err := ingestion.procedures(parsed)
+	if err != nil {
+		return labInternalError(header.HL7Segment, err)
+	}
+	if slices.ContainsFunc(ingestion.problems, func(problem model.LabMessageError) bool { return problem.Severity == model.HL7ErrorSeverity }) {
+		return labMessageAck(header.HL7Segment, model.HL7ErrorAck, ingestion.problems), nil
+	}
+
+	labMessage.Procedures = len(procedures)
+	for _, procedure := range procedures {
+		labMessage.Results += len(procedure.Results)
+	}
+	err = s.tx.InTransaction(func(repos *repository.Repository) error {
+		createdProcedures, err := repos.LabMessage.CreateLabMessage(labMessage, procedures)
+		if err != nil {
+			return err
+		}
+		for _, procedure := range createdProcedures {
+			err := recordAudit(repos.Audit, user, model.CreateAction, model.CourseProcedureResource,
+				strconv.Itoa(procedure.CourseProcedure.Id), nil, procedure.CourseProcedure)
+			if err != nil {
+				return err
+			}
+			for _, result := range procedure.Results {
+				err := recordAudit(repos.Audit, user, model.CreateAction, model.ProcedureBloodCountResource,
+					procedureBloodCountEntityId(result.Procedure, result.BloodCount), nil, result)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return labInternalError(header.HL7Segment, err)
+	}
+	return labMessageAck(header.HL7Segment, model.HL7AcceptAck, ingestion.problems), nil
+}
+
+// procedures turns the orders of the message into course procedures with their results
+func (i *labIngestion) procedures(message utils.HL7Message) ([]model.LabProcedure, error) {
+	orders := i.orders(message)
+	if err := i.loadMappings(orders); err != nil {
+		return nil, err
+	}
+
+	var procedures []model.LabProcedure
+	for _, order := range orders {
+		patient, err := i.patient(order.pid)
+		if err != nil {
+			return nil, err
+		}
+		if patient == nil {
+			continue
+		}
+
+		var results []model.ProcedureBloodCount
+		for _, obx := range order.observations {
+			result, ok, err := i.result(obx)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if slices.ContainsFunc(results, func(other model.ProcedureBloodCount) bool { return other.BloodCount == result.BloodCount }) {
+				i.report(obx, 3, model.HL7DuplicateKeyIdentifier, model.HL7ErrorSeverity, "blood count %s is reported twice in the order", result.BloodCount)
+				continue
+			}
+			results = append(results, result)
+		}
+		if len(results) == 0 {
+			i.report(order.obr, 0, model.HL7MessageAccepted, model.HL7WarningSeverity, "the order has no results of mapped blood counts, no procedure is created")
+			continue
+		}
+
+		date, ok := labDate(order.obr.Component(7, 1))
+		if !ok && len(order.observations) > 0 {
+			date, ok = labDate(order.observations[0].Component(14, 1))
+		}
+		if !ok {
+			i.report(order.obr, 7, model.HL7RequiredFieldMissing, model.HL7ErrorSeverity, "observation date/time is missing or invalid")
+			continue
+		}
+
+		patientCourse, ok, err := i.patientCourse(*patient, order.obr, date)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		procedures = append(procedures, model.LabProcedure{
+			CourseProcedure: model.CourseProcedure{PatientCourse: patientCourse.Id, Doctor: patientCourse.Doctor, BeginDate: date},
+			Results:         results,
+		})
+	}
+	return procedures, nil
+}
+
+// orders groups the OBR segments with the PID segment they follow and the OBX segments that follow them.
+// Other segments are skipped.
+func (i *labIngestion) orders(message utils.HL7Message) []labOrder {
+	var (
+		orders []labOrder
+		pid    *labSegment
+	)
+	occurrences := map[string]int{}
+	for _, hl7Segment := range message.Segments {
+		occurrences[hl7Segment.Name]++
+		segment := labSegment{HL7Segment: hl7Segment, sequence: occurrences[hl7Segment.Name]}
+
+		switch segment.Name {
+		case "PID":
+			pid = &segment
+		case "OBR":
+			if pid == nil {
+				i.report(segment, 0, model.HL7SegmentSequenceError, model.HL7ErrorSeverity, "OBR segment before the PID segment")
+				continue
+			}
+			orders = append(orders, labOrder{pid: *pid, obr: segment})
+		case "OBX":
+			if len(orders) == 0 || orders[len(orders)-1].pid.sequence != pid.sequence {
+				i.report(segment, 0, model.HL7SegmentSequenceError, model.HL7ErrorSeverity, "OBX segment before an OBR segment")
+				continue
+			}
+			orders[len(orders)-1].observations = append(orders[len(orders)-1].observations, segment)
+		}
+	}
+	return orders
+}
+
+// loadMappings loads the mappings of the OBX-3 codes of the orders and their blood counts
+func (i *labIngestion) loadMappings(orders []labOrder) error {
+	var codes []string
+	for _, order := range orders {
+		for _, obx := range order.observations {
+			codes = append(codes, obx.Component(3, 1), obx.Component(3, 4))
+		}
+	}
+
+	mappings, err := i.codeMappings.GetLabCodeMappingListByCodes(codes)
+	if err != nil {
+		return err
+	}
+	i.mappings = map[labCode]model.LabCodeMapping{}
+	var bloodCountIds []string
+	for _, mapping := range mappings {
+		i.mappings[labCode{system: mapping.System, code: mapping.Code}] = mapping
+		bloodCountIds = append(bloodCountIds, mapping.BloodCount)
+	}
+
+	bloodCounts, err := i.bloodCountRepo.GetBloodCountListByIds(bloodCountIds)
+	if err != nil {
+		return err
+	}
+	i.bloodCounts = map[string]model.BloodCount{}
+	for _, bloodCount := range bloodCounts {
+		i.bloodCounts[bloodCount.Id] = bloodCount
+	}
+	return nil
+}
+
+// patient finds the patient of the PID segment by SNILS in PID-3 or PID-19, or by an OncoBase patient ID in PID-3,
+// and checks that the family name in PID-5 is the last name of the patient. The patient is nil if a problem is reported.
+func (i *labIngestion) patient(pid labSegment) (*model.Patient, error) {
+	if patient, ok := i.patients[pid.sequence]; ok {
+		return patient, nil
+	}
+
+	patient, err := i.findPatient(pid)
+	if err != nil {
+		return nil, err
+	}
+	i.patients[pid.sequence] = patient
+	return patient, nil
+}
+
+func (i *labIngestion) findPatient(pid labSegment) (*model.Patient, error) {
+	var snilsList, idList []string
+	for _, identifier := range pid.Repetitions(3) {
+		value := pid.Delimiters.Component(identifier, 1)
+		namespace, universalId, _ := strings.Cut(pid.Delimiters.Component(identifier, 4), string(pid.Delimiters.SubcomponentSeparator))
+		universalId, _, _ = strings.Cut(universalId, string(pid.Delimiters.SubcomponentSeparator))
+
+		switch {
+		case slices.Contains(labSNILSIdentifierTypes, strings.ToUpper(pid.Delimiters.Component(identifier, 5))),
+			strings.EqualFold(namespace, "SNILS"), universalId == labSNILSAuthority:
+			snilsList = append(snilsList, value)
+		case strings.EqualFold(namespace, labPatientAuthority):
+			idList = append(idList, value)
+		}
+	}
+	if snils := pid.Component(19, 1); snils != "" {
+		snilsList = append(snilsList, snils)
+	}
+	if len(snilsList) == 0 && len(idList) == 0 {
+		i.report(pid, 3, model.HL7RequiredFieldMissing, model.HL7ErrorSeverity, "the patient has neither SNILS nor an OncoBase patient ID")
+		return nil, nil
+	}
+
+	var found []model.Patient
+	for _, snils := range snilsList {
+		digits := strings.Map(func(r rune) rune {
+			if r < '0' || r > '9' {
+				return -1
+			}
+			return r
+		}, snils)
+		if len(digits) != 11 {
+			i.report(pid, 3, model.HL7DataTypeError, model.HL7ErrorSeverity, "SNILS %q does not have 11 digits", snils)
+			return nil, nil
+		}
+
+		patient, err := i.patientRepo.GetPatientBySNILS(digits)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, patient)
+	}
+	for _, value := range idList {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			i.report(pid, 3, model.HL7DataTypeError, model.HL7ErrorSeverity, "OncoBase patient ID %q is not a number", value)
+			return nil, nil
+		}
+
+		patient, err := i.patientRepo.GetPatientById(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, patient)
+	}
+
+	if len(found) == 0 {
+		i.report(pid, 3, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity, "the patient is not found by SNILS or OncoBase patient ID")
+		return nil, nil
+	}
+	patient := found[0]
+	for _, other := range found[1:] {
+		if other.Id != patient.Id {
+			i.report(pid, 3, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity, "the identifiers belong to different patients")
+			return nil, nil
+		}
+	}
+
+	// An identifier mistyped into another patient's must not attach the results to that patient
+	family, _, _ := strings.Cut(pid.Component(5, 1), string(pid.Delimiters.SubcomponentSeparator))
+	if family = strings.TrimSpace(family); family != "" && !strings.EqualFold(family, strings.TrimSpace(patient.LastName)) {
+		i.report(pid, 5, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity,
+			"family name %q does not match the patient found by the identifiers", family)
+		return nil, nil
+	}
+	return &patient, nil
+}
+
+// patientCourse returns the patient course the order is placed in, given as the placer order number in OBR-2,
+// or else the only course of the patient under way on the date, and checks the access of the user to it.
+func (i *labIngestion) patientCourse(patient model.Patient, obr labSegment, date string) (model.PatientCourse, bool, error) {
+	var patientCourse model.PatientCourse
+	if id, err := strconv.Atoi(obr.Component(2, 1)); err == nil {
+		patientCourse, err = i.patientCourseRepo.GetPatientCourseById(id)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return model.PatientCourse{}, false, err
+		}
+		if err != nil || patientCourse.Patient != patient.Id {
+			i.report(obr, 2, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity, "patient course %d of the patient does not exist", id)
+			return model.PatientCourse{}, false, nil
+		}
+	} else {
+		patientCourseList, err := i.patientCourseRepo.GetPatientCourseListByDate(patient.Id, date)
+		if err != nil {
+			return model.PatientCourse{}, false, err
+		}
+		switch len(patientCourseList) {
+		case 0:
+			i.report(obr, 7, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity, "the patient has no course under way on %s", date)
+			return model.PatientCourse{}, false, nil
+		case 1:
+			patientCourse = patientCourseList[0]
+		default:
+			i.report(obr, 2, model.HL7UnknownKeyIdentifier, model.HL7ErrorSeverity,
+				"the patient has several courses under way on %s, the placer order number must be the patient course ID", date)
+			return model.PatientCourse{}, false, nil
+		}
+	}
+
+	err := i.access.CheckPatientCourseAccess(i.user, patientCourse.Id)
+	if errors.Is(err, ErrForbidden) {
+		i.report(obr, 0, model.HL7ApplicationError, model.HL7ErrorSeverity, err.Error())
+		return model.PatientCourse{}, false, nil
+	}
+	if err != nil {
+		return model.PatientCourse{}, false, err
+	}
+	return patientCourse, true, nil
+}
+
+// result reads the blood count result of the OBX segment, converted to the unit of the blood count and flagged.
+// Results of unmapped codes, results that are not final and values that are not exact numbers are skipped with a warning.
+func (i *labIngestion) result(obx labSegment) (model.ProcedureBloodCount, bool, error) {
+	mapping, ok := i.mapping(obx)
+	if !ok {
+		i.report(obx, 3, model.HL7TableValueNotFound, model.HL7WarningSeverity,
+			"observation code %s is not mapped to a blood count, the result is skipped", obx.Component(3, 1))
+		return model.ProcedureBloodCount{}, false, nil
+	}
+	bloodCount, ok := i.bloodCounts[mapping.BloodCount]
+	if !ok {
+		return model.ProcedureBloodCount{}, false, fmt.Errorf("blood count %s of the lab code mapping does not exist", mapping.BloodCount)
+	}
+
+	if status := obx.Component(11, 1); status != "" && status != "F" {
+		i.report(obx, 11, model.HL7MessageAccepted, model.HL7WarningSeverity, "result status %s is not final, the result is skipped", status)
+		return model.ProcedureBloodCount{}, false, nil
+	}
+
+	value, _, _ := strings.Cut(obx.Field(5), string(obx.Delimiters.RepetitionSeparator))
+	text := obx.Delimiters.Component(value, 1)
+	if obx.Component(2, 1) == "SN" {
+		// A structured numeric value is exact only as a number with no comparator or with =
+		comparator, number, suffix := text, obx.Delimiters.Component(value, 2), obx.Delimiters.Component(value, 4)
+		if (comparator != "" && comparator != "=") || obx.Delimiters.Component(value, 3) != "" || suffix != "" {
+			i.report(obx, 5, model.HL7DataTypeError, model.HL7WarningSeverity,
+				"value %s is not an exact number, the result is skipped", obx.Delimiters.Unescape(value))
+			return model.ProcedureBloodCount{}, false, nil
+		}
+		text = number
+	}
+	if text = strings.TrimSpace(text); text == "" {
+		i.report(obx, 5, model.HL7RequiredFieldMissing, model.HL7WarningSeverity, "the observation has no value, the result is skipped")
+		return model.ProcedureBloodCount{}, false, nil
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	// ParseFloat also reads NaN and Inf, which are not results
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		i.report(obx, 5, model.HL7DataTypeError, model.HL7ErrorSeverity, "value %q is not a number", text)
+		return model.ProcedureBloodCount{}, false, nil
+	}
+
+	unit := mapping.MeasureCode
+	if unit == "" {
+		unit = obx.Component(6, 1)
+	}
+	if unit == "" {
+		unit = bloodCount.MeasureCode
+	}
+	conversion, err := findUnitConversion(i.conversions, unit, bloodCount.MeasureCode)
+	if errors.Is(err, ErrNoUnitConversion) {
+		i.report(obx, 6, model.HL7TableValueNotFound, model.HL7ErrorSeverity, err.Error())
+		return model.ProcedureBloodCount{}, false, nil
+	}
+	if err != nil {
+		return model.ProcedureBloodCount{}, false, err
+	}
+	number = conversion.Convert(number)
+
+	flag, err := flagBloodCount(bloodCount, &number)
+	if errors.Is(err, ErrValueOutOfRange) {
+		i.report(obx, 5, model.HL7DataTypeError, model.HL7ErrorSeverity, err.Error())
+		return model.ProcedureBloodCount{}, false, nil
+	}
+	if err != nil {
+		return model.ProcedureBloodCount{}, false, err
+	}
+
+	return model.ProcedureBloodCount{Value: &number, MeasureCode: bloodCount.MeasureCode, BloodCount: bloodCount.Id, Flag: flag}, true, nil
+}
+
+// mapping returns the mapping of the identifier of OBX-3 or else of its alternate identifier,
+// a mapping in the coding system of the code comes before a mapping of any system
+func (i *labIngestion) mapping(obx labSegment) (model.LabCodeMapping, bool) {
+	for _, components := range [][2]int{{1, 3}, {4, 6}} {
+		code, system := obx.Component(3, components[0]), obx.Component(3, components[1])
+		if code == "" {
+			continue
+		}
+		for _, key := range []labCode{{system: system, code: code}, {code: code}} {
+			if mapping, ok := i.mappings[key]; ok {
+				return mapping, true
+			}
+		}
+	}
+	return model.LabCodeMapping{}, false
+}
+
+func (i *labIngestion) report(segment labSegment, field, code int, severity, format string, args ...interface{}) {
+	i.problems = append(i.problems, segment.problem(field, code, severity, fmt.Sprintf(format, args...)))
+}
+
+func (s labSegment) problem(field, code int, severity, message string) model.LabMessageError {
+	return model.LabMessageError{Segment: s.Name, Sequence: s.sequence, Field: field, Code: code, Severity: severity, Message: message}
+}
+
+// labDate reads the date of an HL7 date/time value, YYYYMMDD[HHMM[SS]][+ZZZZ], in YYYY-MM-DD format
+func labDate(value string) (string, bool) {
+	if len(value) < len("20060102") {
+		return "", false
+	}
+	date, err := time.Parse("20060102", value[:len("20060102")])
+	if err != nil {
+		return "", false
+	}
+	return date.Format(time.DateOnly), true
+}
+
+// labInternalError rejects the message that failed on an internal error, the error is not disclosed to the sender
+func labInternalError(header utils.HL7Segment, err error) (model.LabMessageAck, error) {
+	return labMessageAck(header, model.HL7RejectAck, []model.LabMessageError{{
+		Code: model.HL7ApplicationError, Severity: model.HL7ErrorSeverity, Message: "the message could not be processed, send it again later",
+	}}), err
+}
+
+// labMessageAck builds the acknowledgment of the message with the MSH header, an empty header if the message could not be parsed.
+// The acknowledgment is sent back to the sender of the message, its problems are reported in ERR segments.
+func labMessageAck(header utils.HL7Segment, code string, problems []model.LabMessageError) model.LabMessageAck {
+	d := utils.DefaultHL7Delimiters
+	// Fields of the message are copied in the default delimiters of the acknowledgment
+	copyField := func(field int) string {
+		components := strings.Split(header.Field(field), string(header.Delimiters.ComponentSeparator))
+		for i := range components {
+			components[i] = header.Delimiters.Unescape(components[i])
+		}
+		return d.Join(components...)
+	}
+
+	sendingApplication := copyField(5)
+	if sendingApplication == "" {
+		sendingApplication = labAckApplication
+	}
+	processingId := header.Component(11, 1)
+	if processingId == "" {
+		processingId = "P"
+	}
+	version := header.Component(12, 1)
+	if version == "" {
+		version = labAckVersion
+	}
+
+	var text string
+	for _, problem := range problems {
+		if problem.Severity == model.HL7ErrorSeverity {
+			text = problem.Message
+			break
+		}
+	}
+
+	now := time.Now()
+	segments := []string{
+		d.Segment("MSH", d.EncodingCharacters(), sendingApplication, copyField(6), copyField(3), copyField(4), now.Format("20060102150405"), "",
+			d.Join("ACK", "R01", "ACK"), strconv.FormatInt(now.UnixNano(), 10), d.Escape(processingId), d.Escape(version)),
+		d.Segment("MSA", code, d.Escape(header.Component(10, 1)), d.Escape(text)),
+	}
+	for _, problem := range problems {
+		var location string
+		if problem.Segment != "" {
+			location = d.Join(problem.Segment, strconv.Itoa(problem.Sequence))
+			if problem.Field > 0 {
+				location = d.Join(problem.Segment, strconv.Itoa(problem.Sequence), strconv.Itoa(problem.Field))
+			}
+		}
+		segments = append(segments, d.Segment("ERR", "", location, d.Join(strconv.Itoa(problem.Code), hl7ErrorTexts[problem.Code], "HL70357"),
+			problem.Severity, "", "", "", d.Escape(problem.Message)))
+	}
+
+	if problems == nil {
+		problems = []model.LabMessageError{}
+	}
+	return model.LabMessageAck{Code: code, Message: strings.Join(segments, "\r") + "\r", Errors: problems}
+}
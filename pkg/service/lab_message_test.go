@@ -0,0 +1,395 @@
+package services
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// labRepository keeps the patients, their courses and the received messages in memory,
+// the embedded interfaces are not used by the ingestion
+type labRepository struct {
+	repository.LabMessage
+	repository.LabCodeMapping
+	repository.Patient
+	repository.PatientCourse
+	repository.BloodCount
+	messages       []model.LabMessage
+	mappings       []model.LabCodeMapping
+	patients       []model.Patient
+	patientCourses []model.PatientCourse
+	bloodCounts    []model.BloodCount
+	created        []model.LabProcedure
+}
+
+func (r *labRepository) GetLabMessage(sendingApplication, sendingFacility, controlId string) (model.LabMessage, error) {
+	for _, labMessage := range r.messages {
+		if labMessage.SendingApplication == sendingApplication && labMessage.SendingFacility == sendingFacility && labMessage.ControlId == controlId {
+			return labMessage, nil
+		}
+	}
+	return model.LabMessage{}, sql.ErrNoRows
+}
+
+func (r *labRepository) CreateLabMessage(labMessage model.LabMessage, procedures []model.LabProcedure) ([]model.LabProcedure, error) {
+	r.messages = append(r.messages, labMessage)
+	for i := range procedures {
+		procedures[i].CourseProcedure.Id = 100 + i
+		for j := range procedures[i].Results {
+			procedures[i].Results[j].Procedure = procedures[i].CourseProcedure.Id
+		}
+	}
+	r.created = procedures
+	return procedures, nil
+}
+
+func (r *labRepository) GetLabCodeMappingListByCodes(codes []string) ([]model.LabCodeMapping, error) {
+	var mappings []model.LabCodeMapping
+	for _, mapping := range r.mappings {
+		if slices.Contains(codes, mapping.Code) {
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings, nil
+}
+
+func (r *labRepository) GetPatientBySNILS(snils string) (model.Patient, error) {
+	for _, patient := range r.patients {
+		if patient.SNILS != nil && strings.ReplaceAll(strings.ReplaceAll(*patient.SNILS, "-", ""), " ", "") == snils {
+			return patient, nil
+		}
+	}
+	return model.Patient{}, sql.ErrNoRows
+}
+
+func (r *labRepository) GetPatientById(id int) (model.Patient, error) {
+	for _, patient := range r.patients {
+		if patient.Id == id {
+			return patient, nil
+		}
+	}
+	return model.Patient{}, sql.ErrNoRows
+}
+
+func (r *labRepository) GetPatientCourseById(id int) (model.PatientCourse, error) {
+	for _, patientCourse := range r.patientCourses {
+		if patientCourse.Id == id {
+			return patientCourse, nil
+		}
+	}
+	return model.PatientCourse{}, sql.ErrNoRows
+}
+
+func (r *labRepository) GetPatientCourseListByDate(patientId int, date string) ([]model.PatientCourse, error) {
+	var patientCourseList []model.PatientCourse
+	for _, patientCourse := range r.patientCourses {
+		if patientCourse.Patient == patientId && patientCourse.BeginDate <= date && (patientCourse.EndDate == nil || *patientCourse.EndDate >= date) {
+			patientCourseList = append(patientCourseList, patientCourse)
+		}
+	}
+	return patientCourseList, nil
+}
+
+func (r *labRepository) GetBloodCountListByIds(ids []string) ([]model.BloodCount, error) {
+	var bloodCountList []model.BloodCount
+	for _, bloodCount := range r.bloodCounts {
+		if slices.Contains(ids, bloodCount.Id) {
+			bloodCountList = append(bloodCountList, bloodCount)
+		}
+	}
+	return bloodCountList, nil
+}
+
+// labAccess forbids access to the listed patient courses
+type labAccess struct {
+	Access
+	forbidden []int
+}
+
+func (a labAccess) CheckPatientCourseAccess(user UserData, patientCourseId int) error {
+	if slices.Contains(a.forbidden, patientCourseId) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// labTestMessage builds an ORU^R01 message of the test analyzer with the control ID and the segments after MSH
+func labTestMessage(messageType, controlId string, segments ...string) string {
+	header := "MSH|^~\\&|ANALYZER|LAB|OncoBase|CLINIC|20240301083000||" + messageType + "|" + controlId + "|P|2.5"
+	return strings.Join(append([]string{header}, segments...), "\r") + "\r"
+}
+
+func TestIngestLabMessage(t *testing.T) {
+	user := UserData{Id: 1, Role: model.AdminRole}
+
+	testTable := []struct {
+		name            string
+		message         string
+		expectedCode    string
+		expectedMSA     string
+		expectedErrors  []model.LabMessageError
+		expectedCreated []model.LabProcedure
+		expectedAudited int
+	}{
+		{
+			name: "OK",
+			message: labTestMessage("ORU^R01", "MSG-1",
+				"PID|1||12345678901^^^^SNILS||Ivanova^Anna",
+				"OBR|1||LAB-77|CBC|||20240301080000",
+				"OBX|1|NM|718-7^Hemoglobin^LN||12.5|g/dl|||||F",
+				"OBX|2|SN|PLT^Platelets^L||^180||||||F",
+				"OBX|3|NM|XYZ^Unknown^L||1|||||||F",
+				"NTE|1||checked",
+			),
+			expectedCode: model.HL7AcceptAck,
+			expectedMSA:  "MSA|AA|MSG-1",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "OBX", Sequence: 3, Field: 3, Code: model.HL7TableValueNotFound, Severity: model.HL7WarningSeverity,
+					Message: "observation code XYZ is not mapped to a blood count, the result is skipped"},
+			},
+			expectedCreated: []model.LabProcedure{{
+				CourseProcedure: model.CourseProcedure{Id: 100, PatientCourse: 10, Doctor: 3, BeginDate: "2024-03-01"},
+				Results: []model.ProcedureBloodCount{
+					{Value: floatPointer(125), MeasureCode: "g/l", Procedure: 100, BloodCount: "HGB", Flag: model.NormalFlag},
+					{Value: floatPointer(180), MeasureCode: "10^9/l", Procedure: 100, BloodCount: "PLT", Flag: model.NormalFlag},
+				},
+			}},
+			expectedAudited: 3,
+		},
+		{
+			name: "Patient course of the placer order number",
+			message: labTestMessage("ORU^R01^ORU_R01", "MSG-2",
+				"PID|1||2^^^OncoBase^PI~98765432100^^^&1.2.643.100.3&ISO",
+				"OBR|1|12||CBC|||20240215",
+				"OBX|1|NM|PLT||90|10\\S\\9/l",
+				"OBR|2|||CBC|||20240110",
+				"OBX|1|NM|PLT||95",
+			),
+			expectedCode:   model.HL7AcceptAck,
+			expectedMSA:    "MSA|AA|MSG-2",
+			expectedErrors: []model.LabMessageError{},
+			expectedCreated: []model.LabProcedure{
+				{
+					CourseProcedure: model.CourseProcedure{Id: 100, PatientCourse: 12, Doctor: 4, BeginDate: "2024-02-15"},
+					Results:         []model.ProcedureBloodCount{{Value: floatPointer(90), MeasureCode: "10^9/l", Procedure: 100, BloodCount: "PLT", Flag: model.LowFlag}},
+				},
+				{
+					CourseProcedure: model.CourseProcedure{Id: 101, PatientCourse: 11, Doctor: 4, BeginDate: "2024-01-10"},
+					Results:         []model.ProcedureBloodCount{{Value: floatPointer(95), MeasureCode: "10^9/l", Procedure: 101, BloodCount: "PLT", Flag: model.LowFlag}},
+				},
+			},
+			expectedAudited: 4,
+		},
+		{
+			name:           "Resent message",
+			message:        labTestMessage("ORU^R01", "MSG-0", "PID|1||12345678901^^^^SNILS", "OBR|1|||CBC|||20240301", "OBX|1|NM|PLT||200"),
+			expectedCode:   model.HL7AcceptAck,
+			expectedMSA:    "MSA|AA|MSG-0",
+			expectedErrors: []model.LabMessageError{},
+		},
+		{
+			name: "Results that are not final",
+			message: labTestMessage("ORU^R01", "MSG-3",
+				"PID|1||12345678901^^^^SNILS",
+				"OBR|1|||CBC|||20240301",
+				"OBX|1|NM|PLT||200||||||P",
+				"OBX|2|SN|PLT||>^1000",
+			),
+			expectedCode: model.HL7AcceptAck,
+			expectedMSA:  "MSA|AA|MSG-3",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "OBX", Sequence: 1, Field: 11, Code: model.HL7MessageAccepted, Severity: model.HL7WarningSeverity,
+					Message: "result status P is not final, the result is skipped"},
+				{Segment: "OBX", Sequence: 2, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7WarningSeverity,
+					Message: "value >^1000 is not an exact number, the result is skipped"},
+				{Segment: "OBR", Sequence: 1, Code: model.HL7MessageAccepted, Severity: model.HL7WarningSeverity,
+					Message: "the order has no results of mapped blood counts, no procedure is created"},
+			},
+		},
+		{
+			name: "Patient errors",
+			message: labTestMessage("ORU^R01", "MSG-4",
+				"PID|1||11111111111^^^^SS",
+				"OBR|1|||CBC|||20240301",
+				"OBX|1|NM|PLT||200",
+				"PID|2||12345678901^^^^SNILS||Petrov",
+				"OBR|1|||CBC|||20240301",
+				"OBX|1|NM|PLT||200",
+				"PID|3||98765432100^^^^SNILS",
+				"OBR|1|||CBC|||20240215",
+				"OBX|1|NM|PLT||200",
+				"PID|4"+strings.Repeat("|", 18)+"123-456",
+				"OBR|1|||CBC|||20240215",
+				"OBX|1|NM|PLT||200",
+			),
+			expectedCode: model.HL7ErrorAck,
+			expectedMSA:  "MSA|AE|MSG-4|the patient is not found by SNILS or OncoBase patient ID",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "PID", Sequence: 1, Field: 3, Code: model.HL7UnknownKeyIdentifier, Severity: model.HL7ErrorSeverity,
+					Message: "the patient is not found by SNILS or OncoBase patient ID"},
+				{Segment: "PID", Sequence: 2, Field: 5, Code: model.HL7UnknownKeyIdentifier, Severity: model.HL7ErrorSeverity,
+					Message: `family name "Petrov" does not match the patient found by the identifiers`},
+				{Segment: "OBR", Sequence: 3, Field: 2, Code: model.HL7UnknownKeyIdentifier, Severity: model.HL7ErrorSeverity,
+					Message: "the patient has several courses under way on 2024-02-15, the placer order number must be the patient course ID"},
+				{Segment: "PID", Sequence: 4, Field: 3, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: `SNILS "123-456" does not have 11 digits`},
+			},
+		},
+		{
+			name: "Order errors",
+			message: labTestMessage("ORU^R01", "MSG-5",
+				"OBR|1|||CBC|||20240301",
+				"PID|1||1^^^oncobase",
+				"OBX|1|NM|PLT||200",
+				"OBR|1|||CBC|||2024",
+				"OBX|1|NM|PLT||200",
+				"OBR|2|||CBC|||20231231",
+				"OBX|1|NM|PLT||200",
+				"OBR|3|13||CBC|||20240301",
+				"OBX|1|NM|PLT||200",
+				"OBR|4|10||CBC|||20240301",
+				"OBX|1|NM|718-7^^LN||abc|g/dl",
+				"OBX|2|NM|PLT||2000",
+				"OBX|3|NM|PLT||200",
+				"OBX|4|NM|718-7^^LN||5|mmol/l",
+			),
+			expectedCode: model.HL7ErrorAck,
+			expectedMSA:  "MSA|AE|MSG-5|OBR segment before the PID segment",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "OBR", Sequence: 1, Code: model.HL7SegmentSequenceError, Severity: model.HL7ErrorSeverity,
+					Message: "OBR segment before the PID segment"},
+				{Segment: "OBX", Sequence: 1, Code: model.HL7SegmentSequenceError, Severity: model.HL7ErrorSeverity,
+					Message: "OBX segment before an OBR segment"},
+				{Segment: "OBR", Sequence: 2, Field: 7, Code: model.HL7RequiredFieldMissing, Severity: model.HL7ErrorSeverity,
+					Message: "observation date/time is missing or invalid"},
+				{Segment: "OBR", Sequence: 3, Field: 7, Code: model.HL7UnknownKeyIdentifier, Severity: model.HL7ErrorSeverity,
+					Message: "the patient has no course under way on 2023-12-31"},
+				{Segment: "OBR", Sequence: 4, Field: 2, Code: model.HL7UnknownKeyIdentifier, Severity: model.HL7ErrorSeverity,
+					Message: "patient course 13 of the patient does not exist"},
+				{Segment: "OBX", Sequence: 5, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: `value "abc" is not a number`},
+				{Segment: "OBX", Sequence: 6, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: "value is outside the possible range: PLT value 2000 is not in 0..1000"},
+				{Segment: "OBX", Sequence: 8, Field: 6, Code: model.HL7TableValueNotFound, Severity: model.HL7ErrorSeverity,
+					Message: "no conversion between units mmol/l and g/l"},
+			},
+		},
+		{
+			name: "Non-finite values",
+			message: labTestMessage("ORU^R01", "MSG-8",
+				"PID|1||12345678901^^^^SNILS",
+				"OBR|1|||CBC|||20240301",
+				"OBX|1|NM|PLT||NaN",
+				"OBX|2|SN|718-7^^LN||^Inf|g/dl",
+				"OBX|3|NM|PLT||-inf",
+				"OBX|4|NM|PLT||200",
+			),
+			expectedCode: model.HL7ErrorAck,
+			expectedMSA:  `MSA|AE|MSG-8|value "NaN" is not a number`,
+			expectedErrors: []model.LabMessageError{
+				{Segment: "OBX", Sequence: 1, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: `value "NaN" is not a number`},
+				{Segment: "OBX", Sequence: 2, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: `value "Inf" is not a number`},
+				{Segment: "OBX", Sequence: 3, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity,
+					Message: `value "-inf" is not a number`},
+			},
+		},
+		{
+			name:         "Forbidden patient course",
+			message:      labTestMessage("ORU^R01", "MSG-6", "PID|1||3^^^OncoBase", "OBR|1|||CBC|||20240301", "OBX|1|NM|PLT||200"),
+			expectedCode: model.HL7ErrorAck,
+			expectedMSA:  "MSA|AE|MSG-6|access to patient data is forbidden",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "OBR", Sequence: 1, Code: model.HL7ApplicationError, Severity: model.HL7ErrorSeverity, Message: "access to patient data is forbidden"},
+			},
+		},
+		{
+			name:         "Unsupported message type",
+			message:      labTestMessage("ADT^A01", "MSG-7", "PID|1||1^^^OncoBase"),
+			expectedCode: model.HL7RejectAck,
+			expectedMSA:  `MSA|AR|MSG-7|unsupported message type ADT\S\A01, expected ORU\S\R01`,
+			expectedErrors: []model.LabMessageError{
+				{Segment: "MSH", Sequence: 1, Field: 9, Code: model.HL7UnsupportedMessageType, Severity: model.HL7ErrorSeverity,
+					Message: "unsupported message type ADT^A01, expected ORU^R01"},
+			},
+		},
+		{
+			name:         "No control ID",
+			message:      labTestMessage("ORU^R01", ""),
+			expectedCode: model.HL7RejectAck,
+			expectedMSA:  "MSA|AR||message control ID is missing",
+			expectedErrors: []model.LabMessageError{
+				{Segment: "MSH", Sequence: 1, Field: 10, Code: model.HL7RequiredFieldMissing, Severity: model.HL7ErrorSeverity,
+					Message: "message control ID is missing"},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &labRepository{
+				messages: []model.LabMessage{{SendingApplication: "ANALYZER", SendingFacility: "LAB", ControlId: "MSG-0"}},
+				mappings: []model.LabCodeMapping{
+					{System: "LN", Code: "718-7", BloodCount: "HGB"},
+					{Code: "PLT", BloodCount: "PLT"},
+				},
+				patients: []model.Patient{
+					{Id: 1, LastName: "Ivanova", SNILS: stringPointer("123-456-789 01")},
+					{Id: 2, LastName: "Smirnov", SNILS: stringPointer("987-654-321 00")},
+					{Id: 3, LastName: "Orlova"},
+				},
+				patientCourses: []model.PatientCourse{
+					{Id: 10, Patient: 1, Doctor: 3, BeginDate: "2024-01-01"},
+					{Id: 11, Patient: 2, Doctor: 4, BeginDate: "2024-01-01", EndDate: stringPointer("2024-03-01")},
+					{Id: 12, Patient: 2, Doctor: 4, BeginDate: "2024-02-01"},
+					{Id: 13, Patient: 3, Doctor: 4, BeginDate: "2024-01-01"},
+				},
+				bloodCounts: []model.BloodCount{
+					{Id: "HGB", MeasureCode: "g/l", MinNormalValue: 120, MaxNormalValue: 160, MaxPossibleValue: 250},
+					{Id: "PLT", MeasureCode: "10^9/l", MinNormalValue: 150, MaxNormalValue: 400, MaxPossibleValue: 1000},
+				},
+			}
+			conversions := conversionRepository{{From: "g/dl", To: "g/l", Factor: 10}}
+			tx := &auditTransaction{repos: repository.Repository{LabMessage: repo}}
+			service := NewLabMessageService(repo, repo, repo, repo, repo, conversions, labAccess{forbidden: []int{13}}, tx)
+
+			ack, err := service.IngestLabMessage(user, testCase.message)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCode, ack.Code)
+			assert.Equal(t, testCase.expectedErrors, ack.Errors)
+			assert.Equal(t, testCase.expectedCreated, repo.created)
+			assert.Len(t, tx.entries, testCase.expectedAudited)
+
+			segments := strings.Split(strings.TrimSuffix(ack.Message, "\r"), "\r")
+			assert.True(t, strings.HasPrefix(segments[0], "MSH|^~\\&|OncoBase|CLINIC|ANALYZER|LAB|"), segments[0])
+			assert.Equal(t, testCase.expectedMSA, segments[1])
+			assert.Len(t, segments, 2+len(testCase.expectedErrors))
+		})
+	}
+}
+
+func TestLabMessageAck(t *testing.T) {
+	header, err := utils.ParseHL7("MSH#*$!@#LIS*Lab!S!1#ROOM 5###20240301##ORU*R01#42#T#2.3.1\r")
+	assert.NoError(t, err)
+
+	ack := labMessageAck(header.Header(), model.HL7ErrorAck, []model.LabMessageError{
+		{Segment: "OBX", Sequence: 2, Field: 5, Code: model.HL7DataTypeError, Severity: model.HL7ErrorSeverity, Message: `value "1|2" is not a number`},
+		{Code: model.HL7ApplicationError, Severity: model.HL7WarningSeverity, Message: "check"},
+	})
+
+	segments := strings.Split(ack.Message, "\r")
+	assert.Len(t, segments, 5)
+	assert.Regexp(t, `^MSH\|\^~\\&\|OncoBase\|\|LIS\^Lab\*1\|ROOM 5\|\d{14}\|\|ACK\^R01\^ACK\|\d+\|T\|2\.3\.1$`, segments[0])
+	assert.Equal(t, `MSA|AE|42|value "1\F\2" is not a number`, segments[1])
+	assert.Equal(t, `ERR||OBX^2^5|102^Data type error^HL70357|E||||value "1\F\2" is not a number`, segments[2])
+	assert.Equal(t, `ERR|||207^Application internal error^HL70357|W||||check`, segments[3])
+	assert.Empty(t, segments[4])
+}
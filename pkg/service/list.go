@@ -0,0 +1,6 @@
+package services
+
+import "med/pkg/repository"
+
+// ErrInvalidListQuery is returned for an unknown sort field or a malformed page cursor.
+var ErrInvalidListQuery = repository.ErrInvalidListQuery
@@ -11,12 +11,65 @@ package mock_services
 
 import (
 	model "med/pkg/model"
-	service "med/pkg/service"
+	services "med/pkg/service"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockAnalysis is a mock of Analysis interface.
+type MockAnalysis struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalysisMockRecorder
+}
+
+// MockAnalysisMockRecorder is the mock recorder for MockAnalysis.
+type MockAnalysisMockRecorder struct {
+	mock *MockAnalysis
+}
+
+// NewMockAnalysis creates a new mock instance.
+func NewMockAnalysis(ctrl *gomock.Controller) *MockAnalysis {
+	mock := &MockAnalysis{ctrl: ctrl}
+	mock.recorder = &MockAnalysisMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalysis) EXPECT() *MockAnalysisMockRecorder {
+	return m.recorder
+}
+
+// GetProcedureRisk mocks base method.
+func (m *MockAnalysis) GetProcedureRisk(user services.UserData, procedureId int) ([]model.DiseaseScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcedureRisk", user, procedureId)
+	ret0, _ := ret[0].([]model.DiseaseScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProcedureRisk indicates an expected call of GetProcedureRisk.
+func (mr *MockAnalysisMockRecorder) GetProcedureRisk(user, procedureId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureRisk", reflect.TypeOf((*MockAnalysis)(nil).GetProcedureRisk), user, procedureId)
+}
+
+// ScoreBloodCounts mocks base method.
+func (m *MockAnalysis) ScoreBloodCounts(results []model.BloodCountResult) ([]model.DiseaseScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreBloodCounts", results)
+	ret0, _ := ret[0].([]model.DiseaseScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScoreBloodCounts indicates an expected call of ScoreBloodCounts.
+func (mr *MockAnalysisMockRecorder) ScoreBloodCounts(results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreBloodCounts", reflect.TypeOf((*MockAnalysis)(nil).ScoreBloodCounts), results)
+}
+
 // MockAccount is a mock of Account interface.
 type MockAccount struct {
 	ctrl     *gomock.Controller
@@ -40,6 +93,132 @@ func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
 	return m.recorder
 }
 
+// GetBloodCountList mocks base method.
+func (m *MockAccount) GetBloodCountList(user services.UserData) ([]model.AccountBloodCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBloodCountList", user)
+	ret0, _ := ret[0].([]model.AccountBloodCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBloodCountList indicates an expected call of GetBloodCountList.
+func (mr *MockAccountMockRecorder) GetBloodCountList(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountList", reflect.TypeOf((*MockAccount)(nil).GetBloodCountList), user)
+}
+
+// GetDoctorList mocks base method.
+func (m *MockAccount) GetDoctorList(user services.UserData) ([]model.Doctor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDoctorList", user)
+	ret0, _ := ret[0].([]model.Doctor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDoctorList indicates an expected call of GetDoctorList.
+func (mr *MockAccountMockRecorder) GetDoctorList(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctorList", reflect.TypeOf((*MockAccount)(nil).GetDoctorList), user)
+}
+
+// GetPatientPanel mocks base method.
+func (m *MockAccount) GetPatientPanel(user services.UserData) ([]model.PanelPatient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientPanel", user)
+	ret0, _ := ret[0].([]model.PanelPatient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientPanel indicates an expected call of GetPatientPanel.
+func (mr *MockAccountMockRecorder) GetPatientPanel(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientPanel", reflect.TypeOf((*MockAccount)(nil).GetPatientPanel), user)
+}
+
+// MockAccess is a mock of Access interface.
+type MockAccess struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessMockRecorder
+}
+
+// MockAccessMockRecorder is the mock recorder for MockAccess.
+type MockAccessMockRecorder struct {
+	mock *MockAccess
+}
+
+// NewMockAccess creates a new mock instance.
+func NewMockAccess(ctrl *gomock.Controller) *MockAccess {
+	mock := &MockAccess{ctrl: ctrl}
+	mock.recorder = &MockAccessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccess) EXPECT() *MockAccessMockRecorder {
+	return m.recorder
+}
+
+// CheckCourseProcedureAccess mocks base method.
+func (m *MockAccess) CheckCourseProcedureAccess(user services.UserData, procedureId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCourseProcedureAccess", user, procedureId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCourseProcedureAccess indicates an expected call of CheckCourseProcedureAccess.
+func (mr *MockAccessMockRecorder) CheckCourseProcedureAccess(user, procedureId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCourseProcedureAccess", reflect.TypeOf((*MockAccess)(nil).CheckCourseProcedureAccess), user, procedureId)
+}
+
+// CheckPatientAccess mocks base method.
+func (m *MockAccess) CheckPatientAccess(user services.UserData, patientId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPatientAccess", user, patientId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPatientAccess indicates an expected call of CheckPatientAccess.
+func (mr *MockAccessMockRecorder) CheckPatientAccess(user, patientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPatientAccess", reflect.TypeOf((*MockAccess)(nil).CheckPatientAccess), user, patientId)
+}
+
+// CheckPatientCourseAccess mocks base method.
+func (m *MockAccess) CheckPatientCourseAccess(user services.UserData, patientCourseId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPatientCourseAccess", user, patientCourseId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPatientCourseAccess indicates an expected call of CheckPatientCourseAccess.
+func (mr *MockAccessMockRecorder) CheckPatientCourseAccess(user, patientCourseId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPatientCourseAccess", reflect.TypeOf((*MockAccess)(nil).CheckPatientCourseAccess), user, patientCourseId)
+}
+
+// GetAccessiblePatientIdList mocks base method.
+func (m *MockAccess) GetAccessiblePatientIdList(user services.UserData) ([]int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessiblePatientIdList", user)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAccessiblePatientIdList indicates an expected call of GetAccessiblePatientIdList.
+func (mr *MockAccessMockRecorder) GetAccessiblePatientIdList(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessiblePatientIdList", reflect.TypeOf((*MockAccess)(nil).GetAccessiblePatientIdList), user)
+}
+
 // MockAuthorization is a mock of Authorization interface.
 type MockAuthorization struct {
 	ctrl     *gomock.Controller
@@ -63,41 +242,40 @@ func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
 	return m.recorder
 }
 
-// CreateUser mocks base method.
-func (m *MockAuthorization) CreateUser(user model.User) (string, error) {
+// GenerateToken mocks base method.
+func (m *MockAuthorization) GenerateToken(email, password, ip string) (model.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GenerateToken", email, password, ip)
+	ret0, _ := ret[0].(model.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockAuthorizationMockRecorder) CreateUser(user any) *gomock.Call {
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockAuthorizationMockRecorder) GenerateToken(email, password, ip any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), email, password, ip)
 }
 
-// GenerateToken mocks base method.
-func (m *MockAuthorization) GenerateToken(email, password string) (string, error) {
+// LogOut mocks base method.
+func (m *MockAuthorization) LogOut(user services.UserData, refreshToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", email, password)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "LogOut", user, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockAuthorizationMockRecorder) GenerateToken(email, password any) *gomock.Call {
+// LogOut indicates an expected call of LogOut.
+func (mr *MockAuthorizationMockRecorder) LogOut(user, refreshToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOut", reflect.TypeOf((*MockAuthorization)(nil).LogOut), user, refreshToken)
 }
 
 // ParseToken mocks base method.
-func (m *MockAuthorization) ParseToken(token string) (*service.UserData, error) {
+func (m *MockAuthorization) ParseToken(token string) (*services.UserData, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", token)
-	ret0, _ := ret[0].(*service.UserData)
+	ret0, _ := ret[0].(*services.UserData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -108,6 +286,63 @@ func (mr *MockAuthorizationMockRecorder) ParseToken(token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), token)
 }
 
+// RefreshToken mocks base method.
+func (m *MockAuthorization) RefreshToken(refreshToken string) (model.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", refreshToken)
+	ret0, _ := ret[0].(model.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockAuthorizationMockRecorder) RefreshToken(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthorization)(nil).RefreshToken), refreshToken)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthorization) RequestPasswordReset(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthorizationMockRecorder) RequestPasswordReset(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthorization)(nil).RequestPasswordReset), email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthorization) ResetPassword(resetToken, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", resetToken, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthorizationMockRecorder) ResetPassword(resetToken, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthorization)(nil).ResetPassword), resetToken, password)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAuthorization) RevokeUserSessions(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAuthorizationMockRecorder) RevokeUserSessions(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAuthorization)(nil).RevokeUserSessions), userId)
+}
+
 // MockBloodCount is a mock of BloodCount interface.
 type MockBloodCount struct {
 	ctrl     *gomock.Controller
@@ -176,18 +411,18 @@ func (mr *MockBloodCountMockRecorder) GetBloodCountById(id any) *gomock.Call {
 }
 
 // GetBloodCountList mocks base method.
-func (m *MockBloodCount) GetBloodCountList() ([]model.BloodCount, error) {
+func (m *MockBloodCount) GetBloodCountList(filter model.BloodCountFilter, listQuery model.ListQuery) (model.Page[model.BloodCount], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBloodCountList")
-	ret0, _ := ret[0].([]model.BloodCount)
+	ret := m.ctrl.Call(m, "GetBloodCountList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.BloodCount])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBloodCountList indicates an expected call of GetBloodCountList.
-func (mr *MockBloodCountMockRecorder) GetBloodCountList() *gomock.Call {
+func (mr *MockBloodCountMockRecorder) GetBloodCountList(filter, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountList", reflect.TypeOf((*MockBloodCount)(nil).GetBloodCountList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountList", reflect.TypeOf((*MockBloodCount)(nil).GetBloodCountList), filter, listQuery)
 }
 
 // UpdateBloodCount mocks base method.
@@ -273,48 +508,18 @@ func (mr *MockBloodCountValueMockRecorder) GetBloodCountValueById(diseaseId, blo
 }
 
 // GetBloodCountValueList mocks base method.
-func (m *MockBloodCountValue) GetBloodCountValueList() ([]model.BloodCountValue, error) {
+func (m *MockBloodCountValue) GetBloodCountValueList(filter model.BloodCountValueFilter, listQuery model.ListQuery) (model.Page[model.BloodCountValue], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBloodCountValueList")
-	ret0, _ := ret[0].([]model.BloodCountValue)
+	ret := m.ctrl.Call(m, "GetBloodCountValueList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.BloodCountValue])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBloodCountValueList indicates an expected call of GetBloodCountValueList.
-func (mr *MockBloodCountValueMockRecorder) GetBloodCountValueList() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountValueList", reflect.TypeOf((*MockBloodCountValue)(nil).GetBloodCountValueList))
-}
-
-// GetBloodCountValueListByBloodCount mocks base method.
-func (m *MockBloodCountValue) GetBloodCountValueListByBloodCount(bloodCountId string) ([]model.BloodCountValue, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBloodCountValueListByBloodCount", bloodCountId)
-	ret0, _ := ret[0].([]model.BloodCountValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetBloodCountValueListByBloodCount indicates an expected call of GetBloodCountValueListByBloodCount.
-func (mr *MockBloodCountValueMockRecorder) GetBloodCountValueListByBloodCount(bloodCountId any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountValueListByBloodCount", reflect.TypeOf((*MockBloodCountValue)(nil).GetBloodCountValueListByBloodCount), bloodCountId)
-}
-
-// GetBloodCountValueListByDisease mocks base method.
-func (m *MockBloodCountValue) GetBloodCountValueListByDisease(diseaseId string) ([]model.BloodCountValue, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBloodCountValueListByDisease", diseaseId)
-	ret0, _ := ret[0].([]model.BloodCountValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetBloodCountValueListByDisease indicates an expected call of GetBloodCountValueListByDisease.
-func (mr *MockBloodCountValueMockRecorder) GetBloodCountValueListByDisease(diseaseId any) *gomock.Call {
+func (mr *MockBloodCountValueMockRecorder) GetBloodCountValueList(filter, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountValueListByDisease", reflect.TypeOf((*MockBloodCountValue)(nil).GetBloodCountValueListByDisease), diseaseId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBloodCountValueList", reflect.TypeOf((*MockBloodCountValue)(nil).GetBloodCountValueList), filter, listQuery)
 }
 
 // UpdateBloodCountValue mocks base method.
@@ -332,216 +537,454 @@ func (mr *MockBloodCountValueMockRecorder) UpdateBloodCountValue(bloodCountValue
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBloodCountValue", reflect.TypeOf((*MockBloodCountValue)(nil).UpdateBloodCountValue), bloodCountValue)
 }
 
-// MockCourse is a mock of Course interface.
-type MockCourse struct {
+// MockCohort is a mock of Cohort interface.
+type MockCohort struct {
 	ctrl     *gomock.Controller
-	recorder *MockCourseMockRecorder
+	recorder *MockCohortMockRecorder
 }
 
-// MockCourseMockRecorder is the mock recorder for MockCourse.
-type MockCourseMockRecorder struct {
-	mock *MockCourse
+// MockCohortMockRecorder is the mock recorder for MockCohort.
+type MockCohortMockRecorder struct {
+	mock *MockCohort
 }
 
-// NewMockCourse creates a new mock instance.
-func NewMockCourse(ctrl *gomock.Controller) *MockCourse {
-	mock := &MockCourse{ctrl: ctrl}
-	mock.recorder = &MockCourseMockRecorder{mock}
+// NewMockCohort creates a new mock instance.
+func NewMockCohort(ctrl *gomock.Controller) *MockCohort {
+	mock := &MockCohort{ctrl: ctrl}
+	mock.recorder = &MockCohortMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCourse) EXPECT() *MockCourseMockRecorder {
+func (m *MockCohort) EXPECT() *MockCohortMockRecorder {
 	return m.recorder
 }
 
-// CreateCourse mocks base method.
-func (m *MockCourse) CreateCourse(course model.Course) (model.Course, error) {
+// CreateCohort mocks base method.
+func (m *MockCohort) CreateCohort(user services.UserData, cohort model.Cohort) (model.Cohort, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCourse", course)
-	ret0, _ := ret[0].(model.Course)
+	ret := m.ctrl.Call(m, "CreateCohort", user, cohort)
+	ret0, _ := ret[0].(model.Cohort)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCourse indicates an expected call of CreateCourse.
-func (mr *MockCourseMockRecorder) CreateCourse(course any) *gomock.Call {
+// CreateCohort indicates an expected call of CreateCohort.
+func (mr *MockCohortMockRecorder) CreateCohort(user, cohort any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourse", reflect.TypeOf((*MockCourse)(nil).CreateCourse), course)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCohort", reflect.TypeOf((*MockCohort)(nil).CreateCohort), user, cohort)
 }
 
-// DeleteCourse mocks base method.
-func (m *MockCourse) DeleteCourse(id string) error {
+// DeleteCohort mocks base method.
+func (m *MockCohort) DeleteCohort(user services.UserData, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourse", id)
+	ret := m.ctrl.Call(m, "DeleteCohort", user, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCourse indicates an expected call of DeleteCourse.
-func (mr *MockCourseMockRecorder) DeleteCourse(id any) *gomock.Call {
+// DeleteCohort indicates an expected call of DeleteCohort.
+func (mr *MockCohortMockRecorder) DeleteCohort(user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockCourse)(nil).DeleteCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCohort", reflect.TypeOf((*MockCohort)(nil).DeleteCohort), user, id)
 }
 
-// GetCourseById mocks base method.
-func (m *MockCourse) GetCourseById(id string) (model.Course, error) {
+// GetCohortById mocks base method.
+func (m *MockCohort) GetCohortById(id int) (model.Cohort, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseById", id)
-	ret0, _ := ret[0].(model.Course)
+	ret := m.ctrl.Call(m, "GetCohortById", id)
+	ret0, _ := ret[0].(model.Cohort)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourseById indicates an expected call of GetCourseById.
-func (mr *MockCourseMockRecorder) GetCourseById(id any) *gomock.Call {
+// GetCohortById indicates an expected call of GetCohortById.
+func (mr *MockCohortMockRecorder) GetCohortById(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseById", reflect.TypeOf((*MockCourse)(nil).GetCourseById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCohortById", reflect.TypeOf((*MockCohort)(nil).GetCohortById), id)
 }
 
-// GetCourseList mocks base method.
-func (m *MockCourse) GetCourseList() ([]model.Course, error) {
+// GetCohortList mocks base method.
+func (m *MockCohort) GetCohortList(filter model.CohortFilter, listQuery model.ListQuery) (model.Page[model.Cohort], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseList")
-	ret0, _ := ret[0].([]model.Course)
+	ret := m.ctrl.Call(m, "GetCohortList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.Cohort])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourseList indicates an expected call of GetCourseList.
-func (mr *MockCourseMockRecorder) GetCourseList() *gomock.Call {
+// GetCohortList indicates an expected call of GetCohortList.
+func (mr *MockCohortMockRecorder) GetCohortList(filter, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseList", reflect.TypeOf((*MockCourse)(nil).GetCourseList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCohortList", reflect.TypeOf((*MockCohort)(nil).GetCohortList), filter, listQuery)
 }
 
-// UpdateCourse mocks base method.
-func (m *MockCourse) UpdateCourse(course model.Course) (model.Course, error) {
+// GetCohortStatistics mocks base method.
+func (m *MockCohort) GetCohortStatistics(id int, query model.CohortStatisticsQuery) (model.CohortStatistics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCourse", course)
-	ret0, _ := ret[0].(model.Course)
+	ret := m.ctrl.Call(m, "GetCohortStatistics", id, query)
+	ret0, _ := ret[0].(model.CohortStatistics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateCourse indicates an expected call of UpdateCourse.
-func (mr *MockCourseMockRecorder) UpdateCourse(course any) *gomock.Call {
+// GetCohortStatistics indicates an expected call of GetCohortStatistics.
+func (mr *MockCohortMockRecorder) GetCohortStatistics(id, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockCourse)(nil).UpdateCourse), course)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCohortStatistics", reflect.TypeOf((*MockCohort)(nil).GetCohortStatistics), id, query)
 }
 
-// MockCourseProcedure is a mock of CourseProcedure interface.
-type MockCourseProcedure struct {
+// UpdateCohort mocks base method.
+func (m *MockCohort) UpdateCohort(user services.UserData, cohort model.Cohort) (model.Cohort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCohort", user, cohort)
+	ret0, _ := ret[0].(model.Cohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCohort indicates an expected call of UpdateCohort.
+func (mr *MockCohortMockRecorder) UpdateCohort(user, cohort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCohort", reflect.TypeOf((*MockCohort)(nil).UpdateCohort), user, cohort)
+}
+
+// MockConsole is a mock of Console interface.
+type MockConsole struct {
 	ctrl     *gomock.Controller
-	recorder *MockCourseProcedureMockRecorder
+	recorder *MockConsoleMockRecorder
 }
 
-// MockCourseProcedureMockRecorder is the mock recorder for MockCourseProcedure.
-type MockCourseProcedureMockRecorder struct {
-	mock *MockCourseProcedure
+// MockConsoleMockRecorder is the mock recorder for MockConsole.
+type MockConsoleMockRecorder struct {
+	mock *MockConsole
 }
 
-// NewMockCourseProcedure creates a new mock instance.
-func NewMockCourseProcedure(ctrl *gomock.Controller) *MockCourseProcedure {
-	mock := &MockCourseProcedure{ctrl: ctrl}
-	mock.recorder = &MockCourseProcedureMockRecorder{mock}
+// NewMockConsole creates a new mock instance.
+func NewMockConsole(ctrl *gomock.Controller) *MockConsole {
+	mock := &MockConsole{ctrl: ctrl}
+	mock.recorder = &MockConsoleMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCourseProcedure) EXPECT() *MockCourseProcedureMockRecorder {
+func (m *MockConsole) EXPECT() *MockConsoleMockRecorder {
 	return m.recorder
 }
 
-// CreateCourseProcedure mocks base method.
-func (m *MockCourseProcedure) CreateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+// ChangeUserRole mocks base method.
+func (m *MockConsole) ChangeUserRole(admin services.UserData, id int, role string) (model.UserProfile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCourseProcedure", courseProcedure)
-	ret0, _ := ret[0].(model.CourseProcedure)
+	ret := m.ctrl.Call(m, "ChangeUserRole", admin, id, role)
+	ret0, _ := ret[0].(model.UserProfile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCourseProcedure indicates an expected call of CreateCourseProcedure.
-func (mr *MockCourseProcedureMockRecorder) CreateCourseProcedure(courseProcedure any) *gomock.Call {
+// ChangeUserRole indicates an expected call of ChangeUserRole.
+func (mr *MockConsoleMockRecorder) ChangeUserRole(admin, id, role any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).CreateCourseProcedure), courseProcedure)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserRole", reflect.TypeOf((*MockConsole)(nil).ChangeUserRole), admin, id, role)
 }
 
-// DeleteCourseProcedure mocks base method.
-func (m *MockCourseProcedure) DeleteCourseProcedure(id string) error {
+// GetChangeList mocks base method.
+func (m *MockConsole) GetChangeList(filter model.AuditFilter) ([]model.AuditEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseProcedure", id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetChangeList", filter)
+	ret0, _ := ret[0].([]model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCourseProcedure indicates an expected call of DeleteCourseProcedure.
-func (mr *MockCourseProcedureMockRecorder) DeleteCourseProcedure(id any) *gomock.Call {
+// GetChangeList indicates an expected call of GetChangeList.
+func (mr *MockConsoleMockRecorder) GetChangeList(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).DeleteCourseProcedure), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeList", reflect.TypeOf((*MockConsole)(nil).GetChangeList), filter)
 }
 
-// GetCourseProcedureById mocks base method.
-func (m *MockCourseProcedure) GetCourseProcedureById(id string) (model.CourseProcedure, error) {
+// GetFailedLoginList mocks base method.
+func (m *MockConsole) GetFailedLoginList(limit int) ([]model.FailedLogin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseProcedureById", id)
-	ret0, _ := ret[0].(model.CourseProcedure)
+	ret := m.ctrl.Call(m, "GetFailedLoginList", limit)
+	ret0, _ := ret[0].([]model.FailedLogin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourseProcedureById indicates an expected call of GetCourseProcedureById.
-func (mr *MockCourseProcedureMockRecorder) GetCourseProcedureById(id any) *gomock.Call {
+// GetFailedLoginList indicates an expected call of GetFailedLoginList.
+func (mr *MockConsoleMockRecorder) GetFailedLoginList(limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseProcedureById", reflect.TypeOf((*MockCourseProcedure)(nil).GetCourseProcedureById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailedLoginList", reflect.TypeOf((*MockConsole)(nil).GetFailedLoginList), limit)
 }
 
-// GetCourseProcedureList mocks base method.
-func (m *MockCourseProcedure) GetCourseProcedureList() ([]model.CourseProcedure, error) {
+// GetStatistics mocks base method.
+func (m *MockConsole) GetStatistics(from, to string) (model.ConsoleStatistics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseProcedureList")
-	ret0, _ := ret[0].([]model.CourseProcedure)
+	ret := m.ctrl.Call(m, "GetStatistics", from, to)
+	ret0, _ := ret[0].(model.ConsoleStatistics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourseProcedureList indicates an expected call of GetCourseProcedureList.
-func (mr *MockCourseProcedureMockRecorder) GetCourseProcedureList() *gomock.Call {
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockConsoleMockRecorder) GetStatistics(from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseProcedureList", reflect.TypeOf((*MockCourseProcedure)(nil).GetCourseProcedureList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockConsole)(nil).GetStatistics), from, to)
 }
 
-// UpdateCourseProcedure mocks base method.
-func (m *MockCourseProcedure) UpdateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+// LockUser mocks base method.
+func (m *MockConsole) LockUser(admin services.UserData, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCourseProcedure", courseProcedure)
-	ret0, _ := ret[0].(model.CourseProcedure)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "LockUser", admin, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateCourseProcedure indicates an expected call of UpdateCourseProcedure.
-func (mr *MockCourseProcedureMockRecorder) UpdateCourseProcedure(courseProcedure any) *gomock.Call {
+// LockUser indicates an expected call of LockUser.
+func (mr *MockConsoleMockRecorder) LockUser(admin, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).UpdateCourseProcedure), courseProcedure)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockConsole)(nil).LockUser), admin, id)
 }
 
-// MockDiagnosis is a mock of Diagnosis interface.
-type MockDiagnosis struct {
-	ctrl     *gomock.Controller
-	recorder *MockDiagnosisMockRecorder
+// SearchUserList mocks base method.
+func (m *MockConsole) SearchUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUserList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.UserProfile])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockDiagnosisMockRecorder is the mock recorder for MockDiagnosis.
-type MockDiagnosisMockRecorder struct {
-	mock *MockDiagnosis
+// SearchUserList indicates an expected call of SearchUserList.
+func (mr *MockConsoleMockRecorder) SearchUserList(filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserList", reflect.TypeOf((*MockConsole)(nil).SearchUserList), filter, listQuery)
 }
 
-// NewMockDiagnosis creates a new mock instance.
-func NewMockDiagnosis(ctrl *gomock.Controller) *MockDiagnosis {
-	mock := &MockDiagnosis{ctrl: ctrl}
-	mock.recorder = &MockDiagnosisMockRecorder{mock}
-	return mock
+// UnlockUser mocks base method.
+func (m *MockConsole) UnlockUser(admin services.UserData, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", admin, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockConsoleMockRecorder) UnlockUser(admin, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockConsole)(nil).UnlockUser), admin, id)
+}
+
+// MockCourse is a mock of Course interface.
+type MockCourse struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseMockRecorder
+}
+
+// MockCourseMockRecorder is the mock recorder for MockCourse.
+type MockCourseMockRecorder struct {
+	mock *MockCourse
+}
+
+// NewMockCourse creates a new mock instance.
+func NewMockCourse(ctrl *gomock.Controller) *MockCourse {
+	mock := &MockCourse{ctrl: ctrl}
+	mock.recorder = &MockCourseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourse) EXPECT() *MockCourseMockRecorder {
+	return m.recorder
+}
+
+// CreateCourse mocks base method.
+func (m *MockCourse) CreateCourse(course model.Course) (model.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCourse", course)
+	ret0, _ := ret[0].(model.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCourse indicates an expected call of CreateCourse.
+func (mr *MockCourseMockRecorder) CreateCourse(course any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourse", reflect.TypeOf((*MockCourse)(nil).CreateCourse), course)
+}
+
+// DeleteCourse mocks base method.
+func (m *MockCourse) DeleteCourse(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourse", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourse indicates an expected call of DeleteCourse.
+func (mr *MockCourseMockRecorder) DeleteCourse(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockCourse)(nil).DeleteCourse), id)
+}
+
+// GetCourseById mocks base method.
+func (m *MockCourse) GetCourseById(id string) (model.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseById", id)
+	ret0, _ := ret[0].(model.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseById indicates an expected call of GetCourseById.
+func (mr *MockCourseMockRecorder) GetCourseById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseById", reflect.TypeOf((*MockCourse)(nil).GetCourseById), id)
+}
+
+// GetCourseList mocks base method.
+func (m *MockCourse) GetCourseList(filter model.CourseFilter, listQuery model.ListQuery) (model.Page[model.Course], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.Course])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseList indicates an expected call of GetCourseList.
+func (mr *MockCourseMockRecorder) GetCourseList(filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseList", reflect.TypeOf((*MockCourse)(nil).GetCourseList), filter, listQuery)
+}
+
+// UpdateCourse mocks base method.
+func (m *MockCourse) UpdateCourse(course model.Course) (model.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCourse", course)
+	ret0, _ := ret[0].(model.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCourse indicates an expected call of UpdateCourse.
+func (mr *MockCourseMockRecorder) UpdateCourse(course any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockCourse)(nil).UpdateCourse), course)
+}
+
+// MockCourseProcedure is a mock of CourseProcedure interface.
+type MockCourseProcedure struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseProcedureMockRecorder
+}
+
+// MockCourseProcedureMockRecorder is the mock recorder for MockCourseProcedure.
+type MockCourseProcedureMockRecorder struct {
+	mock *MockCourseProcedure
+}
+
+// NewMockCourseProcedure creates a new mock instance.
+func NewMockCourseProcedure(ctrl *gomock.Controller) *MockCourseProcedure {
+	mock := &MockCourseProcedure{ctrl: ctrl}
+	mock.recorder = &MockCourseProcedureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseProcedure) EXPECT() *MockCourseProcedureMockRecorder {
+	return m.recorder
+}
+
+// CreateCourseProcedure mocks base method.
+func (m *MockCourseProcedure) CreateCourseProcedure(user services.UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCourseProcedure", user, courseProcedure)
+	ret0, _ := ret[0].(model.CourseProcedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCourseProcedure indicates an expected call of CreateCourseProcedure.
+func (mr *MockCourseProcedureMockRecorder) CreateCourseProcedure(user, courseProcedure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).CreateCourseProcedure), user, courseProcedure)
+}
+
+// DeleteCourseProcedure mocks base method.
+func (m *MockCourseProcedure) DeleteCourseProcedure(user services.UserData, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseProcedure", user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseProcedure indicates an expected call of DeleteCourseProcedure.
+func (mr *MockCourseProcedureMockRecorder) DeleteCourseProcedure(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).DeleteCourseProcedure), user, id)
+}
+
+// GetCourseProcedureById mocks base method.
+func (m *MockCourseProcedure) GetCourseProcedureById(user services.UserData, id int) (model.CourseProcedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseProcedureById", user, id)
+	ret0, _ := ret[0].(model.CourseProcedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseProcedureById indicates an expected call of GetCourseProcedureById.
+func (mr *MockCourseProcedureMockRecorder) GetCourseProcedureById(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseProcedureById", reflect.TypeOf((*MockCourseProcedure)(nil).GetCourseProcedureById), user, id)
+}
+
+// GetCourseProcedureList mocks base method.
+func (m *MockCourseProcedure) GetCourseProcedureList(user services.UserData, filter model.CourseProcedureFilter, listQuery model.ListQuery) (model.Page[model.CourseProcedure], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseProcedureList", user, filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.CourseProcedure])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseProcedureList indicates an expected call of GetCourseProcedureList.
+func (mr *MockCourseProcedureMockRecorder) GetCourseProcedureList(user, filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseProcedureList", reflect.TypeOf((*MockCourseProcedure)(nil).GetCourseProcedureList), user, filter, listQuery)
+}
+
+// UpdateCourseProcedure mocks base method.
+func (m *MockCourseProcedure) UpdateCourseProcedure(user services.UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCourseProcedure", user, courseProcedure)
+	ret0, _ := ret[0].(model.CourseProcedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCourseProcedure indicates an expected call of UpdateCourseProcedure.
+func (mr *MockCourseProcedureMockRecorder) UpdateCourseProcedure(user, courseProcedure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourseProcedure", reflect.TypeOf((*MockCourseProcedure)(nil).UpdateCourseProcedure), user, courseProcedure)
+}
+
+// MockDiagnosis is a mock of Diagnosis interface.
+type MockDiagnosis struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosisMockRecorder
+}
+
+// MockDiagnosisMockRecorder is the mock recorder for MockDiagnosis.
+type MockDiagnosisMockRecorder struct {
+	mock *MockDiagnosis
+}
+
+// NewMockDiagnosis creates a new mock instance.
+func NewMockDiagnosis(ctrl *gomock.Controller) *MockDiagnosis {
+	mock := &MockDiagnosis{ctrl: ctrl}
+	mock.recorder = &MockDiagnosisMockRecorder{mock}
+	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
@@ -594,18 +1037,18 @@ func (mr *MockDiagnosisMockRecorder) GetDiagnosisById(id any) *gomock.Call {
 }
 
 // GetDiagnosisList mocks base method.
-func (m *MockDiagnosis) GetDiagnosisList() ([]model.Diagnosis, error) {
+func (m *MockDiagnosis) GetDiagnosisList(listQuery model.ListQuery) (model.Page[model.Diagnosis], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDiagnosisList")
-	ret0, _ := ret[0].([]model.Diagnosis)
+	ret := m.ctrl.Call(m, "GetDiagnosisList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.Diagnosis])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDiagnosisList indicates an expected call of GetDiagnosisList.
-func (mr *MockDiagnosisMockRecorder) GetDiagnosisList() *gomock.Call {
+func (mr *MockDiagnosisMockRecorder) GetDiagnosisList(listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosisList", reflect.TypeOf((*MockDiagnosis)(nil).GetDiagnosisList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosisList", reflect.TypeOf((*MockDiagnosis)(nil).GetDiagnosisList), listQuery)
 }
 
 // UpdateDiagnosis mocks base method.
@@ -691,18 +1134,18 @@ func (mr *MockDiseaseMockRecorder) GetDiseaseById(id any) *gomock.Call {
 }
 
 // GetDiseaseList mocks base method.
-func (m *MockDisease) GetDiseaseList() ([]model.Disease, error) {
+func (m *MockDisease) GetDiseaseList(listQuery model.ListQuery) (model.Page[model.Disease], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDiseaseList")
-	ret0, _ := ret[0].([]model.Disease)
+	ret := m.ctrl.Call(m, "GetDiseaseList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.Disease])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDiseaseList indicates an expected call of GetDiseaseList.
-func (mr *MockDiseaseMockRecorder) GetDiseaseList() *gomock.Call {
+func (mr *MockDiseaseMockRecorder) GetDiseaseList(listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiseaseList", reflect.TypeOf((*MockDisease)(nil).GetDiseaseList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiseaseList", reflect.TypeOf((*MockDisease)(nil).GetDiseaseList), listQuery)
 }
 
 // UpdateDisease mocks base method.
@@ -788,18 +1231,18 @@ func (mr *MockDoctorMockRecorder) GetDoctorById(id any) *gomock.Call {
 }
 
 // GetDoctorList mocks base method.
-func (m *MockDoctor) GetDoctorList() ([]model.Doctor, error) {
+func (m *MockDoctor) GetDoctorList(listQuery model.ListQuery) (model.Page[model.Doctor], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDoctorList")
-	ret0, _ := ret[0].([]model.Doctor)
+	ret := m.ctrl.Call(m, "GetDoctorList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.Doctor])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDoctorList indicates an expected call of GetDoctorList.
-func (mr *MockDoctorMockRecorder) GetDoctorList() *gomock.Call {
+func (mr *MockDoctorMockRecorder) GetDoctorList(listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctorList", reflect.TypeOf((*MockDoctor)(nil).GetDoctorList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctorList", reflect.TypeOf((*MockDoctor)(nil).GetDoctorList), listQuery)
 }
 
 // UpdateDoctor mocks base method.
@@ -870,18 +1313,18 @@ func (mr *MockDoctorPatientMockRecorder) DeleteDoctorPatient(doctor_id, patient_
 }
 
 // GetDoctorPatientList mocks base method.
-func (m *MockDoctorPatient) GetDoctorPatientList(doctor_id int) ([]model.DoctorPatient, error) {
+func (m *MockDoctorPatient) GetDoctorPatientList(doctorId int, listQuery model.ListQuery) (model.Page[model.DoctorPatient], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDoctorPatientList", doctor_id)
-	ret0, _ := ret[0].([]model.DoctorPatient)
+	ret := m.ctrl.Call(m, "GetDoctorPatientList", doctorId, listQuery)
+	ret0, _ := ret[0].(model.Page[model.DoctorPatient])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDoctorPatientList indicates an expected call of GetDoctorPatientList.
-func (mr *MockDoctorPatientMockRecorder) GetDoctorPatientList(doctor_id any) *gomock.Call {
+func (mr *MockDoctorPatientMockRecorder) GetDoctorPatientList(doctorId, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctorPatientList", reflect.TypeOf((*MockDoctorPatient)(nil).GetDoctorPatientList), doctor_id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoctorPatientList", reflect.TypeOf((*MockDoctorPatient)(nil).GetDoctorPatientList), doctorId, listQuery)
 }
 
 // MockDrug is a mock of Drug interface.
@@ -903,82 +1346,608 @@ func NewMockDrug(ctrl *gomock.Controller) *MockDrug {
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockDrug) EXPECT() *MockDrugMockRecorder {
+func (m *MockDrug) EXPECT() *MockDrugMockRecorder {
+	return m.recorder
+}
+
+// CreateDrug mocks base method.
+func (m *MockDrug) CreateDrug(drug model.Drug) (model.Drug, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDrug", drug)
+	ret0, _ := ret[0].(model.Drug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDrug indicates an expected call of CreateDrug.
+func (mr *MockDrugMockRecorder) CreateDrug(drug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrug", reflect.TypeOf((*MockDrug)(nil).CreateDrug), drug)
+}
+
+// DeleteDrug mocks base method.
+func (m *MockDrug) DeleteDrug(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDrug", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDrug indicates an expected call of DeleteDrug.
+func (mr *MockDrugMockRecorder) DeleteDrug(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrug", reflect.TypeOf((*MockDrug)(nil).DeleteDrug), id)
+}
+
+// GetDrugById mocks base method.
+func (m *MockDrug) GetDrugById(id string) (model.Drug, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrugById", id)
+	ret0, _ := ret[0].(model.Drug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrugById indicates an expected call of GetDrugById.
+func (mr *MockDrugMockRecorder) GetDrugById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrugById", reflect.TypeOf((*MockDrug)(nil).GetDrugById), id)
+}
+
+// GetDrugList mocks base method.
+func (m *MockDrug) GetDrugList(filter model.DrugFilter, listQuery model.ListQuery) (model.Page[model.Drug], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrugList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.Drug])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrugList indicates an expected call of GetDrugList.
+func (mr *MockDrugMockRecorder) GetDrugList(filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrugList", reflect.TypeOf((*MockDrug)(nil).GetDrugList), filter, listQuery)
+}
+
+// UpdateDrug mocks base method.
+func (m *MockDrug) UpdateDrug(drug model.Drug) (model.Drug, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDrug", drug)
+	ret0, _ := ret[0].(model.Drug)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDrug indicates an expected call of UpdateDrug.
+func (mr *MockDrugMockRecorder) UpdateDrug(drug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDrug", reflect.TypeOf((*MockDrug)(nil).UpdateDrug), drug)
+}
+
+// MockExport is a mock of Export interface.
+type MockExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportMockRecorder
+}
+
+// MockExportMockRecorder is the mock recorder for MockExport.
+type MockExportMockRecorder struct {
+	mock *MockExport
+}
+
+// NewMockExport creates a new mock instance.
+func NewMockExport(ctrl *gomock.Controller) *MockExport {
+	mock := &MockExport{ctrl: ctrl}
+	mock.recorder = &MockExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExport) EXPECT() *MockExportMockRecorder {
+	return m.recorder
+}
+
+// CreateExportJob mocks base method.
+func (m *MockExport) CreateExportJob(user services.UserData, job model.ExportJob) (model.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExportJob", user, job)
+	ret0, _ := ret[0].(model.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExportJob indicates an expected call of CreateExportJob.
+func (mr *MockExportMockRecorder) CreateExportJob(user, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExportJob", reflect.TypeOf((*MockExport)(nil).CreateExportJob), user, job)
+}
+
+// FailUnfinishedExportJobs mocks base method.
+func (m *MockExport) FailUnfinishedExportJobs() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailUnfinishedExportJobs")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailUnfinishedExportJobs indicates an expected call of FailUnfinishedExportJobs.
+func (mr *MockExportMockRecorder) FailUnfinishedExportJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailUnfinishedExportJobs", reflect.TypeOf((*MockExport)(nil).FailUnfinishedExportJobs))
+}
+
+// GetExportBundle mocks base method.
+func (m *MockExport) GetExportBundle(user services.UserData, id int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportBundle", user, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportBundle indicates an expected call of GetExportBundle.
+func (mr *MockExportMockRecorder) GetExportBundle(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportBundle", reflect.TypeOf((*MockExport)(nil).GetExportBundle), user, id)
+}
+
+// GetExportJobById mocks base method.
+func (m *MockExport) GetExportJobById(user services.UserData, id int) (model.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportJobById", user, id)
+	ret0, _ := ret[0].(model.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportJobById indicates an expected call of GetExportJobById.
+func (mr *MockExportMockRecorder) GetExportJobById(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportJobById", reflect.TypeOf((*MockExport)(nil).GetExportJobById), user, id)
+}
+
+// GetExportJobList mocks base method.
+func (m *MockExport) GetExportJobList(user services.UserData, filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportJobList", user, filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.ExportJob])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportJobList indicates an expected call of GetExportJobList.
+func (mr *MockExportMockRecorder) GetExportJobList(user, filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportJobList", reflect.TypeOf((*MockExport)(nil).GetExportJobList), user, filter, listQuery)
+}
+
+// MockFHIR is a mock of FHIR interface.
+type MockFHIR struct {
+	ctrl     *gomock.Controller
+	recorder *MockFHIRMockRecorder
+}
+
+// MockFHIRMockRecorder is the mock recorder for MockFHIR.
+type MockFHIRMockRecorder struct {
+	mock *MockFHIR
+}
+
+// NewMockFHIR creates a new mock instance.
+func NewMockFHIR(ctrl *gomock.Controller) *MockFHIR {
+	mock := &MockFHIR{ctrl: ctrl}
+	mock.recorder = &MockFHIRMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFHIR) EXPECT() *MockFHIRMockRecorder {
+	return m.recorder
+}
+
+// CreateFHIRCondition mocks base method.
+func (m *MockFHIR) CreateFHIRCondition(user services.UserData, resource model.FHIRCondition) (model.FHIRCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFHIRCondition", user, resource)
+	ret0, _ := ret[0].(model.FHIRCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFHIRCondition indicates an expected call of CreateFHIRCondition.
+func (mr *MockFHIRMockRecorder) CreateFHIRCondition(user, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFHIRCondition", reflect.TypeOf((*MockFHIR)(nil).CreateFHIRCondition), user, resource)
+}
+
+// CreateFHIRMedicationRequest mocks base method.
+func (m *MockFHIR) CreateFHIRMedicationRequest(user services.UserData, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFHIRMedicationRequest", user, resource)
+	ret0, _ := ret[0].(model.FHIRMedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFHIRMedicationRequest indicates an expected call of CreateFHIRMedicationRequest.
+func (mr *MockFHIRMockRecorder) CreateFHIRMedicationRequest(user, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFHIRMedicationRequest", reflect.TypeOf((*MockFHIR)(nil).CreateFHIRMedicationRequest), user, resource)
+}
+
+// CreateFHIRObservation mocks base method.
+func (m *MockFHIR) CreateFHIRObservation(user services.UserData, resource model.FHIRObservation) (model.FHIRObservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFHIRObservation", user, resource)
+	ret0, _ := ret[0].(model.FHIRObservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFHIRObservation indicates an expected call of CreateFHIRObservation.
+func (mr *MockFHIRMockRecorder) CreateFHIRObservation(user, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFHIRObservation", reflect.TypeOf((*MockFHIR)(nil).CreateFHIRObservation), user, resource)
+}
+
+// CreateFHIRPatient mocks base method.
+func (m *MockFHIR) CreateFHIRPatient(user services.UserData, resource model.FHIRPatient) (model.FHIRPatient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFHIRPatient", user, resource)
+	ret0, _ := ret[0].(model.FHIRPatient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFHIRPatient indicates an expected call of CreateFHIRPatient.
+func (mr *MockFHIRMockRecorder) CreateFHIRPatient(user, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFHIRPatient", reflect.TypeOf((*MockFHIR)(nil).CreateFHIRPatient), user, resource)
+}
+
+// GetFHIRCondition mocks base method.
+func (m *MockFHIR) GetFHIRCondition(user services.UserData, id string) (model.FHIRCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRCondition", user, id)
+	ret0, _ := ret[0].(model.FHIRCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRCondition indicates an expected call of GetFHIRCondition.
+func (mr *MockFHIRMockRecorder) GetFHIRCondition(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRCondition", reflect.TypeOf((*MockFHIR)(nil).GetFHIRCondition), user, id)
+}
+
+// GetFHIRMedication mocks base method.
+func (m *MockFHIR) GetFHIRMedication(id string) (model.FHIRMedication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRMedication", id)
+	ret0, _ := ret[0].(model.FHIRMedication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRMedication indicates an expected call of GetFHIRMedication.
+func (mr *MockFHIRMockRecorder) GetFHIRMedication(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRMedication", reflect.TypeOf((*MockFHIR)(nil).GetFHIRMedication), id)
+}
+
+// GetFHIRMedicationRequest mocks base method.
+func (m *MockFHIR) GetFHIRMedicationRequest(user services.UserData, id string) (model.FHIRMedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRMedicationRequest", user, id)
+	ret0, _ := ret[0].(model.FHIRMedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRMedicationRequest indicates an expected call of GetFHIRMedicationRequest.
+func (mr *MockFHIRMockRecorder) GetFHIRMedicationRequest(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRMedicationRequest", reflect.TypeOf((*MockFHIR)(nil).GetFHIRMedicationRequest), user, id)
+}
+
+// GetFHIRObservation mocks base method.
+func (m *MockFHIR) GetFHIRObservation(user services.UserData, id string) (model.FHIRObservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRObservation", user, id)
+	ret0, _ := ret[0].(model.FHIRObservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRObservation indicates an expected call of GetFHIRObservation.
+func (mr *MockFHIRMockRecorder) GetFHIRObservation(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRObservation", reflect.TypeOf((*MockFHIR)(nil).GetFHIRObservation), user, id)
+}
+
+// GetFHIRPatient mocks base method.
+func (m *MockFHIR) GetFHIRPatient(user services.UserData, id string) (model.FHIRPatient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRPatient", user, id)
+	ret0, _ := ret[0].(model.FHIRPatient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRPatient indicates an expected call of GetFHIRPatient.
+func (mr *MockFHIRMockRecorder) GetFHIRPatient(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRPatient", reflect.TypeOf((*MockFHIR)(nil).GetFHIRPatient), user, id)
+}
+
+// GetFHIRPractitioner mocks base method.
+func (m *MockFHIR) GetFHIRPractitioner(id string) (model.FHIRPractitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFHIRPractitioner", id)
+	ret0, _ := ret[0].(model.FHIRPractitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFHIRPractitioner indicates an expected call of GetFHIRPractitioner.
+func (mr *MockFHIRMockRecorder) GetFHIRPractitioner(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFHIRPractitioner", reflect.TypeOf((*MockFHIR)(nil).GetFHIRPractitioner), id)
+}
+
+// SearchFHIRConditions mocks base method.
+func (m *MockFHIR) SearchFHIRConditions(user services.UserData, query model.FHIRConditionQuery) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRConditions", user, query)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRConditions indicates an expected call of SearchFHIRConditions.
+func (mr *MockFHIRMockRecorder) SearchFHIRConditions(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRConditions", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRConditions), user, query)
+}
+
+// SearchFHIRMedicationRequests mocks base method.
+func (m *MockFHIR) SearchFHIRMedicationRequests(user services.UserData, query model.FHIRMedicationRequestQuery) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRMedicationRequests", user, query)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRMedicationRequests indicates an expected call of SearchFHIRMedicationRequests.
+func (mr *MockFHIRMockRecorder) SearchFHIRMedicationRequests(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRMedicationRequests", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRMedicationRequests), user, query)
+}
+
+// SearchFHIRMedications mocks base method.
+func (m *MockFHIR) SearchFHIRMedications(search model.FHIRSearch) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRMedications", search)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRMedications indicates an expected call of SearchFHIRMedications.
+func (mr *MockFHIRMockRecorder) SearchFHIRMedications(search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRMedications", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRMedications), search)
+}
+
+// SearchFHIRObservations mocks base method.
+func (m *MockFHIR) SearchFHIRObservations(user services.UserData, query model.FHIRObservationQuery) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRObservations", user, query)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRObservations indicates an expected call of SearchFHIRObservations.
+func (mr *MockFHIRMockRecorder) SearchFHIRObservations(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRObservations", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRObservations), user, query)
+}
+
+// SearchFHIRPatients mocks base method.
+func (m *MockFHIR) SearchFHIRPatients(user services.UserData, query model.FHIRPatientQuery) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRPatients", user, query)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRPatients indicates an expected call of SearchFHIRPatients.
+func (mr *MockFHIRMockRecorder) SearchFHIRPatients(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRPatients", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRPatients), user, query)
+}
+
+// SearchFHIRPractitioners mocks base method.
+func (m *MockFHIR) SearchFHIRPractitioners(search model.FHIRSearch) (model.FHIRBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFHIRPractitioners", search)
+	ret0, _ := ret[0].(model.FHIRBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFHIRPractitioners indicates an expected call of SearchFHIRPractitioners.
+func (mr *MockFHIRMockRecorder) SearchFHIRPractitioners(search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFHIRPractitioners", reflect.TypeOf((*MockFHIR)(nil).SearchFHIRPractitioners), search)
+}
+
+// UpdateFHIRCondition mocks base method.
+func (m *MockFHIR) UpdateFHIRCondition(user services.UserData, id string, resource model.FHIRCondition) (model.FHIRCondition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFHIRCondition", user, id, resource)
+	ret0, _ := ret[0].(model.FHIRCondition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFHIRCondition indicates an expected call of UpdateFHIRCondition.
+func (mr *MockFHIRMockRecorder) UpdateFHIRCondition(user, id, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFHIRCondition", reflect.TypeOf((*MockFHIR)(nil).UpdateFHIRCondition), user, id, resource)
+}
+
+// UpdateFHIRMedicationRequest mocks base method.
+func (m *MockFHIR) UpdateFHIRMedicationRequest(user services.UserData, id string, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFHIRMedicationRequest", user, id, resource)
+	ret0, _ := ret[0].(model.FHIRMedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFHIRMedicationRequest indicates an expected call of UpdateFHIRMedicationRequest.
+func (mr *MockFHIRMockRecorder) UpdateFHIRMedicationRequest(user, id, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFHIRMedicationRequest", reflect.TypeOf((*MockFHIR)(nil).UpdateFHIRMedicationRequest), user, id, resource)
+}
+
+// UpdateFHIRObservation mocks base method.
+func (m *MockFHIR) UpdateFHIRObservation(user services.UserData, id string, resource model.FHIRObservation) (model.FHIRObservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFHIRObservation", user, id, resource)
+	ret0, _ := ret[0].(model.FHIRObservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFHIRObservation indicates an expected call of UpdateFHIRObservation.
+func (mr *MockFHIRMockRecorder) UpdateFHIRObservation(user, id, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFHIRObservation", reflect.TypeOf((*MockFHIR)(nil).UpdateFHIRObservation), user, id, resource)
+}
+
+// UpdateFHIRPatient mocks base method.
+func (m *MockFHIR) UpdateFHIRPatient(user services.UserData, id string, resource model.FHIRPatient) (model.FHIRPatient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFHIRPatient", user, id, resource)
+	ret0, _ := ret[0].(model.FHIRPatient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFHIRPatient indicates an expected call of UpdateFHIRPatient.
+func (mr *MockFHIRMockRecorder) UpdateFHIRPatient(user, id, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFHIRPatient", reflect.TypeOf((*MockFHIR)(nil).UpdateFHIRPatient), user, id, resource)
+}
+
+// MockLabCodeMapping is a mock of LabCodeMapping interface.
+type MockLabCodeMapping struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabCodeMappingMockRecorder
+}
+
+// MockLabCodeMappingMockRecorder is the mock recorder for MockLabCodeMapping.
+type MockLabCodeMappingMockRecorder struct {
+	mock *MockLabCodeMapping
+}
+
+// NewMockLabCodeMapping creates a new mock instance.
+func NewMockLabCodeMapping(ctrl *gomock.Controller) *MockLabCodeMapping {
+	mock := &MockLabCodeMapping{ctrl: ctrl}
+	mock.recorder = &MockLabCodeMappingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabCodeMapping) EXPECT() *MockLabCodeMappingMockRecorder {
 	return m.recorder
 }
 
-// CreateDrug mocks base method.
-func (m *MockDrug) CreateDrug(drug model.Drug) (model.Drug, error) {
+// CreateLabCodeMapping mocks base method.
+func (m *MockLabCodeMapping) CreateLabCodeMapping(labCodeMapping model.LabCodeMapping) (model.LabCodeMapping, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDrug", drug)
-	ret0, _ := ret[0].(model.Drug)
+	ret := m.ctrl.Call(m, "CreateLabCodeMapping", labCodeMapping)
+	ret0, _ := ret[0].(model.LabCodeMapping)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDrug indicates an expected call of CreateDrug.
-func (mr *MockDrugMockRecorder) CreateDrug(drug any) *gomock.Call {
+// CreateLabCodeMapping indicates an expected call of CreateLabCodeMapping.
+func (mr *MockLabCodeMappingMockRecorder) CreateLabCodeMapping(labCodeMapping any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrug", reflect.TypeOf((*MockDrug)(nil).CreateDrug), drug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabCodeMapping", reflect.TypeOf((*MockLabCodeMapping)(nil).CreateLabCodeMapping), labCodeMapping)
 }
 
-// DeleteDrug mocks base method.
-func (m *MockDrug) DeleteDrug(id string) error {
+// DeleteLabCodeMapping mocks base method.
+func (m *MockLabCodeMapping) DeleteLabCodeMapping(system, code string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDrug", id)
+	ret := m.ctrl.Call(m, "DeleteLabCodeMapping", system, code)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteDrug indicates an expected call of DeleteDrug.
-func (mr *MockDrugMockRecorder) DeleteDrug(id any) *gomock.Call {
+// DeleteLabCodeMapping indicates an expected call of DeleteLabCodeMapping.
+func (mr *MockLabCodeMappingMockRecorder) DeleteLabCodeMapping(system, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrug", reflect.TypeOf((*MockDrug)(nil).DeleteDrug), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabCodeMapping", reflect.TypeOf((*MockLabCodeMapping)(nil).DeleteLabCodeMapping), system, code)
 }
 
-// GetDrugById mocks base method.
-func (m *MockDrug) GetDrugById(id string) (model.Drug, error) {
+// GetLabCodeMappingList mocks base method.
+func (m *MockLabCodeMapping) GetLabCodeMappingList(listQuery model.ListQuery) (model.Page[model.LabCodeMapping], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDrugById", id)
-	ret0, _ := ret[0].(model.Drug)
+	ret := m.ctrl.Call(m, "GetLabCodeMappingList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.LabCodeMapping])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDrugById indicates an expected call of GetDrugById.
-func (mr *MockDrugMockRecorder) GetDrugById(id any) *gomock.Call {
+// GetLabCodeMappingList indicates an expected call of GetLabCodeMappingList.
+func (mr *MockLabCodeMappingMockRecorder) GetLabCodeMappingList(listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrugById", reflect.TypeOf((*MockDrug)(nil).GetDrugById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabCodeMappingList", reflect.TypeOf((*MockLabCodeMapping)(nil).GetLabCodeMappingList), listQuery)
 }
 
-// GetDrugList mocks base method.
-func (m *MockDrug) GetDrugList() ([]model.Drug, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDrugList")
-	ret0, _ := ret[0].([]model.Drug)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockLabMessage is a mock of LabMessage interface.
+type MockLabMessage struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabMessageMockRecorder
 }
 
-// GetDrugList indicates an expected call of GetDrugList.
-func (mr *MockDrugMockRecorder) GetDrugList() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrugList", reflect.TypeOf((*MockDrug)(nil).GetDrugList))
+// MockLabMessageMockRecorder is the mock recorder for MockLabMessage.
+type MockLabMessageMockRecorder struct {
+	mock *MockLabMessage
 }
 
-// UpdateDrug mocks base method.
-func (m *MockDrug) UpdateDrug(drug model.Drug) (model.Drug, error) {
+// NewMockLabMessage creates a new mock instance.
+func NewMockLabMessage(ctrl *gomock.Controller) *MockLabMessage {
+	mock := &MockLabMessage{ctrl: ctrl}
+	mock.recorder = &MockLabMessageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabMessage) EXPECT() *MockLabMessageMockRecorder {
+	return m.recorder
+}
+
+// IngestLabMessage mocks base method.
+func (m *MockLabMessage) IngestLabMessage(user services.UserData, message string) (model.LabMessageAck, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDrug", drug)
-	ret0, _ := ret[0].(model.Drug)
+	ret := m.ctrl.Call(m, "IngestLabMessage", user, message)
+	ret0, _ := ret[0].(model.LabMessageAck)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateDrug indicates an expected call of UpdateDrug.
-func (mr *MockDrugMockRecorder) UpdateDrug(drug any) *gomock.Call {
+// IngestLabMessage indicates an expected call of IngestLabMessage.
+func (mr *MockLabMessageMockRecorder) IngestLabMessage(user, message any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDrug", reflect.TypeOf((*MockDrug)(nil).UpdateDrug), drug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestLabMessage", reflect.TypeOf((*MockLabMessage)(nil).IngestLabMessage), user, message)
 }
 
 // MockPatient is a mock of Patient interface.
@@ -1005,77 +1974,92 @@ func (m *MockPatient) EXPECT() *MockPatientMockRecorder {
 }
 
 // CreatePatient mocks base method.
-func (m *MockPatient) CreatePatient(patient model.Patient) (model.Patient, error) {
+func (m *MockPatient) CreatePatient(user services.UserData, patient model.Patient) (model.Patient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePatient", patient)
+	ret := m.ctrl.Call(m, "CreatePatient", user, patient)
 	ret0, _ := ret[0].(model.Patient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePatient indicates an expected call of CreatePatient.
-func (mr *MockPatientMockRecorder) CreatePatient(patient any) *gomock.Call {
+func (mr *MockPatientMockRecorder) CreatePatient(user, patient any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatient)(nil).CreatePatient), patient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatient)(nil).CreatePatient), user, patient)
 }
 
 // DeletePatient mocks base method.
-func (m *MockPatient) DeletePatient(id int) error {
+func (m *MockPatient) DeletePatient(user services.UserData, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePatient", id)
+	ret := m.ctrl.Call(m, "DeletePatient", user, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePatient indicates an expected call of DeletePatient.
-func (mr *MockPatientMockRecorder) DeletePatient(id any) *gomock.Call {
+func (mr *MockPatientMockRecorder) DeletePatient(user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatient", reflect.TypeOf((*MockPatient)(nil).DeletePatient), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatient", reflect.TypeOf((*MockPatient)(nil).DeletePatient), user, id)
 }
 
 // GetPatientById mocks base method.
-func (m *MockPatient) GetPatientById(id int) (model.Patient, error) {
+func (m *MockPatient) GetPatientById(user services.UserData, id int) (model.Patient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientById", id)
+	ret := m.ctrl.Call(m, "GetPatientById", user, id)
 	ret0, _ := ret[0].(model.Patient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientById indicates an expected call of GetPatientById.
-func (mr *MockPatientMockRecorder) GetPatientById(id any) *gomock.Call {
+func (mr *MockPatientMockRecorder) GetPatientById(user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientById", reflect.TypeOf((*MockPatient)(nil).GetPatientById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientById", reflect.TypeOf((*MockPatient)(nil).GetPatientById), user, id)
 }
 
 // GetPatientList mocks base method.
-func (m *MockPatient) GetPatientList() ([]model.Patient, error) {
+func (m *MockPatient) GetPatientList(user services.UserData, filter model.PatientFilter, listQuery model.ListQuery) (model.Page[model.Patient], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientList")
-	ret0, _ := ret[0].([]model.Patient)
+	ret := m.ctrl.Call(m, "GetPatientList", user, filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.Patient])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientList indicates an expected call of GetPatientList.
-func (mr *MockPatientMockRecorder) GetPatientList() *gomock.Call {
+func (mr *MockPatientMockRecorder) GetPatientList(user, filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientList", reflect.TypeOf((*MockPatient)(nil).GetPatientList), user, filter, listQuery)
+}
+
+// SearchPatientList mocks base method.
+func (m *MockPatient) SearchPatientList(user services.UserData, search model.PatientSearch) ([]model.PatientSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPatientList", user, search)
+	ret0, _ := ret[0].([]model.PatientSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPatientList indicates an expected call of SearchPatientList.
+func (mr *MockPatientMockRecorder) SearchPatientList(user, search any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientList", reflect.TypeOf((*MockPatient)(nil).GetPatientList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPatientList", reflect.TypeOf((*MockPatient)(nil).SearchPatientList), user, search)
 }
 
 // UpdatePatient mocks base method.
-func (m *MockPatient) UpdatePatient(patient model.Patient) (model.Patient, error) {
+func (m *MockPatient) UpdatePatient(user services.UserData, patient model.Patient) (model.Patient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePatient", patient)
+	ret := m.ctrl.Call(m, "UpdatePatient", user, patient)
 	ret0, _ := ret[0].(model.Patient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePatient indicates an expected call of UpdatePatient.
-func (mr *MockPatientMockRecorder) UpdatePatient(patient any) *gomock.Call {
+func (mr *MockPatientMockRecorder) UpdatePatient(user, patient any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatient", reflect.TypeOf((*MockPatient)(nil).UpdatePatient), patient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatient", reflect.TypeOf((*MockPatient)(nil).UpdatePatient), user, patient)
 }
 
 // MockPatientCourse is a mock of PatientCourse interface.
@@ -1102,77 +2086,77 @@ func (m *MockPatientCourse) EXPECT() *MockPatientCourseMockRecorder {
 }
 
 // CreatePatientCourse mocks base method.
-func (m *MockPatientCourse) CreatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error) {
+func (m *MockPatientCourse) CreatePatientCourse(user services.UserData, patientCourse model.PatientCourse) (model.PatientCourse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePatientCourse", patientCourse)
+	ret := m.ctrl.Call(m, "CreatePatientCourse", user, patientCourse)
 	ret0, _ := ret[0].(model.PatientCourse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePatientCourse indicates an expected call of CreatePatientCourse.
-func (mr *MockPatientCourseMockRecorder) CreatePatientCourse(patientCourse any) *gomock.Call {
+func (mr *MockPatientCourseMockRecorder) CreatePatientCourse(user, patientCourse any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).CreatePatientCourse), patientCourse)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).CreatePatientCourse), user, patientCourse)
 }
 
 // DeletePatientCourse mocks base method.
-func (m *MockPatientCourse) DeletePatientCourse(id int) error {
+func (m *MockPatientCourse) DeletePatientCourse(user services.UserData, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePatientCourse", id)
+	ret := m.ctrl.Call(m, "DeletePatientCourse", user, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePatientCourse indicates an expected call of DeletePatientCourse.
-func (mr *MockPatientCourseMockRecorder) DeletePatientCourse(id any) *gomock.Call {
+func (mr *MockPatientCourseMockRecorder) DeletePatientCourse(user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).DeletePatientCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).DeletePatientCourse), user, id)
 }
 
 // GetPatientCourseById mocks base method.
-func (m *MockPatientCourse) GetPatientCourseById(id int) (model.PatientCourse, error) {
+func (m *MockPatientCourse) GetPatientCourseById(user services.UserData, id int) (model.PatientCourse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientCourseById", id)
+	ret := m.ctrl.Call(m, "GetPatientCourseById", user, id)
 	ret0, _ := ret[0].(model.PatientCourse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientCourseById indicates an expected call of GetPatientCourseById.
-func (mr *MockPatientCourseMockRecorder) GetPatientCourseById(id any) *gomock.Call {
+func (mr *MockPatientCourseMockRecorder) GetPatientCourseById(user, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientCourseById", reflect.TypeOf((*MockPatientCourse)(nil).GetPatientCourseById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientCourseById", reflect.TypeOf((*MockPatientCourse)(nil).GetPatientCourseById), user, id)
 }
 
 // GetPatientCourseList mocks base method.
-func (m *MockPatientCourse) GetPatientCourseList() ([]model.PatientCourse, error) {
+func (m *MockPatientCourse) GetPatientCourseList(user services.UserData, filter model.PatientCourseFilter, listQuery model.ListQuery) (model.Page[model.PatientCourse], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientCourseList")
-	ret0, _ := ret[0].([]model.PatientCourse)
+	ret := m.ctrl.Call(m, "GetPatientCourseList", user, filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.PatientCourse])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientCourseList indicates an expected call of GetPatientCourseList.
-func (mr *MockPatientCourseMockRecorder) GetPatientCourseList() *gomock.Call {
+func (mr *MockPatientCourseMockRecorder) GetPatientCourseList(user, filter, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientCourseList", reflect.TypeOf((*MockPatientCourse)(nil).GetPatientCourseList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientCourseList", reflect.TypeOf((*MockPatientCourse)(nil).GetPatientCourseList), user, filter, listQuery)
 }
 
 // UpdatePatientCourse mocks base method.
-func (m *MockPatientCourse) UpdatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error) {
+func (m *MockPatientCourse) UpdatePatientCourse(user services.UserData, patientCourse model.PatientCourse) (model.PatientCourse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePatientCourse", patientCourse)
+	ret := m.ctrl.Call(m, "UpdatePatientCourse", user, patientCourse)
 	ret0, _ := ret[0].(model.PatientCourse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePatientCourse indicates an expected call of UpdatePatientCourse.
-func (mr *MockPatientCourseMockRecorder) UpdatePatientCourse(patientCourse any) *gomock.Call {
+func (mr *MockPatientCourseMockRecorder) UpdatePatientCourse(user, patientCourse any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).UpdatePatientCourse), patientCourse)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatientCourse", reflect.TypeOf((*MockPatientCourse)(nil).UpdatePatientCourse), user, patientCourse)
 }
 
 // MockPatientDisease is a mock of PatientDisease interface.
@@ -1199,107 +2183,159 @@ func (m *MockPatientDisease) EXPECT() *MockPatientDiseaseMockRecorder {
 }
 
 // CreatePatientDisease mocks base method.
-func (m *MockPatientDisease) CreatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error) {
+func (m *MockPatientDisease) CreatePatientDisease(user services.UserData, patientDisease model.PatientDisease) (model.PatientDisease, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePatientDisease", patientDisease)
+	ret := m.ctrl.Call(m, "CreatePatientDisease", user, patientDisease)
 	ret0, _ := ret[0].(model.PatientDisease)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePatientDisease indicates an expected call of CreatePatientDisease.
-func (mr *MockPatientDiseaseMockRecorder) CreatePatientDisease(patientDisease any) *gomock.Call {
+func (mr *MockPatientDiseaseMockRecorder) CreatePatientDisease(user, patientDisease any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).CreatePatientDisease), patientDisease)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).CreatePatientDisease), user, patientDisease)
 }
 
 // DeletePatientDisease mocks base method.
-func (m *MockPatientDisease) DeletePatientDisease(patientId, diseaseId int) error {
+func (m *MockPatientDisease) DeletePatientDisease(user services.UserData, patientId int, diseaseId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePatientDisease", patientId, diseaseId)
+	ret := m.ctrl.Call(m, "DeletePatientDisease", user, patientId, diseaseId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePatientDisease indicates an expected call of DeletePatientDisease.
-func (mr *MockPatientDiseaseMockRecorder) DeletePatientDisease(patientId, diseaseId any) *gomock.Call {
+func (mr *MockPatientDiseaseMockRecorder) DeletePatientDisease(user, patientId, diseaseId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).DeletePatientDisease), patientId, diseaseId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).DeletePatientDisease), user, patientId, diseaseId)
 }
 
 // GetPatientDiseaseById mocks base method.
-func (m *MockPatientDisease) GetPatientDiseaseById(patientId, diseaseId int) (model.PatientDisease, error) {
+func (m *MockPatientDisease) GetPatientDiseaseById(user services.UserData, patientId int, diseaseId string) (model.PatientDisease, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientDiseaseById", patientId, diseaseId)
+	ret := m.ctrl.Call(m, "GetPatientDiseaseById", user, patientId, diseaseId)
 	ret0, _ := ret[0].(model.PatientDisease)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientDiseaseById indicates an expected call of GetPatientDiseaseById.
-func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseById(patientId, diseaseId any) *gomock.Call {
+func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseById(user, patientId, diseaseId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseById", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseById), patientId, diseaseId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseById", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseById), user, patientId, diseaseId)
 }
 
 // GetPatientDiseaseList mocks base method.
-func (m *MockPatientDisease) GetPatientDiseaseList() ([]model.PatientDisease, error) {
+func (m *MockPatientDisease) GetPatientDiseaseList(user services.UserData, filter model.PatientDiseaseFilter, listQuery model.ListQuery) (model.Page[model.PatientDisease], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientDiseaseList")
-	ret0, _ := ret[0].([]model.PatientDisease)
+	ret := m.ctrl.Call(m, "GetPatientDiseaseList", user, filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.PatientDisease])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatientDiseaseList indicates an expected call of GetPatientDiseaseList.
-func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseList() *gomock.Call {
+func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseList(user, filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseList", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseList), user, filter, listQuery)
+}
+
+// UpdatePatientDisease mocks base method.
+func (m *MockPatientDisease) UpdatePatientDisease(user services.UserData, patientDisease model.PatientDisease) (model.PatientDisease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePatientDisease", user, patientDisease)
+	ret0, _ := ret[0].(model.PatientDisease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePatientDisease indicates an expected call of UpdatePatientDisease.
+func (mr *MockPatientDiseaseMockRecorder) UpdatePatientDisease(user, patientDisease any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseList", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).UpdatePatientDisease), user, patientDisease)
+}
+
+// MockPermission is a mock of Permission interface.
+type MockPermission struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionMockRecorder
 }
 
-// GetPatientDiseaseListByDisease mocks base method.
-func (m *MockPatientDisease) GetPatientDiseaseListByDisease(diseaseId int) ([]model.PatientDisease, error) {
+// MockPermissionMockRecorder is the mock recorder for MockPermission.
+type MockPermissionMockRecorder struct {
+	mock *MockPermission
+}
+
+// NewMockPermission creates a new mock instance.
+func NewMockPermission(ctrl *gomock.Controller) *MockPermission {
+	mock := &MockPermission{ctrl: ctrl}
+	mock.recorder = &MockPermissionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermission) EXPECT() *MockPermissionMockRecorder {
+	return m.recorder
+}
+
+// CreatePermission mocks base method.
+func (m *MockPermission) CreatePermission(permission model.Permission) (model.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientDiseaseListByDisease", diseaseId)
-	ret0, _ := ret[0].([]model.PatientDisease)
+	ret := m.ctrl.Call(m, "CreatePermission", permission)
+	ret0, _ := ret[0].(model.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPatientDiseaseListByDisease indicates an expected call of GetPatientDiseaseListByDisease.
-func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseListByDisease(diseaseId any) *gomock.Call {
+// CreatePermission indicates an expected call of CreatePermission.
+func (mr *MockPermissionMockRecorder) CreatePermission(permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockPermission)(nil).CreatePermission), permission)
+}
+
+// DeletePermission mocks base method.
+func (m *MockPermission) DeletePermission(permission model.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermission", permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermission indicates an expected call of DeletePermission.
+func (mr *MockPermissionMockRecorder) DeletePermission(permission any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseListByDisease", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseListByDisease), diseaseId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockPermission)(nil).DeletePermission), permission)
 }
 
-// GetPatientDiseaseListByPatient mocks base method.
-func (m *MockPatientDisease) GetPatientDiseaseListByPatient(patientId int) ([]model.PatientDisease, error) {
+// GetPermissionList mocks base method.
+func (m *MockPermission) GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatientDiseaseListByPatient", patientId)
-	ret0, _ := ret[0].([]model.PatientDisease)
+	ret := m.ctrl.Call(m, "GetPermissionList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.Permission])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPatientDiseaseListByPatient indicates an expected call of GetPatientDiseaseListByPatient.
-func (mr *MockPatientDiseaseMockRecorder) GetPatientDiseaseListByPatient(patientId any) *gomock.Call {
+// GetPermissionList indicates an expected call of GetPermissionList.
+func (mr *MockPermissionMockRecorder) GetPermissionList(filter, listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientDiseaseListByPatient", reflect.TypeOf((*MockPatientDisease)(nil).GetPatientDiseaseListByPatient), patientId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionList", reflect.TypeOf((*MockPermission)(nil).GetPermissionList), filter, listQuery)
 }
 
-// UpdatePatientDisease mocks base method.
-func (m *MockPatientDisease) UpdatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error) {
+// HasPermission mocks base method.
+func (m *MockPermission) HasPermission(role, resource, action string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePatientDisease", patientDisease)
-	ret0, _ := ret[0].(model.PatientDisease)
+	ret := m.ctrl.Call(m, "HasPermission", role, resource, action)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdatePatientDisease indicates an expected call of UpdatePatientDisease.
-func (mr *MockPatientDiseaseMockRecorder) UpdatePatientDisease(patientDisease any) *gomock.Call {
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockPermissionMockRecorder) HasPermission(role, resource, action any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatientDisease", reflect.TypeOf((*MockPatientDisease)(nil).UpdatePatientDisease), patientDisease)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockPermission)(nil).HasPermission), role, resource, action)
 }
 
 // MockProcedureBloodCount is a mock of ProcedureBloodCount interface.
@@ -1326,107 +2362,333 @@ func (m *MockProcedureBloodCount) EXPECT() *MockProcedureBloodCountMockRecorder
 }
 
 // CreateProcedureBloodCount mocks base method.
-func (m *MockProcedureBloodCount) CreateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
+func (m *MockProcedureBloodCount) CreateProcedureBloodCount(user services.UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProcedureBloodCount", procedureBloodCount)
+	ret := m.ctrl.Call(m, "CreateProcedureBloodCount", user, procedureBloodCount)
 	ret0, _ := ret[0].(model.ProcedureBloodCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProcedureBloodCount indicates an expected call of CreateProcedureBloodCount.
-func (mr *MockProcedureBloodCountMockRecorder) CreateProcedureBloodCount(procedureBloodCount any) *gomock.Call {
+func (mr *MockProcedureBloodCountMockRecorder) CreateProcedureBloodCount(user, procedureBloodCount any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).CreateProcedureBloodCount), procedureBloodCount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).CreateProcedureBloodCount), user, procedureBloodCount)
 }
 
 // DeleteProcedureBloodCount mocks base method.
-func (m *MockProcedureBloodCount) DeleteProcedureBloodCount(procedureId int, bloodCountId string) error {
+func (m *MockProcedureBloodCount) DeleteProcedureBloodCount(user services.UserData, procedureId int, bloodCountId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProcedureBloodCount", procedureId, bloodCountId)
+	ret := m.ctrl.Call(m, "DeleteProcedureBloodCount", user, procedureId, bloodCountId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProcedureBloodCount indicates an expected call of DeleteProcedureBloodCount.
-func (mr *MockProcedureBloodCountMockRecorder) DeleteProcedureBloodCount(procedureId, bloodCountId any) *gomock.Call {
+func (mr *MockProcedureBloodCountMockRecorder) DeleteProcedureBloodCount(user, procedureId, bloodCountId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).DeleteProcedureBloodCount), procedureId, bloodCountId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).DeleteProcedureBloodCount), user, procedureId, bloodCountId)
 }
 
 // GetProcedureBloodCountById mocks base method.
-func (m *MockProcedureBloodCount) GetProcedureBloodCountById(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error) {
+func (m *MockProcedureBloodCount) GetProcedureBloodCountById(user services.UserData, procedureId int, bloodCountId, unit string) (model.ProcedureBloodCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProcedureBloodCountById", procedureId, bloodCountId)
+	ret := m.ctrl.Call(m, "GetProcedureBloodCountById", user, procedureId, bloodCountId, unit)
 	ret0, _ := ret[0].(model.ProcedureBloodCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProcedureBloodCountById indicates an expected call of GetProcedureBloodCountById.
-func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountById(procedureId, bloodCountId any) *gomock.Call {
+func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountById(user, procedureId, bloodCountId, unit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountById", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountById), procedureId, bloodCountId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountById", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountById), user, procedureId, bloodCountId, unit)
 }
 
 // GetProcedureBloodCountList mocks base method.
-func (m *MockProcedureBloodCount) GetProcedureBloodCountList() ([]model.ProcedureBloodCount, error) {
+func (m *MockProcedureBloodCount) GetProcedureBloodCountList(user services.UserData, filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, unit string) (model.Page[model.ProcedureBloodCount], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProcedureBloodCountList")
-	ret0, _ := ret[0].([]model.ProcedureBloodCount)
+	ret := m.ctrl.Call(m, "GetProcedureBloodCountList", user, filter, listQuery, unit)
+	ret0, _ := ret[0].(model.Page[model.ProcedureBloodCount])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProcedureBloodCountList indicates an expected call of GetProcedureBloodCountList.
-func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountList() *gomock.Call {
+func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountList(user, filter, listQuery, unit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountList", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountList", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountList), user, filter, listQuery, unit)
 }
 
-// GetProcedureBloodCountListByBloodCount mocks base method.
-func (m *MockProcedureBloodCount) GetProcedureBloodCountListByBloodCount(bloodCountId string) ([]model.ProcedureBloodCount, error) {
+// ImportProcedureBloodCounts mocks base method.
+func (m *MockProcedureBloodCount) ImportProcedureBloodCounts(user services.UserData, rows [][]string, mapping model.ImportMapping, dryRun bool) (model.ImportReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProcedureBloodCountListByBloodCount", bloodCountId)
-	ret0, _ := ret[0].([]model.ProcedureBloodCount)
+	ret := m.ctrl.Call(m, "ImportProcedureBloodCounts", user, rows, mapping, dryRun)
+	ret0, _ := ret[0].(model.ImportReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProcedureBloodCountListByBloodCount indicates an expected call of GetProcedureBloodCountListByBloodCount.
-func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountListByBloodCount(bloodCountId any) *gomock.Call {
+// ImportProcedureBloodCounts indicates an expected call of ImportProcedureBloodCounts.
+func (mr *MockProcedureBloodCountMockRecorder) ImportProcedureBloodCounts(user, rows, mapping, dryRun any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountListByBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountListByBloodCount), bloodCountId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProcedureBloodCounts", reflect.TypeOf((*MockProcedureBloodCount)(nil).ImportProcedureBloodCounts), user, rows, mapping, dryRun)
 }
 
-// GetProcedureBloodCountListByProcedure mocks base method.
-func (m *MockProcedureBloodCount) GetProcedureBloodCountListByProcedure(procedureId int) ([]model.ProcedureBloodCount, error) {
+// UpdateProcedureBloodCount mocks base method.
+func (m *MockProcedureBloodCount) UpdateProcedureBloodCount(user services.UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProcedureBloodCountListByProcedure", procedureId)
-	ret0, _ := ret[0].([]model.ProcedureBloodCount)
+	ret := m.ctrl.Call(m, "UpdateProcedureBloodCount", user, procedureBloodCount)
+	ret0, _ := ret[0].(model.ProcedureBloodCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProcedureBloodCountListByProcedure indicates an expected call of GetProcedureBloodCountListByProcedure.
-func (mr *MockProcedureBloodCountMockRecorder) GetProcedureBloodCountListByProcedure(procedureId any) *gomock.Call {
+// UpdateProcedureBloodCount indicates an expected call of UpdateProcedureBloodCount.
+func (mr *MockProcedureBloodCountMockRecorder) UpdateProcedureBloodCount(user, procedureBloodCount any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcedureBloodCountListByProcedure", reflect.TypeOf((*MockProcedureBloodCount)(nil).GetProcedureBloodCountListByProcedure), procedureId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).UpdateProcedureBloodCount), user, procedureBloodCount)
 }
 
-// UpdateProcedureBloodCount mocks base method.
-func (m *MockProcedureBloodCount) UpdateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
+// MockRegistration is a mock of Registration interface.
+type MockRegistration struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrationMockRecorder
+}
+
+// MockRegistrationMockRecorder is the mock recorder for MockRegistration.
+type MockRegistrationMockRecorder struct {
+	mock *MockRegistration
+}
+
+// NewMockRegistration creates a new mock instance.
+func NewMockRegistration(ctrl *gomock.Controller) *MockRegistration {
+	mock := &MockRegistration{ctrl: ctrl}
+	mock.recorder = &MockRegistrationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistration) EXPECT() *MockRegistrationMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockRegistration) AcceptInvitation(acceptance model.InvitationAcceptance) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProcedureBloodCount", procedureBloodCount)
-	ret0, _ := ret[0].(model.ProcedureBloodCount)
+	ret := m.ctrl.Call(m, "AcceptInvitation", acceptance)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateProcedureBloodCount indicates an expected call of UpdateProcedureBloodCount.
-func (mr *MockProcedureBloodCountMockRecorder) UpdateProcedureBloodCount(procedureBloodCount any) *gomock.Call {
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockRegistrationMockRecorder) AcceptInvitation(acceptance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockRegistration)(nil).AcceptInvitation), acceptance)
+}
+
+// InviteUser mocks base method.
+func (m *MockRegistration) InviteUser(user services.UserData, invitation model.Invitation) (model.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteUser", user, invitation)
+	ret0, _ := ret[0].(model.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteUser indicates an expected call of InviteUser.
+func (mr *MockRegistrationMockRecorder) InviteUser(user, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockRegistration)(nil).InviteUser), user, invitation)
+}
+
+// IssueRegistrationCode mocks base method.
+func (m *MockRegistration) IssueRegistrationCode(user services.UserData, patientId int) (model.RegistrationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRegistrationCode", user, patientId)
+	ret0, _ := ret[0].(model.RegistrationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRegistrationCode indicates an expected call of IssueRegistrationCode.
+func (mr *MockRegistrationMockRecorder) IssueRegistrationCode(user, patientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRegistrationCode", reflect.TypeOf((*MockRegistration)(nil).IssueRegistrationCode), user, patientId)
+}
+
+// RegisterPatient mocks base method.
+func (m *MockRegistration) RegisterPatient(registration model.PatientRegistration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPatient", registration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterPatient indicates an expected call of RegisterPatient.
+func (mr *MockRegistrationMockRecorder) RegisterPatient(registration any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProcedureBloodCount", reflect.TypeOf((*MockProcedureBloodCount)(nil).UpdateProcedureBloodCount), procedureBloodCount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPatient", reflect.TypeOf((*MockRegistration)(nil).RegisterPatient), registration)
+}
+
+// MockTimeline is a mock of Timeline interface.
+type MockTimeline struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimelineMockRecorder
+}
+
+// MockTimelineMockRecorder is the mock recorder for MockTimeline.
+type MockTimelineMockRecorder struct {
+	mock *MockTimeline
+}
+
+// NewMockTimeline creates a new mock instance.
+func NewMockTimeline(ctrl *gomock.Controller) *MockTimeline {
+	mock := &MockTimeline{ctrl: ctrl}
+	mock.recorder = &MockTimelineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeline) EXPECT() *MockTimelineMockRecorder {
+	return m.recorder
+}
+
+// GetPatientTimeline mocks base method.
+func (m *MockTimeline) GetPatientTimeline(user services.UserData, patientId int) ([]model.TimelineEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientTimeline", user, patientId)
+	ret0, _ := ret[0].([]model.TimelineEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientTimeline indicates an expected call of GetPatientTimeline.
+func (mr *MockTimelineMockRecorder) GetPatientTimeline(user, patientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientTimeline", reflect.TypeOf((*MockTimeline)(nil).GetPatientTimeline), user, patientId)
+}
+
+// MockTrend is a mock of Trend interface.
+type MockTrend struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrendMockRecorder
+}
+
+// MockTrendMockRecorder is the mock recorder for MockTrend.
+type MockTrendMockRecorder struct {
+	mock *MockTrend
+}
+
+// NewMockTrend creates a new mock instance.
+func NewMockTrend(ctrl *gomock.Controller) *MockTrend {
+	mock := &MockTrend{ctrl: ctrl}
+	mock.recorder = &MockTrendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrend) EXPECT() *MockTrendMockRecorder {
+	return m.recorder
+}
+
+// GetPatientTrend mocks base method.
+func (m *MockTrend) GetPatientTrend(user services.UserData, patientId int, query model.TrendQuery) (model.Trend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientTrend", user, patientId, query)
+	ret0, _ := ret[0].(model.Trend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientTrend indicates an expected call of GetPatientTrend.
+func (mr *MockTrendMockRecorder) GetPatientTrend(user, patientId, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientTrend", reflect.TypeOf((*MockTrend)(nil).GetPatientTrend), user, patientId, query)
+}
+
+// MockUnitConversion is a mock of UnitConversion interface.
+type MockUnitConversion struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitConversionMockRecorder
+}
+
+// MockUnitConversionMockRecorder is the mock recorder for MockUnitConversion.
+type MockUnitConversionMockRecorder struct {
+	mock *MockUnitConversion
+}
+
+// NewMockUnitConversion creates a new mock instance.
+func NewMockUnitConversion(ctrl *gomock.Controller) *MockUnitConversion {
+	mock := &MockUnitConversion{ctrl: ctrl}
+	mock.recorder = &MockUnitConversionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnitConversion) EXPECT() *MockUnitConversionMockRecorder {
+	return m.recorder
+}
+
+// ConvertValue mocks base method.
+func (m *MockUnitConversion) ConvertValue(value float64, from, to string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertValue", value, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertValue indicates an expected call of ConvertValue.
+func (mr *MockUnitConversionMockRecorder) ConvertValue(value, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertValue", reflect.TypeOf((*MockUnitConversion)(nil).ConvertValue), value, from, to)
+}
+
+// CreateUnitConversion mocks base method.
+func (m *MockUnitConversion) CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUnitConversion", unitConversion)
+	ret0, _ := ret[0].(model.UnitConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUnitConversion indicates an expected call of CreateUnitConversion.
+func (mr *MockUnitConversionMockRecorder) CreateUnitConversion(unitConversion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnitConversion", reflect.TypeOf((*MockUnitConversion)(nil).CreateUnitConversion), unitConversion)
+}
+
+// DeleteUnitConversion mocks base method.
+func (m *MockUnitConversion) DeleteUnitConversion(from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnitConversion", from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnitConversion indicates an expected call of DeleteUnitConversion.
+func (mr *MockUnitConversionMockRecorder) DeleteUnitConversion(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnitConversion", reflect.TypeOf((*MockUnitConversion)(nil).DeleteUnitConversion), from, to)
+}
+
+// GetUnitConversionList mocks base method.
+func (m *MockUnitConversion) GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnitConversionList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.UnitConversion])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnitConversionList indicates an expected call of GetUnitConversionList.
+func (mr *MockUnitConversionMockRecorder) GetUnitConversionList(listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitConversionList", reflect.TypeOf((*MockUnitConversion)(nil).GetUnitConversionList), listQuery)
 }
 
 // MockUnitMeasure is a mock of UnitMeasure interface.
@@ -1497,18 +2759,18 @@ func (mr *MockUnitMeasureMockRecorder) GetUnitMeasureById(id any) *gomock.Call {
 }
 
 // GetUnitMeasureList mocks base method.
-func (m *MockUnitMeasure) GetUnitMeasureList() ([]model.UnitMeasure, error) {
+func (m *MockUnitMeasure) GetUnitMeasureList(listQuery model.ListQuery) (model.Page[model.UnitMeasure], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnitMeasureList")
-	ret0, _ := ret[0].([]model.UnitMeasure)
+	ret := m.ctrl.Call(m, "GetUnitMeasureList", listQuery)
+	ret0, _ := ret[0].(model.Page[model.UnitMeasure])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUnitMeasureList indicates an expected call of GetUnitMeasureList.
-func (mr *MockUnitMeasureMockRecorder) GetUnitMeasureList() *gomock.Call {
+func (mr *MockUnitMeasureMockRecorder) GetUnitMeasureList(listQuery any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitMeasureList", reflect.TypeOf((*MockUnitMeasure)(nil).GetUnitMeasureList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitMeasureList", reflect.TypeOf((*MockUnitMeasure)(nil).GetUnitMeasureList), listQuery)
 }
 
 // UpdateUnitMeasure mocks base method.
@@ -1525,3 +2787,86 @@ func (mr *MockUnitMeasureMockRecorder) UpdateUnitMeasure(unitMeasure any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUnitMeasure", reflect.TypeOf((*MockUnitMeasure)(nil).UpdateUnitMeasure), unitMeasure)
 }
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// CreateStaffUser mocks base method.
+func (m *MockUser) CreateStaffUser(staffUser model.StaffUser) (model.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStaffUser", staffUser)
+	ret0, _ := ret[0].(model.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStaffUser indicates an expected call of CreateStaffUser.
+func (mr *MockUserMockRecorder) CreateStaffUser(staffUser any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStaffUser", reflect.TypeOf((*MockUser)(nil).CreateStaffUser), staffUser)
+}
+
+// GetUserById mocks base method.
+func (m *MockUser) GetUserById(id int) (model.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", id)
+	ret0, _ := ret[0].(model.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUserMockRecorder) GetUserById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUser)(nil).GetUserById), id)
+}
+
+// GetUserList mocks base method.
+func (m *MockUser) GetUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserList", filter, listQuery)
+	ret0, _ := ret[0].(model.Page[model.UserProfile])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserList indicates an expected call of GetUserList.
+func (mr *MockUserMockRecorder) GetUserList(filter, listQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserList", reflect.TypeOf((*MockUser)(nil).GetUserList), filter, listQuery)
+}
+
+// MigrateLegacyUsers mocks base method.
+func (m *MockUser) MigrateLegacyUsers() (model.LegacyUserMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateLegacyUsers")
+	ret0, _ := ret[0].(model.LegacyUserMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateLegacyUsers indicates an expected call of MigrateLegacyUsers.
+func (mr *MockUserMockRecorder) MigrateLegacyUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateLegacyUsers", reflect.TypeOf((*MockUser)(nil).MigrateLegacyUsers))
+}
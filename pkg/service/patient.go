@@ -3,28 +3,96 @@ package services
 import (
 	"med/pkg/model"
 	"med/pkg/repository"
+	"strconv"
 )
 
 type PatientService struct {
-	repo repository.Patient
+	repo   repository.Patient
+	access Access
+	tx     repository.Transaction
 }
 
-func NewPatientService(repo repository.Patient) *PatientService {
-	return &PatientService{repo: repo}
+func NewPatientService(repo repository.Patient, access Access, tx repository.Transaction) *PatientService {
+	return &PatientService{repo: repo, access: access, tx: tx}
 }
 
-func (s *PatientService) CreatePatient(patient model.Patient) (model.Patient, error) {
-	return s.repo.CreatePatient(patient)
+// CreatePatient creates the patient record. A doctor who creates a patient gets the patient on their panel,
+// so the doctor keeps access to the record.
+func (s *PatientService) CreatePatient(user UserData, patient model.Patient) (model.Patient, error) {
+	var createdPatient model.Patient
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if createdPatient, err = repos.Patient.CreatePatient(patient); err != nil {
+			return err
+		}
+		if user.Role == model.DoctorRole {
+			if err := repos.DoctorPatient.CreateDoctorPatientByUser(user.Id, createdPatient.Id); err != nil {
+				return err
+			}
+		}
+		return recordAudit(repos.Audit, user, model.CreateAction, model.PatientResource, strconv.Itoa(createdPatient.Id), nil, createdPatient)
+	})
+	if err != nil {
+		return model.Patient{}, err
+	}
+	return createdPatient, nil
 }
-func (s *PatientService) GetPatientById(id int) (model.Patient, error) {
+func (s *PatientService) GetPatientById(user UserData, id int) (model.Patient, error) {
+	if err := s.access.CheckPatientAccess(user, id); err != nil {
+		return model.Patient{}, err
+	}
 	return s.repo.GetPatientById(id)
 }
-func (s *PatientService) GetPatientList() ([]model.Patient, error) {
-	return s.repo.GetPatientList()
+func (s *PatientService) GetPatientList(user UserData, filter model.PatientFilter, listQuery model.ListQuery) (model.Page[model.Patient], error) {
+	patientIds, err := accessiblePatientIds(s.access, user)
+	if err != nil {
+		return model.Page[model.Patient]{}, err
+	}
+	return s.repo.GetPatientList(filter, listQuery, patientIds)
 }
-func (s *PatientService) UpdatePatient(patient model.Patient) (model.Patient, error) {
-	return s.repo.UpdatePatient(patient)
+
+// SearchPatientList searches the patients visible to the user, so doctors only find the patients of their panel.
+func (s *PatientService) SearchPatientList(user UserData, search model.PatientSearch) ([]model.PatientSearchResult, error) {
+	patientIds, err := accessiblePatientIds(s.access, user)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SearchPatientList(search, patientIds)
+}
+func (s *PatientService) UpdatePatient(user UserData, patient model.Patient) (model.Patient, error) {
+	if err := s.access.CheckPatientAccess(user, patient.Id); err != nil {
+		return model.Patient{}, err
+	}
+
+	var updatedPatient model.Patient
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		patientBefore, err := repos.Patient.GetPatientByIdForUpdate(patient.Id)
+		if err != nil {
+			return err
+		}
+		if updatedPatient, err = repos.Patient.UpdatePatient(patient); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.UpdateAction, model.PatientResource, strconv.Itoa(patient.Id), patientBefore, updatedPatient)
+	})
+	if err != nil {
+		return model.Patient{}, err
+	}
+	return updatedPatient, nil
 }
-func (s *PatientService) DeletePatient(id int) error {
-	return s.repo.DeletePatient(id)
+func (s *PatientService) DeletePatient(user UserData, id int) error {
+	if err := s.access.CheckPatientAccess(user, id); err != nil {
+		return err
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		patient, err := repos.Patient.GetPatientByIdForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Patient.DeletePatient(id); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.DeleteAction, model.PatientResource, strconv.Itoa(id), patient, nil)
+	})
 }
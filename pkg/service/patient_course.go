@@ -3,28 +3,88 @@ package services
 import (
 	"med/pkg/model"
 	"med/pkg/repository"
+	"strconv"
 )
 
 type PatientCourseService struct {
-	repo repository.PatientCourse
+	repo   repository.PatientCourse
+	access Access
+	tx     repository.Transaction
 }
 
-func NewPatientCourseService(repo repository.PatientCourse) *PatientCourseService {
-	return &PatientCourseService{repo: repo}
+func NewPatientCourseService(repo repository.PatientCourse, access Access, tx repository.Transaction) *PatientCourseService {
+	return &PatientCourseService{repo: repo, access: access, tx: tx}
 }
 
-func (s *PatientCourseService) CreatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error) {
-	return s.repo.CreatePatientCourse(patientCourse)
+func (s *PatientCourseService) CreatePatientCourse(user UserData, patientCourse model.PatientCourse) (model.PatientCourse, error) {
+	if err := s.access.CheckPatientAccess(user, patientCourse.Patient); err != nil {
+		return model.PatientCourse{}, err
+	}
+
+	var createdPatientCourse model.PatientCourse
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if createdPatientCourse, err = repos.PatientCourse.CreatePatientCourse(patientCourse); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.CreateAction, model.PatientCourseResource, strconv.Itoa(createdPatientCourse.Id), nil, createdPatientCourse)
+	})
+	if err != nil {
+		return model.PatientCourse{}, err
+	}
+	return createdPatientCourse, nil
 }
-func (s *PatientCourseService) GetPatientCourseById(id int) (model.PatientCourse, error) {
+func (s *PatientCourseService) GetPatientCourseById(user UserData, id int) (model.PatientCourse, error) {
+	if err := s.access.CheckPatientCourseAccess(user, id); err != nil {
+		return model.PatientCourse{}, err
+	}
 	return s.repo.GetPatientCourseById(id)
 }
-func (s *PatientCourseService) GetPatientCourseList() ([]model.PatientCourse, error) {
-	return s.repo.GetPatientCourseList()
+func (s *PatientCourseService) GetPatientCourseList(user UserData, filter model.PatientCourseFilter, listQuery model.ListQuery) (model.Page[model.PatientCourse], error) {
+	patientIds, err := accessiblePatientIds(s.access, user)
+	if err != nil {
+		return model.Page[model.PatientCourse]{}, err
+	}
+	return s.repo.GetPatientCourseList(filter, listQuery, patientIds)
 }
-func (s *PatientCourseService) UpdatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error) {
-	return s.repo.UpdatePatientCourse(patientCourse)
+func (s *PatientCourseService) UpdatePatientCourse(user UserData, patientCourse model.PatientCourse) (model.PatientCourse, error) {
+	// The course must be accessible both before and after moving it to another patient
+	if err := s.access.CheckPatientCourseAccess(user, patientCourse.Id); err != nil {
+		return model.PatientCourse{}, err
+	}
+	if err := s.access.CheckPatientAccess(user, patientCourse.Patient); err != nil {
+		return model.PatientCourse{}, err
+	}
+
+	var updatedPatientCourse model.PatientCourse
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		patientCourseBefore, err := repos.PatientCourse.GetPatientCourseByIdForUpdate(patientCourse.Id)
+		if err != nil {
+			return err
+		}
+		if updatedPatientCourse, err = repos.PatientCourse.UpdatePatientCourse(patientCourse); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.UpdateAction, model.PatientCourseResource, strconv.Itoa(patientCourse.Id), patientCourseBefore, updatedPatientCourse)
+	})
+	if err != nil {
+		return model.PatientCourse{}, err
+	}
+	return updatedPatientCourse, nil
 }
-func (s *PatientCourseService) DeletePatientCourse(id int) error {
-	return s.repo.DeletePatientCourse(id)
+func (s *PatientCourseService) DeletePatientCourse(user UserData, id int) error {
+	if err := s.access.CheckPatientCourseAccess(user, id); err != nil {
+		return err
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		patientCourse, err := repos.PatientCourse.GetPatientCourseByIdForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if err := repos.PatientCourse.DeletePatientCourse(id); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.DeleteAction, model.PatientCourseResource, strconv.Itoa(id), patientCourse, nil)
+	})
 }
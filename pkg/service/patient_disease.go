@@ -1,36 +1,100 @@
 package services
 
 import (
+	"fmt"
 	"med/pkg/model"
 	"med/pkg/repository"
 )
 
 type PatientDiseaseService struct {
-	repo repository.PatientDisease
+	repo   repository.PatientDisease
+	access Access
+	tx     repository.Transaction
 }
 
-func NewPatientDiseaseService(repo repository.PatientDisease) *PatientDiseaseService {
-	return &PatientDiseaseService{repo: repo}
+func NewPatientDiseaseService(repo repository.PatientDisease, access Access, tx repository.Transaction) *PatientDiseaseService {
+	return &PatientDiseaseService{repo: repo, access: access, tx: tx}
 }
 
-func (s *PatientDiseaseService) CreatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error) {
-	return s.repo.CreatePatientDisease(patientDisease)
-}
-func (s *PatientDiseaseService) GetPatientDiseaseById(diseaseId, patientId int) (model.PatientDisease, error) {
-	return s.repo.GetPatientDiseaseById(diseaseId, patientId)
+func (s *PatientDiseaseService) CreatePatientDisease(user UserData, patientDisease model.PatientDisease) (model.PatientDisease, error) {
+	if err := s.access.CheckPatientAccess(user, patientDisease.Patient); err != nil {
+		return model.PatientDisease{}, err
+	}
+
+	var createdPatientDisease model.PatientDisease
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if createdPatientDisease, err = repos.PatientDisease.CreatePatientDisease(patientDisease); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.CreateAction, model.PatientDiseaseResource,
+			patientDiseaseEntityId(createdPatientDisease.Patient, createdPatientDisease.Disease), nil, createdPatientDisease)
+	})
+	if err != nil {
+		return model.PatientDisease{}, err
+	}
+	return createdPatientDisease, nil
 }
-func (s *PatientDiseaseService) GetPatientDiseaseListByDisease(diseaseId int) ([]model.PatientDisease, error) {
-	return s.repo.GetPatientDiseaseListByDisease(diseaseId)
+func (s *PatientDiseaseService) GetPatientDiseaseById(user UserData, patientId int, diseaseId string) (model.PatientDisease, error) {
+	if err := s.access.CheckPatientAccess(user, patientId); err != nil {
+		return model.PatientDisease{}, err
+	}
+	return s.repo.GetPatientDiseaseById(patientId, diseaseId)
 }
-func (s *PatientDiseaseService) GetPatientDiseaseListByPatient(patientId int) ([]model.PatientDisease, error) {
-	return s.repo.GetPatientDiseaseListByPatient(patientId)
+func (s *PatientDiseaseService) GetPatientDiseaseList(user UserData, filter model.PatientDiseaseFilter, listQuery model.ListQuery) (model.Page[model.PatientDisease], error) {
+	// The diseases of a patient the user has no access to are forbidden rather than an empty list
+	if filter.Patient != nil {
+		if err := s.access.CheckPatientAccess(user, *filter.Patient); err != nil {
+			return model.Page[model.PatientDisease]{}, err
+		}
+	}
+	patientIds, err := accessiblePatientIds(s.access, user)
+	if err != nil {
+		return model.Page[model.PatientDisease]{}, err
+	}
+	return s.repo.GetPatientDiseaseList(filter, listQuery, patientIds)
 }
-func (s *PatientDiseaseService) GetPatientDiseaseList() ([]model.PatientDisease, error) {
-	return s.repo.GetPatientDiseaseList()
+func (s *PatientDiseaseService) UpdatePatientDisease(user UserData, patientDisease model.PatientDisease) (model.PatientDisease, error) {
+	if err := s.access.CheckPatientAccess(user, patientDisease.Patient); err != nil {
+		return model.PatientDisease{}, err
+	}
+
+	var updatedPatientDisease model.PatientDisease
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		patientDiseaseBefore, err := repos.PatientDisease.GetPatientDiseaseByIdForUpdate(patientDisease.Patient, patientDisease.Disease)
+		if err != nil {
+			return err
+		}
+		if updatedPatientDisease, err = repos.PatientDisease.UpdatePatientDisease(patientDisease); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.UpdateAction, model.PatientDiseaseResource,
+			patientDiseaseEntityId(patientDisease.Patient, patientDisease.Disease), patientDiseaseBefore, updatedPatientDisease)
+	})
+	if err != nil {
+		return model.PatientDisease{}, err
+	}
+	return updatedPatientDisease, nil
 }
-func (s *PatientDiseaseService) UpdatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error) {
-	return s.repo.UpdatePatientDisease(patientDisease)
+func (s *PatientDiseaseService) DeletePatientDisease(user UserData, patientId int, diseaseId string) error {
+	if err := s.access.CheckPatientAccess(user, patientId); err != nil {
+		return err
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		patientDisease, err := repos.PatientDisease.GetPatientDiseaseByIdForUpdate(patientId, diseaseId)
+		if err != nil {
+			return err
+		}
+		if err := repos.PatientDisease.DeletePatientDisease(patientId, diseaseId); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.DeleteAction, model.PatientDiseaseResource,
+			patientDiseaseEntityId(patientId, diseaseId), patientDisease, nil)
+	})
 }
-func (s *PatientDiseaseService) DeletePatientDisease(diseaseId, patientId int) error {
-	return s.repo.DeletePatientDisease(diseaseId, patientId)
+
+// patientDiseaseEntityId is the audit log ID of a patient disease, made of the patient and disease IDs.
+func patientDiseaseEntityId(patientId int, diseaseId string) string {
+	return fmt.Sprintf("%d/%s", patientId, diseaseId)
 }
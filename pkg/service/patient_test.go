@@ -0,0 +1,56 @@
+package services
+
+import (
+	"med/pkg/model"
+	"med/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// panelRepository keeps the patients added to the panels of the doctor users
+type panelRepository struct {
+	repository.DoctorPatient
+	links map[int][]int
+}
+
+func (r *panelRepository) CreateDoctorPatientByUser(userId, patientId int) error {
+	if r.links == nil {
+		r.links = map[int][]int{}
+	}
+	r.links[userId] = append(r.links[userId], patientId)
+	return nil
+}
+
+func TestCreatePatientPanel(t *testing.T) {
+	testTable := []struct {
+		name          string
+		user          UserData
+		expectedLinks map[int][]int
+	}{
+		{
+			name:          "Doctor",
+			user:          UserData{Id: 7, Role: model.DoctorRole},
+			expectedLinks: map[int][]int{7: {1}},
+		},
+		{
+			name: "Admin",
+			user: UserData{Id: 1, Role: model.AdminRole},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &auditPatientRepository{patients: map[int]model.Patient{}}
+			panel := &panelRepository{}
+			tx := &auditTransaction{repos: repository.Repository{Patient: repo, DoctorPatient: panel}}
+			service := NewPatientService(repo, auditAccess{}, tx)
+
+			createdPatient, err := service.CreatePatient(testCase.user, model.Patient{FirstName: "Petr", LastName: "Petrov", Sex: "м"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, createdPatient.Id)
+			assert.Equal(t, testCase.expectedLinks, panel.links)
+		})
+	}
+}
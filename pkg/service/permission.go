@@ -0,0 +1,132 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrInvalidPermission is returned when a permission references an unknown role, resource or action.
+	ErrInvalidPermission = errors.New("invalid permission")
+	// ErrPermissionExists is returned when granting a permission the role already has.
+	ErrPermissionExists = errors.New("permission already exists")
+)
+
+// PermissionService keeps the role permission matrix cached in memory.
+// The matrix is loaded from the repository on first use and updated on every change.
+type PermissionService struct {
+	repo        repository.Permission
+	mu          sync.RWMutex
+	permissions map[model.Permission]struct{}
+}
+
+func NewPermissionService(repo repository.Permission) *PermissionService {
+	return &PermissionService{repo: repo}
+}
+
+// HasPermission reports whether the role is allowed to perform the action on the resource.
+func (s *PermissionService) HasPermission(role, resource, action string) (bool, error) {
+	if err := s.load(); err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.permissions[model.Permission{Role: role, Resource: resource, Action: action}]
+	return ok, nil
+}
+
+func (s *PermissionService) GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error) {
+	return s.repo.GetPermissionList(filter, listQuery)
+}
+
+func (s *PermissionService) CreatePermission(permission model.Permission) (model.Permission, error) {
+	if err := validatePermission(permission); err != nil {
+		return model.Permission{}, err
+	}
+	if err := s.load(); err != nil {
+		return model.Permission{}, err
+	}
+
+	createdPermission, err := s.repo.CreatePermission(permission)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The permission may have been granted by another instance, the cache has to know it anyway
+		s.mu.Lock()
+		s.permissions[permission] = struct{}{}
+		s.mu.Unlock()
+		return model.Permission{}, fmt.Errorf("%w: %s may already %s %s", ErrPermissionExists, permission.Role, permission.Action, permission.Resource)
+	}
+	if err != nil {
+		return createdPermission, err
+	}
+
+	s.mu.Lock()
+	s.permissions[createdPermission] = struct{}{}
+	s.mu.Unlock()
+	return createdPermission, nil
+}
+
+func (s *PermissionService) DeletePermission(permission model.Permission) error {
+	if err := validatePermission(permission); err != nil {
+		return err
+	}
+	// Admins must keep access to the matrix itself, otherwise nobody could repair it.
+	if permission.Role == model.AdminRole && permission.Resource == model.PermissionResource {
+		return fmt.Errorf("%w: admin permissions on %s can not be revoked", ErrInvalidPermission, model.PermissionResource)
+	}
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePermission(permission); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.permissions, permission)
+	s.mu.Unlock()
+	return nil
+}
+
+// load reads the permission matrix from the repository unless it is already cached.
+func (s *PermissionService) load() error {
+	s.mu.RLock()
+	loaded := s.permissions != nil
+	s.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	permissionList, err := s.repo.GetAllPermissions()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.permissions == nil {
+		s.permissions = make(map[model.Permission]struct{}, len(permissionList))
+		for _, permission := range permissionList {
+			s.permissions[permission] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func validatePermission(permission model.Permission) error {
+	if !slices.Contains(model.Roles, permission.Role) {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidPermission, permission.Role)
+	}
+	if !slices.Contains(model.Resources, permission.Resource) {
+		return fmt.Errorf("%w: unknown resource %q", ErrInvalidPermission, permission.Resource)
+	}
+	if !slices.Contains(model.Actions, permission.Action) {
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidPermission, permission.Action)
+	}
+	return nil
+}
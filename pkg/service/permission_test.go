@@ -0,0 +1,127 @@
+package services
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// permissionRepository keeps the permission matrix in memory and counts the loads of the whole matrix
+type permissionRepository struct {
+	permissions []model.Permission
+	loads       int
+}
+
+func (r *permissionRepository) CreatePermission(permission model.Permission) (model.Permission, error) {
+	if slices.Contains(r.permissions, permission) {
+		return model.Permission{}, sql.ErrNoRows
+	}
+	r.permissions = append(r.permissions, permission)
+	return permission, nil
+}
+
+func (r *permissionRepository) GetAllPermissions() ([]model.Permission, error) {
+	r.loads++
+	return slices.Clone(r.permissions), nil
+}
+
+func (r *permissionRepository) GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error) {
+	return model.Page[model.Permission]{Items: r.permissions}, nil
+}
+
+func (r *permissionRepository) DeletePermission(permission model.Permission) error {
+	r.permissions = slices.DeleteFunc(r.permissions, func(p model.Permission) bool { return p == permission })
+	return nil
+}
+
+func TestPermissionCache(t *testing.T) {
+	readDrug := model.Permission{Role: model.DoctorRole, Resource: model.DrugResource, Action: model.ReadAction}
+	deleteDrug := model.Permission{Role: model.DoctorRole, Resource: model.DrugResource, Action: model.DeleteAction}
+	repo := &permissionRepository{permissions: []model.Permission{readDrug}}
+	service := NewPermissionService(repo)
+
+	hasPermission := func(permission model.Permission) bool {
+		allowed, err := service.HasPermission(permission.Role, permission.Resource, permission.Action)
+		assert.NoError(t, err)
+		return allowed
+	}
+
+	assert.True(t, hasPermission(readDrug))
+	assert.False(t, hasPermission(deleteDrug))
+	assert.False(t, hasPermission(model.Permission{Role: model.PatientRole, Resource: model.DrugResource, Action: model.ReadAction}))
+
+	// A grant is seen at once
+	createdPermission, err := service.CreatePermission(deleteDrug)
+	assert.NoError(t, err)
+	assert.Equal(t, deleteDrug, createdPermission)
+	assert.True(t, hasPermission(deleteDrug))
+
+	// So is a revoke
+	assert.NoError(t, service.DeletePermission(readDrug))
+	assert.False(t, hasPermission(readDrug))
+	assert.True(t, hasPermission(deleteDrug))
+
+	// The matrix is read once and kept in the cache afterwards
+	assert.Equal(t, 1, repo.loads)
+	assert.Equal(t, []model.Permission{deleteDrug}, repo.permissions)
+}
+
+func TestCreatePermission(t *testing.T) {
+	readDrug := model.Permission{Role: model.DoctorRole, Resource: model.DrugResource, Action: model.ReadAction}
+
+	testTable := []struct {
+		name          string
+		permission    model.Permission
+		expectedError error
+		expectedText  string
+	}{
+		{
+			name:          "Duplicate",
+			permission:    readDrug,
+			expectedError: ErrPermissionExists,
+			expectedText:  "permission already exists: doctor may already read drug",
+		},
+		{
+			name:          "Unknown role",
+			permission:    model.Permission{Role: "nurse", Resource: model.DrugResource, Action: model.ReadAction},
+			expectedError: ErrInvalidPermission,
+			expectedText:  `invalid permission: unknown role "nurse"`,
+		},
+		{
+			name:          "Unknown action",
+			permission:    model.Permission{Role: model.DoctorRole, Resource: model.DrugResource, Action: "approve"},
+			expectedError: ErrInvalidPermission,
+			expectedText:  `invalid permission: unknown action "approve"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &permissionRepository{permissions: []model.Permission{readDrug}}
+			service := NewPermissionService(repo)
+
+			_, err := service.CreatePermission(testCase.permission)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.EqualError(t, err, testCase.expectedText)
+			assert.Equal(t, []model.Permission{readDrug}, repo.permissions)
+		})
+	}
+}
+
+func TestDeleteAdminPermission(t *testing.T) {
+	managePermissions := model.Permission{Role: model.AdminRole, Resource: model.PermissionResource, Action: model.DeleteAction}
+	repo := &permissionRepository{permissions: []model.Permission{managePermissions}}
+	service := NewPermissionService(repo)
+
+	err := service.DeletePermission(managePermissions)
+
+	assert.ErrorIs(t, err, ErrInvalidPermission)
+	assert.Equal(t, []model.Permission{managePermissions}, repo.permissions)
+	allowed, err := service.HasPermission(model.AdminRole, model.PermissionResource, model.DeleteAction)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"fmt"
+	"med/pkg/model"
+	"sort"
+	"strings"
+)
+
+// minPercentileResults is the number of results a blood count bucket needs for its 5th and 95th percentiles
+// to have at least one result below and above them, so neither is the extreme result of a single patient
+const minPercentileResults = 20
+
+// anonymizeCohortStatistics keeps every reported group of the cohort at least k patients large.
+// A cohort smaller than k is suppressed as a whole, small sex and stage cells are merged into the other cell
+// and blood count buckets of fewer than k patients are suppressed. Ranges of the remaining buckets are trimmed
+// to the 5th and 95th percentiles, which are suppressed in buckets of fewer than minPercentileResults results.
+func anonymizeCohortStatistics(statistics *model.CohortStatistics, k int) model.PrivacyReport {
+	report := model.PrivacyReport{K: k, Suppressed: []model.PrivacySuppression{}}
+	if k <= 1 || statistics.Patients == 0 {
+		return report
+	}
+
+	if statistics.Patients < k {
+		statistics.Patients, statistics.Courses = 0, 0
+		statistics.Sex = []model.CohortCount{}
+		statistics.Stages = []model.CohortCount{}
+		statistics.BloodCounts = []model.CohortBloodCountStatistics{}
+		report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: "cohort", Action: model.SuppressedCell})
+		return report
+	}
+
+	statistics.Sex = mergeSmallCells(statistics.Sex, k, "sex", &report)
+	statistics.Stages = mergeSmallCells(statistics.Stages, k, "stage", &report)
+
+	bloodCounts := []model.CohortBloodCountStatistics{}
+	var rangeSuppressions []model.PrivacySuppression
+	for _, bucket := range statistics.BloodCounts {
+		value := fmt.Sprintf("%s days %d-%d", bucket.BloodCount, bucket.FromDay, bucket.ToDay)
+		if bucket.Patients < k {
+			report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: "blood-count", Value: value, Action: model.SuppressedCell})
+			continue
+		}
+		if bucket.Results < minPercentileResults {
+			bucket.P5, bucket.P95 = nil, nil
+			rangeSuppressions = append(rangeSuppressions, model.PrivacySuppression{Field: "blood-count-range", Value: value, Action: model.SuppressedCell})
+		}
+		bloodCounts = append(bloodCounts, bucket)
+	}
+	statistics.BloodCounts = bloodCounts
+	if len(bloodCounts) > 0 {
+		report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: "blood-count-range", Value: "p5-p95", Action: model.TrimmedRange})
+		report.Suppressed = append(report.Suppressed, rangeSuppressions...)
+	}
+
+	return report
+}
+
+// mergeSmallCells merges the cells of fewer than k patients into the other cell. While the other cell
+// is smaller than k the smallest remaining cell is merged too, so no small cell can be derived from the total.
+// The other cell is suppressed if all cells together are fewer than k patients.
+func mergeSmallCells(counts []model.CohortCount, k int, field string, report *model.PrivacyReport) []model.CohortCount {
+	bySize := make([]int, len(counts))
+	for i := range counts {
+		bySize[i] = i
+	}
+	sort.SliceStable(bySize, func(i, j int) bool { return counts[bySize[i]].Patients < counts[bySize[j]].Patients })
+
+	merged := map[int]bool{}
+	other := model.CohortCount{Value: model.OtherCell}
+	for _, i := range bySize {
+		if counts[i].Patients >= k && (other.Patients == 0 || other.Patients >= k) {
+			break
+		}
+		merged[i] = true
+		other.Patients += counts[i].Patients
+	}
+	if len(merged) == 0 {
+		return counts
+	}
+
+	kept := []model.CohortCount{}
+	for i, count := range counts {
+		if !merged[i] {
+			kept = append(kept, count)
+			continue
+		}
+		report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: field, Value: count.Value, Action: model.MergedCell})
+	}
+
+	if other.Patients < k {
+		report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: field, Value: model.OtherCell, Action: model.SuppressedCell})
+		return kept
+	}
+	return append(kept, other)
+}
+
+// anonymizeExport makes every patient of the export share the quasi-identifiers sex, birth date, disease and stage
+// with at least k-1 other patients. Values are suppressed step by step for the patients of smaller groups:
+// first the birth date, then the stages, then the sex. Patients whose group is still smaller are removed with their rows.
+func anonymizeExport(dataset *model.ExportDataset, k int) model.PrivacyReport {
+	report := model.PrivacyReport{K: k, Suppressed: []model.PrivacySuppression{}}
+	if k <= 1 {
+		return report
+	}
+
+	diseases := map[int][]int{}
+	for i, patientDisease := range dataset.PatientDiseases {
+		diseases[patientDisease.Patient] = append(diseases[patientDisease.Patient], i)
+	}
+
+	smallGroups := func() []int {
+		keys := make([]string, len(dataset.Patients))
+		sizes := map[string]int{}
+		for i, patient := range dataset.Patients {
+			var diseaseStages []string
+			for _, j := range diseases[patient.Id] {
+				diseaseStages = append(diseaseStages, dataset.PatientDiseases[j].Disease+":"+dataset.PatientDiseases[j].Stage)
+			}
+			sort.Strings(diseaseStages)
+			keys[i] = patient.Sex + "|" + patient.BirthDate + "|" + strings.Join(diseaseStages, ",")
+			sizes[keys[i]]++
+		}
+
+		var small []int
+		for i := range dataset.Patients {
+			if sizes[keys[i]] < k {
+				small = append(small, i)
+			}
+		}
+		return small
+	}
+
+	steps := []struct {
+		field    string
+		suppress func(patient *model.ExportPatient) int
+	}{
+		{field: "birth_date", suppress: func(patient *model.ExportPatient) int {
+			return suppressValue(&patient.BirthDate)
+		}},
+		{field: "stage", suppress: func(patient *model.ExportPatient) int {
+			rows := 0
+			for _, j := range diseases[patient.Id] {
+				rows += suppressValue(&dataset.PatientDiseases[j].Stage)
+			}
+			return rows
+		}},
+		{field: "sex", suppress: func(patient *model.ExportPatient) int {
+			return suppressValue(&patient.Sex)
+		}},
+	}
+
+	for _, step := range steps {
+		rows := 0
+		for _, i := range smallGroups() {
+			rows += step.suppress(&dataset.Patients[i])
+		}
+		if rows > 0 {
+			report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: step.field, Action: model.SuppressedCell, Rows: rows})
+		}
+	}
+
+	if small := smallGroups(); len(small) > 0 {
+		removed := map[int]bool{}
+		for _, i := range small {
+			removed[dataset.Patients[i].Id] = true
+		}
+		removePatients(dataset, removed)
+		report.Suppressed = append(report.Suppressed, model.PrivacySuppression{Field: "patient", Action: model.SuppressedCell, Rows: len(removed)})
+	}
+
+	return report
+}
+
+func suppressValue(value *string) int {
+	if *value == model.SuppressedValue {
+		return 0
+	}
+	*value = model.SuppressedValue
+	return 1
+}
+
+// removePatients removes the patients from the dataset with their diseases, the streamed rows of removed patients are skipped while written
+func removePatients(dataset *model.ExportDataset, removed map[int]bool) {
+	patients := dataset.Patients[:0]
+	for _, patient := range dataset.Patients {
+		if !removed[patient.Id] {
+			patients = append(patients, patient)
+		}
+	}
+	dataset.Patients = patients
+
+	patientDiseases := dataset.PatientDiseases[:0]
+	for _, patientDisease := range dataset.PatientDiseases {
+		if !removed[patientDisease.Patient] {
+			patientDiseases = append(patientDiseases, patientDisease)
+		}
+	}
+	dataset.PatientDiseases = patientDiseases
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSmallCells(t *testing.T) {
+	testTable := []struct {
+		name             string
+		counts           []model.CohortCount
+		expectedCounts   []model.CohortCount
+		expectedReported []model.PrivacySuppression
+	}{
+		{
+			name:           "Large cells",
+			counts:         []model.CohortCount{{Value: "female", Patients: 12}, {Value: "male", Patients: 7}},
+			expectedCounts: []model.CohortCount{{Value: "female", Patients: 12}, {Value: "male", Patients: 7}},
+		},
+		{
+			name:           "Small cells merged",
+			counts:         []model.CohortCount{{Value: "I", Patients: 3}, {Value: "II", Patients: 12}, {Value: "III", Patients: 2}},
+			expectedCounts: []model.CohortCount{{Value: "II", Patients: 12}, {Value: "other", Patients: 5}},
+			expectedReported: []model.PrivacySuppression{
+				{Field: "stage", Value: "I", Action: "merged"},
+				{Field: "stage", Value: "III", Action: "merged"},
+			},
+		},
+		{
+			name:           "Smallest large cell merged into small other",
+			counts:         []model.CohortCount{{Value: "I", Patients: 2}, {Value: "II", Patients: 12}, {Value: "III", Patients: 6}},
+			expectedCounts: []model.CohortCount{{Value: "II", Patients: 12}, {Value: "other", Patients: 8}},
+			expectedReported: []model.PrivacySuppression{
+				{Field: "stage", Value: "I", Action: "merged"},
+				{Field: "stage", Value: "III", Action: "merged"},
+			},
+		},
+		{
+			name:           "All small",
+			counts:         []model.CohortCount{{Value: "I", Patients: 1}, {Value: "II", Patients: 2}},
+			expectedCounts: []model.CohortCount{},
+			expectedReported: []model.PrivacySuppression{
+				{Field: "stage", Value: "I", Action: "merged"},
+				{Field: "stage", Value: "II", Action: "merged"},
+				{Field: "stage", Value: "other", Action: "suppressed"},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			report := model.PrivacyReport{K: 5}
+			counts := mergeSmallCells(testCase.counts, 5, "stage", &report)
+
+			assert.Equal(t, testCase.expectedCounts, counts)
+			assert.Equal(t, testCase.expectedReported, report.Suppressed)
+		})
+	}
+}
+
+func TestAnonymizeCohortStatistics(t *testing.T) {
+	t.Run("Small cohort", func(t *testing.T) {
+		statistics := model.CohortStatistics{
+			Patients: 3,
+			Courses:  4,
+			Sex:      []model.CohortCount{{Value: "female", Patients: 3}},
+		}
+
+		report := anonymizeCohortStatistics(&statistics, 5)
+
+		assert.Equal(t, 0, statistics.Patients)
+		assert.Equal(t, 0, statistics.Courses)
+		assert.Empty(t, statistics.Sex)
+		assert.Equal(t, []model.PrivacySuppression{{Field: "cohort", Action: "suppressed"}}, report.Suppressed)
+	})
+
+	t.Run("Small buckets", func(t *testing.T) {
+		statistics := model.CohortStatistics{
+			Patients: 20,
+			Sex:      []model.CohortCount{{Value: "female", Patients: 20}},
+			Stages:   []model.CohortCount{{Value: "II", Patients: 20}},
+			BloodCounts: []model.CohortBloodCountStatistics{
+				{BloodCount: "CA15-3", FromDay: 0, ToDay: 29, Patients: 18, Results: 25, P5: floatPointer(8.2), P95: floatPointer(41.5)},
+				{BloodCount: "CA15-3", FromDay: 30, ToDay: 59, Patients: 4, Results: 4, P5: floatPointer(9.1), P95: floatPointer(30.4)},
+				{BloodCount: "CA15-3", FromDay: 60, ToDay: 89, Patients: 6, Results: 12, P5: floatPointer(10.3), P95: floatPointer(28.8)},
+			},
+		}
+
+		report := anonymizeCohortStatistics(&statistics, 5)
+
+		assert.Equal(t, 20, statistics.Patients)
+		assert.Equal(t, []model.CohortBloodCountStatistics{
+			{BloodCount: "CA15-3", FromDay: 0, ToDay: 29, Patients: 18, Results: 25, P5: floatPointer(8.2), P95: floatPointer(41.5)},
+			{BloodCount: "CA15-3", FromDay: 60, ToDay: 89, Patients: 6, Results: 12},
+		}, statistics.BloodCounts)
+		assert.Equal(t, model.PrivacyReport{K: 5, Suppressed: []model.PrivacySuppression{
+			{Field: "blood-count", Value: "CA15-3 days 30-59", Action: "suppressed"},
+			{Field: "blood-count-range", Value: "p5-p95", Action: "trimmed"},
+			{Field: "blood-count-range", Value: "CA15-3 days 60-89", Action: "suppressed"},
+		}}, report)
+	})
+}
+
+func TestAnonymizeExport(t *testing.T) {
+	dataset := model.ExportDataset{
+		Patients: []model.ExportPatient{
+			{Id: 1, Sex: "female", BirthDate: "1970"},
+			{Id: 2, Sex: "female", BirthDate: "1970"},
+			{Id: 3, Sex: "female", BirthDate: "1971"},
+			{Id: 4, Sex: "female", BirthDate: "1972"},
+			{Id: 5, Sex: "male", BirthDate: "1980"},
+		},
+		PatientDiseases: []model.PatientDisease{
+			{Patient: 1, Disease: "C50", Stage: "II"},
+			{Patient: 2, Disease: "C50", Stage: "II"},
+			{Patient: 3, Disease: "C50", Stage: "II"},
+			{Patient: 4, Disease: "C50", Stage: "III"},
+			{Patient: 5, Disease: "C61", Stage: "I"},
+		},
+	}
+
+	report := anonymizeExport(&dataset, 2)
+
+	assert.Equal(t, []model.ExportPatient{
+		{Id: 1, Sex: "female", BirthDate: "1970"},
+		{Id: 2, Sex: "female", BirthDate: "1970"},
+		{Id: 3, Sex: "female", BirthDate: "*"},
+		{Id: 4, Sex: "female", BirthDate: "*"},
+	}, dataset.Patients)
+	assert.Equal(t, []model.PatientDisease{
+		{Patient: 1, Disease: "C50", Stage: "II"},
+		{Patient: 2, Disease: "C50", Stage: "II"},
+		{Patient: 3, Disease: "C50", Stage: "*"},
+		{Patient: 4, Disease: "C50", Stage: "*"},
+	}, dataset.PatientDiseases)
+	assert.Equal(t, model.PrivacyReport{K: 2, Suppressed: []model.PrivacySuppression{
+		{Field: "birth_date", Action: "suppressed", Rows: 3},
+		{Field: "stage", Action: "suppressed", Rows: 3},
+		{Field: "sex", Action: "suppressed", Rows: 1},
+		{Field: "patient", Action: "suppressed", Rows: 1},
+	}}, report)
+}
@@ -1,36 +1,235 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"med/pkg/model"
 	"med/pkg/repository"
 )
 
+// ErrValueOutOfRange is returned for a blood count result outside the possible range of the blood count.
+var ErrValueOutOfRange = errors.New("value is outside the possible range")
+
 type ProcedureBloodCountService struct {
-	repo repository.ProcedureBloodCount
+	repo           repository.ProcedureBloodCount
+	bloodCountRepo repository.BloodCount
+	conversions    repository.UnitConversion
+	access         Access
+	tx             repository.Transaction
 }
 
-func NewProcedureBloodCountService(repo repository.ProcedureBloodCount) *ProcedureBloodCountService {
-	return &ProcedureBloodCountService{repo: repo}
+func NewProcedureBloodCountService(repo repository.ProcedureBloodCount, bloodCountRepo repository.BloodCount,
+	conversions repository.UnitConversion, access Access, tx repository.Transaction) *ProcedureBloodCountService {
+	return &ProcedureBloodCountService{repo: repo, bloodCountRepo: bloodCountRepo, conversions: conversions, access: access, tx: tx}
 }
 
-func (s *ProcedureBloodCountService) CreateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
-	return s.repo.CreateProcedureBloodCount(procedureBloodCount)
+func (s *ProcedureBloodCountService) CreateProcedureBloodCount(user UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, procedureBloodCount.Procedure); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+	if err := s.normalize(&procedureBloodCount); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+
+	var createdProcedureBloodCount model.ProcedureBloodCount
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		var err error
+		if createdProcedureBloodCount, err = repos.ProcedureBloodCount.CreateProcedureBloodCount(procedureBloodCount); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.CreateAction, model.ProcedureBloodCountResource,
+			procedureBloodCountEntityId(createdProcedureBloodCount.Procedure, createdProcedureBloodCount.BloodCount), nil, createdProcedureBloodCount)
+	})
+	if err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+	return createdProcedureBloodCount, nil
 }
-func (s *ProcedureBloodCountService) GetProcedureBloodCountById(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error) {
-	return s.repo.GetProcedureBloodCountById(procedureId, bloodCountId)
+func (s *ProcedureBloodCountService) GetProcedureBloodCountById(user UserData, procedureId int, bloodCountId, unit string) (model.ProcedureBloodCount, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, procedureId); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+
+	procedureBloodCount, err := s.repo.GetProcedureBloodCountById(procedureId, bloodCountId)
+	if err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+	if err := s.inUnit(&procedureBloodCount, unit); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+	return procedureBloodCount, nil
 }
-func (s *ProcedureBloodCountService) GetProcedureBloodCountListByProcedure(procedureId int) ([]model.ProcedureBloodCount, error) {
-	return s.repo.GetProcedureBloodCountListByProcedure(procedureId)
+
+// GetProcedureBloodCountList lists the results of the patients the user has access to.
+// Filtering by a patient or procedure the user has no access to is forbidden rather than an empty list.
+func (s *ProcedureBloodCountService) GetProcedureBloodCountList(user UserData, filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, unit string) (model.Page[model.ProcedureBloodCount], error) {
+	if filter.Patient != nil {
+		if err := s.access.CheckPatientAccess(user, *filter.Patient); err != nil {
+			return model.Page[model.ProcedureBloodCount]{}, err
+		}
+	}
+	if filter.Procedure != nil {
+		if err := s.access.CheckCourseProcedureAccess(user, *filter.Procedure); err != nil {
+			return model.Page[model.ProcedureBloodCount]{}, err
+		}
+	}
+	patientIds, err := accessiblePatientIds(s.access, user)
+	if err != nil {
+		return model.Page[model.ProcedureBloodCount]{}, err
+	}
+
+	page, err := s.repo.GetProcedureBloodCountList(filter, listQuery, patientIds)
+	if err != nil {
+		return model.Page[model.ProcedureBloodCount]{}, err
+	}
+	return page, s.listInUnit(page.Items, unit)
 }
-func (s *ProcedureBloodCountService) GetProcedureBloodCountListByBloodCount(bloodCountId string) ([]model.ProcedureBloodCount, error) {
-	return s.repo.GetProcedureBloodCountListByBloodCount(bloodCountId)
+func (s *ProcedureBloodCountService) UpdateProcedureBloodCount(user UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
+	if err := s.access.CheckCourseProcedureAccess(user, procedureBloodCount.Procedure); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+
+	if err := s.normalize(&procedureBloodCount); err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+
+	var updatedProcedureBloodCount model.ProcedureBloodCount
+	err := s.tx.InTransaction(func(repos *repository.Repository) error {
+		procedureBloodCountBefore, err := repos.ProcedureBloodCount.GetProcedureBloodCountByIdForUpdate(procedureBloodCount.Procedure, procedureBloodCount.BloodCount)
+		if err != nil {
+			return err
+		}
+		if updatedProcedureBloodCount, err = repos.ProcedureBloodCount.UpdateProcedureBloodCount(procedureBloodCount); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.UpdateAction, model.ProcedureBloodCountResource,
+			procedureBloodCountEntityId(procedureBloodCount.Procedure, procedureBloodCount.BloodCount), procedureBloodCountBefore, updatedProcedureBloodCount)
+	})
+	if err != nil {
+		return model.ProcedureBloodCount{}, err
+	}
+	return updatedProcedureBloodCount, nil
 }
-func (s *ProcedureBloodCountService) GetProcedureBloodCountList() ([]model.ProcedureBloodCount, error) {
-	return s.repo.GetProcedureBloodCountList()
+func (s *ProcedureBloodCountService) DeleteProcedureBloodCount(user UserData, procedureId int, bloodCountId string) error {
+	if err := s.access.CheckCourseProcedureAccess(user, procedureId); err != nil {
+		return err
+	}
+
+	return s.tx.InTransaction(func(repos *repository.Repository) error {
+		procedureBloodCount, err := repos.ProcedureBloodCount.GetProcedureBloodCountByIdForUpdate(procedureId, bloodCountId)
+		if err != nil {
+			return err
+		}
+		if err := repos.ProcedureBloodCount.DeleteProcedureBloodCount(procedureId, bloodCountId); err != nil {
+			return err
+		}
+		return recordAudit(repos.Audit, user, model.DeleteAction, model.ProcedureBloodCountResource,
+			procedureBloodCountEntityId(procedureId, bloodCountId), procedureBloodCount, nil)
+	})
 }
-func (s *ProcedureBloodCountService) UpdateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error) {
-	return s.repo.UpdateProcedureBloodCount(procedureBloodCount)
+
+// normalize converts the value to the unit of the blood count, which the ranges are expressed in,
+// checks it against the ranges and sets the flag of the result. A value without a unit is taken to be in the unit of the blood count.
+func (s *ProcedureBloodCountService) normalize(procedureBloodCount *model.ProcedureBloodCount) error {
+	bloodCount, err := s.bloodCountRepo.GetBloodCountById(procedureBloodCount.BloodCount)
+	if err != nil {
+		return err
+	}
+
+	if procedureBloodCount.Value != nil && procedureBloodCount.MeasureCode != "" {
+		value, err := convertValue(s.conversions, *procedureBloodCount.Value, procedureBloodCount.MeasureCode, bloodCount.MeasureCode)
+		if err != nil {
+			return err
+		}
+		procedureBloodCount.Value = &value
+	}
+	procedureBloodCount.MeasureCode = bloodCount.MeasureCode
+
+	flag, err := flagBloodCount(bloodCount, procedureBloodCount.Value)
+	if err != nil {
+		return err
+	}
+	procedureBloodCount.Flag = flag
+	return nil
 }
-func (s *ProcedureBloodCountService) DeleteProcedureBloodCount(procedureId int, bloodCountId string) error {
-	return s.repo.DeleteProcedureBloodCount(procedureId, bloodCountId)
+
+// inUnit converts the value of the result to the unit requested by the client, an empty unit keeps the stored unit.
+func (s *ProcedureBloodCountService) inUnit(procedureBloodCount *model.ProcedureBloodCount, unit string) error {
+	if unit == "" || procedureBloodCount.MeasureCode == unit {
+		return nil
+	}
+
+	if procedureBloodCount.Value != nil {
+		value, err := convertValue(s.conversions, *procedureBloodCount.Value, procedureBloodCount.MeasureCode, unit)
+		if err != nil {
+			return err
+		}
+		procedureBloodCount.Value = &value
+	}
+	procedureBloodCount.MeasureCode = unit
+	return nil
+}
+
+// listInUnit converts the values of the results to the unit requested by the client,
+// the conversion from each stored unit is looked up once for the whole list.
+func (s *ProcedureBloodCountService) listInUnit(procedureBloodCountList []model.ProcedureBloodCount, unit string) error {
+	if unit == "" {
+		return nil
+	}
+
+	conversions := map[string]model.UnitConversion{}
+	for i := range procedureBloodCountList {
+		procedureBloodCount := &procedureBloodCountList[i]
+		if procedureBloodCount.MeasureCode == unit {
+			continue
+		}
+
+		if procedureBloodCount.Value != nil {
+			unitConversion, ok := conversions[procedureBloodCount.MeasureCode]
+			if !ok {
+				var err error
+				if unitConversion, err = findUnitConversion(s.conversions, procedureBloodCount.MeasureCode, unit); err != nil {
+					return err
+				}
+				conversions[procedureBloodCount.MeasureCode] = unitConversion
+			}
+			value := unitConversion.Convert(*procedureBloodCount.Value)
+			procedureBloodCount.Value = &value
+		}
+		procedureBloodCount.MeasureCode = unit
+	}
+	return nil
+}
+
+// flagBloodCount classifies the value as low, normal or high against the normal range of the blood count.
+// A value outside the possible range is rejected, no value has no flag.
+// NaN and infinities fail every comparison, so they are rejected before the range is checked.
+func flagBloodCount(bloodCount model.BloodCount, value *float64) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	v := *value
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("%w: %s value %g is not a finite number", ErrValueOutOfRange, bloodCount.Id, v)
+	}
+	if v < bloodCount.MinPossibleValue || v > bloodCount.MaxPossibleValue {
+		return "", fmt.Errorf("%w: %s value %g is not in %g..%g", ErrValueOutOfRange,
+			bloodCount.Id, v, bloodCount.MinPossibleValue, bloodCount.MaxPossibleValue)
+	}
+
+	switch {
+	case v < bloodCount.MinNormalValue:
+		return model.LowFlag, nil
+	case v > bloodCount.MaxNormalValue:
+		return model.HighFlag, nil
+	default:
+		return model.NormalFlag, nil
+	}
+}
+
+// procedureBloodCountEntityId is the audit log ID of a procedure blood count, made of the procedure and blood count IDs.
+func procedureBloodCountEntityId(procedureId int, bloodCountId string) string {
+	return fmt.Sprintf("%d/%s", procedureId, bloodCountId)
 }
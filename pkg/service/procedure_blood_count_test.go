@@ -0,0 +1,267 @@
+package services
+
+import (
+	"math"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingConversionRepository counts the lookups of unit conversions
+type countingConversionRepository struct {
+	conversionRepository
+	lookups int
+}
+
+func (r *countingConversionRepository) GetUnitConversion(from, to string) (model.UnitConversion, error) {
+	r.lookups++
+	return r.conversionRepository.GetUnitConversion(from, to)
+}
+
+func TestListInUnit(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+
+	testTable := []struct {
+		name            string
+		unit            string
+		list            []model.ProcedureBloodCount
+		expectedList    []model.ProcedureBloodCount
+		expectedLookups int
+		expectedError   string
+	}{
+		{
+			name:         "Stored unit",
+			list:         []model.ProcedureBloodCount{{BloodCount: "HGB", Value: value(125), MeasureCode: "g/l"}},
+			expectedList: []model.ProcedureBloodCount{{BloodCount: "HGB", Value: value(125), MeasureCode: "g/l"}},
+		},
+		{
+			name: "Conversion looked up once",
+			unit: "g/dl",
+			list: []model.ProcedureBloodCount{
+				{Procedure: 1, BloodCount: "HGB", Value: value(125), MeasureCode: "g/l"},
+				{Procedure: 2, BloodCount: "HGB", Value: value(130), MeasureCode: "g/l"},
+				{Procedure: 3, BloodCount: "HGB", MeasureCode: "g/l"},
+				{Procedure: 4, BloodCount: "HGB", Value: value(12), MeasureCode: "g/dl"},
+			},
+			expectedList: []model.ProcedureBloodCount{
+				{Procedure: 1, BloodCount: "HGB", Value: value(12.5), MeasureCode: "g/dl"},
+				{Procedure: 2, BloodCount: "HGB", Value: value(13), MeasureCode: "g/dl"},
+				{Procedure: 3, BloodCount: "HGB", MeasureCode: "g/dl"},
+				{Procedure: 4, BloodCount: "HGB", Value: value(12), MeasureCode: "g/dl"},
+			},
+			// the stored conversion is from g/dl to g/l, so the reverse is looked up after it
+			expectedLookups: 2,
+		},
+		{
+			name:            "Unknown",
+			unit:            "mmol/l",
+			list:            []model.ProcedureBloodCount{{BloodCount: "HGB", Value: value(125), MeasureCode: "g/l"}},
+			expectedLookups: 2,
+			expectedError:   "no conversion between units g/l and mmol/l",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &countingConversionRepository{conversionRepository: conversionRepository{{From: "g/dl", To: "g/l", Factor: 10}}}
+			service := NewProcedureBloodCountService(nil, nil, repo, nil, nil)
+
+			err := service.listInUnit(testCase.list, testCase.unit)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedList, testCase.list)
+			}
+			assert.Equal(t, testCase.expectedLookups, repo.lookups)
+		})
+	}
+}
+
+func TestFlagBloodCount(t *testing.T) {
+	// Bounds that have no exact binary representation, stored as double precision like the columns
+	bloodCount := model.BloodCount{Id: "EOS", MinPossibleValue: 0.1, MinNormalValue: 0.3, MaxNormalValue: 4.2, MaxPossibleValue: 4.6}
+	value := func(v float64) *float64 { return &v }
+
+	testTable := []struct {
+		name          string
+		value         *float64
+		expectedFlag  string
+		expectedError string
+	}{
+		{name: "No value"},
+		{name: "Min possible", value: value(0.1), expectedFlag: model.LowFlag},
+		{name: "Low", value: value(0.2), expectedFlag: model.LowFlag},
+		{name: "Min normal", value: value(0.3), expectedFlag: model.NormalFlag},
+		{name: "Normal", value: value(2), expectedFlag: model.NormalFlag},
+		{name: "Max normal", value: value(4.2), expectedFlag: model.NormalFlag},
+		{name: "High", value: value(4.3), expectedFlag: model.HighFlag},
+		{name: "Max possible", value: value(4.6), expectedFlag: model.HighFlag},
+		{name: "Below possible", value: value(0.09), expectedError: "value is outside the possible range: EOS value 0.09 is not in 0.1..4.6"},
+		{name: "Above possible", value: value(4.61), expectedError: "value is outside the possible range: EOS value 4.61 is not in 0.1..4.6"},
+		{name: "NaN", value: value(math.NaN()), expectedError: "value is outside the possible range: EOS value NaN is not a finite number"},
+		{name: "Infinity", value: value(math.Inf(1)), expectedError: "value is outside the possible range: EOS value +Inf is not a finite number"},
+		{name: "Negative infinity", value: value(math.Inf(-1)), expectedError: "value is outside the possible range: EOS value -Inf is not a finite number"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			flag, err := flagBloodCount(bloodCount, testCase.value)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.ErrorIs(t, err, ErrValueOutOfRange)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedFlag, flag)
+		})
+	}
+}
+
+// normalizeBloodCountRepository returns the hemoglobin blood count
+type normalizeBloodCountRepository struct {
+	repository.BloodCount
+}
+
+func (r normalizeBloodCountRepository) GetBloodCountById(id string) (model.BloodCount, error) {
+	return model.BloodCount{Id: "HGB", MeasureCode: "g/l", MinNormalValue: 120, MaxNormalValue: 160, MaxPossibleValue: 250}, nil
+}
+
+func TestNormalize(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+
+	testTable := []struct {
+		name                        string
+		procedureBloodCount         model.ProcedureBloodCount
+		expectedProcedureBloodCount model.ProcedureBloodCount
+		expectedError               string
+	}{
+		{
+			name:                        "Unit of the blood count",
+			procedureBloodCount:         model.ProcedureBloodCount{BloodCount: "HGB", Value: value(130), MeasureCode: "g/l"},
+			expectedProcedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", Value: value(130), MeasureCode: "g/l", Flag: model.NormalFlag},
+		},
+		{
+			name:                        "No unit",
+			procedureBloodCount:         model.ProcedureBloodCount{BloodCount: "HGB", Value: value(110)},
+			expectedProcedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", Value: value(110), MeasureCode: "g/l", Flag: model.LowFlag},
+		},
+		{
+			name:                        "Converted",
+			procedureBloodCount:         model.ProcedureBloodCount{BloodCount: "HGB", Value: value(17), MeasureCode: "g/dl"},
+			expectedProcedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", Value: value(170), MeasureCode: "g/l", Flag: model.HighFlag},
+		},
+		{
+			name:                        "No value",
+			procedureBloodCount:         model.ProcedureBloodCount{BloodCount: "HGB", MeasureCode: "g/dl"},
+			expectedProcedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", MeasureCode: "g/l"},
+		},
+		{
+			name:                "Out of range after conversion",
+			procedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", Value: value(130), MeasureCode: "g/dl"},
+			expectedError:       "value is outside the possible range: HGB value 1300 is not in 0..250",
+		},
+		{
+			name:                "Unknown unit",
+			procedureBloodCount: model.ProcedureBloodCount{BloodCount: "HGB", Value: value(8), MeasureCode: "mmol/l"},
+			expectedError:       "no conversion between units mmol/l and g/l",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			conversions := conversionRepository{{From: "g/dl", To: "g/l", Factor: 10}}
+			service := NewProcedureBloodCountService(nil, normalizeBloodCountRepository{}, conversions, nil, nil)
+
+			procedureBloodCount := testCase.procedureBloodCount
+			err := service.normalize(&procedureBloodCount)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedProcedureBloodCount, procedureBloodCount)
+		})
+	}
+}
+
+// listProcedureBloodCountRepository records the patients the list is limited to
+type listProcedureBloodCountRepository struct {
+	repository.ProcedureBloodCount
+	patientIds []int
+}
+
+func (r *listProcedureBloodCountRepository) GetProcedureBloodCountList(filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, patientIds []int) (model.Page[model.ProcedureBloodCount], error) {
+	r.patientIds = patientIds
+	return model.Page[model.ProcedureBloodCount]{Items: []model.ProcedureBloodCount{}}, nil
+}
+
+func TestGetProcedureBloodCountList(t *testing.T) {
+	abnormal := true
+	ownPatient, foreignPatient, foreignProcedure := 1, 2, 200
+
+	testTable := []struct {
+		name               string
+		user               UserData
+		filter             model.ProcedureBloodCountFilter
+		expectedPatientIds []int
+		expectedError      error
+	}{
+		{
+			name:   "Admin, every patient",
+			user:   UserData{Id: 1, Role: model.AdminRole},
+			filter: model.ProcedureBloodCountFilter{Abnormal: &abnormal},
+		},
+		{
+			name:               "Doctor, abnormal results of own patients",
+			user:               UserData{Id: 7, Role: model.DoctorRole},
+			filter:             model.ProcedureBloodCountFilter{Abnormal: &abnormal},
+			expectedPatientIds: []int{1},
+		},
+		{
+			name:               "Doctor, own patient",
+			user:               UserData{Id: 7, Role: model.DoctorRole},
+			filter:             model.ProcedureBloodCountFilter{Patient: &ownPatient},
+			expectedPatientIds: []int{1},
+		},
+		{
+			name:          "Doctor, foreign patient",
+			user:          UserData{Id: 7, Role: model.DoctorRole},
+			filter:        model.ProcedureBloodCountFilter{Patient: &foreignPatient},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:          "Doctor, foreign procedure",
+			user:          UserData{Id: 7, Role: model.DoctorRole},
+			filter:        model.ProcedureBloodCountFilter{Procedure: &foreignProcedure},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:               "Doctor without patients",
+			user:               UserData{Id: 8, Role: model.DoctorRole},
+			expectedPatientIds: []int{},
+		},
+		{
+			name:          "Researcher",
+			user:          UserData{Id: 11, Role: model.ResearcherRole},
+			expectedError: ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo := &listProcedureBloodCountRepository{}
+			service := NewProcedureBloodCountService(repo, nil, nil, NewAccessService(newAccessRepository()), nil)
+
+			_, err := service.GetProcedureBloodCountList(testCase.user, testCase.filter, model.ListQuery{}, "")
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expectedPatientIds, repo.patientIds)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrInvalidRegistrationCode is returned when the SNILS and registration code do not match an unregistered patient.
+	ErrInvalidRegistrationCode = errors.New("invalid SNILS or registration code")
+	// ErrInvalidInvitation is returned when an invitation token is unknown, expired or already accepted.
+	ErrInvalidInvitation = errors.New("invalid invitation")
+)
+
+// registrationCodeAttempts is the number of checks allowed for a registration code before it has to be reissued.
+const registrationCodeAttempts = 5
+
+type RegistrationService struct {
+	repo   repository.Registration
+	access Access
+	mailer utils.Mailer
+}
+
+func NewRegistrationService(repo repository.Registration, access Access, mailer utils.Mailer) *RegistrationService {
+	return &RegistrationService{repo: repo, access: access, mailer: mailer}
+}
+
+// IssueRegistrationCode creates a one-time code the patient uses to register an account.
+// The code is returned once and is handed over to the patient by the doctor.
+func (s *RegistrationService) IssueRegistrationCode(user UserData, patientId int) (model.RegistrationCode, error) {
+	if err := s.access.CheckPatientAccess(user, patientId); err != nil {
+		return model.RegistrationCode{}, err
+	}
+
+	code, err := utils.GenerateRegistrationCode()
+	if err != nil {
+		return model.RegistrationCode{}, err
+	}
+
+	expiresAt := time.Now().Add(utils.RegistrationCodeTTL)
+	if err := s.repo.SaveRegistrationCode(patientId, utils.HashToken(code), expiresAt); err != nil {
+		return model.RegistrationCode{}, err
+	}
+
+	return model.RegistrationCode{PatientId: patientId, Code: code, ExpiresAt: expiresAt}, nil
+}
+
+// RegisterPatient creates a patient account linked to the patient record with the given SNILS.
+func (s *RegistrationService) RegisterPatient(registration model.PatientRegistration) (string, error) {
+	patientId, err := s.repo.GetUnregisteredPatientId(registration.SNILS)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrInvalidRegistrationCode
+		}
+		return "", err
+	}
+
+	match, err := s.repo.CheckRegistrationCode(patientId, utils.HashToken(registration.Code), registrationCodeAttempts)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", ErrInvalidRegistrationCode
+	}
+
+	passwordHash, err := utils.GeneratePasswordHash(registration.Password)
+	if err != nil {
+		return "", err
+	}
+
+	email, err := s.repo.RegisterPatient(model.User{Email: registration.Email, Password: passwordHash}, patientId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidRegistrationCode
+	}
+	return email, err
+}
+
+// InviteUser emails an invitation to create a staff account with the given role.
+func (s *RegistrationService) InviteUser(user UserData, invitation model.Invitation) (model.Invitation, error) {
+	if !slices.Contains(staffRoles, invitation.Role) {
+		return model.Invitation{}, ErrInvalidRole
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	invitation.ExpiresAt = time.Now().Add(utils.InvitationTTL)
+	if err := s.repo.CreateInvitation(utils.HashToken(token), invitation, user.Id); err != nil {
+		return model.Invitation{}, err
+	}
+
+	body := fmt.Sprintf("You are invited to join OncoBase as %s. Use this token to create your account: %s\r\nThe invitation expires in %s.", invitation.Role, token, utils.InvitationTTL)
+	if err := s.mailer.SendEmail([]string{invitation.Email}, "OncoBase invitation", body); err != nil {
+		return model.Invitation{}, err
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation creates the invited staff account. Invited doctors get a doctor record as well.
+func (s *RegistrationService) AcceptInvitation(acceptance model.InvitationAcceptance) (string, error) {
+	passwordHash, err := utils.GeneratePasswordHash(acceptance.Password)
+	if err != nil {
+		return "", err
+	}
+
+	email, err := s.repo.AcceptInvitation(utils.HashToken(acceptance.Token), model.User{
+		Password:   passwordHash,
+		FirstName:  acceptance.FirstName,
+		MiddleName: acceptance.MiddleName,
+		LastName:   acceptance.LastName,
+		Phone:      acceptance.Phone,
+	}, acceptance.Qualification)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidInvitation
+	}
+	return email, err
+}
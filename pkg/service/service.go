@@ -1,25 +1,46 @@
 package services
 
 import (
+	"med/pkg/config"
 	"med/pkg/model"
 	"med/pkg/repository"
+	"med/pkg/utils"
 )
 
 //go:generate mockgen -source=service.go -destination=mock/mock.go
 
+type Analysis interface {
+	GetProcedureRisk(user UserData, procedureId int) ([]model.DiseaseScore, error)
+	ScoreBloodCounts(results []model.BloodCountResult) ([]model.DiseaseScore, error)
+}
+
 type Account interface {
+	GetBloodCountList(user UserData) ([]model.AccountBloodCount, error)
+	GetDoctorList(user UserData) ([]model.Doctor, error)
+	GetPatientPanel(user UserData) ([]model.PanelPatient, error)
+}
+
+type Access interface {
+	CheckPatientAccess(user UserData, patientId int) error
+	CheckPatientCourseAccess(user UserData, patientCourseId int) error
+	CheckCourseProcedureAccess(user UserData, procedureId int) error
+	GetAccessiblePatientIdList(user UserData) ([]int, bool, error)
 }
 
 type Authorization interface {
-	CreateUser(user model.User) (string, error)
-	GenerateToken(email, password string) (string, error)
+	GenerateToken(email, password, ip string) (model.Tokens, error)
+	RefreshToken(refreshToken string) (model.Tokens, error)
+	LogOut(user UserData, refreshToken string) error
+	RevokeUserSessions(userId int) error
+	RequestPasswordReset(email string) error
+	ResetPassword(resetToken, password string) error
 	ParseToken(token string) (*UserData, error)
 }
 
 type BloodCount interface {
 	CreateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error)
 	GetBloodCountById(id string) (model.BloodCount, error)
-	GetBloodCountList() ([]model.BloodCount, error)
+	GetBloodCountList(filter model.BloodCountFilter, listQuery model.ListQuery) (model.Page[model.BloodCount], error)
 	UpdateBloodCount(bloodCount model.BloodCount) (model.BloodCount, error)
 	DeleteBloodCount(id string) error
 }
@@ -27,33 +48,50 @@ type BloodCount interface {
 type BloodCountValue interface {
 	CreateBloodCountValue(bloodCountValue model.BloodCountValue) (model.BloodCountValue, error)
 	GetBloodCountValueById(diseaseId, bloodCountId string) (model.BloodCountValue, error)
-	GetBloodCountValueListByDisease(diseaseId string) ([]model.BloodCountValue, error)
-	GetBloodCountValueListByBloodCount(bloodCountId string) ([]model.BloodCountValue, error)
-	GetBloodCountValueList() ([]model.BloodCountValue, error)
+	GetBloodCountValueList(filter model.BloodCountValueFilter, listQuery model.ListQuery) (model.Page[model.BloodCountValue], error)
 	UpdateBloodCountValue(bloodCountValue model.BloodCountValue) (model.BloodCountValue, error)
 	DeleteBloodCountValue(diseaseId, bloodCountId string) error
 }
 
+type Cohort interface {
+	CreateCohort(user UserData, cohort model.Cohort) (model.Cohort, error)
+	GetCohortList(filter model.CohortFilter, listQuery model.ListQuery) (model.Page[model.Cohort], error)
+	GetCohortById(id int) (model.Cohort, error)
+	UpdateCohort(user UserData, cohort model.Cohort) (model.Cohort, error)
+	DeleteCohort(user UserData, id int) error
+	GetCohortStatistics(id int, query model.CohortStatisticsQuery) (model.CohortStatistics, error)
+}
+
+type Console interface {
+	GetStatistics(from, to string) (model.ConsoleStatistics, error)
+	SearchUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error)
+	LockUser(admin UserData, id int) error
+	UnlockUser(admin UserData, id int) error
+	ChangeUserRole(admin UserData, id int, role string) (model.UserProfile, error)
+	GetFailedLoginList(limit int) ([]model.FailedLogin, error)
+	GetChangeList(filter model.AuditFilter) ([]model.AuditEntry, error)
+}
+
 type Course interface {
 	CreateCourse(course model.Course) (model.Course, error)
 	GetCourseById(id string) (model.Course, error)
-	GetCourseList() ([]model.Course, error)
+	GetCourseList(filter model.CourseFilter, listQuery model.ListQuery) (model.Page[model.Course], error)
 	UpdateCourse(course model.Course) (model.Course, error)
 	DeleteCourse(id string) error
 }
 
 type CourseProcedure interface {
-	CreateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
-	GetCourseProcedureById(id string) (model.CourseProcedure, error)
-	GetCourseProcedureList() ([]model.CourseProcedure, error)
-	UpdateCourseProcedure(courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
-	DeleteCourseProcedure(id string) error
+	CreateCourseProcedure(user UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
+	GetCourseProcedureById(user UserData, id int) (model.CourseProcedure, error)
+	GetCourseProcedureList(user UserData, filter model.CourseProcedureFilter, listQuery model.ListQuery) (model.Page[model.CourseProcedure], error)
+	UpdateCourseProcedure(user UserData, courseProcedure model.CourseProcedure) (model.CourseProcedure, error)
+	DeleteCourseProcedure(user UserData, id int) error
 }
 
 type Diagnosis interface {
 	CreateDiagnosis(diagnosis model.Diagnosis) (model.Diagnosis, error)
 	GetDiagnosisById(id string) (model.Diagnosis, error)
-	GetDiagnosisList() ([]model.Diagnosis, error)
+	GetDiagnosisList(listQuery model.ListQuery) (model.Page[model.Diagnosis], error)
 	UpdateDiagnosis(diagnosis model.Diagnosis) (model.Diagnosis, error)
 	DeleteDiagnosis(id string) error
 }
@@ -61,7 +99,7 @@ type Diagnosis interface {
 type Disease interface {
 	CreateDisease(disease model.Disease) (model.Disease, error)
 	GetDiseaseById(id string) (model.Disease, error)
-	GetDiseaseList() ([]model.Disease, error)
+	GetDiseaseList(listQuery model.ListQuery) (model.Page[model.Disease], error)
 	UpdateDisease(disease model.Disease) (model.Disease, error)
 	DeleteDisease(id string) error
 }
@@ -69,74 +107,153 @@ type Disease interface {
 type Doctor interface {
 	CreateDoctor(doctor model.Doctor) (model.Doctor, error)
 	GetDoctorById(id int) (model.Doctor, error)
-	GetDoctorList() ([]model.Doctor, error)
+	GetDoctorList(listQuery model.ListQuery) (model.Page[model.Doctor], error)
 	UpdateDoctor(doctor model.Doctor) (model.Doctor, error)
 	DeleteDoctor(id int) error
 }
 
 type DoctorPatient interface {
 	CreateDoctorPatient(doctorPatient model.DoctorPatient) (model.DoctorPatient, error)
-	GetDoctorPatientList(doctor_id int) ([]model.DoctorPatient, error)
+	GetDoctorPatientList(doctorId int, listQuery model.ListQuery) (model.Page[model.DoctorPatient], error)
 	DeleteDoctorPatient(doctor_id, patient_id int) error
 }
 
 type Drug interface {
 	CreateDrug(drug model.Drug) (model.Drug, error)
 	GetDrugById(id string) (model.Drug, error)
-	GetDrugList() ([]model.Drug, error)
+	GetDrugList(filter model.DrugFilter, listQuery model.ListQuery) (model.Page[model.Drug], error)
 	UpdateDrug(drug model.Drug) (model.Drug, error)
 	DeleteDrug(id string) error
 }
 
+type Export interface {
+	CreateExportJob(user UserData, job model.ExportJob) (model.ExportJob, error)
+	GetExportJobList(user UserData, filter model.ExportJobFilter, listQuery model.ListQuery) (model.Page[model.ExportJob], error)
+	GetExportJobById(user UserData, id int) (model.ExportJob, error)
+	GetExportBundle(user UserData, id int) (string, error)
+	FailUnfinishedExportJobs() (int, error)
+}
+
+type FHIR interface {
+	GetFHIRPatient(user UserData, id string) (model.FHIRPatient, error)
+	SearchFHIRPatients(user UserData, query model.FHIRPatientQuery) (model.FHIRBundle, error)
+	CreateFHIRPatient(user UserData, resource model.FHIRPatient) (model.FHIRPatient, error)
+	UpdateFHIRPatient(user UserData, id string, resource model.FHIRPatient) (model.FHIRPatient, error)
+	GetFHIRObservation(user UserData, id string) (model.FHIRObservation, error)
+	SearchFHIRObservations(user UserData, query model.FHIRObservationQuery) (model.FHIRBundle, error)
+	CreateFHIRObservation(user UserData, resource model.FHIRObservation) (model.FHIRObservation, error)
+	UpdateFHIRObservation(user UserData, id string, resource model.FHIRObservation) (model.FHIRObservation, error)
+	GetFHIRCondition(user UserData, id string) (model.FHIRCondition, error)
+	SearchFHIRConditions(user UserData, query model.FHIRConditionQuery) (model.FHIRBundle, error)
+	CreateFHIRCondition(user UserData, resource model.FHIRCondition) (model.FHIRCondition, error)
+	UpdateFHIRCondition(user UserData, id string, resource model.FHIRCondition) (model.FHIRCondition, error)
+	GetFHIRMedicationRequest(user UserData, id string) (model.FHIRMedicationRequest, error)
+	SearchFHIRMedicationRequests(user UserData, query model.FHIRMedicationRequestQuery) (model.FHIRBundle, error)
+	CreateFHIRMedicationRequest(user UserData, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error)
+	UpdateFHIRMedicationRequest(user UserData, id string, resource model.FHIRMedicationRequest) (model.FHIRMedicationRequest, error)
+	GetFHIRMedication(id string) (model.FHIRMedication, error)
+	SearchFHIRMedications(search model.FHIRSearch) (model.FHIRBundle, error)
+	GetFHIRPractitioner(id string) (model.FHIRPractitioner, error)
+	SearchFHIRPractitioners(search model.FHIRSearch) (model.FHIRBundle, error)
+}
+
+type LabCodeMapping interface {
+	CreateLabCodeMapping(labCodeMapping model.LabCodeMapping) (model.LabCodeMapping, error)
+	GetLabCodeMappingList(listQuery model.ListQuery) (model.Page[model.LabCodeMapping], error)
+	DeleteLabCodeMapping(system, code string) error
+}
+
+type LabMessage interface {
+	IngestLabMessage(user UserData, message string) (model.LabMessageAck, error)
+}
+
 type Patient interface {
-	CreatePatient(patient model.Patient) (model.Patient, error)
-	GetPatientById(id int) (model.Patient, error)
-	GetPatientList() ([]model.Patient, error)
-	UpdatePatient(patient model.Patient) (model.Patient, error)
-	DeletePatient(id int) error
+	CreatePatient(user UserData, patient model.Patient) (model.Patient, error)
+	GetPatientById(user UserData, id int) (model.Patient, error)
+	GetPatientList(user UserData, filter model.PatientFilter, listQuery model.ListQuery) (model.Page[model.Patient], error)
+	SearchPatientList(user UserData, search model.PatientSearch) ([]model.PatientSearchResult, error)
+	UpdatePatient(user UserData, patient model.Patient) (model.Patient, error)
+	DeletePatient(user UserData, id int) error
 }
 
 type PatientCourse interface {
-	CreatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error)
-	GetPatientCourseById(id int) (model.PatientCourse, error)
-	GetPatientCourseList() ([]model.PatientCourse, error)
-	UpdatePatientCourse(patientCourse model.PatientCourse) (model.PatientCourse, error)
-	DeletePatientCourse(id int) error
+	CreatePatientCourse(user UserData, patientCourse model.PatientCourse) (model.PatientCourse, error)
+	GetPatientCourseById(user UserData, id int) (model.PatientCourse, error)
+	GetPatientCourseList(user UserData, filter model.PatientCourseFilter, listQuery model.ListQuery) (model.Page[model.PatientCourse], error)
+	UpdatePatientCourse(user UserData, patientCourse model.PatientCourse) (model.PatientCourse, error)
+	DeletePatientCourse(user UserData, id int) error
 }
 
 type PatientDisease interface {
-	CreatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error)
-	GetPatientDiseaseById(patientId, diseaseId int) (model.PatientDisease, error)
-	GetPatientDiseaseListByPatient(patientId int) ([]model.PatientDisease, error)
-	GetPatientDiseaseListByDisease(diseaseId int) ([]model.PatientDisease, error)
-	GetPatientDiseaseList() ([]model.PatientDisease, error)
-	UpdatePatientDisease(patientDisease model.PatientDisease) (model.PatientDisease, error)
-	DeletePatientDisease(patientId, diseaseId int) error
+	CreatePatientDisease(user UserData, patientDisease model.PatientDisease) (model.PatientDisease, error)
+	GetPatientDiseaseById(user UserData, patientId int, diseaseId string) (model.PatientDisease, error)
+	GetPatientDiseaseList(user UserData, filter model.PatientDiseaseFilter, listQuery model.ListQuery) (model.Page[model.PatientDisease], error)
+	UpdatePatientDisease(user UserData, patientDisease model.PatientDisease) (model.PatientDisease, error)
+	DeletePatientDisease(user UserData, patientId int, diseaseId string) error
+}
+
+type Permission interface {
+	HasPermission(role, resource, action string) (bool, error)
+	GetPermissionList(filter model.PermissionFilter, listQuery model.ListQuery) (model.Page[model.Permission], error)
+	CreatePermission(permission model.Permission) (model.Permission, error)
+	DeletePermission(permission model.Permission) error
 }
 
 type ProcedureBloodCount interface {
-	CreateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
-	GetProcedureBloodCountById(procedureId int, bloodCountId string) (model.ProcedureBloodCount, error)
-	GetProcedureBloodCountListByProcedure(procedureId int) ([]model.ProcedureBloodCount, error)
-	GetProcedureBloodCountListByBloodCount(bloodCountId string) ([]model.ProcedureBloodCount, error)
-	GetProcedureBloodCountList() ([]model.ProcedureBloodCount, error)
-	UpdateProcedureBloodCount(procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
-	DeleteProcedureBloodCount(procedureId int, bloodCountId string) error
+	CreateProcedureBloodCount(user UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
+	GetProcedureBloodCountById(user UserData, procedureId int, bloodCountId, unit string) (model.ProcedureBloodCount, error)
+	GetProcedureBloodCountList(user UserData, filter model.ProcedureBloodCountFilter, listQuery model.ListQuery, unit string) (model.Page[model.ProcedureBloodCount], error)
+	UpdateProcedureBloodCount(user UserData, procedureBloodCount model.ProcedureBloodCount) (model.ProcedureBloodCount, error)
+	DeleteProcedureBloodCount(user UserData, procedureId int, bloodCountId string) error
+	ImportProcedureBloodCounts(user UserData, rows [][]string, mapping model.ImportMapping, dryRun bool) (model.ImportReport, error)
+}
+
+type Registration interface {
+	IssueRegistrationCode(user UserData, patientId int) (model.RegistrationCode, error)
+	RegisterPatient(registration model.PatientRegistration) (string, error)
+	InviteUser(user UserData, invitation model.Invitation) (model.Invitation, error)
+	AcceptInvitation(acceptance model.InvitationAcceptance) (string, error)
+}
+
+type Timeline interface {
+	GetPatientTimeline(user UserData, patientId int) ([]model.TimelineEvent, error)
+}
+
+type Trend interface {
+	GetPatientTrend(user UserData, patientId int, query model.TrendQuery) (model.Trend, error)
+}
+
+type UnitConversion interface {
+	CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error)
+	GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error)
+	DeleteUnitConversion(from, to string) error
+	ConvertValue(value float64, from, to string) (float64, error)
 }
 
 type UnitMeasure interface {
 	CreateUnitMeasure(unitMeasure model.UnitMeasure) (model.UnitMeasure, error)
 	GetUnitMeasureById(id string) (model.UnitMeasure, error)
-	GetUnitMeasureList() ([]model.UnitMeasure, error)
+	GetUnitMeasureList(listQuery model.ListQuery) (model.Page[model.UnitMeasure], error)
 	UpdateUnitMeasure(unitMeasure model.UnitMeasure) (model.UnitMeasure, error)
 	DeleteUnitMeasure(id string) error
 }
 
+type User interface {
+	CreateStaffUser(staffUser model.StaffUser) (model.UserProfile, error)
+	GetUserById(id int) (model.UserProfile, error)
+	GetUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error)
+	MigrateLegacyUsers() (model.LegacyUserMigration, error)
+}
+
 type Service struct {
+	Access
 	Account
+	Analysis
 	Authorization
 	BloodCountValue
 	BloodCount
+	Cohort
+	Console
 	Course
 	CourseProcedure
 	Diagnosis
@@ -144,29 +261,58 @@ type Service struct {
 	Doctor
 	DoctorPatient
 	Drug
+	Export
+	FHIR
+	LabCodeMapping
+	LabMessage
 	Patient
 	PatientCourse
 	PatientDisease
+	Permission
 	ProcedureBloodCount
+	Registration
+	Timeline
+	Trend
+	UnitConversion
 	UnitMeasure
+	User
 }
 
-func NewService(repos repository.Repository) *Service {
-	return &Service{
-		Authorization:       NewAuthService(repos),
+func NewService(repos repository.Repository, mailer utils.Mailer, exportConfig config.ConfigExport, privacyConfig config.ConfigPrivacy) *Service {
+	access := NewAccessService(repos)
+	service := &Service{
+		Access:              access,
+		Account:             NewAccountService(repos),
+		Analysis:            NewAnalysisService(repos, repos, repos, repos, access),
+		Authorization:       NewAuthService(repos, repos, mailer),
 		BloodCount:          NewBloodCountService(repos),
 		BloodCountValue:     NewBloodCountValueService(repos),
+		Cohort:              NewCohortService(repos, privacyConfig.K),
+		Console:             NewConsoleService(repos, repos, repos, repos),
 		Course:              NewCourseService(repos),
-		CourseProcedure:     NewCourseProcedureService(repos),
+		CourseProcedure:     NewCourseProcedureService(repos, access, repos),
 		Diagnosis:           NewDiagnosisService(repos),
 		Disease:             NewDiseaseService(repos),
 		Doctor:              NewDoctorService(repos),
 		DoctorPatient:       NewDoctorPatientService(repos),
 		Drug:                NewDrugService(repos),
-		Patient:             NewPatientService(repos),
-		PatientCourse:       NewPatientCourseService(repos),
-		PatientDisease:      NewPatientDiseaseService(repos),
-		ProcedureBloodCount: NewProcedureBloodCountService(repos),
+		Export:              NewExportService(repos, exportConfig, privacyConfig.K),
+		LabCodeMapping:      NewLabCodeMappingService(repos),
+		LabMessage:          NewLabMessageService(repos, repos, repos, repos, repos, repos, access, repos),
+		Patient:             NewPatientService(repos, access, repos),
+		PatientCourse:       NewPatientCourseService(repos, access, repos),
+		PatientDisease:      NewPatientDiseaseService(repos, access, repos),
+		Permission:          NewPermissionService(repos),
+		ProcedureBloodCount: NewProcedureBloodCountService(repos, repos, repos, access, repos),
+		Registration:        NewRegistrationService(repos, access, mailer),
+		Timeline:            NewTimelineService(repos, repos, access),
+		Trend:               NewTrendService(repos, repos, repos, access),
+		UnitConversion:      NewUnitConversionService(repos),
 		UnitMeasure:         NewUnitMeasureService(repos),
+		User:                NewUserService(repos),
 	}
+	// The FHIR facade maps resources to the other services
+	service.FHIR = NewFHIRService(service.Patient, service.PatientDisease, service.PatientCourse, service.CourseProcedure,
+		service.ProcedureBloodCount, service.BloodCount, service.Course, service.Drug, service.Doctor)
+	return service
 }
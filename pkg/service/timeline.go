@@ -0,0 +1,28 @@
+package services
+
+import (
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+type TimelineService struct {
+	repo        repository.Timeline
+	patientRepo repository.Patient
+	access      Access
+}
+
+func NewTimelineService(repo repository.Timeline, patientRepo repository.Patient, access Access) *TimelineService {
+	return &TimelineService{repo: repo, patientRepo: patientRepo, access: access}
+}
+
+// GetPatientTimeline returns the history of the patient as a chronological event stream.
+func (s *TimelineService) GetPatientTimeline(user UserData, patientId int) ([]model.TimelineEvent, error) {
+	if err := s.access.CheckPatientAccess(user, patientId); err != nil {
+		return nil, err
+	}
+	// An unknown patient has an empty history, so check that the patient exists to respond with not found
+	if _, err := s.patientRepo.GetPatientById(patientId); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPatientTimeline(patientId)
+}
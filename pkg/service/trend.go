@@ -0,0 +1,183 @@
+package services
+
+import (
+	"database/sql"
+	"math"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"time"
+)
+
+type TrendService struct {
+	repo           repository.Trend
+	bloodCountRepo repository.BloodCount
+	patientRepo    repository.Patient
+	access         Access
+}
+
+func NewTrendService(repo repository.Trend, bloodCountRepo repository.BloodCount, patientRepo repository.Patient, access Access) *TrendService {
+	return &TrendService{repo: repo, bloodCountRepo: bloodCountRepo, patientRepo: patientRepo, access: access}
+}
+
+// GetPatientTrend returns the results of the blood count of the patient over time with their statistics.
+// With a patient course the results are split into phases before, during and after the course.
+func (s *TrendService) GetPatientTrend(user UserData, patientId int, query model.TrendQuery) (model.Trend, error) {
+	if err := s.access.CheckPatientAccess(user, patientId); err != nil {
+		return model.Trend{}, err
+	}
+	if _, err := s.patientRepo.GetPatientById(patientId); err != nil {
+		return model.Trend{}, err
+	}
+	if _, err := s.bloodCountRepo.GetBloodCountById(query.BloodCount); err != nil {
+		return model.Trend{}, err
+	}
+
+	var course *model.TrendCourse
+	if query.PatientCourse != nil {
+		patientCourse, err := s.repo.GetTrendCourse(*query.PatientCourse)
+		if err != nil {
+			return model.Trend{}, err
+		}
+		// A course of another patient is not found for this one
+		if patientCourse.Patient != patientId {
+			return model.Trend{}, sql.ErrNoRows
+		}
+		course = &patientCourse
+	}
+
+	pointList, err := s.repo.GetBloodCountSeries(patientId, query.BloodCount)
+	if err != nil {
+		return model.Trend{}, err
+	}
+
+	trend := buildTrend(pointList, course)
+	trend.Patient = patientId
+	trend.BloodCount = query.BloodCount
+	trend.PatientCourse = query.PatientCourse
+	return trend, nil
+}
+
+// buildTrend computes the statistics of the results, which are ordered by date
+func buildTrend(pointList []model.TrendPoint, course *model.TrendCourse) model.Trend {
+	trend := model.Trend{Points: pointList, Streaks: []model.TrendStreak{}}
+
+	if course != nil {
+		for i := range pointList {
+			pointList[i].Phase = coursePhase(pointList[i].Date, *course)
+		}
+		trend.Phases = summarizePhases(pointList)
+	}
+
+	trend.Baseline = trendBaseline(pointList, course != nil)
+
+	for i := range pointList {
+		point := &pointList[i]
+		if i > 0 {
+			point.RateOfChange = rateOfChange(pointList[i-1], *point)
+		}
+		if trend.Baseline != nil && *trend.Baseline != 0 {
+			change := (point.Value - *trend.Baseline) / math.Abs(*trend.Baseline) * 100
+			point.ChangeFromBaseline = &change
+		}
+
+		if point.Flag != model.LowFlag && point.Flag != model.HighFlag {
+			continue
+		}
+		// The previous result with the same flag has started the last streak
+		if i > 0 && pointList[i-1].Flag == point.Flag {
+			streak := &trend.Streaks[len(trend.Streaks)-1]
+			streak.To = point.Date
+			streak.Length++
+			continue
+		}
+		trend.Streaks = append(trend.Streaks, model.TrendStreak{Flag: point.Flag, From: point.Date, To: point.Date, Length: 1})
+	}
+
+	return trend
+}
+
+// coursePhase places the date relative to the course period, dates in YYYY-MM-DD format compare as strings
+func coursePhase(date string, course model.TrendCourse) string {
+	switch {
+	case date < course.BeginDate:
+		return model.BeforeCoursePhase
+	case course.EndDate == "" || date <= course.EndDate:
+		return model.DuringCoursePhase
+	default:
+		return model.AfterCoursePhase
+	}
+}
+
+// trendBaseline is the first result, or by phases the last result before the course and the first one of the course otherwise
+func trendBaseline(pointList []model.TrendPoint, byPhase bool) *float64 {
+	if len(pointList) == 0 {
+		return nil
+	}
+	if !byPhase {
+		return &pointList[0].Value
+	}
+
+	var baseline *float64
+	for i := range pointList {
+		switch pointList[i].Phase {
+		case model.BeforeCoursePhase:
+			baseline = &pointList[i].Value
+		case model.DuringCoursePhase:
+			if baseline == nil {
+				baseline = &pointList[i].Value
+			}
+			return baseline
+		}
+	}
+	return baseline
+}
+
+// rateOfChange is the change per day between the results, nil for results of the same day
+func rateOfChange(previous, current model.TrendPoint) *float64 {
+	previousDate, err := time.Parse(time.DateOnly, previous.Date)
+	if err != nil {
+		return nil
+	}
+	currentDate, err := time.Parse(time.DateOnly, current.Date)
+	if err != nil {
+		return nil
+	}
+
+	days := currentDate.Sub(previousDate).Hours() / 24
+	if days <= 0 {
+		return nil
+	}
+	rate := (current.Value - previous.Value) / days
+	return &rate
+}
+
+func summarizePhases(pointList []model.TrendPoint) []model.TrendPhase {
+	phases := []model.TrendPhase{
+		{Phase: model.BeforeCoursePhase},
+		{Phase: model.DuringCoursePhase},
+		{Phase: model.AfterCoursePhase},
+	}
+	sums := make([]float64, len(phases))
+
+	for i := range pointList {
+		for j := range phases {
+			if phases[j].Phase != pointList[i].Phase {
+				continue
+			}
+			if phases[j].First == nil {
+				phases[j].First = &pointList[i].Value
+			}
+			phases[j].Last = &pointList[i].Value
+			phases[j].Count++
+			sums[j] += pointList[i].Value
+		}
+	}
+
+	for j := range phases {
+		if phases[j].Count > 0 {
+			mean := sums[j] / float64(phases[j].Count)
+			phases[j].Mean = &mean
+		}
+	}
+	return phases
+}
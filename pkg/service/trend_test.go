@@ -0,0 +1,75 @@
+package services
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTrend(t *testing.T) {
+	points := func() []model.TrendPoint {
+		return []model.TrendPoint{
+			{Date: "2024-01-01", Value: 20, Flag: model.NormalFlag},
+			{Date: "2024-01-11", Value: 40, Flag: model.HighFlag},
+			{Date: "2024-01-21", Value: 50, Flag: model.HighFlag},
+			{Date: "2024-01-21", Value: 30, Flag: model.NormalFlag},
+			{Date: "2024-02-10", Value: 10, Flag: model.LowFlag},
+		}
+	}
+
+	t.Run("Whole history", func(t *testing.T) {
+		trend := buildTrend(points(), nil)
+
+		assert.Equal(t, 20.0, *trend.Baseline)
+		assert.Nil(t, trend.Points[0].RateOfChange)
+		assert.Equal(t, 2.0, *trend.Points[1].RateOfChange)
+		assert.Nil(t, trend.Points[3].RateOfChange)
+		assert.Equal(t, -1.0, *trend.Points[4].RateOfChange)
+		assert.Equal(t, 0.0, *trend.Points[0].ChangeFromBaseline)
+		assert.Equal(t, 150.0, *trend.Points[2].ChangeFromBaseline)
+		assert.Equal(t, -50.0, *trend.Points[4].ChangeFromBaseline)
+		assert.Equal(t, []model.TrendStreak{
+			{Flag: model.HighFlag, From: "2024-01-11", To: "2024-01-21", Length: 2},
+			{Flag: model.LowFlag, From: "2024-02-10", To: "2024-02-10", Length: 1},
+		}, trend.Streaks)
+		assert.Empty(t, trend.Phases)
+	})
+
+	t.Run("Patient course", func(t *testing.T) {
+		trend := buildTrend(points(), &model.TrendCourse{BeginDate: "2024-01-10", EndDate: "2024-01-31"})
+
+		var phases []string
+		for _, point := range trend.Points {
+			phases = append(phases, point.Phase)
+		}
+		assert.Equal(t, []string{"before", "during", "during", "during", "after"}, phases)
+		assert.Equal(t, 20.0, *trend.Baseline)
+
+		mean := 40.0
+		assert.Equal(t, []model.TrendPhase{
+			{Phase: model.BeforeCoursePhase, Count: 1, First: &trend.Points[0].Value, Last: &trend.Points[0].Value, Mean: &trend.Points[0].Value},
+			{Phase: model.DuringCoursePhase, Count: 3, First: &trend.Points[1].Value, Last: &trend.Points[3].Value, Mean: &mean},
+			{Phase: model.AfterCoursePhase, Count: 1, First: &trend.Points[4].Value, Last: &trend.Points[4].Value, Mean: &trend.Points[4].Value},
+		}, trend.Phases)
+	})
+
+	t.Run("Ongoing course without earlier results", func(t *testing.T) {
+		trend := buildTrend(points(), &model.TrendCourse{BeginDate: "2023-12-01"})
+
+		assert.Equal(t, 20.0, *trend.Baseline)
+		for _, point := range trend.Points {
+			assert.Equal(t, model.DuringCoursePhase, point.Phase)
+		}
+		assert.Equal(t, 0, trend.Phases[0].Count)
+		assert.Nil(t, trend.Phases[0].Mean)
+	})
+
+	t.Run("No results", func(t *testing.T) {
+		trend := buildTrend([]model.TrendPoint{}, nil)
+
+		assert.Nil(t, trend.Baseline)
+		assert.Empty(t, trend.Points)
+		assert.Equal(t, []model.TrendStreak{}, trend.Streaks)
+	})
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"med/pkg/model"
+	"med/pkg/repository"
+)
+
+// ErrNoUnitConversion is returned when a value cannot be converted between the units.
+var ErrNoUnitConversion = errors.New("no conversion between units")
+
+type UnitConversionService struct {
+	repo repository.UnitConversion
+}
+
+func NewUnitConversionService(repo repository.UnitConversion) *UnitConversionService {
+	return &UnitConversionService{repo: repo}
+}
+
+func (s *UnitConversionService) CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error) {
+	return s.repo.CreateUnitConversion(unitConversion)
+}
+func (s *UnitConversionService) GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error) {
+	return s.repo.GetUnitConversionList(listQuery)
+}
+func (s *UnitConversionService) DeleteUnitConversion(from, to string) error {
+	return s.repo.DeleteUnitConversion(from, to)
+}
+
+// ConvertValue converts the value between the units with the stored conversion or the reverse of it.
+func (s *UnitConversionService) ConvertValue(value float64, from, to string) (float64, error) {
+	return convertValue(s.repo, value, from, to)
+}
+
+// convertValue converts the value between the units, a value in the same unit is returned as is.
+func convertValue(repo repository.UnitConversion, value float64, from, to string) (float64, error) {
+	if from == to {
+		return value, nil
+	}
+
+	unitConversion, err := findUnitConversion(repo, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return unitConversion.Convert(value), nil
+}
+
+// findUnitConversion returns the stored conversion between the units or the reverse of the stored conversion in the other direction.
+func findUnitConversion(repo repository.UnitConversion, from, to string) (model.UnitConversion, error) {
+	if from == to {
+		return model.UnitConversion{From: from, To: to, Factor: 1}, nil
+	}
+
+	unitConversion, err := repo.GetUnitConversion(from, to)
+	if errors.Is(err, sql.ErrNoRows) {
+		var reverse model.UnitConversion
+		if reverse, err = repo.GetUnitConversion(to, from); err == nil {
+			unitConversion = reverse.Reverse()
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UnitConversion{}, fmt.Errorf("%w %s and %s", ErrNoUnitConversion, from, to)
+	}
+	return unitConversion, err
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"database/sql"
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// conversionRepository keeps unit conversions in memory
+type conversionRepository []model.UnitConversion
+
+func (r conversionRepository) CreateUnitConversion(unitConversion model.UnitConversion) (model.UnitConversion, error) {
+	return unitConversion, nil
+}
+
+func (r conversionRepository) GetUnitConversionList(listQuery model.ListQuery) (model.Page[model.UnitConversion], error) {
+	return model.Page[model.UnitConversion]{Items: r}, nil
+}
+
+func (r conversionRepository) GetUnitConversion(from, to string) (model.UnitConversion, error) {
+	for _, unitConversion := range r {
+		if unitConversion.From == from && unitConversion.To == to {
+			return unitConversion, nil
+		}
+	}
+	return model.UnitConversion{}, sql.ErrNoRows
+}
+
+func (r conversionRepository) DeleteUnitConversion(from, to string) error {
+	return nil
+}
+
+func TestConvertValue(t *testing.T) {
+	repo := conversionRepository{
+		{From: "g/dl", To: "g/l", Factor: 10},
+		{From: "degC", To: "degF", Factor: 1.8, Offset: 32},
+	}
+
+	testTable := []struct {
+		name          string
+		value         float64
+		from, to      string
+		expectedValue float64
+		expectedError string
+	}{
+		{name: "Same unit", value: 12, from: "g/l", to: "g/l", expectedValue: 12},
+		{name: "Stored", value: 12.5, from: "g/dl", to: "g/l", expectedValue: 125},
+		{name: "Reverse", value: 125, from: "g/l", to: "g/dl", expectedValue: 12.5},
+		{name: "Offset", value: 37, from: "degC", to: "degF", expectedValue: 98.6},
+		{name: "Reverse offset", value: 98.6, from: "degF", to: "degC", expectedValue: 37},
+		{name: "Unknown", value: 1, from: "g/l", to: "mmol/l", expectedError: "no conversion between units g/l and mmol/l"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, err := convertValue(repo, testCase.value, testCase.from, testCase.to)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.ErrorIs(t, err, ErrNoUnitConversion)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, testCase.expectedValue, value, 1e-9)
+		})
+	}
+}
@@ -19,8 +19,8 @@ func (s *UnitMeasureService) CreateUnitMeasure(unitMeasure model.UnitMeasure) (m
 func (s *UnitMeasureService) GetUnitMeasureById(id string) (model.UnitMeasure, error) {
 	return s.repo.GetUnitMeasureById(id)
 }
-func (s *UnitMeasureService) GetUnitMeasureList() ([]model.UnitMeasure, error) {
-	return s.repo.GetUnitMeasureList()
+func (s *UnitMeasureService) GetUnitMeasureList(listQuery model.ListQuery) (model.Page[model.UnitMeasure], error) {
+	return s.repo.GetUnitMeasureList(listQuery)
 }
 func (s *UnitMeasureService) UpdateUnitMeasure(unitMeasure model.UnitMeasure) (model.UnitMeasure, error) {
 	return s.repo.UpdateUnitMeasure(unitMeasure)
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"med/pkg/model"
+	"med/pkg/repository"
+	"med/pkg/utils"
+	"slices"
+)
+
+// ErrInvalidRole is returned when a user is given a role that is not allowed for the user type.
+var ErrInvalidRole = errors.New("invalid role")
+
+// staffRoles are the roles internal staff accounts can be created with.
+var staffRoles = []string{model.AdminRole, model.DoctorRole, model.ResearcherRole}
+
+type UserService struct {
+	repo repository.User
+}
+
+func NewUserService(repo repository.User) *UserService {
+	return &UserService{repo: repo}
+}
+
+// CreateStaffUser creates an internal account for clinic staff.
+func (s *UserService) CreateStaffUser(staffUser model.StaffUser) (model.UserProfile, error) {
+	if !slices.Contains(staffRoles, staffUser.Role) {
+		return model.UserProfile{}, ErrInvalidRole
+	}
+
+	passwordHash, err := utils.GeneratePasswordHash(staffUser.Password)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+
+	return s.repo.CreateStaffUser(model.User{
+		Email:      staffUser.Email,
+		Password:   passwordHash,
+		Role:       staffUser.Role,
+		UserType:   model.InternalUserType,
+		FirstName:  staffUser.FirstName,
+		MiddleName: staffUser.MiddleName,
+		LastName:   staffUser.LastName,
+		Phone:      staffUser.Phone,
+	})
+}
+
+func (s *UserService) GetUserById(id int) (model.UserProfile, error) {
+	return s.repo.GetUserById(id)
+}
+
+func (s *UserService) GetUserList(filter model.UserFilter, listQuery model.ListQuery) (model.Page[model.UserProfile], error) {
+	return s.repo.GetUserList(filter, listQuery)
+}
+
+// MigrateLegacyUsers moves users from the legacy internal and external user tables into the unified table.
+func (s *UserService) MigrateLegacyUsers() (model.LegacyUserMigration, error) {
+	return s.repo.MigrateLegacyUsers()
+}
@@ -2,43 +2,91 @@ package utils
 
 import (
 	"fmt"
+	"med/pkg/config"
 	"net/smtp"
-	"os"
+	"strings"
+	"sync"
 )
 
-const (
-	smtpHost = "smtp.gmail.com"
-	smtpPort = "587"
-)
+// Mailer sends emails to users.
+type Mailer interface {
+	SendEmail(to []string, subject, body string) error
+}
 
-// EmailService provides functionality to send emails.
+// EmailService sends emails through an SMTP server.
 type EmailService struct {
+	host     string // SMTP server host
+	port     string // SMTP server port
 	from     string // Sender email address
 	password string // Sender email password
 }
 
-// NewEmailService creates a new EmailService instance.
-func NewEmailService() *EmailService {
+// NewEmailService creates a new EmailService instance from the email configuration.
+func NewEmailService(cfg *config.ConfigEmail) *EmailService {
 	return &EmailService{
-		from:     "from@gmail.com",
-		password: os.Getenv("EMAIL_PASSWORD"), // Get email password from environment variable
+		host:     cfg.Host,
+		port:     cfg.Port,
+		from:     cfg.From,
+		password: cfg.Password,
 	}
 }
 
 // Authentication returns an smtp.Auth object for authentication.
+// Local SMTP stand-ins usually accept mail without authentication, so no auth is used without a password.
 func (es *EmailService) Authentication() smtp.Auth {
-	return smtp.PlainAuth("", es.from, es.password, smtpHost)
+	if es.password == "" {
+		return nil
+	}
+	return smtp.PlainAuth("", es.from, es.password, es.host)
 }
 
 // SendEmail sends an email with the provided subject and body to the specified recipients.
 func (es *EmailService) SendEmail(to []string, subject, body string) error {
-	// Construct email message with subject and body
-	message := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body))
+	// Construct email message with headers and body
+	message := buildMessage(es.from, to, subject, body)
 
 	// Construct SMTP server address
-	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	addr := fmt.Sprintf("%s:%s", es.host, es.port)
 
 	// Send email using SMTP server and authentication
 	err := smtp.SendMail(addr, es.Authentication(), es.from, to, message)
 	return err
 }
+
+// buildMessage constructs an email message with the given headers and body.
+func buildMessage(from string, to []string, subject, body string) []byte {
+	return []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", from, strings.Join(to, ", "), subject, body))
+}
+
+// Email is a message captured by MemoryMailer.
+type Email struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// MemoryMailer keeps sent emails in memory instead of sending them. Useful for tests and local runs.
+type MemoryMailer struct {
+	mu     sync.Mutex
+	emails []Email
+}
+
+// NewMemoryMailer creates a new MemoryMailer instance.
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// SendEmail stores the email in memory.
+func (mm *MemoryMailer) SendEmail(to []string, subject, body string) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.emails = append(mm.emails, Email{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// Emails returns a copy of the emails sent so far.
+func (mm *MemoryMailer) Emails() []Email {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	return append([]Email(nil), mm.emails...)
+}
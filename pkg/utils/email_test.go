@@ -1 +1,24 @@
 package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMessage(t *testing.T) {
+	message := buildMessage("from@mail.ru", []string{"first@mail.ru", "second@mail.ru"}, "Subject", "Body")
+
+	expected := "From: from@mail.ru\r\nTo: first@mail.ru, second@mail.ru\r\nSubject: Subject\r\n\r\nBody"
+	assert.Equal(t, expected, string(message))
+}
+
+func TestMemoryMailer(t *testing.T) {
+	mailer := NewMemoryMailer()
+
+	err := mailer.SendEmail([]string{"user@mail.ru"}, "Subject", "Body")
+	assert.NoError(t, err)
+
+	emails := mailer.Emails()
+	assert.Equal(t, []Email{{To: []string{"user@mail.ru"}, Subject: "Subject", Body: "Body"}}, emails)
+}
@@ -3,14 +3,36 @@ package utils
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 )
 
-// Salt is the salt value used for password hashing.
+// Salt is the global salt value used by legacy SHA-512 password hashes.
 var Salt = []byte(os.Getenv("SALT"))
 
+// argon2id parameters used for new password hashes.
+const (
+	argonTime    uint32 = 1
+	argonMemory  uint32 = 64 * 1024
+	argonThreads uint8  = 4
+	argonKeyLen  uint32 = 32
+	argonSaltLen        = 16
+)
+
+// argonPrefix marks password hashes produced by argon2id. Hashes without a prefix are legacy SHA-512 hex strings.
+const argonPrefix = "$argon2id$"
+
+// ErrInvalidHash is returned when a stored password hash cannot be parsed.
+var ErrInvalidHash = errors.New("invalid password hash format")
+
 // generateRandomSalt generates a random salt of the specified size.
 func generateRandomSalt() []byte {
 	saltSize, err := strconv.Atoi(os.Getenv("SALT_SIZE"))
@@ -25,7 +47,8 @@ func generateRandomSalt() []byte {
 	return salt
 }
 
-// HashPassword generates a hash for the given password using SHA-512 algorithm and the provided salt.
+// HashPassword generates a legacy hash for the given password using SHA-512 algorithm and the provided salt.
+// New passwords are hashed with GeneratePasswordHash, HashPassword is kept to verify existing hashes.
 func HashPassword(password string, salt []byte) string {
 	passwordBytes := []byte(password)
 	sha512Hasher := sha512.New()
@@ -41,3 +64,67 @@ func DoPasswordsMatch(hashedPassword, currPassword string, salt []byte) bool {
 	currPasswordHash := HashPassword(currPassword, salt)
 	return hashedPassword == currPasswordHash
 }
+
+// GeneratePasswordHash hashes the password with argon2id and a random per-user salt.
+// The hash is encoded in the PHC string format: $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+func GeneratePasswordHash(password string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argonPrefix,
+		argon2.Version,
+		argonMemory,
+		argonTime,
+		argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword checks the password against the stored hash. Legacy SHA-512 hashes are checked with the global Salt.
+// needsRehash reports that the password matched but the hash is outdated and should be replaced with GeneratePasswordHash.
+func VerifyPassword(hash, password string) (match, needsRehash bool, err error) {
+	if !strings.HasPrefix(hash, argonPrefix) {
+		match = subtle.ConstantTimeCompare([]byte(hash), []byte(HashPassword(password, Salt))) == 1
+		return match, match, nil
+	}
+
+	var (
+		version      int
+		memory, time uint32
+		threads      uint8
+	)
+	parts := strings.Split(strings.TrimPrefix(hash, argonPrefix), "$")
+	if len(parts) != 4 {
+		return false, false, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, false, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, false, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return false, false, ErrInvalidHash
+	}
+
+	currKey := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, currKey) != 1 {
+		return false, false, nil
+	}
+
+	// Hashes made with weaker parameters are upgraded as well
+	needsRehash = memory != argonMemory || time != argonTime || threads != argonThreads || uint32(len(key)) != argonKeyLen
+	return true, needsRehash, nil
+}
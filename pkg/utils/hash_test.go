@@ -2,11 +2,14 @@ package utils
 
 import (
 	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
 )
 
 func TestGenerateRandomSaltSizeErr(t *testing.T) {
@@ -50,3 +53,78 @@ func generateExpectedHash(password string, salt []byte) string {
 	hashedPasswordHex := hex.EncodeToString(hashedPasswordBytes)
 	return hashedPasswordHex
 }
+
+func TestGeneratePasswordHash(t *testing.T) {
+	hash, err := GeneratePasswordHash("password")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=1,p=4$"))
+
+	// Every hash gets its own salt
+	otherHash, err := GeneratePasswordHash("password")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := GeneratePasswordHash("password")
+	assert.NoError(t, err)
+
+	weakHash := "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$" + base64.RawStdEncoding.EncodeToString(
+		argon2.IDKey([]byte("password"), []byte("saltsalt"), 1, 1024, 1, 32))
+
+	testTable := []struct {
+		name                string
+		hash                string
+		password            string
+		expectedMatch       bool
+		expectedNeedsRehash bool
+		expectedErr         error
+	}{
+		{
+			name:          "Argon2id match",
+			hash:          hash,
+			password:      "password",
+			expectedMatch: true,
+		},
+		{
+			name:     "Argon2id mismatch",
+			hash:     hash,
+			password: "incorrectPassword",
+		},
+		{
+			name:                "Argon2id with outdated parameters",
+			hash:                weakHash,
+			password:            "password",
+			expectedMatch:       true,
+			expectedNeedsRehash: true,
+		},
+		{
+			name:                "Legacy match",
+			hash:                HashPassword("password", Salt),
+			password:            "password",
+			expectedMatch:       true,
+			expectedNeedsRehash: true,
+		},
+		{
+			name:     "Legacy mismatch",
+			hash:     HashPassword("password", Salt),
+			password: "incorrectPassword",
+		},
+		{
+			name:        "Malformed hash",
+			hash:        "$argon2id$v=19$broken",
+			password:    "password",
+			expectedErr: ErrInvalidHash,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			match, needsRehash, err := VerifyPassword(testCase.hash, testCase.password)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedMatch, match)
+			assert.Equal(t, testCase.expectedNeedsRehash, needsRehash)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidHL7 is returned for a message that does not start with a valid MSH segment.
+var ErrInvalidHL7 = errors.New("invalid HL7 message")
+
+// ErrInvalidMLLP is returned for a malformed MLLP frame or a frame larger than maxMLLPMessageSize.
+var ErrInvalidMLLP = errors.New("invalid MLLP frame")
+
+// MLLP wraps a message in a start block and an end block followed by a carriage return
+const (
+	mllpStartBlock     = 0x0b
+	mllpEndBlock       = 0x1c
+	mllpCarriageReturn = 0x0d
+	maxMLLPMessageSize = 1 << 20
+)
+
+// HL7Delimiters are the separators and the escape character of an HL7 v2 message, set by the MSH segment.
+type HL7Delimiters struct {
+	FieldSeparator        byte
+	ComponentSeparator    byte
+	RepetitionSeparator   byte
+	EscapeCharacter       byte
+	SubcomponentSeparator byte
+}
+
+// DefaultHL7Delimiters are the delimiters recommended by the standard, |^~\&
+var DefaultHL7Delimiters = HL7Delimiters{
+	FieldSeparator: '|', ComponentSeparator: '^', RepetitionSeparator: '~', EscapeCharacter: '\\', SubcomponentSeparator: '&',
+}
+
+// EncodingCharacters is the value of MSH-2
+func (d HL7Delimiters) EncodingCharacters() string {
+	return string([]byte{d.ComponentSeparator, d.RepetitionSeparator, d.EscapeCharacter, d.SubcomponentSeparator})
+}
+
+// Escape replaces the delimiters in the text with their escape sequences.
+func (d HL7Delimiters) Escape(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case d.FieldSeparator:
+			builder.WriteString(string(d.EscapeCharacter) + "F" + string(d.EscapeCharacter))
+		case d.ComponentSeparator:
+			builder.WriteString(string(d.EscapeCharacter) + "S" + string(d.EscapeCharacter))
+		case d.RepetitionSeparator:
+			builder.WriteString(string(d.EscapeCharacter) + "R" + string(d.EscapeCharacter))
+		case d.SubcomponentSeparator:
+			builder.WriteString(string(d.EscapeCharacter) + "T" + string(d.EscapeCharacter))
+		case d.EscapeCharacter:
+			builder.WriteString(string(d.EscapeCharacter) + "E" + string(d.EscapeCharacter))
+		case '\r', '\n':
+			// A line break is a single escape sequence whether it is CR, LF or CR LF
+			if value[i] == '\r' && i+1 < len(value) && value[i+1] == '\n' {
+				continue
+			}
+			builder.WriteString(string(d.EscapeCharacter) + ".br" + string(d.EscapeCharacter))
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
+
+// Unescape replaces the escape sequences of the delimiters, line breaks and hexadecimal data in the text.
+// Formatting and character set sequences are dropped.
+func (d HL7Delimiters) Unescape(value string) string {
+	if strings.IndexByte(value, d.EscapeCharacter) < 0 {
+		return value
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != d.EscapeCharacter {
+			builder.WriteByte(value[i])
+			continue
+		}
+		end := strings.IndexByte(value[i+1:], d.EscapeCharacter)
+		if end < 0 {
+			builder.WriteString(value[i:])
+			break
+		}
+		sequence := value[i+1 : i+1+end]
+		i += end + 1
+
+		switch {
+		case sequence == "F":
+			builder.WriteByte(d.FieldSeparator)
+		case sequence == "S":
+			builder.WriteByte(d.ComponentSeparator)
+		case sequence == "R":
+			builder.WriteByte(d.RepetitionSeparator)
+		case sequence == "T":
+			builder.WriteByte(d.SubcomponentSeparator)
+		case sequence == "E":
+			builder.WriteByte(d.EscapeCharacter)
+		case sequence == ".br":
+			builder.WriteByte('\n')
+		case strings.HasPrefix(sequence, "X"):
+			for j := 1; j+1 < len(sequence); j += 2 {
+				if b, err := strconv.ParseUint(sequence[j:j+2], 16, 8); err == nil {
+					builder.WriteByte(byte(b))
+				}
+			}
+		}
+	}
+	return builder.String()
+}
+
+// Component returns the unescaped component of the field value, numbered from 1
+func (d HL7Delimiters) Component(value string, n int) string {
+	components := strings.Split(value, string(d.ComponentSeparator))
+	if n < 1 || n > len(components) {
+		return ""
+	}
+	return d.Unescape(components[n-1])
+}
+
+// Join escapes the components and joins them into a field value, trailing empty components are left out.
+func (d HL7Delimiters) Join(components ...string) string {
+	escaped := make([]string, len(components))
+	for i, component := range components {
+		escaped[i] = d.Escape(component)
+	}
+	return strings.TrimRight(strings.Join(escaped, string(d.ComponentSeparator)), string(d.ComponentSeparator))
+}
+
+// Segment joins the name and the field values, which must be escaped already, into a segment.
+// The fields of MSH start with MSH-2, the field separator being MSH-1. Trailing empty fields are left out.
+func (d HL7Delimiters) Segment(name string, fields ...string) string {
+	return strings.TrimRight(name+string(d.FieldSeparator)+strings.Join(fields, string(d.FieldSeparator)), string(d.FieldSeparator))
+}
+
+// HL7Segment is a segment of an HL7 v2 message. Fields are numbered from 1 as in the standard.
+type HL7Segment struct {
+	Name       string
+	Delimiters HL7Delimiters
+	fields     []string
+}
+
+// Field returns the raw value of the field with its repetitions, components and escape sequences
+func (s HL7Segment) Field(n int) string {
+	// MSH-1 is the field separator itself, so the split fields of MSH are shifted by one
+	if s.Name == "MSH" {
+		if n == 1 {
+			return string(s.Delimiters.FieldSeparator)
+		}
+		n--
+	}
+	if n < 1 || n >= len(s.fields) {
+		return ""
+	}
+	return s.fields[n]
+}
+
+// Repetitions returns the raw values of the repetitions of the field, an empty field has none
+func (s HL7Segment) Repetitions(n int) []string {
+	field := s.Field(n)
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, string(s.Delimiters.RepetitionSeparator))
+}
+
+// Component returns the unescaped component of the first repetition of the field
+func (s HL7Segment) Component(n, c int) string {
+	field, _, _ := strings.Cut(s.Field(n), string(s.Delimiters.RepetitionSeparator))
+	return s.Delimiters.Component(field, c)
+}
+
+// HL7Message is an HL7 v2 message in the ER7 encoding. The first segment is MSH.
+type HL7Message struct {
+	Delimiters HL7Delimiters
+	Segments   []HL7Segment
+}
+
+// Header returns the MSH segment
+func (m HL7Message) Header() HL7Segment {
+	return m.Segments[0]
+}
+
+// ParseHL7 splits the message into segments with the delimiters set by MSH. Segments are separated by carriage returns,
+// line feeds are accepted as well, so that messages saved to files can be parsed.
+func ParseHL7(message string) (HL7Message, error) {
+	lines := strings.FieldsFunc(message, func(r rune) bool { return r == '\r' || r == '\n' })
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "MSH") || len(lines[0]) < 8 {
+		return HL7Message{}, fmt.Errorf("%w: the message does not start with an MSH segment", ErrInvalidHL7)
+	}
+
+	// MSH-2 has four encoding characters, HL7 v2.7 adds the truncation character as the fifth
+	header := lines[0]
+	delimiters := HL7Delimiters{FieldSeparator: header[3], ComponentSeparator: header[4], RepetitionSeparator: header[5],
+		EscapeCharacter: header[6], SubcomponentSeparator: header[7]}
+	if encoding, _, _ := strings.Cut(header[4:], string(delimiters.FieldSeparator)); len(encoding) < 4 || len(encoding) > 5 {
+		return HL7Message{}, fmt.Errorf("%w: MSH-2 encoding characters %q", ErrInvalidHL7, encoding)
+	}
+
+	parsed := HL7Message{Delimiters: delimiters}
+	for _, line := range lines {
+		fields := strings.Split(line, string(delimiters.FieldSeparator))
+		if len(fields[0]) != 3 {
+			return HL7Message{}, fmt.Errorf("%w: segment name %q", ErrInvalidHL7, fields[0])
+		}
+		parsed.Segments = append(parsed.Segments, HL7Segment{Name: fields[0], Delimiters: delimiters, fields: fields})
+	}
+	return parsed, nil
+}
+
+// ReadMLLP reads the message of the next MLLP frame, bytes before the start block are skipped.
+// It returns io.EOF if the connection is closed between frames.
+func ReadMLLP(r *bufio.Reader) (string, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b == mllpStartBlock {
+			break
+		}
+	}
+
+	var message []byte
+	for {
+		b, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("%w: the frame has no end block", ErrInvalidMLLP)
+		}
+		if err != nil {
+			return "", err
+		}
+		if b == mllpEndBlock {
+			break
+		}
+		if len(message) == maxMLLPMessageSize {
+			return "", fmt.Errorf("%w: the message is larger than %d bytes", ErrInvalidMLLP, maxMLLPMessageSize)
+		}
+		message = append(message, b)
+	}
+
+	if b, err := r.ReadByte(); err != nil || b != mllpCarriageReturn {
+		return "", fmt.Errorf("%w: the end block is not followed by a carriage return", ErrInvalidMLLP)
+	}
+	return string(message), nil
+}
+
+// WriteMLLP writes the message in an MLLP frame
+func WriteMLLP(w io.Writer, message string) error {
+	frame := make([]byte, 0, len(message)+3)
+	frame = append(frame, mllpStartBlock)
+	frame = append(frame, message...)
+	frame = append(frame, mllpEndBlock, mllpCarriageReturn)
+	_, err := w.Write(frame)
+	return err
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHL7(t *testing.T) {
+	message := "MSH|^~\\&|ANALYZER|LAB|OncoBase|CLINIC|20240301083000||ORU^R01|MSG-1|P|2.5\r" +
+		"PID|||123-456-789 01^^^SNILS^SNILS~42^^^OncoBase^PI||Ivanova^Anna\r" +
+		"OBX|1|NM|718-7^Hemoglobin^LN||12.5|g/dl^^UCUM|||||F\n" +
+		"NTE|1||Sample \\T\\ control\\.br\\ok\r"
+
+	parsed, err := ParseHL7(message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHL7Delimiters, parsed.Delimiters)
+	assert.Len(t, parsed.Segments, 4)
+
+	header := parsed.Header()
+	assert.Equal(t, "|", header.Field(1))
+	assert.Equal(t, "^~\\&", header.Field(2))
+	assert.Equal(t, "ANALYZER", header.Field(3))
+	assert.Equal(t, "R01", header.Component(9, 2))
+	assert.Equal(t, "MSG-1", header.Field(10))
+
+	pid := parsed.Segments[1]
+	assert.Equal(t, []string{"123-456-789 01^^^SNILS^SNILS", "42^^^OncoBase^PI"}, pid.Repetitions(3))
+	assert.Equal(t, "123-456-789 01", pid.Component(3, 1))
+	assert.Equal(t, "Anna", pid.Component(5, 2))
+	assert.Empty(t, pid.Repetitions(2))
+	assert.Empty(t, pid.Field(30))
+
+	assert.Equal(t, "g/dl", parsed.Segments[2].Component(6, 1))
+	assert.Equal(t, "Sample & control\nok", parsed.Segments[3].Component(3, 1))
+}
+
+func TestParseHL7Delimiters(t *testing.T) {
+	parsed, err := ParseHL7("MSH#*$!@#APP\rOBX#1#SN#X*Y##>*5$6")
+
+	assert.NoError(t, err)
+	assert.Equal(t, HL7Delimiters{FieldSeparator: '#', ComponentSeparator: '*', RepetitionSeparator: '$', EscapeCharacter: '!', SubcomponentSeparator: '@'},
+		parsed.Delimiters)
+	assert.Equal(t, "APP", parsed.Header().Field(3))
+	assert.Equal(t, ">", parsed.Segments[1].Component(5, 1))
+	assert.Equal(t, []string{">*5", "6"}, parsed.Segments[1].Repetitions(5))
+}
+
+func TestParseHL7Invalid(t *testing.T) {
+	testTable := []struct {
+		name          string
+		message       string
+		expectedError string
+	}{
+		{
+			name:          "Empty",
+			message:       "\r\n",
+			expectedError: "invalid HL7 message: the message does not start with an MSH segment",
+		},
+		{
+			name:          "No MSH",
+			message:       "PID|||1\r",
+			expectedError: "invalid HL7 message: the message does not start with an MSH segment",
+		},
+		{
+			name:          "Encoding characters",
+			message:       "MSH|^~|APP\r",
+			expectedError: `invalid HL7 message: MSH-2 encoding characters "^~"`,
+		},
+		{
+			name:          "Segment name",
+			message:       "MSH|^~\\&|APP\rOBSERVATION|1\r",
+			expectedError: `invalid HL7 message: segment name "OBSERVATION"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseHL7(testCase.message)
+
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.ErrorIs(t, err, ErrInvalidHL7)
+		})
+	}
+}
+
+func TestHL7Escape(t *testing.T) {
+	d := DefaultHL7Delimiters
+	value := "a|b^c~d\\e&f\r\ng\nh"
+
+	assert.Equal(t, "a\\F\\b\\S\\c\\R\\d\\E\\e\\T\\f\\.br\\g\\.br\\h", d.Escape(value))
+	assert.Equal(t, "a|b^c~d\\e&f\ng\nh", d.Unescape(d.Escape(value)))
+	assert.Equal(t, "AB", d.Unescape("\\X4142\\\\H\\"))
+	assert.Equal(t, "open\\", d.Unescape("open\\"))
+	assert.Equal(t, "ACK^R01^ACK", d.Join("ACK", "R01", "ACK"))
+	assert.Equal(t, "x\\S\\y", d.Join("x^y", "", ""))
+	assert.Equal(t, "MSA|AA|1", d.Segment("MSA", "AA", "1", ""))
+}
+
+func TestMLLP(t *testing.T) {
+	var frames bytes.Buffer
+	assert.NoError(t, WriteMLLP(&frames, "MSH|^~\\&|A\r"))
+	assert.NoError(t, WriteMLLP(&frames, "MSH|^~\\&|B\r"))
+	assert.Equal(t, "\x0bMSH|^~\\&|A\r\x1c\r\x0bMSH|^~\\&|B\r\x1c\r", frames.String())
+
+	reader := bufio.NewReader(io.MultiReader(strings.NewReader("\n"), &frames))
+	message, err := ReadMLLP(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "MSH|^~\\&|A\r", message)
+	message, err = ReadMLLP(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "MSH|^~\\&|B\r", message)
+	_, err = ReadMLLP(reader)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = ReadMLLP(bufio.NewReader(strings.NewReader("\x0bMSH|^~\\&|A\r")))
+	assert.EqualError(t, err, "invalid MLLP frame: the frame has no end block")
+	_, err = ReadMLLP(bufio.NewReader(strings.NewReader("\x0bMSH|^~\\&|A\r\x1c\x0b")))
+	assert.ErrorIs(t, err, ErrInvalidMLLP)
+}
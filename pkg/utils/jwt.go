@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"med/pkg/model"
 	"os"
 	"time"
@@ -16,6 +20,27 @@ var jwtKey = []byte(os.Getenv("JWT_KEY"))
 // tokenTTL specifies the time-to-live (TTL) duration for JWT tokens.
 const tokenTTL = 30 * time.Minute
 
+// RefreshTokenTTL specifies the time-to-live (TTL) duration for refresh tokens.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// ResetTokenTTL specifies the time-to-live (TTL) duration for password reset tokens.
+const ResetTokenTTL = time.Hour
+
+// RegistrationCodeTTL specifies the time-to-live (TTL) duration for patient registration codes.
+const RegistrationCodeTTL = 24 * time.Hour
+
+// InvitationTTL specifies the time-to-live (TTL) duration for staff invitations.
+const InvitationTTL = 7 * 24 * time.Hour
+
+// registrationCodeLength is the number of digits in a patient registration code.
+const registrationCodeLength = 8
+
+// tokenIdSize is the number of random bytes in a JWT ID.
+const tokenIdSize = 16
+
+// refreshTokenSize is the number of random bytes in a refresh token.
+const refreshTokenSize = 32
+
 // tokenClaims represents the custom claims to be included in JWT tokens.
 type tokenClaims struct {
 	UserId               int    `json:"id"`   // User ID associated with the token
@@ -28,11 +53,18 @@ func GenerateJWT(user model.User) (string, error) {
 	// Calculate token expiration time
 	expirationTime := time.Now().Add(tokenTTL)
 
+	// Generate unique token ID so the token can be revoked
+	tokenId, err := randomToken(tokenIdSize)
+	if err != nil {
+		return "", err
+	}
+
 	// Create custom claims
 	claims := &tokenClaims{
 		UserId:   user.Id,
 		UserRole: user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -67,3 +99,46 @@ func ParseToken(accessToken string) (*tokenClaims, error) {
 	}
 	return claims, nil
 }
+
+// GenerateRefreshToken generates an opaque random refresh token.
+func GenerateRefreshToken() (string, error) {
+	return randomToken(refreshTokenSize)
+}
+
+// GenerateResetToken generates an opaque random password reset token.
+func GenerateResetToken() (string, error) {
+	return randomToken(refreshTokenSize)
+}
+
+// GenerateInvitationToken generates an opaque random invitation token.
+func GenerateInvitationToken() (string, error) {
+	return randomToken(refreshTokenSize)
+}
+
+// GenerateRegistrationCode generates a numeric code that is easy to hand over to a patient.
+func GenerateRegistrationCode() (string, error) {
+	code := make([]byte, registrationCodeLength)
+	for i := range code {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + digit.Int64())
+	}
+	return string(code), nil
+}
+
+// HashToken returns the SHA-256 hash of the token, so only hashes are stored server-side.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// randomToken generates a hex encoded random string of the given size in bytes.
+func randomToken(size int) (string, error) {
+	tokenBytes := make([]byte, size)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
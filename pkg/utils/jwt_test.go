@@ -1 +1,57 @@
 package utils
+
+import (
+	"med/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateJWT(t *testing.T) {
+	user := model.User{Id: 42, Role: "doctor"}
+
+	token, err := GenerateJWT(user)
+	assert.NoError(t, err)
+
+	claims, err := ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Id, claims.UserId)
+	assert.Equal(t, user.Role, claims.UserRole)
+	assert.Len(t, claims.ID, 2*tokenIdSize, "Token should carry a unique ID")
+	assert.True(t, claims.ExpiresAt.After(claims.IssuedAt.Time))
+
+	otherToken, err := GenerateJWT(user)
+	assert.NoError(t, err)
+	otherClaims, err := ParseToken(otherToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, claims.ID, otherClaims.ID, "Token IDs should be unique")
+}
+
+func TestParseTokenInvalid(t *testing.T) {
+	_, err := ParseToken("invalid.token.value")
+	assert.Error(t, err)
+}
+
+func TestGenerateRefreshToken(t *testing.T) {
+	token, err := GenerateRefreshToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 2*refreshTokenSize)
+
+	otherToken, err := GenerateRefreshToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, otherToken, "Refresh tokens should be unique")
+}
+
+func TestHashToken(t *testing.T) {
+	hash := HashToken("token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashToken("token"), "Hash should be deterministic")
+	assert.NotEqual(t, hash, HashToken("other token"))
+}
+
+func TestGenerateRegistrationCode(t *testing.T) {
+	code, err := GenerateRegistrationCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-9]{8}$`, code)
+}
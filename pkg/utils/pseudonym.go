@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+)
+
+// Pseudonym returns a stable pseudonym of the patient id keyed with the key, the id cannot be recovered without the key.
+func Pseudonym(key []byte, patientId int) string {
+	return EntityPseudonym(key, "patient", patientId)
+}
+
+// EntityPseudonym returns a stable pseudonym of the id of an entity keyed with the key,
+// ids of different entities get unrelated pseudonyms.
+func EntityPseudonym(key []byte, entity string, id int) string {
+	return hex.EncodeToString(keyedHash(key, entity+":"+strconv.Itoa(id)))[:16]
+}
+
+// DateShift returns a stable number of days in -maxDays..maxDays to shift the dates of the patient by, keyed with the key.
+func DateShift(key []byte, patientId int, maxDays int) int {
+	hash := binary.BigEndian.Uint64(keyedHash(key, "date-shift:"+strconv.Itoa(patientId)))
+	return int(hash%uint64(2*maxDays+1)) - maxDays
+}
+
+func keyedHash(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPseudonym(t *testing.T) {
+	key := []byte("export key")
+
+	assert.Len(t, Pseudonym(key, 1), 16)
+	assert.Equal(t, Pseudonym(key, 1), Pseudonym(key, 1))
+	assert.NotEqual(t, Pseudonym(key, 1), Pseudonym(key, 2))
+	assert.NotEqual(t, Pseudonym(key, 1), Pseudonym([]byte("another key"), 1))
+}
+
+func TestEntityPseudonym(t *testing.T) {
+	key := []byte("export key")
+
+	assert.Equal(t, Pseudonym(key, 1), EntityPseudonym(key, "patient", 1))
+	assert.NotEqual(t, EntityPseudonym(key, "patient", 1), EntityPseudonym(key, "patient_course", 1))
+}
+
+func TestDateShift(t *testing.T) {
+	key := []byte("export key")
+
+	shifts := map[int]bool{}
+	for patientId := 1; patientId <= 100; patientId++ {
+		shift := DateShift(key, patientId, 182)
+		assert.GreaterOrEqual(t, shift, -182)
+		assert.LessOrEqual(t, shift, 182)
+		assert.Equal(t, shift, DateShift(key, patientId, 182))
+		shifts[shift] = true
+	}
+	assert.Greater(t, len(shifts), 50)
+}
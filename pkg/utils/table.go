@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedTable is returned for a file that is neither CSV nor XLSX.
+var ErrUnsupportedTable = errors.New("unsupported table format, expected .csv or .xlsx")
+
+// ErrTableTooLarge is returned for a table with more than maxTableCells cells or an XLSX part larger than maxXLSXPartSize.
+var ErrTableTooLarge = errors.New("table is too large")
+
+const (
+	// maxTableCells bounds the cells of a table after its rows are padded to the same length
+	maxTableCells = 1_000_000
+	// maxXLSXPartSize bounds a decompressed part of an XLSX workbook, the sizes in the zip headers are not trusted
+	maxXLSXPartSize = 50 << 20
+	// The last column XFD and the last row of an XLSX worksheet
+	maxXLSXColumns = 16384
+	maxXLSXRows    = 1_048_576
+)
+
+// ReadTable reads the rows of a CSV file or of the first worksheet of an XLSX workbook, chosen by the file extension.
+// Cells are returned as text, rows are padded to the same length.
+func ReadTable(name string, r io.Reader) ([][]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		rows, err = readCSV(r)
+	case ".xlsx":
+		rows, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedTable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width*len(rows) > maxTableCells {
+		return nil, fmt.Errorf("%w: %d rows of %d columns, at most %d cells", ErrTableTooLarge, len(rows), width, maxTableCells)
+	}
+	for i := range rows {
+		for len(rows[i]) < width {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows, nil
+}
+
+// readCSV reads comma or semicolon separated values, spreadsheets in locales with a decimal comma save the latter
+func readCSV(r io.Reader) ([][]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	header, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+// Parts of an XLSX workbook needed to read the cell values
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			Id string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			Id     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxWorksheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+// readXLSX reads the cell values of the first worksheet of a workbook
+func readXLSX(r io.Reader) ([][]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	var workbook xlsxWorkbook
+	if err := readXLSXPart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, errors.New("invalid xlsx file: workbook has no worksheets")
+	}
+	var relationships xlsxRelationships
+	if err := readXLSXPart(archive, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+
+	sheetPath := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.Id == workbook.Sheets[0].Id {
+			// Targets are relative to the xl directory unless they start with a slash
+			sheetPath = path.Join("xl", relationship.Target)
+			if strings.HasPrefix(relationship.Target, "/") {
+				sheetPath = strings.TrimPrefix(relationship.Target, "/")
+			}
+		}
+	}
+	if sheetPath == "" {
+		return nil, errors.New("invalid xlsx file: first worksheet not found")
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if err := readXLSXPart(archive, "xl/sharedStrings.xml", &sharedStrings); err != nil && !errors.Is(err, errNoXLSXPart) {
+		return nil, err
+	}
+	var worksheet xlsxWorksheet
+	if err := readXLSXPart(archive, sheetPath, &worksheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(worksheet.Rows))
+	cells := 0
+	for _, xlsxRow := range worksheet.Rows {
+		if xlsxRow.Number > maxXLSXRows {
+			return nil, fmt.Errorf("invalid xlsx file: row %d is past the last row %d", xlsxRow.Number, maxXLSXRows)
+		}
+		// Empty rows are left out of the worksheet, keep them so row numbers match the spreadsheet
+		for xlsxRow.Number > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+
+		var row []string
+		for _, cell := range xlsxRow.Cells {
+			column := len(row)
+			if cell.Ref != "" {
+				if column, err = xlsxColumn(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if column >= len(row) {
+				if cells += column + 1 - len(row); cells > maxTableCells {
+					return nil, fmt.Errorf("%w: more than %d cells", ErrTableTooLarge, maxTableCells)
+				}
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				i, err := strconv.Atoi(cell.Value)
+				if err != nil || i < 0 || i >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid xlsx file: cell %s refers to unknown shared string", cell.Ref)
+				}
+				row[column] = sharedStrings.Items[i].String()
+			case "inlineStr":
+				row[column] = cell.Inline.String()
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+var errNoXLSXPart = errors.New("invalid xlsx file: missing part")
+
+func readXLSXPart(archive *zip.Reader, name string, v interface{}) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w %s", errNoXLSXPart, name)
+	}
+	defer file.Close()
+
+	if err := xml.NewDecoder(&xlsxPartReader{r: file, remaining: maxXLSXPartSize + 1}).Decode(v); err != nil {
+		return fmt.Errorf("invalid xlsx file: %s: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxPartReader fails once the part is larger than maxXLSXPartSize, so a zip bomb is not decompressed
+type xlsxPartReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (p *xlsxPartReader) Read(b []byte) (int, error) {
+	if p.remaining == 0 {
+		return 0, fmt.Errorf("%w: part is larger than %d bytes", ErrTableTooLarge, maxXLSXPartSize)
+	}
+	if int64(len(b)) > p.remaining {
+		b = b[:p.remaining]
+	}
+	n, err := p.r.Read(b)
+	p.remaining -= int64(n)
+	return n, err
+}
+
+// xlsxColumn returns the zero-based column index of a cell reference such as AB12, up to the last column XFD
+func xlsxColumn(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		letters++
+		// Stop before a long reference overflows
+		if column > maxXLSXColumns {
+			return 0, fmt.Errorf("invalid xlsx file: cell reference %q is past the last column XFD", ref)
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("invalid xlsx file: invalid cell reference %q", ref)
+	}
+	return column - 1, nil
+}
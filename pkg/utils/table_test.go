@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTableCSV(t *testing.T) {
+	testTable := []struct {
+		name         string
+		content      string
+		expectedRows [][]string
+	}{
+		{
+			name:         "Comma",
+			content:      "procedure,CA15-3,HGB\n5,31.5,120\n6,,118\n",
+			expectedRows: [][]string{{"procedure", "CA15-3", "HGB"}, {"5", "31.5", "120"}, {"6", "", "118"}},
+		},
+		{
+			name:         "Semicolon with BOM",
+			content:      "\xef\xbb\xbfprocedure;CA15-3\n5;31,5\n7\n",
+			expectedRows: [][]string{{"procedure", "CA15-3"}, {"5", "31,5"}, {"7", ""}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := ReadTable("results.CSV", strings.NewReader(testCase.content))
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRows, rows)
+		})
+	}
+}
+
+func TestReadTableXLSX(t *testing.T) {
+	content := xlsxFile(t, map[string]string{
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>procedure</t></si><si><r><t>CA</t></r><r><t>15-3</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1"><v>1</v></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>HGB</t></is></c></row>` +
+			`<row r="3"><c r="A3"><v>5</v></c><c r="C3"><v>120.5</v></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadTable("results.xlsx", content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"procedure", "CA15-3", "HGB"}, {"", "", ""}, {"5", "", "120.5"}}, rows)
+}
+
+func TestReadTableXLSXLimits(t *testing.T) {
+	worksheet := func(rows string) string {
+		return `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`
+	}
+
+	testTable := []struct {
+		name          string
+		worksheet     string
+		expectedError string
+		tooLarge      bool
+	}{
+		{
+			name:      "Last cell",
+			worksheet: worksheet(`<row r="1"><c r="XFD1"><v>1</v></c></row><row r="2"><c r="A2"><v>2</v></c></row>`),
+		},
+		{
+			name:          "Column past XFD",
+			worksheet:     worksheet(`<row r="1"><c r="XFE1"><v>1</v></c></row>`),
+			expectedError: `invalid xlsx file: cell reference "XFE1" is past the last column XFD`,
+		},
+		{
+			name:          "Overflowing column",
+			worksheet:     worksheet(`<row r="1"><c r="ZZZZZZZZZZZZZZ1"><v>1</v></c></row>`),
+			expectedError: `invalid xlsx file: cell reference "ZZZZZZZZZZZZZZ1" is past the last column XFD`,
+		},
+		{
+			name:          "Row past the last row",
+			worksheet:     worksheet(`<row r="1000000000"><c r="A1000000000"><v>1</v></c></row>`),
+			expectedError: "invalid xlsx file: row 1000000000 is past the last row 1048576",
+		},
+		{
+			name:          "Rows too wide",
+			worksheet:     worksheet(`<row r="1"><c r="XFD1"><v>1</v></c></row><row r="100"><c r="A100"><v>2</v></c></row>`),
+			expectedError: "table is too large: 100 rows of 16384 columns, at most 1000000 cells",
+			tooLarge:      true,
+		},
+		{
+			name:          "Too many cells",
+			worksheet:     worksheet(strings.Repeat(`<row><c r="XFD1"/></row>`, 70)),
+			expectedError: "table is too large: more than 1000000 cells",
+			tooLarge:      true,
+		},
+		{
+			name:          "Decompressed part too large",
+			worksheet:     worksheet(`<row r="1"><c r="A1"><v>` + strings.Repeat("9", maxXLSXPartSize) + `</v></c></row>`),
+			expectedError: "invalid xlsx file: xl/worksheets/sheet2.xml: table is too large: part is larger than 52428800 bytes",
+			tooLarge:      true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := ReadTable("results.xlsx", xlsxFile(t, map[string]string{"xl/worksheets/sheet2.xml": testCase.worksheet}))
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.Equal(t, testCase.tooLarge, errors.Is(err, ErrTableTooLarge))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, rows, 2)
+			assert.Equal(t, "1", rows[0][maxXLSXColumns-1])
+		})
+	}
+}
+
+// xlsxFile builds a workbook of the parts, its first worksheet being xl/worksheets/sheet2.xml
+func xlsxFile(t *testing.T, parts map[string]string) *bytes.Buffer {
+	var content bytes.Buffer
+	archive := zip.NewWriter(&content)
+	parts["xl/workbook.xml"] = `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Results" sheetId="1" r:id="rId2"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets></workbook>`
+	parts["xl/_rels/workbook.xml.rels"] = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`
+	for name, part := range parts {
+		w, err := archive.Create(name)
+		assert.NoError(t, err)
+		w.Write([]byte(part))
+	}
+	assert.NoError(t, archive.Close())
+	return &content
+}
+
+func TestReadTableUnsupported(t *testing.T) {
+	_, err := ReadTable("results.xls", strings.NewReader(""))
+
+	assert.ErrorIs(t, err, ErrUnsupportedTable)
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for ref, expected := range map[string]int{"A1": 0, "C7": 2, "Z10": 25, "AA2": 26, "AB12": 27} {
+		column, err := xlsxColumn(ref)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, column, ref)
+	}
+
+	_, err := xlsxColumn("12")
+	assert.Error(t, err)
+	_, err = xlsxColumn("AAAA1")
+	assert.Error(t, err)
+}